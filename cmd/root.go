@@ -18,7 +18,10 @@
 //
 // It implements the root command and configuration management using Cobra and Viper,
 // including subcommands for viewing roll data, frames, custom functions, focus points,
-// thumbnails, and writing EXIF metadata.
+// thumbnails, writing EXIF metadata, editing roll and frame remarks, validating
+// the consistency of a roll, splitting and merging rolls, rendering contact
+// sheets, dumping the raw structure of a file, and researching the undocumented
+// parts of the format.
 package cmd
 
 import (
@@ -31,12 +34,21 @@ import (
 	"time"
 
 	"github.com/lmittmann/tint"
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/contactsheet"
 	"github.com/ma-tf/meta1v/internal/cli/customfunctions"
+	"github.com/ma-tf/meta1v/internal/cli/edit"
 	"github.com/ma-tf/meta1v/internal/cli/exif"
 	"github.com/ma-tf/meta1v/internal/cli/focusingpoints"
 	"github.com/ma-tf/meta1v/internal/cli/frame"
+	"github.com/ma-tf/meta1v/internal/cli/inspect"
+	"github.com/ma-tf/meta1v/internal/cli/merge"
+	"github.com/ma-tf/meta1v/internal/cli/research"
 	"github.com/ma-tf/meta1v/internal/cli/roll"
+	"github.com/ma-tf/meta1v/internal/cli/split"
 	"github.com/ma-tf/meta1v/internal/cli/thumbnail"
+	"github.com/ma-tf/meta1v/internal/cli/validate"
+	"github.com/ma-tf/meta1v/internal/cli/xmp"
 	"github.com/ma-tf/meta1v/internal/container"
 	"github.com/ma-tf/meta1v/internal/service/osexec"
 	"github.com/spf13/cobra"
@@ -49,6 +61,7 @@ type Config struct {
 		Level string `mapstructure:"level"`
 	} `mapstructure:"log"`
 	Strict  bool          `mapstructure:"strict"`
+	Recover bool          `mapstructure:"recover"`
 	Timeout time.Duration `mapstructure:"timeout"`
 }
 
@@ -131,6 +144,11 @@ func Execute(version, commit, date string) {
 
 	err := rootCmd.Execute()
 	if err != nil {
+		var exitErr *cli.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+
 		os.Exit(1)
 	}
 }
@@ -156,11 +174,70 @@ func init() {
 		"enable strict mode (fail on unknown metadata values)",
 	)
 
+	rootCmd.PersistentFlags().BoolVar(
+		&config.Recover,
+		"recover",
+		false,
+		"skip corrupt or unknown records, reporting each skipped region",
+	)
+
 	ctr := container.New(logger, osexec.NewLookPath())
 
-	exifUseCase := exif.NewUseCase(logger, ctr.EFDService, ctr.ExifService)
+	exifUseCase := exif.NewUseCase(
+		logger,
+		ctr.EFDService,
+		ctr.ExifService,
+		ctr.FrameBuilder,
+	)
+	exifBatchUseCase := exif.NewBatchUseCase(
+		logger,
+		ctr.EFDService,
+		ctr.ExifService,
+		ctr.FileSystem,
+		ctr.FrameBuilder,
+	)
+	editUseCase := edit.NewUseCase(logger, ctr.EFDService, ctr.FileSystem)
+	validateUseCase := validate.NewUseCase(
+		logger,
+		ctr.EFDService,
+		ctr.ValidateService,
+	)
+	inspectUseCase := inspect.NewUseCase(
+		logger,
+		ctr.EFDService,
+		ctr.InspectService,
+	)
+	splitUseCase := split.NewUseCase(
+		logger,
+		ctr.EFDService,
+		ctr.SplitMergeService,
+		ctr.FileSystem,
+	)
+	mergeUseCase := merge.NewUseCase(
+		logger,
+		ctr.EFDService,
+		ctr.SplitMergeService,
+		ctr.FileSystem,
+	)
+	contactsheetUseCase := contactsheet.NewUseCase(
+		logger,
+		ctr.EFDService,
+		ctr.DisplayableRollFactory,
+		ctr.ContactSheetService,
+		ctr.FileSystem,
+	)
 
-	rootCmd.AddCommand(exif.NewCommand(logger, exifUseCase))
+	rootCmd.AddCommand(
+		exif.NewCommand(logger, exifUseCase, exifBatchUseCase),
+	)
+	rootCmd.AddCommand(xmp.NewCommand(logger, exifUseCase))
+	rootCmd.AddCommand(edit.NewCommand(logger, editUseCase))
+	rootCmd.AddCommand(validate.NewCommand(logger, validateUseCase))
+	rootCmd.AddCommand(inspect.NewCommand(logger, inspectUseCase))
+	rootCmd.AddCommand(research.NewCommand(logger, ctr))
+	rootCmd.AddCommand(split.NewCommand(logger, splitUseCase))
+	rootCmd.AddCommand(merge.NewCommand(logger, mergeUseCase))
+	rootCmd.AddCommand(contactsheet.NewCommand(logger, contactsheetUseCase))
 	rootCmd.AddCommand(roll.NewCommand(logger, ctr))
 	rootCmd.AddCommand(customfunctions.NewCommand(logger, ctr))
 	rootCmd.AddCommand(focusingpoints.NewCommand(logger, ctr))
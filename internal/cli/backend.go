@@ -0,0 +1,58 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// BackendKey is the configuration key the EXIF backend is read from when the
+// --backend flag is not given, e.g.
+//
+//	exif:
+//	  backend: native
+const BackendKey = "exif.backend"
+
+var ErrFailedToGetBackendFlag = errors.New("failed to get backend flag")
+
+// AddBackendFlag adds the --backend flag to cmd.
+func AddBackendFlag(cmd *cobra.Command) {
+	cmd.Flags().String("backend", "",
+		fmt.Sprintf("how to write metadata (%s), default exiftool",
+			strings.Join(exif.Backends(), ", ")))
+}
+
+// GetBackend returns the EXIF backend given to the --backend flag of cmd, or
+// the one configured under BackendKey if the flag is not set.
+func GetBackend(cmd *cobra.Command, v *viper.Viper) (exif.Backend, error) {
+	name, err := cmd.Flags().GetString("backend")
+	if err != nil {
+		return "", errors.Join(ErrFailedToGetBackendFlag, err)
+	}
+
+	if !cmd.Flags().Changed("backend") {
+		name = v.GetString(BackendKey)
+	}
+
+	return exif.ParseBackend(name) //nolint:wrapcheck // already descriptive
+}
@@ -0,0 +1,104 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func Test_GetBackend(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		config        string
+		args          []string
+		expected      exif.Backend
+		expectedError error
+	}{
+		{
+			name:     "default",
+			config:   "strict: false\n",
+			args:     nil,
+			expected: exif.BackendExiftool,
+		},
+		{
+			name:     "configured",
+			config:   "exif:\n  backend: native\n",
+			args:     nil,
+			expected: exif.BackendNative,
+		},
+		{
+			name:     "flag overrides configuration",
+			config:   "exif:\n  backend: native\n",
+			args:     []string{"--backend", "exiftool"},
+			expected: exif.BackendExiftool,
+		},
+		{
+			name:          "unsupported backend",
+			config:        "strict: false\n",
+			args:          []string{"--backend", "perl"},
+			expectedError: exif.ErrUnsupportedBackend,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := viper.New()
+			v.SetConfigType("yaml")
+
+			err := v.ReadConfig(bytes.NewBufferString(tt.config))
+			if err != nil {
+				t.Fatalf("failed to read config: %v", err)
+			}
+
+			cmd := &cobra.Command{}
+			cli.AddBackendFlag(cmd)
+
+			if err = cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("unexpected error parsing flags: %v", err)
+			}
+
+			got, err := cli.GetBackend(cmd, v)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("unexpected backend: got %q, want %q",
+					got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := cli.GetBackend(&cobra.Command{}, viper.New()); !errors.Is(
+		err,
+		cli.ErrFailedToGetBackendFlag,
+	) {
+		t.Errorf("expected %v without the flag, got %v",
+			cli.ErrFailedToGetBackendFlag, err)
+	}
+}
@@ -0,0 +1,64 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/service/display"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ColumnsKey is the configuration key the default frame columns are read from
+// when the --columns flag is not given, e.g.
+//
+//	frame:
+//	  columns: [frame, tv, av, ec, takenAt]
+const ColumnsKey = "frame.columns"
+
+var ErrFailedToGetColumnsFlag = errors.New("failed to get columns flag")
+
+// AddColumnsFlag adds the --columns flag to cmd, which must already have the
+// --template flag it cannot be combined with.
+func AddColumnsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("columns", nil,
+		fmt.Sprintf("frame columns to write, in order (%s)",
+			strings.Join(display.FrameColumnNames(), ", ")))
+	cmd.MarkFlagsMutuallyExclusive("columns", "template")
+}
+
+// GetColumns returns the frame columns given to the --columns flag of cmd, or
+// the ones configured under ColumnsKey if the flag is not set.
+func GetColumns(cmd *cobra.Command, v *viper.Viper) ([]string, error) {
+	columns, err := cmd.Flags().GetStringSlice("columns")
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetColumnsFlag, err)
+	}
+
+	if !cmd.Flags().Changed("columns") {
+		columns = v.GetStringSlice(ColumnsKey)
+	}
+
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,98 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func Test_GetColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "default",
+			config:   "strict: false\n",
+			args:     nil,
+			expected: nil,
+		},
+		{
+			name:     "configured",
+			config:   "frame:\n  columns: [frame, tv, av]\n",
+			args:     nil,
+			expected: []string{"frame", "tv", "av"},
+		},
+		{
+			name:     "flag overrides configuration",
+			config:   "frame:\n  columns: [frame, tv, av]\n",
+			args:     []string{"--columns", "takenAt,frame"},
+			expected: []string{"takenAt", "frame"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := viper.New()
+			v.SetConfigType("yaml")
+
+			err := v.ReadConfig(bytes.NewBufferString(tt.config))
+			if err != nil {
+				t.Fatalf("failed to read config: %v", err)
+			}
+
+			cmd := &cobra.Command{}
+			cli.AddTemplateFlag(cmd)
+			cli.AddColumnsFlag(cmd)
+
+			if err = cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("unexpected error parsing flags: %v", err)
+			}
+
+			got, err := cli.GetColumns(cmd, v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("unexpected columns: got %v, want %v",
+					got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := cli.GetColumns(&cobra.Command{}, viper.New()); !errors.Is(
+		err,
+		cli.ErrFailedToGetColumnsFlag,
+	) {
+		t.Errorf("expected %v without the flag, got %v",
+			cli.ErrFailedToGetColumnsFlag, err)
+	}
+}
@@ -0,0 +1,177 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=contactsheet_test github.com/ma-tf/meta1v/internal/cli/contactsheet UseCase
+
+// Package contactsheet provides the CLI command for rendering contact sheets of a roll.
+package contactsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/contactsheet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrUnsupportedFormat     = errors.New("unsupported contact sheet format")
+	ErrFailedToGetFormatFlag = errors.New("failed to get format flag")
+	ErrFormatNotDeterminable = errors.New(
+		"cannot tell the format from the target file, use --format",
+	)
+)
+
+// UseCase defines the business logic for rendering contact sheets of EFD files.
+type UseCase interface {
+	// Render reads an EFD file and writes a contact sheet of its frames, or only
+	// those within frames, to targetFile in the given format.
+	Render(
+		ctx context.Context,
+		efdFile string,
+		targetFile string,
+		format string,
+		strict bool,
+		recovery bool,
+		force bool,
+		frames cli.FrameRanges,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contactsheet <efd_file> <target_file>",
+		Short: "Render a contact sheet of a roll's thumbnails",
+		Long: `Render a printable contact sheet of the thumbnails of a roll as PNG, SVG or PDF.
+
+Frames are laid out in strips following the roll's contact sheet layout: the per row
+value gives the frames in each row, and the first row is shortened to match the first
+strip of negatives. Each frame is captioned with its number, shutter speed, aperture
+and exposure compensation, and the sheet is headed with the film ID, title, load date
+and ISO. Frames without a thumbnail are shown as grey boxes.
+
+The format is taken from the target file's extension unless --format is given.
+
+With --frames, only the frames with the given numbers are on the sheet, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.`,
+		Example: `  # Render a PNG contact sheet
+  meta1v contactsheet data.efd sheet.png
+
+  # Render a PDF, whatever the file is called
+  meta1v contactsheet data.efd sheet --format pdf
+
+  # Overwrite an existing sheet
+  meta1v contactsheet data.efd sheet.svg --force
+
+  # Only frames 1 to 12
+  meta1v contactsheet data.efd sheet.png --frames 1-12`,
+		Args: cobra.ExactArgs(2), //nolint:mnd // source and target
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			strict, err := cmd.Flags().GetBool("strict")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
+			}
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetForceFlag, err)
+			}
+
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return errors.Join(ErrFailedToGetFormatFlag, err)
+			}
+
+			format, err = resolveFormat(format, args[1])
+			if err != nil {
+				return err
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.String("target_file", args[1]),
+				slog.String("format", format),
+				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.Bool("force", force),
+				slog.String("frames", frames.String()),
+			)
+
+			return uc.Render(
+				ctx,
+				args[0],
+				args[1],
+				format,
+				strict,
+				recovery,
+				force,
+				frames,
+			)
+		},
+	}
+
+	cmd.Flags().String(
+		"format",
+		"",
+		"sheet format (png, svg, pdf), taken from the target file if empty",
+	)
+	cmd.Flags().BoolP("force", "F", false, "overwrite output file if it exists")
+	cli.AddFramesFlag(cmd)
+
+	return cmd
+}
+
+// resolveFormat checks the requested format, falling back to the extension of
+// the target file when none was requested.
+func resolveFormat(format string, targetFile string) (string, error) {
+	if format == "" {
+		ext := filepath.Ext(targetFile)
+		format = strings.ToLower(strings.TrimPrefix(ext, "."))
+		if format == "" {
+			return "", ErrFormatNotDeterminable
+		}
+	}
+
+	formats := []string{
+		contactsheet.FormatPNG,
+		contactsheet.FormatSVG,
+		contactsheet.FormatPDF,
+	}
+	if !slices.Contains(formats, format) {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	return format, nil
+}
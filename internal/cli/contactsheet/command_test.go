@@ -0,0 +1,160 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package contactsheet_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/contactsheet"
+	contactsheet_test "github.com/ma-tf/meta1v/internal/cli/contactsheet/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name          string
+		args          []string
+		registerFlags bool
+		expect        func(mockUseCase *contactsheet_test.MockUseCase)
+		expectedError error
+	}
+
+	tests := []testcase{
+		{
+			name:          "strict flag not registered",
+			args:          []string{"file.efd", "sheet.png"},
+			registerFlags: false,
+			expectedError: cli.ErrFailedToGetStrictFlag,
+		},
+		{
+			name:          "format from target file",
+			args:          []string{"file.efd", "sheet.PDF", "--force"},
+			registerFlags: true,
+			expect: func(mockUseCase *contactsheet_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Render(gomock.Any(), "file.efd", "sheet.PDF", "pdf",
+						false, false, true, cli.FrameRanges(nil)).
+					Return(nil)
+			},
+		},
+		{
+			name:          "format flag overrides target file",
+			args:          []string{"file.efd", "sheet.png", "--format", "svg"},
+			registerFlags: true,
+			expect: func(mockUseCase *contactsheet_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Render(gomock.Any(), "file.efd", "sheet.png", "svg",
+						false, false, false, cli.FrameRanges(nil)).
+					Return(nil)
+			},
+		},
+		{
+			name:          "selected frames",
+			args:          []string{"file.efd", "sheet.png", "--frames", "1-12"},
+			registerFlags: true,
+			expect: func(mockUseCase *contactsheet_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Render(gomock.Any(), "file.efd", "sheet.png", "png",
+						false, false, false,
+						cli.FrameRanges{{First: 1, Last: 12}}).
+					Return(nil)
+			},
+		},
+		{
+			name:          "invalid frames",
+			args:          []string{"file.efd", "sheet.png", "--frames", "5-1"},
+			registerFlags: true,
+			expectedError: cli.ErrInvalidFrameRange,
+		},
+		{
+			name:          "no format and no extension",
+			args:          []string{"file.efd", "sheet"},
+			registerFlags: true,
+			expectedError: contactsheet.ErrFormatNotDeterminable,
+		},
+		{
+			name:          "unsupported extension",
+			args:          []string{"file.efd", "sheet.gif"},
+			registerFlags: true,
+			expectedError: contactsheet.ErrUnsupportedFormat,
+		},
+		{
+			name:          "unsupported format flag",
+			args:          []string{"file.efd", "sheet.png", "--format", "jpeg"},
+			registerFlags: true,
+			expectedError: contactsheet.ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := contactsheet_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase)
+			}
+
+			cmd := contactsheet.NewCommand(logger, mockUseCase)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if tt.registerFlags {
+				cmd.Flags().Bool("strict", false, "enable strict mode")
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
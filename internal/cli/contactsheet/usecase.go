@@ -0,0 +1,151 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package contactsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/contactsheet"
+	"github.com/ma-tf/meta1v/internal/service/display"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+)
+
+const permission = 0o666 // rw-rw-rw-
+
+var (
+	ErrFailedToReadFile = errors.New(
+		"failed to read file for contact sheet",
+	)
+	ErrFailedToParseFile = errors.New(
+		"failed to parse file for contact sheet",
+	)
+	ErrFailedToCreateOutputFile = errors.New(
+		"failed to create output file for contact sheet",
+	)
+	ErrFailedToRender = errors.New("failed to render contact sheet")
+)
+
+type usecase struct {
+	log                    *slog.Logger
+	efdService             efd.Service
+	displayableRollFactory display.DisplayableRollFactory
+	contactsheetService    contactsheet.Service
+	fs                     osfs.FileSystem
+}
+
+func NewUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	displayableRollFactory display.DisplayableRollFactory,
+	contactsheetService contactsheet.Service,
+	fs osfs.FileSystem,
+) UseCase {
+	return usecase{
+		log:                    log,
+		efdService:             efdService,
+		displayableRollFactory: displayableRollFactory,
+		contactsheetService:    contactsheetService,
+		fs:                     fs,
+	}
+}
+
+func (uc usecase) Render(
+	ctx context.Context,
+	efdFile string,
+	targetFile string,
+	format string,
+	strict bool,
+	recovery bool,
+	force bool,
+	frames cli.FrameRanges,
+) error {
+	uc.log.InfoContext(ctx, "starting contact sheet",
+		slog.String("efd_file", efdFile),
+		slog.String("target_file", targetFile),
+		slog.String("format", format),
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.Bool("force", force),
+		slog.String("frames", frames.String()))
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	if err = frames.Check(root.EFRMs); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
+	}
+
+	dr, err := uc.displayableRollFactory.Create(ctx, root, strict)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
+	}
+
+	uc.log.DebugContext(ctx, "displayable roll created",
+		slog.String("film_id", string(dr.FilmID)))
+
+	// the frames are selected from the sheet as thumbnails are paired with
+	// frames by their position on the whole roll
+	sheet := contactsheet.NewSheet(dr, root)
+	sheet.Frames = slices.DeleteFunc(sheet.Frames, func(f contactsheet.Frame) bool {
+		return !frames.Contains(uint32(f.Number)) //nolint:gosec // a frame number
+	})
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	f, err := uc.fs.OpenFile(targetFile, flags, permission)
+	if err != nil {
+		if !force && errors.Is(err, os.ErrExist) {
+			return cli.ErrOutputFileAlreadyExists
+		}
+
+		return fmt.Errorf("%w %q: %w",
+			ErrFailedToCreateOutputFile, targetFile, err)
+	}
+	defer f.Close()
+
+	err = uc.contactsheetService.Render(ctx, f, sheet, format)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToRender, targetFile, err)
+	}
+
+	thumbnails := 0
+
+	for _, f := range sheet.Frames {
+		if f.Thumbnail != nil {
+			thumbnails++
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: %d frame(s), %d thumbnail(s)\n",
+		targetFile, len(sheet.Frames), thumbnails)
+
+	uc.log.InfoContext(ctx, "contact sheet completed successfully")
+
+	return nil
+}
@@ -0,0 +1,287 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package contactsheet_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/contactsheet"
+	"github.com/ma-tf/meta1v/internal/records"
+	contactsheetsvc "github.com/ma-tf/meta1v/internal/service/contactsheet"
+	contactsheetsvc_test "github.com/ma-tf/meta1v/internal/service/contactsheet/mocks"
+	"github.com/ma-tf/meta1v/internal/service/display"
+	display_test "github.com/ma-tf/meta1v/internal/service/display/mocks"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct,funlen,maintidx // only partial is needed, table driven test
+func Test_Render(t *testing.T) {
+	t.Parallel()
+
+	const (
+		unforcedFlags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		forcedFlags   = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		permissions   = os.FileMode(0o666)
+	)
+
+	root := records.Root{
+		EFDF:  records.EFDF{PerRow: 6},
+		EFRMs: []records.EFRM{{FrameNumber: 1}},
+	}
+	roll := display.DisplayableRoll{
+		FilmID: "12-345",
+		Frames: []display.DisplayableFrame{{FrameNumber: 1, Tv: "1/250"}},
+	}
+
+	rollOfThree := records.Root{
+		EFDF: records.EFDF{PerRow: 6},
+		EFRMs: []records.EFRM{
+			{FrameNumber: 1},
+			{FrameNumber: 2},
+			{FrameNumber: 3},
+		},
+	}
+	displayableRollOfThree := display.DisplayableRoll{
+		Frames: []display.DisplayableFrame{
+			{FrameNumber: 1},
+			{FrameNumber: 2},
+			{FrameNumber: 3},
+		},
+	}
+
+	type mocks struct {
+		efd          *efd_test.MockService
+		factory      *display_test.MockDisplayableRollFactory
+		contactsheet *contactsheetsvc_test.MockService
+		fs           *osfs_test.MockFileSystem
+		file         *osfs_test.MockFile
+	}
+
+	tests := []struct {
+		name          string
+		force         bool
+		frames        cli.FrameRanges
+		expect        func(m mocks)
+		expectedError error
+	}{
+		{
+			name: "failed to read file",
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: contactsheet.ErrFailedToReadFile,
+		},
+		{
+			name: "failed to parse file",
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(root, nil)
+				m.factory.EXPECT().
+					Create(gomock.Any(), root, true).
+					Return(display.DisplayableRoll{}, errExample)
+			},
+			expectedError: contactsheet.ErrFailedToParseFile,
+		},
+		{
+			name:   "selected frame missing",
+			frames: cli.FrameRanges{{First: 2, Last: 2}},
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(root, nil)
+			},
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+		{
+			name: "target file exists",
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(root, nil)
+				m.factory.EXPECT().
+					Create(gomock.Any(), root, true).
+					Return(roll, nil)
+				m.fs.EXPECT().
+					OpenFile("sheet.png", unforcedFlags, permissions).
+					Return(nil, os.ErrExist)
+			},
+			expectedError: cli.ErrOutputFileAlreadyExists,
+		},
+		{
+			name:  "failed to create target file",
+			force: true,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(root, nil)
+				m.factory.EXPECT().
+					Create(gomock.Any(), root, true).
+					Return(roll, nil)
+				m.fs.EXPECT().
+					OpenFile("sheet.png", forcedFlags, permissions).
+					Return(nil, errExample)
+			},
+			expectedError: contactsheet.ErrFailedToCreateOutputFile,
+		},
+		{
+			name: "failed to render",
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(root, nil)
+				m.factory.EXPECT().
+					Create(gomock.Any(), root, true).
+					Return(roll, nil)
+				m.fs.EXPECT().
+					OpenFile("sheet.png", unforcedFlags, permissions).
+					Return(m.file, nil)
+				m.contactsheet.EXPECT().
+					Render(gomock.Any(), m.file, gomock.Any(), "png").
+					Return(errExample)
+				m.file.EXPECT().Close().Return(nil)
+			},
+			expectedError: contactsheet.ErrFailedToRender,
+		},
+		{
+			name:   "selected frames rendered",
+			frames: cli.FrameRanges{{First: 2, Last: 3}},
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(rollOfThree, nil)
+				m.factory.EXPECT().
+					Create(gomock.Any(), rollOfThree, true).
+					Return(displayableRollOfThree, nil)
+				m.fs.EXPECT().
+					OpenFile("sheet.png", unforcedFlags, permissions).
+					Return(m.file, nil)
+				m.contactsheet.EXPECT().
+					Render(gomock.Any(), m.file, gomock.Any(), "png").
+					DoAndReturn(func(
+						_ context.Context,
+						_ io.Writer,
+						sheet contactsheetsvc.Sheet,
+						_ string,
+					) error {
+						if len(sheet.Frames) != 2 || sheet.Frames[0].Number != 2 {
+							t.Errorf("expected frames 2 and 3, got %+v",
+								sheet.Frames)
+						}
+
+						return nil
+					})
+				m.file.EXPECT().Close().Return(nil)
+			},
+		},
+		{
+			name: "sheet rendered",
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(root, nil)
+				m.factory.EXPECT().
+					Create(gomock.Any(), root, true).
+					Return(roll, nil)
+				m.fs.EXPECT().
+					OpenFile("sheet.png", unforcedFlags, permissions).
+					Return(m.file, nil)
+				m.contactsheet.EXPECT().
+					Render(gomock.Any(), m.file, gomock.Any(), "png").
+					Return(nil)
+				m.file.EXPECT().Close().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				efd:          efd_test.NewMockService(ctrl),
+				factory:      display_test.NewMockDisplayableRollFactory(ctrl),
+				contactsheet: contactsheetsvc_test.NewMockService(ctrl),
+				fs:           osfs_test.NewMockFileSystem(ctrl),
+				file:         osfs_test.NewMockFile(ctrl),
+			}
+			tt.expect(m)
+
+			uc := contactsheet.NewUseCase(
+				newTestLogger(),
+				m.efd,
+				m.factory,
+				m.contactsheet,
+				m.fs,
+			)
+
+			err := uc.Render(
+				t.Context(),
+				"in.efd",
+				"sheet.png",
+				"png",
+				true,
+				false,
+				tt.force,
+				tt.frames,
+			)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=check_test github.com/ma-tf/meta1v/internal/cli/customfunctions/check UseCase
+
+// Package check provides the CLI command for checking custom function settings against a preset.
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ExitCodeDeviations is reported when a frame differs from the preset. Failing
+// to read the file exits with status 1 like every other command.
+const ExitCodeDeviations = 2
+
+var (
+	ErrFramesDifferFromPreset = errors.New("frames differ from preset")
+	ErrFailedToGetPresetFlag  = errors.New("failed to get preset flag")
+	ErrUnknownPreset          = errors.New("unknown custom function preset")
+)
+
+// UseCase defines the business logic for checking custom function settings against a preset.
+type UseCase interface {
+	// Check reads an EFD file and prints every custom function of every frame that differs
+	// from the preset. It returns ErrFramesDifferFromPreset if there are any. Only the frames
+	// matching where are checked.
+	Check(
+		ctx context.Context,
+		efdFile string,
+		strict bool,
+		recovery bool,
+		preset domain.CustomFunctionPreset,
+		frames cli.FrameRanges,
+		where framefilter.Filter,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check <efd_file> --preset <name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Report frames whose custom functions differ from a preset",
+		Long: `Report every frame whose custom functions differ from a preset, listing each
+differing custom function with the value found and the value the preset expects.
+
+Presets are defined in the configuration file under customfunctions.presets, each
+mapping custom function numbers, counted from 0 as on the camera, to values. Custom
+functions a preset leaves out may have any value, and frames without any custom
+function recorded are skipped:
+
+  customfunctions:
+    presets:
+      sports: {4: 2, 13: 1}
+      studio: {12: 1, 15: 1}
+
+The exit status is 0 when every frame matches the preset, 1 when the file cannot be
+read, and 2 when at least one frame differs.
+
+With --frames, only the frames with the given numbers are checked, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are checked; see the
+README for the fields and operators available.`,
+		Example: `  # Check a roll against the sports preset
+  meta1v customfunctions check data.efd --preset sports
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll
+  meta1v cf check data.efd --preset sports --frames 1-5,12,30-
+
+  # Only check the frames shot in AI Servo
+  meta1v cf check data.efd --preset sports --where 'afMode == "AI Servo AF"'
+
+  # Use the exit status in a script
+  meta1v cf check data.efd --preset studio > /dev/null || echo "C.Fn changed"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			strict, err := cmd.Flags().GetBool("strict")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
+			}
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			name, err := cmd.Flags().GetString("preset")
+			if err != nil {
+				return errors.Join(ErrFailedToGetPresetFlag, err)
+			}
+
+			preset, err := findPreset(name)
+			if err != nil {
+				return err
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.String("preset", name),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()),
+			)
+
+			// deviations are already printed, usage would only bury them
+			cmd.SilenceUsage = true
+
+			err = uc.Check(
+				ctx,
+				args[0],
+				strict,
+				recovery,
+				preset,
+				frames,
+				where,
+			)
+			if errors.Is(err, ErrFramesDifferFromPreset) {
+				return &cli.ExitError{Code: ExitCodeDeviations, Err: err}
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().String("preset", "", "name of the preset to check against")
+	_ = cmd.MarkFlagRequired("preset")
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+
+	return cmd
+}
+
+func findPreset(name string) (domain.CustomFunctionPreset, error) {
+	presets, err := cli.CustomFunctionPresets(viper.GetViper())
+	if err != nil {
+		return domain.CustomFunctionPreset{}, err
+	}
+
+	names := make([]string, 0, len(presets))
+
+	for _, p := range presets {
+		// viper keys are case insensitive
+		if strings.EqualFold(p.Name, name) {
+			return p, nil
+		}
+
+		names = append(names, p.Name)
+	}
+
+	if len(names) == 0 {
+		return domain.CustomFunctionPreset{}, fmt.Errorf(
+			"%w %q: no presets configured under %s",
+			ErrUnknownPreset, name, cli.PresetsKey)
+	}
+
+	return domain.CustomFunctionPreset{}, fmt.Errorf(
+		"%w %q, configured presets: %s",
+		ErrUnknownPreset, name, strings.Join(names, ", "))
+}
@@ -0,0 +1,197 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package check_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/customfunctions/check"
+	check_test "github.com/ma-tf/meta1v/internal/cli/customfunctions/check/mocks"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/spf13/viper"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+func Test_CommandRun_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		args            []string
+		registerStrict  bool
+		registerRecover bool
+		expectedError   error
+	}{
+		{
+			name:           "strict flag not registered",
+			args:           []string{"file.efd", "--preset", "sports"},
+			registerStrict: false,
+			expectedError:  cli.ErrFailedToGetStrictFlag,
+		},
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd", "--preset", "sports"},
+			registerStrict:  true,
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "no presets configured",
+			args:            []string{"file.efd", "--preset", "sports"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   check.ErrUnknownPreset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cmd := check.NewCommand(
+				newTestLogger(),
+				check_test.NewMockUseCase(ctrl),
+			)
+			if tt.registerStrict {
+				cmd.Flags().Bool("strict", false, "enable strict mode")
+			}
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest,funlen // sets the global configuration, table driven test
+func Test_CommandRun_Presets(t *testing.T) {
+	viper.Set(cli.PresetsKey, map[string]any{
+		"sports": map[string]any{"4": 2, "13": 1},
+		"studio": map[string]any{"12": 1},
+	})
+	t.Cleanup(viper.Reset)
+
+	sports := domain.CustomFunctionPreset{
+		Name:   "sports",
+		Values: map[int]byte{4: 2, 13: 1},
+	}
+
+	tests := []struct {
+		name             string
+		preset           string
+		expect           func(uc *check_test.MockUseCase)
+		expectedError    error
+		expectedExitCode int
+	}{
+		{
+			name:          "unknown preset",
+			preset:        "landscape",
+			expect:        func(_ *check_test.MockUseCase) {},
+			expectedError: check.ErrUnknownPreset,
+		},
+		{
+			name:   "every frame matches",
+			preset: "sports",
+			expect: func(uc *check_test.MockUseCase) {
+				uc.EXPECT().
+					Check(gomock.Any(), "file.efd", false, false, sports,
+						gomock.Nil(),
+						framefilter.Filter{}).
+					Return(nil)
+			},
+		},
+		{
+			name:   "frames differ",
+			preset: "Sports",
+			expect: func(uc *check_test.MockUseCase) {
+				uc.EXPECT().
+					Check(gomock.Any(), "file.efd", false, false, sports,
+						gomock.Nil(),
+						framefilter.Filter{}).
+					Return(check.ErrFramesDifferFromPreset)
+			},
+			expectedError:    check.ErrFramesDifferFromPreset,
+			expectedExitCode: check.ExitCodeDeviations,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := check_test.NewMockUseCase(ctrl)
+			tt.expect(mockUseCase)
+
+			cmd := check.NewCommand(newTestLogger(), mockUseCase)
+			cmd.Flags().Bool("strict", false, "enable strict mode")
+			cmd.Flags().Bool("recover", false, "enable recovery mode")
+			cmd.SetArgs([]string{"file.efd", "--preset", tt.preset})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			var exitErr *cli.ExitError
+
+			exitCode := 0
+			if errors.As(err, &exitErr) {
+				exitCode = exitErr.Code
+			}
+
+			if exitCode != tt.expectedExitCode {
+				t.Errorf("expected exit code %d, got %d",
+					tt.expectedExitCode, exitCode)
+			}
+		})
+	}
+}
@@ -14,12 +14,14 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-// Package customfunctions provides business logic for listing and exporting custom function settings.
+// Package customfunctions provides business logic for listing, exporting and comparing custom function settings.
 package customfunctions
 
 import (
 	"log/slog"
 
+	"github.com/ma-tf/meta1v/internal/cli/customfunctions/check"
+	"github.com/ma-tf/meta1v/internal/cli/customfunctions/diff"
 	"github.com/ma-tf/meta1v/internal/cli/customfunctions/export"
 	"github.com/ma-tf/meta1v/internal/cli/customfunctions/ls"
 	"github.com/ma-tf/meta1v/internal/container"
@@ -29,8 +31,8 @@ import (
 func NewCommand(log *slog.Logger, ctr *container.Container) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "customfunctions <command>",
-		Short: "List or export custom function settings from EFD files",
-		Long: `Display or export custom function settings used by the frames.
+		Short: "List, export, compare or check custom function settings from EFD files",
+		Long: `Display, export, compare or check custom function settings used by the frames.
 
 For the meaning of each custom function and its respective value, refer to the 
 Canon EOS-1V manual.`,
@@ -42,6 +44,8 @@ Canon EOS-1V manual.`,
 		ctr.EFDService,
 		ctr.DisplayableRollFactory,
 		ctr.DisplayService,
+		ctr.JSONService,
+		ctr.TemplateService,
 	)
 
 	exportUseCase := NewExportUseCase(
@@ -50,8 +54,25 @@ Canon EOS-1V manual.`,
 		ctr.DisplayableRollFactory,
 		ctr.CSVService,
 		ctr.FileSystem,
+		ctr.TemplateService,
 	)
 
+	diffUseCase := NewDiffUseCase(
+		log,
+		ctr.EFDService,
+		ctr.DisplayableRollFactory,
+		ctr.CFDiffService,
+	)
+
+	checkUseCase := NewCheckUseCase(
+		log,
+		ctr.EFDService,
+		ctr.DisplayableRollFactory,
+		ctr.CFDiffService,
+	)
+
+	cmd.AddCommand(check.NewCommand(log, checkUseCase))
+	cmd.AddCommand(diff.NewCommand(log, diffUseCase))
 	cmd.AddCommand(export.NewCommand(log, exportUseCase))
 	cmd.AddCommand(ls.NewCommand(log, listUseCase))
 
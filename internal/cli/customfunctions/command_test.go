@@ -46,14 +46,11 @@ func Test_NewCommand(t *testing.T) {
 	}))
 
 	mockLookPath := osexec_test.NewMockLookPath(ctrl)
-	mockLookPath.EXPECT().
-		LookPath("exiftool").
-		Return("/usr/bin/exiftool", nil)
 
 	ctr := container.New(logger, mockLookPath)
 	cmd := customfunctions.NewCommand(logger, ctr)
 
-	const expectedSubcommands = 2
+	const expectedSubcommands = 4
 	if len(cmd.Commands()) != expectedSubcommands {
 		t.Fatalf("expected %d subcommand to be registered, got %d",
 			expectedSubcommands, len(cmd.Commands()))
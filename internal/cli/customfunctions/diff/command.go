@@ -0,0 +1,165 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=diff_test github.com/ma-tf/meta1v/internal/cli/customfunctions/diff UseCase
+
+// Package diff provides the CLI command for finding custom function changes in EFD files.
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+
+	minArgs        = 1
+	maxArgs        = 2
+	otherFileIndex = 1
+)
+
+var (
+	ErrUnsupportedFormat     = errors.New("unsupported output format")
+	ErrFailedToGetFormatFlag = errors.New("failed to get format flag")
+)
+
+// UseCase defines the business logic for finding custom function changes in EFD files.
+type UseCase interface {
+	// Diff reads an EFD file and prints where custom function settings change between its frames.
+	// If otherFile is set, the settings in effect at the end of the first roll are compared with
+	// those at the start of the other roll instead. Only the frames matching where are compared.
+	Diff(
+		ctx context.Context,
+		efdFile string,
+		otherFile *string,
+		strict bool,
+		recovery bool,
+		format string,
+		frames cli.FrameRanges,
+		where framefilter.Filter,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <efd_file> [other_efd_file]",
+		Args:  cobra.RangeArgs(minArgs, maxArgs),
+		Short: "Show where custom function settings change",
+		Long: `Show where custom function settings change, so a custom function changed mid-roll
+does not go unnoticed.
+
+Given one file, every frame at which a custom function differs from the previous frame
+is listed with the old and new value. Given two files, the settings in effect at the end
+of the first roll are compared with those at the start of the second. Frames without any
+custom function recorded are skipped.
+
+Custom functions are numbered from C.Fn-0 as on the camera.
+
+With --frames, only the frames with the given numbers are compared, in both files,
+e.g. 1-5,12,30- where 30- runs to the end of the roll. Any missing frame of a closed
+range fails, as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are compared, in both
+files; see the README for the fields and operators available.`,
+		Example: `  # Show changes within a roll
+  meta1v customfunctions diff data.efd
+
+  # Compare the settings carried over from one roll to the next
+  meta1v cf diff roll1.efd roll2.efd
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll
+  meta1v cf diff data.efd --frames 1-5,12,30-
+
+  # Only compare the frames shot in manual exposure
+  meta1v cf diff data.efd --where 'shootingMode == "Manual exposure"'
+
+  # Write the changes as JSON
+  meta1v cf diff data.efd --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			strict, err := cmd.Flags().GetBool("strict")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
+			}
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return errors.Join(ErrFailedToGetFormatFlag, err)
+			}
+
+			if !slices.Contains([]string{FormatTable, FormatJSON}, format) {
+				return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
+			var otherFile *string
+			if len(args) == maxArgs {
+				otherFile = &args[otherFileIndex]
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.Any("other_efd_file", otherFile),
+				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.String("format", format),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()),
+			)
+
+			return uc.Diff(
+				ctx,
+				args[0],
+				otherFile,
+				strict,
+				recovery,
+				format,
+				frames,
+				where,
+			)
+		},
+	}
+
+	cmd.Flags().String("format", FormatTable, "output format (table, json)")
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+
+	return cmd
+}
@@ -0,0 +1,161 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package diff_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/customfunctions/diff"
+	diff_test "github.com/ma-tf/meta1v/internal/cli/customfunctions/diff/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_CommandRun(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name            string
+		args            []string
+		registerStrict  bool
+		registerRecover bool
+		expect          func(uc diff_test.MockUseCase, tt testcase)
+		expectedError   error
+	}
+
+	other := "other.efd"
+
+	tests := []testcase{
+		{
+			name:           "strict flag not registered",
+			args:           []string{"file.efd"},
+			registerStrict: false,
+			expectedError:  cli.ErrFailedToGetStrictFlag,
+		},
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd"},
+			registerStrict:  true,
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "unsupported format",
+			args:            []string{"file.efd", "--format", "csv"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   diff.ErrUnsupportedFormat,
+		},
+		{
+			name:            "changes within a roll",
+			args:            []string{"file.efd"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase diff_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					Diff(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						false,
+						false,
+						diff.FormatTable,
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "changes across rolls as JSON",
+			args:            []string{"file.efd", other, "--format", "json"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase diff_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					Diff(
+						gomock.Any(),
+						tt.args[0],
+						&other,
+						false,
+						false,
+						diff.FormatJSON,
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := diff_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(*mockUseCase, tt)
+			}
+
+			cmd := diff.NewCommand(logger, mockUseCase)
+			if tt.registerStrict {
+				cmd.Flags().Bool("strict", false, "enable strict mode")
+			}
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v to be in chain, got %v",
+						tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"log/slog"
 
 	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
 )
 
@@ -37,12 +38,20 @@ const (
 // UseCase defines the business logic for exporting custom function settings from EFD files.
 type UseCase interface {
 	// Export reads an EFD file and exports custom function settings in CSV format to stdout or a specified file.
+	// The output is written through the output template instead if one is set.
+	// If verbose is set, each setting is exported as its own row with the name of the custom function and the
+	// meaning of its value. Only the frames matching where are exported.
 	Export(
 		ctx context.Context,
 		efdFile string,
 		outputFile *string,
 		strict bool,
+		recovery bool,
 		force bool,
+		verbose bool,
+		output cli.Output,
+		frames cli.FrameRanges,
+		where framefilter.Filter,
 	) error
 }
 
@@ -51,17 +60,43 @@ func NewCommand(log *slog.Logger, useCase UseCase) *cobra.Command {
 		Use:   "export <efd_file> [target_file]",
 		Args:  cobra.RangeArgs(minArgs, maxArgs),
 		Short: "Export custom function settings to CSV format",
-		Long: `Export custom function settings to CSV format, including function ID, name, 
-description, parameters, and user-provided remarks. Output can be directed to stdout or 
-saved to a specified file.`,
+		Long: `Export custom function settings to CSV format, with a column per custom function
+for each frame. Custom functions are numbered from C.Fn-0 as on the camera, so the
+header runs from C.Fn-0 to C.Fn-19; it ran from C.Fn-1 to C.Fn-20 before. Output
+can be directed to stdout or saved to a specified file.
+
+With --verbose, each custom function of each frame is exported as its own row,
+with its name, value and the meaning of the value.
+
+With --template, the roll is written through a Go text/template given inline or as a
+file instead of as CSV; see the README for the helper functions available.
+
+With --frames, only the frames with the given numbers are exported, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are exported; see the
+README for the fields and operators available.`,
 		Example: `  # Export custom functions to stdout
   meta1v customfunctions export data.efd
 
   # Export to a file
   meta1v customfunctions export data.efd output.csv
 
+  # Export with the name and meaning of each setting
+  meta1v cf export data.efd output.csv --verbose
+
   # Overwrite existing file
-  meta1v cf export data.efd output.csv --force`,
+  meta1v cf export data.efd output.csv --force
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll
+  meta1v cf export data.efd --frames 1-5,12,30-
+
+  # Only frames shot with flash
+  meta1v cf export data.efd --where 'flash != "OFF"'
+
+  # Through a template
+  meta1v cf export data.efd --template '{{range .Frames}}{{.FrameNumber}} {{.CustomFunctions}}{{"\n"}}{{end}}'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
@@ -70,11 +105,36 @@ saved to a specified file.`,
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
 			force, err := cmd.Flags().GetBool("force")
 			if err != nil {
 				return errors.Join(cli.ErrFailedToGetForceFlag, err)
 			}
 
+			verbose, err := cmd.Flags().GetBool("verbose")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetVerboseFlag, err)
+			}
+
+			tmpl, err := cli.GetTemplate(cmd)
+			if err != nil {
+				return err
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
 			var targetFile *string
 			if len(args) == maxArgs {
 				targetFile = &args[targetFileIndex]
@@ -88,14 +148,35 @@ saved to a specified file.`,
 				slog.String("efd_file", args[0]),
 				slog.Any("target_file", targetFile),
 				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
 				slog.Bool("force", force),
+				slog.Bool("verbose", verbose),
+				slog.String("template", tmpl),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()),
 			)
 
-			return useCase.Export(ctx, args[0], targetFile, strict, force)
+			return useCase.Export(
+				ctx,
+				args[0],
+				targetFile,
+				strict,
+				recovery,
+				force,
+				verbose,
+				cli.Output{Format: cli.OutputCSV, Template: tmpl},
+				frames,
+				where,
+			)
 		},
 	}
 
+	cli.AddTemplateFlag(cmd)
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
 	cmd.Flags().BoolP("force", "F", false, "overwrite output file if it exists")
+	cmd.Flags().BoolP("verbose", "v", false,
+		"describe each custom function and setting")
 
 	return cmd
 }
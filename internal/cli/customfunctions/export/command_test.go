@@ -25,6 +25,7 @@ import (
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/customfunctions/export"
 	export_test "github.com/ma-tf/meta1v/internal/cli/customfunctions/export/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
 	"go.uber.org/mock/gomock"
 )
@@ -47,7 +48,12 @@ func Test_NewCommand(t *testing.T) {
 	type testcase struct {
 		name          string
 		strict        *bool
+		recovery      *bool
 		force         *bool
+		verbose       *bool
+		template      *string
+		frames        *string
+		where         *string
 		args          []string
 		expect        func(uc export_test.MockUseCase, tt testcase)
 		expectedError error
@@ -65,6 +71,10 @@ func Test_NewCommand(t *testing.T) {
 		return &b
 	}
 
+	setString := func(s string) *string {
+		return &s
+	}
+
 	tests := []testcase{
 		{
 			name:          "failed to get strict flag",
@@ -74,42 +84,200 @@ func Test_NewCommand(t *testing.T) {
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrFailedToGetStrictFlag,
 		},
+		{
+			name:          "failed to get recover flag",
+			strict:        setTrue(),
+			recovery:      nil,
+			force:         setFalse(),
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetRecoverFlag,
+		},
 		{
 			name:          "failed to get force flag",
 			strict:        setTrue(),
+			recovery:      setFalse(),
 			force:         nil,
 			args:          []string{"file.efd", "output.csv"},
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrFailedToGetForceFlag,
 		},
+		{
+			name:          "failed to get verbose flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			verbose:       nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetVerboseFlag,
+		},
+		{
+			name:          "failed to get template flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			verbose:       setFalse(),
+			template:      nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetTemplateFlag,
+		},
+		{
+			name:          "failed to get frames flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			verbose:       setFalse(),
+			template:      setString(""),
+			frames:        nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetFramesFlag,
+		},
+		{
+			name:          "failed to get where flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			verbose:       setFalse(),
+			template:      setString(""),
+			frames:        setString(""),
+			where:         nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetWhereFlag,
+		},
 		{
 			name:          "force flag without target file",
 			strict:        setTrue(),
+			recovery:      setFalse(),
 			force:         setTrue(),
+			verbose:       setFalse(),
+			template:      setString(""),
+			frames:        setString(""),
+			where:         setString(""),
 			args:          []string{"file.efd"},
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrForceFlagRequiresTargetFile,
 		},
 		{
-			name:   "successful export to stdout (1 arg)",
-			strict: setTrue(),
-			force:  setFalse(),
-			args:   []string{"file.efd"},
+			name:     "successful export to stdout (1 arg)",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			verbose:  setFalse(),
+			template: setString(""),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd"},
+			expect: func(uc export_test.MockUseCase, tt testcase) {
+				uc.EXPECT().
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						*tt.verbose,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "successful export with target file",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setTrue(),
+			verbose:  setTrue(),
+			template: setString(""),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd", "output.csv"},
+			expect: func(uc export_test.MockUseCase, tt testcase) {
+				uc.EXPECT().
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						&tt.args[1],
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						*tt.verbose,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "successful export through a template",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			verbose:  setFalse(),
+			template: setString("{{.FilmID}}"),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd"},
 			expect: func(uc export_test.MockUseCase, tt testcase) {
 				uc.EXPECT().
-					Export(gomock.Any(), tt.args[0], nil, *tt.strict, *tt.force).
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						*tt.verbose,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
 					Return(nil)
 			},
 			expectedError: nil,
 		},
 		{
-			name:   "successful export with target file",
-			strict: setTrue(),
-			force:  setTrue(),
-			args:   []string{"file.efd", "output.csv"},
+			name:     "successful export of filtered frames",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			verbose:  setFalse(),
+			template: setString(""),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd", "--where", "flash != OFF"},
 			expect: func(uc export_test.MockUseCase, tt testcase) {
 				uc.EXPECT().
-					Export(gomock.Any(), tt.args[0], &tt.args[1], *tt.strict, *tt.force).
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						*tt.verbose,
+						cli.Output{Format: cli.OutputCSV},
+						gomock.Nil(),
+						mustParseFilter(t, "flash != OFF"),
+					).
 					Return(nil)
 			},
 			expectedError: nil,
@@ -124,10 +292,30 @@ func Test_NewCommand(t *testing.T) {
 			cmd.Flags().Bool("strict", *tt.strict, "enable strict mode")
 		}
 
+		if tt.recovery != nil {
+			cmd.Flags().Bool("recover", *tt.recovery, "enable recovery mode")
+		}
+
 		if tt.force != nil {
 			cmd.Flags().Bool("force", *tt.force, "enable force mode")
 		}
 
+		if tt.verbose != nil {
+			cmd.Flags().Bool("verbose", *tt.verbose, "enable verbose mode")
+		}
+
+		if tt.template != nil {
+			cmd.Flags().String("template", *tt.template, "template")
+		}
+
+		if tt.frames != nil {
+			cmd.Flags().String("frames", *tt.frames, "frames")
+		}
+
+		if tt.where != nil {
+			cmd.Flags().String("where", *tt.where, "where")
+		}
+
 		cmd.SetArgs(tt.args)
 
 		return cmd
@@ -178,3 +366,14 @@ func Test_NewCommand(t *testing.T) {
 		})
 	}
 }
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
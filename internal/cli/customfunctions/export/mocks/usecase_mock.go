@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	cli "github.com/ma-tf/meta1v/internal/cli"
+	framefilter "github.com/ma-tf/meta1v/internal/service/framefilter"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,15 +43,15 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 }
 
 // Export mocks base method.
-func (m *MockUseCase) Export(ctx context.Context, efdFile string, outputFile *string, strict, force bool) error {
+func (m *MockUseCase) Export(ctx context.Context, efdFile string, outputFile *string, strict, recovery, force, verbose bool, output cli.Output, frames cli.FrameRanges, where framefilter.Filter) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Export", ctx, efdFile, outputFile, strict, force)
+	ret := m.ctrl.Call(m, "Export", ctx, efdFile, outputFile, strict, recovery, force, verbose, output, frames, where)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Export indicates an expected call of Export.
-func (mr *MockUseCaseMockRecorder) Export(ctx, efdFile, outputFile, strict, force any) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) Export(ctx, efdFile, outputFile, strict, recovery, force, verbose, output, frames, where any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUseCase)(nil).Export), ctx, efdFile, outputFile, strict, force)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUseCase)(nil).Export), ctx, efdFile, outputFile, strict, recovery, force, verbose, output, frames, where)
 }
@@ -25,35 +25,81 @@ import (
 	"log/slog"
 
 	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // UseCase defines the business logic for listing custom function settings from EFD files.
 type UseCase interface {
 	// List reads an EFD file and prints custom function settings used by the frames in a human-readable format.
+	// If verbose is set, the name of each custom function and the meaning of its value are printed too.
+	// Otherwise the preset each frame matches best is shown, if there are any presets.
+	// If the output format is cli.OutputJSON, the settings are written as a JSON document instead,
+	// or through the output template if set. Only the frames matching where are listed.
 	List(
 		ctx context.Context,
 		filename string,
 		strict bool,
+		recovery bool,
+		verbose bool,
+		output cli.Output,
+		presets []domain.CustomFunctionPreset,
+		frames cli.FrameRanges,
+		where framefilter.Filter,
 	) error
 }
 
 func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list <filename>",
 		Short: "Display custom function settings in human-readable format",
-		Long: `Display a table of custom function settings used by the frames.
+		Long: `Display a table of custom function settings used by the frames. Custom functions
+are numbered from C.Fn-0 as on the camera.
 
-For the meaning of each custom function and its respective value, refer to the 
-Canon EOS-1V manual.`,
+With --verbose, every custom function of every frame is listed on its own line,
+with its name and the meaning of its value. For the full description of each
+custom function, refer to the Canon EOS-1V manual.
+
+If custom function presets are configured under customfunctions.presets, the table
+shows the preset each frame matches best and how many custom functions differ from it.
+
+With --output json, a JSON document following the versioned schema described in
+the README is written instead, for use with tools such as jq. It always includes the
+name and meaning of each setting. With --template, the roll is written through a Go
+text/template given inline or as a file; see the README for the helper functions
+available.
+
+With --frames, only the frames with the given numbers are listed, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are listed; see the
+README for the fields and operators available.`,
 		Example: `  # Display custom functions
   meta1v customfunctions list data.efd
 
   # Using the short alias
   meta1v customfunctions ls data.efd
 
+  # With the name and meaning of each setting
+  meta1v cf ls data.efd --verbose
+
   # With strict mode
-  meta1v cf ls data.efd --strict`,
+  meta1v cf ls data.efd --strict
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll
+  meta1v cf ls data.efd --frames 1-5,12,30-
+
+  # Only frames shot in manual exposure
+  meta1v cf ls data.efd --where 'shootingMode == "Manual exposure"'
+
+  # As JSON
+  meta1v cf ls data.efd --output json
+
+  # Through a template
+  meta1v cf ls data.efd --template '{{range .Frames}}{{.FrameNumber}}: {{index .CustomFunctions 4}}{{"\n"}}{{end}}'`,
 		Aliases: []string{"ls"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -64,12 +110,67 @@ Canon EOS-1V manual.`,
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			verbose, err := cmd.Flags().GetBool("verbose")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetVerboseFlag, err)
+			}
+
+			output, err := cli.GetOutput(cmd, cli.OutputTable, cli.OutputJSON)
+			if err != nil {
+				return err
+			}
+
+			presets, err := cli.CustomFunctionPresets(viper.GetViper())
+			if err != nil {
+				return err
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
 			log.DebugContext(ctx, "arguments:",
 				slog.String("filename", args[0]),
 				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.Bool("verbose", verbose),
+				slog.String("output", output.Format),
+				slog.String("template", output.Template),
+				slog.Int("presets", len(presets)),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()),
 			)
 
-			return uc.List(ctx, args[0], strict)
+			return uc.List(
+				ctx,
+				args[0],
+				strict,
+				recovery,
+				verbose,
+				output,
+				presets,
+				frames,
+				where,
+			)
 		},
 	}
+
+	cmd.Flags().BoolP("verbose", "v", false,
+		"describe each custom function and setting")
+	cli.AddOutputFlags(cmd, cli.OutputTable, cli.OutputJSON)
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+
+	return cmd
 }
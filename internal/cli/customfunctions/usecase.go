@@ -24,12 +24,19 @@ import (
 	"os"
 
 	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/customfunctions/check"
+	"github.com/ma-tf/meta1v/internal/cli/customfunctions/diff"
 	"github.com/ma-tf/meta1v/internal/cli/customfunctions/export"
 	"github.com/ma-tf/meta1v/internal/cli/customfunctions/ls"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/cfdiff"
 	"github.com/ma-tf/meta1v/internal/service/csvexport"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/ma-tf/meta1v/internal/service/jsonexport"
 	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
 )
 
 const permission = 0o666 // rw-rw-rw-
@@ -45,6 +52,9 @@ var (
 	ErrFailedToCreateOutputFile = errors.New(
 		"failed to create output file for custom functions",
 	)
+	ErrFailedToWriteChanges = errors.New(
+		"failed to write custom function changes",
+	)
 	ErrFailedToWriteCSV = errors.New("failed to write custom functions to csv")
 )
 
@@ -53,6 +63,8 @@ type listUseCase struct {
 	efdService             efd.Service
 	displayableRollFactory display.DisplayableRollFactory
 	displayService         display.Service
+	jsonService            jsonexport.Service
+	templateService        usertemplate.Service
 }
 
 func NewListUseCase(
@@ -60,12 +72,16 @@ func NewListUseCase(
 	efdService efd.Service,
 	displayableRollFactory display.DisplayableRollFactory,
 	displayService display.Service,
+	jsonService jsonexport.Service,
+	templateService usertemplate.Service,
 ) ls.UseCase {
 	return listUseCase{
 		log:                    log,
 		efdService:             efdService,
 		displayableRollFactory: displayableRollFactory,
 		displayService:         displayService,
+		jsonService:            jsonService,
+		templateService:        templateService,
 	}
 }
 
@@ -73,12 +89,36 @@ func (uc listUseCase) List(
 	ctx context.Context,
 	filename string,
 	strict bool,
+	recovery bool,
+	verbose bool,
+	output cli.Output,
+	presets []domain.CustomFunctionPreset,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
 ) error {
 	uc.log.InfoContext(ctx, "starting custom functions list",
 		slog.String("file", filename),
-		slog.Bool("strict", strict))
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.Bool("verbose", verbose),
+		slog.String("output", output.Format),
+		slog.String("template", output.Template),
+		slog.Int("presets", len(presets)),
+		slog.String("frames", frames.String()),
+		slog.String("where", where.String()))
+
+	tmpl, err := cli.ParseTemplate(ctx, uc.templateService, output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToDisplay, err)
+	}
 
-	records, err := uc.efdService.RecordsFromFile(ctx, filename)
+	records, err := cli.ReadRecords(
+		ctx,
+		uc.log,
+		uc.efdService,
+		filename,
+		recovery,
+	)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, filename, err)
 	}
@@ -91,10 +131,31 @@ func (uc listUseCase) List(
 		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
 	}
 
+	dr, err = frames.Select(dr)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
+	}
+
+	dr = where.Apply(dr)
+
 	uc.log.DebugContext(ctx, "displayable custom functions created",
 		slog.Int("frame_count", len(dr.Frames)))
 
-	err = uc.displayService.DisplayCustomFunctions(ctx, os.Stdout, dr)
+	switch {
+	case tmpl != nil:
+		err = uc.templateService.Execute(ctx, os.Stdout, tmpl, dr)
+	case output.Format == cli.OutputJSON:
+		err = uc.jsonService.ExportCustomFunctions(ctx, os.Stdout, dr, presets)
+	default:
+		err = uc.displayService.DisplayCustomFunctions(
+			ctx,
+			os.Stdout,
+			dr,
+			verbose,
+			presets,
+		)
+	}
+
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailedToDisplay, err)
 	}
@@ -110,6 +171,7 @@ type exportUseCase struct {
 	displayableRollFactory display.DisplayableRollFactory
 	csvService             csvexport.Service
 	fs                     osfs.FileSystem
+	templateService        usertemplate.Service
 }
 
 func NewExportUseCase(
@@ -118,6 +180,7 @@ func NewExportUseCase(
 	displayableRollFactory display.DisplayableRollFactory,
 	csvService csvexport.Service,
 	fs osfs.FileSystem,
+	templateService usertemplate.Service,
 ) export.UseCase {
 	return exportUseCase{
 		log:                    log,
@@ -125,6 +188,7 @@ func NewExportUseCase(
 		displayableRollFactory: displayableRollFactory,
 		csvService:             csvService,
 		fs:                     fs,
+		templateService:        templateService,
 	}
 }
 
@@ -133,15 +197,36 @@ func (uc exportUseCase) Export(
 	efdFile string,
 	outputFile *string,
 	strict bool,
+	recovery bool,
 	force bool,
+	verbose bool,
+	output cli.Output,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
 ) error {
 	uc.log.InfoContext(ctx, "starting custom functions export",
 		slog.String("efd_file", efdFile),
 		slog.Any("output_file", outputFile),
 		slog.Bool("strict", strict),
-		slog.Bool("force", force))
+		slog.Bool("recover", recovery),
+		slog.Bool("force", force),
+		slog.Bool("verbose", verbose),
+		slog.String("template", output.Template),
+		slog.String("frames", frames.String()),
+		slog.String("where", where.String()))
+
+	tmpl, err := cli.ParseTemplate(ctx, uc.templateService, output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToWriteCSV, err)
+	}
 
-	records, err := uc.efdService.RecordsFromFile(ctx, efdFile)
+	records, err := cli.ReadRecords(
+		ctx,
+		uc.log,
+		uc.efdService,
+		efdFile,
+		recovery,
+	)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
 	}
@@ -154,6 +239,13 @@ func (uc exportUseCase) Export(
 		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
 	}
 
+	dr, err = frames.Select(dr)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
+	}
+
+	dr = where.Apply(dr)
+
 	uc.log.DebugContext(ctx, "displayable custom functions created",
 		slog.Int("frame_count", len(dr.Frames)))
 
@@ -181,7 +273,13 @@ func (uc exportUseCase) Export(
 			slog.String("file", *outputFile))
 	}
 
-	if err = uc.csvService.ExportCustomFunctions(ctx, writer, dr); err != nil {
+	if tmpl != nil {
+		err = uc.templateService.Execute(ctx, writer, tmpl, dr)
+	} else {
+		err = uc.csvService.ExportCustomFunctions(ctx, writer, dr, verbose)
+	}
+
+	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailedToWriteCSV, err)
 	}
 
@@ -189,3 +287,202 @@ func (uc exportUseCase) Export(
 
 	return nil
 }
+
+type diffUseCase struct {
+	log                    *slog.Logger
+	efdService             efd.Service
+	displayableRollFactory display.DisplayableRollFactory
+	cfdiffService          cfdiff.Service
+}
+
+func NewDiffUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	displayableRollFactory display.DisplayableRollFactory,
+	cfdiffService cfdiff.Service,
+) diff.UseCase {
+	return diffUseCase{
+		log:                    log,
+		efdService:             efdService,
+		displayableRollFactory: displayableRollFactory,
+		cfdiffService:          cfdiffService,
+	}
+}
+
+func (uc diffUseCase) Diff(
+	ctx context.Context,
+	efdFile string,
+	otherFile *string,
+	strict bool,
+	recovery bool,
+	format string,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
+) error {
+	uc.log.InfoContext(ctx, "starting custom functions diff",
+		slog.String("efd_file", efdFile),
+		slog.Any("other_efd_file", otherFile),
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.String("format", format),
+		slog.String("frames", frames.String()),
+		slog.String("where", where.String()))
+
+	dr, err := readRoll(
+		ctx,
+		uc.log,
+		uc.efdService,
+		uc.displayableRollFactory,
+		efdFile,
+		strict,
+		recovery,
+		frames,
+		where,
+	)
+	if err != nil {
+		return err
+	}
+
+	var changes []cfdiff.Change
+
+	if otherFile == nil {
+		changes = uc.cfdiffService.Frames(ctx, dr)
+	} else {
+		other, err := readRoll(
+			ctx,
+			uc.log,
+			uc.efdService,
+			uc.displayableRollFactory,
+			*otherFile,
+			strict,
+			recovery,
+			frames,
+			where,
+		)
+		if err != nil {
+			return err
+		}
+
+		changes = uc.cfdiffService.Rolls(ctx, dr, other)
+	}
+
+	if format == diff.FormatJSON {
+		err = uc.cfdiffService.WriteJSON(ctx, os.Stdout, changes)
+		if err != nil {
+			return errors.Join(ErrFailedToWriteChanges, err)
+		}
+	} else {
+		uc.cfdiffService.WriteTable(ctx, os.Stdout, changes)
+	}
+
+	uc.log.InfoContext(ctx, "custom functions diff completed successfully",
+		slog.Int("changes", len(changes)))
+
+	return nil
+}
+
+// readRoll reads an EFD file and decodes it for display, keeping only the
+// frames matching where.
+func readRoll(
+	ctx context.Context,
+	log *slog.Logger,
+	efdService efd.Service,
+	displayableRollFactory display.DisplayableRollFactory,
+	filename string,
+	strict bool,
+	recovery bool,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
+) (display.DisplayableRoll, error) {
+	records, err := cli.ReadRecords(ctx, log, efdService, filename, recovery)
+	if err != nil {
+		return display.DisplayableRoll{},
+			fmt.Errorf("%w %q: %w", ErrFailedToReadFile, filename, err)
+	}
+
+	dr, err := displayableRollFactory.Create(ctx, records, strict)
+	if err != nil {
+		return display.DisplayableRoll{},
+			fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
+	}
+
+	dr, err = frames.Select(dr)
+	if err != nil {
+		return display.DisplayableRoll{},
+			fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
+	}
+
+	dr = where.Apply(dr)
+
+	log.DebugContext(ctx, "displayable custom functions created",
+		slog.String("file", filename),
+		slog.Int("frame_count", len(dr.Frames)))
+
+	return dr, nil
+}
+
+type checkUseCase struct {
+	log                    *slog.Logger
+	efdService             efd.Service
+	displayableRollFactory display.DisplayableRollFactory
+	cfdiffService          cfdiff.Service
+}
+
+func NewCheckUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	displayableRollFactory display.DisplayableRollFactory,
+	cfdiffService cfdiff.Service,
+) check.UseCase {
+	return checkUseCase{
+		log:                    log,
+		efdService:             efdService,
+		displayableRollFactory: displayableRollFactory,
+		cfdiffService:          cfdiffService,
+	}
+}
+
+func (uc checkUseCase) Check(
+	ctx context.Context,
+	efdFile string,
+	strict bool,
+	recovery bool,
+	preset domain.CustomFunctionPreset,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
+) error {
+	uc.log.InfoContext(ctx, "starting custom functions check",
+		slog.String("efd_file", efdFile),
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.String("preset", preset.Name),
+		slog.String("frames", frames.String()),
+		slog.String("where", where.String()))
+
+	dr, err := readRoll(
+		ctx,
+		uc.log,
+		uc.efdService,
+		uc.displayableRollFactory,
+		efdFile,
+		strict,
+		recovery,
+		frames,
+		where,
+	)
+	if err != nil {
+		return err
+	}
+
+	deviations := uc.cfdiffService.Check(ctx, dr, preset)
+	uc.cfdiffService.WriteDeviations(ctx, os.Stdout, preset, deviations)
+
+	uc.log.InfoContext(ctx, "custom functions check completed",
+		slog.Int("deviations", len(deviations)))
+
+	if len(deviations) > 0 {
+		return fmt.Errorf("%w %q", check.ErrFramesDifferFromPreset, preset.Name)
+	}
+
+	return nil
+}
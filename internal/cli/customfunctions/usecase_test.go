@@ -22,15 +22,24 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"text/template"
 
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/customfunctions"
+	"github.com/ma-tf/meta1v/internal/cli/customfunctions/check"
+	"github.com/ma-tf/meta1v/internal/cli/customfunctions/diff"
+	"github.com/ma-tf/meta1v/internal/domain"
 	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/cfdiff"
+	cfdiff_test "github.com/ma-tf/meta1v/internal/service/cfdiff/mocks"
 	csvexport_test "github.com/ma-tf/meta1v/internal/service/csvexport/mocks"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	display_test "github.com/ma-tf/meta1v/internal/service/display/mocks"
 	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	jsonexport_test "github.com/ma-tf/meta1v/internal/service/jsonexport/mocks"
 	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	usertemplate_test "github.com/ma-tf/meta1v/internal/service/usertemplate/mocks"
 	"go.uber.org/mock/gomock"
 )
 
@@ -67,6 +76,8 @@ func Test_CustomFunctionsUseCase_List(t *testing.T) {
 		records       records.Root
 		roll          display.DisplayableRoll
 		strict        bool
+		verbose       bool
+		presets       []domain.CustomFunctionPreset
 		expectedError error
 	}
 
@@ -140,7 +151,13 @@ func Test_CustomFunctionsUseCase_List(t *testing.T) {
 						nil,
 					)
 				mockDisplayService.EXPECT().
-					DisplayCustomFunctions(gomock.Any(), gomock.Any(), tt.roll).
+					DisplayCustomFunctions(
+						gomock.Any(),
+						gomock.Any(),
+						tt.roll,
+						tt.verbose,
+						tt.presets,
+					).
 					Return(customfunctions.ErrFailedToDisplay)
 			},
 			filename: "file.efd",
@@ -175,7 +192,13 @@ func Test_CustomFunctionsUseCase_List(t *testing.T) {
 						nil,
 					)
 				mockDisplayService.EXPECT().
-					DisplayCustomFunctions(gomock.Any(), gomock.Any(), tt.roll).
+					DisplayCustomFunctions(
+						gomock.Any(),
+						gomock.Any(),
+						tt.roll,
+						tt.verbose,
+						tt.presets,
+					).
 					Return(nil)
 			},
 			filename: "file.efd",
@@ -187,6 +210,10 @@ func Test_CustomFunctionsUseCase_List(t *testing.T) {
 			roll: display.DisplayableRoll{
 				Title: "title",
 			},
+			verbose: true,
+			presets: []domain.CustomFunctionPreset{
+				{Name: "sports", Values: map[int]byte{4: 2}},
+			},
 		},
 	}
 
@@ -218,9 +245,21 @@ func Test_CustomFunctionsUseCase_List(t *testing.T) {
 				mockEFDService,
 				mockDisplayableRollFactory,
 				mockDisplayService,
+				jsonexport_test.NewMockService(ctrl),
+				usertemplate_test.NewMockService(ctrl),
 			)
 
-			err := uc.List(ctx, tt.filename, tt.strict)
+			err := uc.List(
+				ctx,
+				tt.filename,
+				tt.strict,
+				false,
+				tt.verbose,
+				cli.Output{Format: cli.OutputTable},
+				tt.presets,
+				nil,
+				framefilter.Filter{},
+			)
 
 			if tt.expectedError != nil {
 				if err == nil {
@@ -257,6 +296,7 @@ func Test_CustomFunctionsUseCase_Export(t *testing.T) {
 		outputFile      *string
 		strict          bool
 		force           bool
+		verbose         bool
 		expect          func(
 			*efd_test.MockService,
 			*display_test.MockDisplayableRollFactory,
@@ -398,7 +438,12 @@ func Test_CustomFunctionsUseCase_Export(t *testing.T) {
 					Return(mockFile, nil)
 
 				mockCSVService.EXPECT().
-					ExportCustomFunctions(gomock.Any(), mockFile, tt.displayableRoll).
+					ExportCustomFunctions(
+						gomock.Any(),
+						mockFile,
+						tt.displayableRoll,
+						tt.verbose,
+					).
 					Return(errExample)
 			},
 			expectedError: customfunctions.ErrFailedToWriteCSV,
@@ -408,6 +453,7 @@ func Test_CustomFunctionsUseCase_Export(t *testing.T) {
 			efdFile:    "file.efd",
 			outputFile: &outputFileName,
 			force:      true,
+			verbose:    true,
 			expect: func(
 				mockEFDService *efd_test.MockService,
 				mockDisplayableRollFactory *display_test.MockDisplayableRollFactory,
@@ -433,7 +479,12 @@ func Test_CustomFunctionsUseCase_Export(t *testing.T) {
 					Return(mockFile, nil)
 
 				mockCSVService.EXPECT().
-					ExportCustomFunctions(gomock.Any(), mockFile, gomock.Any()).
+					ExportCustomFunctions(
+						gomock.Any(),
+						mockFile,
+						gomock.Any(),
+						tt.verbose,
+					).
 					Return(nil)
 			},
 		},
@@ -472,6 +523,7 @@ func Test_CustomFunctionsUseCase_Export(t *testing.T) {
 				mockDisplayableRollFactory,
 				mockCSVService,
 				mockFileSystem,
+				usertemplate_test.NewMockService(ctrl),
 			)
 
 			err := uc.Export(
@@ -479,7 +531,12 @@ func Test_CustomFunctionsUseCase_Export(t *testing.T) {
 				tt.efdFile,
 				tt.outputFile,
 				tt.strict,
+				false,
 				tt.force,
+				tt.verbose,
+				cli.Output{Format: cli.OutputCSV},
+				nil,
+				framefilter.Filter{},
 			)
 
 			if tt.expectedError != nil {
@@ -504,3 +561,507 @@ func Test_CustomFunctionsUseCase_Export(t *testing.T) {
 		})
 	}
 }
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_CustomFunctionsUseCase_Diff(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name      string
+		efdFile   string
+		otherFile *string
+		format    string
+		expect    func(
+			*efd_test.MockService,
+			*display_test.MockDisplayableRollFactory,
+			*cfdiff_test.MockService,
+			testcase,
+		)
+		expectedError error
+	}
+
+	otherFileName := "other.efd"
+	first := display.DisplayableRoll{FilmID: "12-345"}
+	second := display.DisplayableRoll{FilmID: "12-346"}
+	changes := []cfdiff.Change{{Function: 4}}
+
+	tests := []testcase{
+		{
+			name:    "failed to read file",
+			efdFile: "file.efd",
+			format:  diff.FormatTable,
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				_ *display_test.MockDisplayableRollFactory,
+				_ *cfdiff_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(records.Root{}, errExample)
+			},
+			expectedError: customfunctions.ErrFailedToReadFile,
+		},
+		{
+			name:      "failed to parse other file",
+			efdFile:   "file.efd",
+			otherFile: &otherFileName,
+			format:    diff.FormatTable,
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockDisplayableRollFactory *display_test.MockDisplayableRollFactory,
+				_ *cfdiff_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(records.Root{}, nil)
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), *tt.otherFile).
+					Return(records.Root{}, nil)
+
+				gomock.InOrder(
+					mockDisplayableRollFactory.EXPECT().
+						Create(gomock.Any(), gomock.Any(), false).
+						Return(first, nil),
+					mockDisplayableRollFactory.EXPECT().
+						Create(gomock.Any(), gomock.Any(), false).
+						Return(display.DisplayableRoll{}, errExample),
+				)
+			},
+			expectedError: customfunctions.ErrFailedToParseFile,
+		},
+		{
+			name:    "changes within a roll as a table",
+			efdFile: "file.efd",
+			format:  diff.FormatTable,
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockDisplayableRollFactory *display_test.MockDisplayableRollFactory,
+				mockCFDiffService *cfdiff_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(records.Root{}, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), gomock.Any(), false).
+					Return(first, nil)
+				mockCFDiffService.EXPECT().
+					Frames(gomock.Any(), first).
+					Return(changes)
+				mockCFDiffService.EXPECT().
+					WriteTable(gomock.Any(), gomock.Any(), changes)
+			},
+		},
+		{
+			name:      "changes across rolls as JSON",
+			efdFile:   "file.efd",
+			otherFile: &otherFileName,
+			format:    diff.FormatJSON,
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockDisplayableRollFactory *display_test.MockDisplayableRollFactory,
+				mockCFDiffService *cfdiff_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(records.Root{}, nil)
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), *tt.otherFile).
+					Return(records.Root{}, nil)
+
+				gomock.InOrder(
+					mockDisplayableRollFactory.EXPECT().
+						Create(gomock.Any(), gomock.Any(), false).
+						Return(first, nil),
+					mockDisplayableRollFactory.EXPECT().
+						Create(gomock.Any(), gomock.Any(), false).
+						Return(second, nil),
+				)
+
+				mockCFDiffService.EXPECT().
+					Rolls(gomock.Any(), first, second).
+					Return(changes)
+				mockCFDiffService.EXPECT().
+					WriteJSON(gomock.Any(), gomock.Any(), changes).
+					Return(nil)
+			},
+		},
+		{
+			name:    "failed to write JSON",
+			efdFile: "file.efd",
+			format:  diff.FormatJSON,
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockDisplayableRollFactory *display_test.MockDisplayableRollFactory,
+				mockCFDiffService *cfdiff_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(records.Root{}, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), gomock.Any(), false).
+					Return(first, nil)
+				mockCFDiffService.EXPECT().
+					Frames(gomock.Any(), first).
+					Return(changes)
+				mockCFDiffService.EXPECT().
+					WriteJSON(gomock.Any(), gomock.Any(), changes).
+					Return(errExample)
+			},
+			expectedError: customfunctions.ErrFailedToWriteChanges,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockCFDiffService := cfdiff_test.NewMockService(ctrl)
+
+			tt.expect(
+				mockEFDService,
+				mockDisplayableRollFactory,
+				mockCFDiffService,
+				tt,
+			)
+
+			uc := customfunctions.NewDiffUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				mockCFDiffService,
+			)
+
+			err := uc.Diff(
+				t.Context(),
+				tt.efdFile,
+				tt.otherFile,
+				false,
+				false,
+				tt.format,
+				nil,
+				framefilter.Filter{},
+			)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v to be in chain, got %v",
+						tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_CustomFunctionsUseCase_Check(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name   string
+		expect func(
+			*efd_test.MockService,
+			*display_test.MockDisplayableRollFactory,
+			*cfdiff_test.MockService,
+		)
+		expectedError error
+	}
+
+	const efdFile = "file.efd"
+
+	roll := display.DisplayableRoll{FilmID: "12-345"}
+	preset := domain.CustomFunctionPreset{
+		Name:   "sports",
+		Values: map[int]byte{4: 2},
+	}
+
+	tests := []testcase{
+		{
+			name: "failed to parse file",
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockDisplayableRollFactory *display_test.MockDisplayableRollFactory,
+				_ *cfdiff_test.MockService,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), efdFile).
+					Return(records.Root{}, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), gomock.Any(), false).
+					Return(display.DisplayableRoll{}, errExample)
+			},
+			expectedError: customfunctions.ErrFailedToParseFile,
+		},
+		{
+			name: "every frame matches",
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockDisplayableRollFactory *display_test.MockDisplayableRollFactory,
+				mockCFDiffService *cfdiff_test.MockService,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), efdFile).
+					Return(records.Root{}, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), gomock.Any(), false).
+					Return(roll, nil)
+				mockCFDiffService.EXPECT().
+					Check(gomock.Any(), roll, preset).
+					Return(nil)
+				mockCFDiffService.EXPECT().
+					WriteDeviations(gomock.Any(), gomock.Any(), preset, nil)
+			},
+		},
+		{
+			name: "frames differ",
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockDisplayableRollFactory *display_test.MockDisplayableRollFactory,
+				mockCFDiffService *cfdiff_test.MockService,
+			) {
+				deviations := []cfdiff.Deviation{{Function: 4}}
+
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), efdFile).
+					Return(records.Root{}, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), gomock.Any(), false).
+					Return(roll, nil)
+				mockCFDiffService.EXPECT().
+					Check(gomock.Any(), roll, preset).
+					Return(deviations)
+				mockCFDiffService.EXPECT().
+					WriteDeviations(
+						gomock.Any(),
+						gomock.Any(),
+						preset,
+						deviations,
+					)
+			},
+			expectedError: check.ErrFramesDifferFromPreset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockCFDiffService := cfdiff_test.NewMockService(ctrl)
+
+			tt.expect(
+				mockEFDService,
+				mockDisplayableRollFactory,
+				mockCFDiffService,
+			)
+
+			uc := customfunctions.NewCheckUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				mockCFDiffService,
+			)
+
+			err := uc.Check(
+				t.Context(),
+				efdFile,
+				false,
+				false,
+				preset,
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_CustomFunctionsUseCase_List_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedError error
+	}{
+		{
+			name:          "successfully write JSON",
+			err:           nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to write JSON",
+			err:           errExample,
+			expectedError: customfunctions.ErrFailedToDisplay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockJSONService := jsonexport_test.NewMockService(ctrl)
+
+			mockEFDService.EXPECT().
+				RecordsFromFile(gomock.Any(), "file.efd").
+				Return(root, nil)
+			mockDisplayableRollFactory.EXPECT().
+				Create(gomock.Any(), root, false).
+				Return(dr, nil)
+			mockJSONService.EXPECT().
+				ExportCustomFunctions(gomock.Any(), gomock.Any(), dr, gomock.Nil()).
+				Return(tt.err)
+
+			uc := customfunctions.NewListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				mockJSONService,
+				usertemplate_test.NewMockService(ctrl),
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				false,
+				cli.Output{Format: cli.OutputJSON},
+				nil,
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_CustomFunctionsUseCase_List_Template(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		parseErr      error
+		executeErr    error
+		expectedError error
+	}{
+		{
+			name:          "successfully execute template",
+			parseErr:      nil,
+			executeErr:    nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to parse template",
+			parseErr:      errExample,
+			executeErr:    nil,
+			expectedError: customfunctions.ErrFailedToDisplay,
+		},
+		{
+			name:          "failed to execute template",
+			parseErr:      nil,
+			executeErr:    errExample,
+			expectedError: customfunctions.ErrFailedToDisplay,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockTemplateService := usertemplate_test.NewMockService(ctrl)
+			tmpl := template.Must(template.New("template").Parse("{{.FilmID}}"))
+
+			mockTemplateService.EXPECT().
+				Parse(gomock.Any(), "{{.FilmID}}").
+				Return(tmpl, tt.parseErr)
+
+			if tt.parseErr == nil {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), root, false).
+					Return(dr, nil)
+				mockTemplateService.EXPECT().
+					Execute(gomock.Any(), gomock.Any(), tmpl, dr).
+					Return(tt.executeErr)
+			}
+
+			uc := customfunctions.NewListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				jsonexport_test.NewMockService(ctrl),
+				mockTemplateService,
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				false,
+				cli.Output{
+					Format:   cli.OutputTable,
+					Template: "{{.FilmID}}",
+				},
+				nil,
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=edit_test github.com/ma-tf/meta1v/internal/cli/edit UseCase
+
+// Package edit provides the CLI command for editing user-entered text in EFD files.
+package edit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrNoEditsSpecified = errors.New(
+		"no edits specified, use --title, --remarks or --frame/--frame-remarks",
+	)
+	ErrMismatchedFrameRemarks = errors.New(
+		"each --frame must be paired with exactly one --frame-remarks",
+	)
+	ErrDuplicateFrameEdit   = errors.New("frame specified more than once")
+	ErrInvalidFrameNumber   = errors.New("invalid specified frame number")
+	ErrFailedToGetEditFlags = errors.New("failed to get edit flags")
+)
+
+// Edits describes the changes to apply to an EFD file.
+// Nil fields and frames missing from FrameRemarks are left unchanged.
+type Edits struct {
+	Title        *string
+	Remarks      *string
+	FrameRemarks map[uint32]string
+}
+
+// UseCase defines the business logic for editing user-entered text in EFD files.
+type UseCase interface {
+	// Edit applies the edits to an EFD file in place, keeping a backup of the original.
+	// In recovery mode any unreadable regions are dropped from the rewritten file.
+	Edit(ctx context.Context, efdFile string, edits Edits, recovery bool) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit <efd_file>",
+		Short: "Edit roll title, roll remarks and frame remarks in an EFD file",
+		Long: `Edit the user-entered text stored in an EFD file: the roll title (up to 63 bytes),
+the roll remarks (up to 255 bytes) and the remarks of individual frames (up to 255 bytes).
+
+Frames are selected by their recorded frame number, and any frame whose remarks change
+is flagged as a user-modified record. All other bytes of the file are written back as
+they were. The file is rewritten atomically and the original is kept alongside it with
+a .bak extension; an existing .bak is never overwritten. With --recover, records that
+cannot be read are reported and left out of the rewritten file.`,
+		Example: `  # Set the roll title and remarks
+  meta1v edit data.efd --title "Portra 400" --remarks "Pushed one stop"
+
+  # Set the remarks of frames 3 and 12
+  meta1v edit data.efd --frame 3 --frame-remarks "Harbour" \
+    --frame 12 --frame-remarks "Lighthouse"
+
+  # Clear the roll remarks
+  meta1v edit data.efd --remarks ""`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			edits, err := editsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.Any("title", edits.Title),
+				slog.Any("remarks", edits.Remarks),
+				slog.Int("frame_edits", len(edits.FrameRemarks)),
+				slog.Bool("recover", recovery),
+			)
+
+			return uc.Edit(ctx, args[0], edits, recovery)
+		},
+	}
+
+	cmd.Flags().String("title", "", "new roll title")
+	cmd.Flags().String("remarks", "", "new roll remarks")
+	cmd.Flags().UintSlice("frame", nil, "frame number to edit (repeatable)")
+	cmd.Flags().StringArray(
+		"frame-remarks",
+		nil,
+		"new remarks for the matching --frame (repeatable)",
+	)
+
+	return cmd
+}
+
+func editsFromFlags(cmd *cobra.Command) (Edits, error) {
+	edits := Edits{
+		Title:        nil,
+		Remarks:      nil,
+		FrameRemarks: map[uint32]string{},
+	}
+
+	if cmd.Flags().Changed("title") {
+		title, err := cmd.Flags().GetString("title")
+		if err != nil {
+			return Edits{}, errors.Join(ErrFailedToGetEditFlags, err)
+		}
+
+		edits.Title = &title
+	}
+
+	if cmd.Flags().Changed("remarks") {
+		remarks, err := cmd.Flags().GetString("remarks")
+		if err != nil {
+			return Edits{}, errors.Join(ErrFailedToGetEditFlags, err)
+		}
+
+		edits.Remarks = &remarks
+	}
+
+	frames, err := cmd.Flags().GetUintSlice("frame")
+	if err != nil {
+		return Edits{}, errors.Join(ErrFailedToGetEditFlags, err)
+	}
+
+	frameRemarks, err := cmd.Flags().GetStringArray("frame-remarks")
+	if err != nil {
+		return Edits{}, errors.Join(ErrFailedToGetEditFlags, err)
+	}
+
+	if len(frames) != len(frameRemarks) {
+		return Edits{}, fmt.Errorf("%w: got %d frames and %d remarks",
+			ErrMismatchedFrameRemarks, len(frames), len(frameRemarks))
+	}
+
+	for i, frame := range frames {
+		if frame > math.MaxUint32 {
+			return Edits{}, fmt.Errorf("%w: %d", ErrInvalidFrameNumber, frame)
+		}
+
+		if _, ok := edits.FrameRemarks[uint32(frame)]; ok {
+			return Edits{}, fmt.Errorf("%w: frame number %d",
+				ErrDuplicateFrameEdit, frame)
+		}
+
+		edits.FrameRemarks[uint32(frame)] = frameRemarks[i]
+	}
+
+	if edits.Title == nil && edits.Remarks == nil &&
+		len(edits.FrameRemarks) == 0 {
+		return Edits{}, ErrNoEditsSpecified
+	}
+
+	return edits, nil
+}
@@ -0,0 +1,163 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package edit_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/edit"
+	edit_test "github.com/ma-tf/meta1v/internal/cli/edit/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct // only partial is needed
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	title, emptyRemarks := "Portra 400", ""
+
+	type testcase struct {
+		name            string
+		args            []string
+		registerRecover bool
+		expect          func(mockUseCase *edit_test.MockUseCase, tc testcase)
+		expectedError   error
+	}
+
+	tests := []testcase{
+		{
+			name:          "no edits specified",
+			args:          []string{"file.efd"},
+			expectedError: edit.ErrNoEditsSpecified,
+		},
+		{
+			name: "frame without remarks",
+			args: []string{
+				"file.efd",
+				"--frame", "1",
+				"--frame", "2",
+				"--frame-remarks", "one",
+			},
+			expectedError: edit.ErrMismatchedFrameRemarks,
+		},
+		{
+			name: "frame specified twice",
+			args: []string{
+				"file.efd",
+				"--frame", "1", "--frame-remarks", "one",
+				"--frame", "1", "--frame-remarks", "uno",
+			},
+			expectedError: edit.ErrDuplicateFrameEdit,
+		},
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd", "--title", title},
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name: "title and cleared remarks",
+			args: []string{
+				"file.efd",
+				"--title", title,
+				"--remarks", "",
+			},
+			registerRecover: true,
+			expect: func(mockUseCase *edit_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Edit(gomock.Any(), tc.args[0], edit.Edits{
+						Title:        &title,
+						Remarks:      &emptyRemarks,
+						FrameRemarks: map[uint32]string{},
+					}, false).
+					Return(nil)
+			},
+		},
+		{
+			name: "paired frame remarks",
+			args: []string{
+				"file.efd",
+				"--frame", "3", "--frame-remarks", "Harbour",
+				"--frame", "12", "--frame-remarks", "Lighthouse, dusk",
+			},
+			registerRecover: true,
+			expect: func(mockUseCase *edit_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Edit(gomock.Any(), tc.args[0], edit.Edits{
+						FrameRemarks: map[uint32]string{
+							3:  "Harbour",
+							12: "Lighthouse, dusk",
+						},
+					}, false).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := edit_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase, tt)
+			}
+
+			cmd := edit.NewCommand(logger, mockUseCase)
+			cmd.SilenceUsage = true
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
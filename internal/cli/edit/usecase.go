@@ -0,0 +1,161 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package edit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+)
+
+var (
+	ErrFailedToReadFile     = errors.New("failed to read file for edit")
+	ErrFailedToApplyEdits   = errors.New("failed to apply edits")
+	ErrFrameNumberNotFound  = cli.ErrFrameNumberNotFound
+	ErrDuplicateFrameNumber = cli.ErrDuplicateFrameNumber
+	ErrFailedToWriteFile    = cli.ErrFailedToWriteFile
+	ErrFailedToBackupFile   = cli.ErrFailedToBackupFile
+	ErrFailedToReplaceFile  = cli.ErrFailedToReplaceFile
+)
+
+type usecase struct {
+	log        *slog.Logger
+	efdService efd.Service
+	fs         osfs.FileSystem
+}
+
+func NewUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	fs osfs.FileSystem,
+) UseCase {
+	return usecase{
+		log:        log,
+		efdService: efdService,
+		fs:         fs,
+	}
+}
+
+func (uc usecase) Edit(
+	ctx context.Context,
+	efdFile string,
+	edits Edits,
+	recovery bool,
+) error {
+	uc.log.InfoContext(ctx, "starting edit",
+		slog.String("efd_file", efdFile),
+		slog.Int("frame_edits", len(edits.FrameRemarks)),
+		slog.Bool("recover", recovery))
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	changed, err := applyEdits(&root, edits)
+	if err != nil {
+		return fmt.Errorf("%w to %q: %w", ErrFailedToApplyEdits, efdFile, err)
+	}
+
+	if !changed {
+		uc.log.InfoContext(ctx, "no changes to write",
+			slog.String("efd_file", efdFile))
+
+		return nil
+	}
+
+	backupFile, err := cli.RewriteRecords(
+		ctx,
+		uc.log,
+		uc.efdService,
+		uc.fs,
+		efdFile,
+		root,
+	)
+	if err != nil {
+		return err //nolint:wrapcheck // already descriptive
+	}
+
+	uc.log.InfoContext(ctx, "edit completed successfully",
+		slog.String("efd_file", efdFile),
+		slog.String("backup_file", backupFile))
+
+	return nil
+}
+
+// applyEdits mutates root with the requested edits and reports whether anything changed.
+// Frames whose remarks change are flagged as user-modified records.
+func applyEdits(root *records.Root, edits Edits) (bool, error) {
+	changed := false
+
+	if edits.Title != nil {
+		title, err := domain.EncodeTitle(*edits.Title)
+		if err != nil {
+			return false, err //nolint:wrapcheck // domain errors are descriptive
+		}
+
+		changed = changed || title != root.EFDF.Title
+		root.EFDF.Title = title
+	}
+
+	if edits.Remarks != nil {
+		remarks, err := domain.EncodeRemarks(*edits.Remarks)
+		if err != nil {
+			return false, err //nolint:wrapcheck // domain errors are descriptive
+		}
+
+		changed = changed || remarks != root.EFDF.Remarks
+		root.EFDF.Remarks = remarks
+	}
+
+	frameNumbers := make([]uint32, 0, len(edits.FrameRemarks))
+	for frameNumber := range edits.FrameRemarks {
+		frameNumbers = append(frameNumbers, frameNumber)
+	}
+
+	slices.Sort(frameNumbers)
+
+	for _, frameNumber := range frameNumbers {
+		remarks, err := domain.EncodeRemarks(edits.FrameRemarks[frameNumber])
+		if err != nil {
+			return false, fmt.Errorf("frame %d: %w", frameNumber, err)
+		}
+
+		i, err := cli.FindFrame(root.EFRMs, frameNumber)
+		if err != nil {
+			return false, err //nolint:wrapcheck // already descriptive
+		}
+
+		efrm := &root.EFRMs[i]
+
+		if efrm.Remarks != remarks {
+			efrm.Remarks = remarks
+			efrm.IsModifiedRecord = 1
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
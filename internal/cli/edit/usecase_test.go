@@ -0,0 +1,615 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package edit_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli/edit"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	backupFlags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	permissions = os.FileMode(0o666)
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+func mustRemarks(t *testing.T, s string) [256]byte {
+	t.Helper()
+
+	r, err := domain.EncodeRemarks(s)
+	if err != nil {
+		t.Fatalf("failed to encode remarks: %v", err)
+	}
+
+	return r
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_EditUseCase(t *testing.T) {
+	t.Parallel()
+
+	title := "Portra 400"
+	longTitle := strings.Repeat("t", 64)
+
+	type testcase struct {
+		name   string
+		edits  edit.Edits
+		root   records.Root
+		expect func(
+			mockEFDService *efd_test.MockService,
+			mockFileSystem *osfs_test.MockFileSystem,
+			mockOriginal *osfs_test.MockFile,
+			mockBackup *osfs_test.MockFile,
+			tt testcase,
+		)
+		expectedError error
+	}
+
+	frameOneEdited := func(t *testing.T) records.Root {
+		t.Helper()
+
+		return records.Root{
+			EFRMs: []records.EFRM{
+				{
+					FrameNumber:      1,
+					Remarks:          mustRemarks(t, "edited"),
+					IsModifiedRecord: 1,
+				},
+				{FrameNumber: 2},
+			},
+		}
+	}
+
+	tests := []testcase{
+		{
+			name:  "failed to read file",
+			edits: edit.Edits{Title: &title},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				_ *osfs_test.MockFileSystem,
+				_ *osfs_test.MockFile,
+				_ *osfs_test.MockFile,
+				_ testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: edit.ErrFailedToReadFile,
+		},
+		{
+			name:  "title too long",
+			edits: edit.Edits{Title: &longTitle},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				_ *osfs_test.MockFileSystem,
+				_ *osfs_test.MockFile,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(tt.root, nil)
+			},
+			expectedError: domain.ErrTextTooLong,
+		},
+		{
+			name: "frame number not found",
+			edits: edit.Edits{
+				FrameRemarks: map[uint32]string{9: "missing"},
+			},
+			root: records.Root{EFRMs: []records.EFRM{{FrameNumber: 1}}},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				_ *osfs_test.MockFileSystem,
+				_ *osfs_test.MockFile,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(tt.root, nil)
+			},
+			expectedError: edit.ErrFrameNumberNotFound,
+		},
+		{
+			name: "duplicate frame number",
+			edits: edit.Edits{
+				FrameRemarks: map[uint32]string{1: "ambiguous"},
+			},
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}, {FrameNumber: 1}},
+			},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				_ *osfs_test.MockFileSystem,
+				_ *osfs_test.MockFile,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(tt.root, nil)
+			},
+			expectedError: edit.ErrDuplicateFrameNumber,
+		},
+		{
+			name: "unchanged remarks are not written",
+			edits: edit.Edits{
+				FrameRemarks: map[uint32]string{1: "same"},
+			},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				_ *osfs_test.MockFileSystem,
+				_ *osfs_test.MockFile,
+				_ *osfs_test.MockFile,
+				_ testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(records.Root{
+						EFRMs: []records.EFRM{{
+							FrameNumber: 1,
+							Remarks:     mustRemarks(t, "same"),
+						}},
+					}, nil)
+			},
+		},
+		{
+			name: "failed to write edited file",
+			edits: edit.Edits{
+				FrameRemarks: map[uint32]string{1: "edited"},
+			},
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}, {FrameNumber: 2}},
+			},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockFileSystem *osfs_test.MockFileSystem,
+				_ *osfs_test.MockFile,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(tt.root, nil)
+
+				mockEFDService.EXPECT().
+					RecordsToFile(gomock.Any(), "file.efd.tmp", frameOneEdited(t)).
+					Return(errExample)
+
+				mockFileSystem.EXPECT().
+					Remove("file.efd.tmp").
+					Return(nil)
+			},
+			expectedError: edit.ErrFailedToWriteFile,
+		},
+		{
+			name: "failed to back up original",
+			edits: edit.Edits{
+				FrameRemarks: map[uint32]string{1: "edited"},
+			},
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}, {FrameNumber: 2}},
+			},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockFileSystem *osfs_test.MockFileSystem,
+				_ *osfs_test.MockFile,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(tt.root, nil)
+
+				mockEFDService.EXPECT().
+					RecordsToFile(gomock.Any(), "file.efd.tmp", frameOneEdited(t)).
+					Return(nil)
+
+				mockFileSystem.EXPECT().
+					Stat("file.efd.bak").
+					Return(nil, os.ErrNotExist)
+
+				mockFileSystem.EXPECT().
+					Open("file.efd").
+					Return(nil, errExample)
+
+				mockFileSystem.EXPECT().
+					Remove("file.efd.tmp").
+					Return(nil)
+			},
+			expectedError: edit.ErrFailedToBackupFile,
+		},
+		{
+			name: "failed to replace original",
+			edits: edit.Edits{
+				FrameRemarks: map[uint32]string{1: "edited"},
+			},
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}, {FrameNumber: 2}},
+			},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockOriginal *osfs_test.MockFile,
+				mockBackup *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(tt.root, nil)
+
+				mockEFDService.EXPECT().
+					RecordsToFile(gomock.Any(), "file.efd.tmp", frameOneEdited(t)).
+					Return(nil)
+
+				mockFileSystem.EXPECT().
+					Stat("file.efd.bak").
+					Return(nil, os.ErrNotExist)
+
+				mockFileSystem.EXPECT().
+					Open("file.efd").
+					Return(mockOriginal, nil)
+
+				mockFileSystem.EXPECT().
+					OpenFile("file.efd.bak", backupFlags, permissions).
+					Return(mockBackup, nil)
+
+				mockOriginal.EXPECT().
+					Read(gomock.Any()).
+					Return(0, io.EOF)
+
+				mockOriginal.EXPECT().Close().Return(nil)
+				mockBackup.EXPECT().Close().Return(nil)
+
+				mockFileSystem.EXPECT().
+					Rename("file.efd.tmp", "file.efd").
+					Return(errExample)
+
+				mockFileSystem.EXPECT().
+					Remove("file.efd.tmp").
+					Return(nil)
+			},
+			expectedError: edit.ErrFailedToReplaceFile,
+		},
+		{
+			name: "successfully edited file",
+			edits: edit.Edits{
+				FrameRemarks: map[uint32]string{1: "edited"},
+			},
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}, {FrameNumber: 2}},
+			},
+			expect: func(
+				mockEFDService *efd_test.MockService,
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockOriginal *osfs_test.MockFile,
+				mockBackup *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(tt.root, nil)
+
+				mockEFDService.EXPECT().
+					RecordsToFile(gomock.Any(), "file.efd.tmp", frameOneEdited(t)).
+					Return(nil)
+
+				mockFileSystem.EXPECT().
+					Stat("file.efd.bak").
+					Return(nil, os.ErrNotExist)
+
+				mockFileSystem.EXPECT().
+					Open("file.efd").
+					Return(mockOriginal, nil)
+
+				mockFileSystem.EXPECT().
+					OpenFile("file.efd.bak", backupFlags, permissions).
+					Return(mockBackup, nil)
+
+				gomock.InOrder(
+					mockOriginal.EXPECT().
+						Read(gomock.Any()).
+						DoAndReturn(func(p []byte) (int, error) {
+							return copy(p, "EFDF"), nil
+						}),
+					mockOriginal.EXPECT().
+						Read(gomock.Any()).
+						Return(0, io.EOF),
+				)
+
+				mockBackup.EXPECT().
+					Write([]byte("EFDF")).
+					Return(4, nil)
+
+				mockOriginal.EXPECT().Close().Return(nil)
+				mockBackup.EXPECT().Close().Return(nil)
+
+				mockFileSystem.EXPECT().
+					Rename("file.efd.tmp", "file.efd").
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
+			mockOriginal := osfs_test.NewMockFile(ctrl)
+			mockBackup := osfs_test.NewMockFile(ctrl)
+
+			tt.expect(
+				mockEFDService,
+				mockFileSystem,
+				mockOriginal,
+				mockBackup,
+				tt,
+			)
+
+			uc := edit.NewUseCase(
+				newTestLogger(),
+				mockEFDService,
+				mockFileSystem,
+			)
+
+			err := uc.Edit(t.Context(), "file.efd", tt.edits, false)
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+// Test_Edit_Twice edits the same file twice and checks the backup still holds
+// the file as it was before the first edit.
+//
+//nolint:exhaustruct // for records
+func Test_Edit_Twice(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	log := newTestLogger()
+	fs := osfs.NewFileSystem()
+	efdService := efd.NewService(
+		log,
+		efd.NewRootBuilder(log),
+		efd.NewReader(log, records.NewDefaultThumbnailFactory()),
+		efd.NewWriter(log),
+		fs,
+	)
+
+	efdFile := filepath.Join(t.TempDir(), "roll.efd")
+
+	err := efdService.RecordsToFile(ctx, efdFile, records.Root{
+		EFDF:  records.EFDF{Title: [64]byte{'o', 'r', 'i', 'g', 'i', 'n', 'a', 'l'}},
+		EFRMs: []records.EFRM{{FrameNumber: 1}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	original, err := os.ReadFile(efdFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	uc := edit.NewUseCase(log, efdService, fs)
+
+	for _, title := range []string{"first", "second"} {
+		if err = uc.Edit(ctx, efdFile, edit.Edits{Title: &title}, false); err != nil {
+			t.Fatalf("unexpected error editing title to %q: %v", title, err)
+		}
+	}
+
+	backup, err := os.ReadFile(efdFile + ".bak")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(backup, original) {
+		t.Fatal("expected the backup to keep the file from before the first edit")
+	}
+
+	root, err := efdService.RecordsFromFile(ctx, efdFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := domain.NewTitle(root.EFDF.Title); got != "second" {
+		t.Fatalf("expected title %q, got %q", "second", got)
+	}
+}
+
+// Test_Edit_OnlyEditedBytes edits a file whose records carry header bytes,
+// trailing bytes and a thumbnail between the frames, and checks that only the
+// bytes of the edited fields changed.
+//
+//nolint:exhaustruct // for records
+func Test_Edit_OnlyEditedBytes(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	log := newTestLogger()
+	fs := osfs.NewFileSystem()
+	efdService := efd.NewService(
+		log,
+		efd.NewRootBuilder(log),
+		efd.NewReader(log, records.NewDefaultThumbnailFactory()),
+		efd.NewWriter(log),
+		fs,
+	)
+
+	efdf := encodeRecord(t, records.MagicEFDF, [4]byte{1, 0, 2, 0},
+		records.EFDF{FrameCount: 2, Title: [64]byte{'o', 'l', 'd'}}, nil)
+	efrm1 := encodeRecord(t, records.MagicEFRM, [4]byte{3, 4, 5, 6},
+		records.EFRM{FrameNumber: 1, Unknown14: [64]byte{0xCA, 0xFE}}, nil)
+	eftp := encodeRecord(t, records.MagicEFTP, [4]byte{7, 8, 9, 10},
+		struct {
+			Index, Unknown, Width, Height uint16
+			Unknown3                      [8]byte
+			Filepath                      [256]byte
+			Pixels                        [3]byte
+		}{Index: 1, Width: 1, Height: 1, Pixels: [3]byte{1, 2, 3}},
+		[]byte{0x77, 0x88})
+	efrm2 := encodeRecord(t, records.MagicEFRM, [4]byte{},
+		records.EFRM{FrameNumber: 2}, []byte{0x99})
+
+	original := bytes.Join([][]byte{efdf, efrm1, eftp, efrm2}, nil)
+
+	efdFile := filepath.Join(t.TempDir(), "roll.efd")
+	if err := os.WriteFile(efdFile, original, 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	title, remarks, frameRemarks := "new title", "roll remarks", "frame remarks"
+
+	err := edit.NewUseCase(log, efdService, fs).Edit(ctx, efdFile, edit.Edits{
+		Title:        &title,
+		Remarks:      &remarks,
+		FrameRemarks: map[uint32]string{2: frameRemarks},
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encodedTitle, err := domain.EncodeTitle(title)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encodedRemarks := mustRemarks(t, remarks)
+	encodedFrameRemarks := mustRemarks(t, frameRemarks)
+
+	efrm2Offset := len(efdf) + len(efrm1) + len(eftp)
+
+	expected := bytes.Clone(original)
+	copy(expected[fieldOffset(t, records.MagicEFDF, "Title"):],
+		encodedTitle[:])
+	copy(expected[fieldOffset(t, records.MagicEFDF, "Remarks"):],
+		encodedRemarks[:])
+	copy(expected[efrm2Offset+fieldOffset(t, records.MagicEFRM, "Remarks"):],
+		encodedFrameRemarks[:])
+	// edited frames are flagged as modified by the user
+	expected[efrm2Offset+fieldOffset(t, records.MagicEFRM, "IsModifiedRecord")] = 1
+
+	got, err := os.ReadFile(efdFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		for i := range min(len(got), len(expected)) {
+			if got[i] != expected[i] {
+				t.Fatalf("unexpected change at byte %d: expected %#x, got %#x",
+					i, expected[i], got[i])
+			}
+		}
+
+		t.Fatalf("expected %d bytes, got %d", len(expected), len(got))
+	}
+}
+
+// encodeRecord encodes data as a record with the given header bytes, followed
+// by trailing.
+func encodeRecord(
+	t *testing.T,
+	magic string,
+	unknown [4]byte,
+	data any,
+	trailing []byte,
+) []byte {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	if err := binary.Write(body, binary.LittleEndian, data); err != nil {
+		t.Fatalf("failed to encode %s record: %v", magic, err)
+	}
+
+	_, _ = body.Write(trailing)
+
+	buf := &bytes.Buffer{}
+	_, _ = buf.WriteString(magic)
+	_, _ = buf.Write(unknown[:])
+	_ = binary.Write(buf, binary.LittleEndian,
+		uint64(records.HeaderSize+body.Len()))
+	_, _ = buf.Write(body.Bytes())
+
+	return buf.Bytes()
+}
+
+// fieldOffset returns the offset of the named field from the start of a record.
+func fieldOffset(t *testing.T, magic, name string) int {
+	t.Helper()
+
+	for _, f := range records.Layout(magic) {
+		if f.Name == name {
+			return f.Offset
+		}
+	}
+
+	t.Fatalf("no field %q in %s records", name, magic)
+
+	return 0
+}
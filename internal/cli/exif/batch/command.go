@@ -0,0 +1,251 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=batch_test github.com/ma-tf/meta1v/internal/cli/exif/batch UseCase
+
+// Package batch provides the CLI command for writing the EXIF metadata of a
+// whole roll to a directory of scans.
+package batch
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	numArgs    = 2
+	frameWidth = 6
+)
+
+var (
+	ErrFailedToGetPatternFlag = errors.New("failed to get pattern flag")
+	ErrFailedToGetYesFlag     = errors.New("failed to get yes flag")
+	ErrNothingToWrite         = errors.New("no frame matched a scan")
+)
+
+// Options selects the frames of a batch and how they are mapped to scans.
+type Options struct {
+	Pattern  string // file name template, sorted-order matching if empty
+	Frames   cli.FrameRanges
+	Where    framefilter.Filter
+	Recovery bool
+}
+
+// Entry is a frame and the scan its EXIF data is written to.
+type Entry struct {
+	Frame records.EFRM
+	File  string
+}
+
+// Plan is the mapping of the frames of a roll to the scans in a directory.
+type Plan struct {
+	Entries   []Entry
+	Unmatched []uint32 // frame numbers without a scan
+	Unused    []string // scans without a frame
+}
+
+// UseCase defines the business logic for writing EXIF metadata to a directory
+// of scans.
+type UseCase interface {
+	// Plan maps the selected frames of efdFile to the scans in scanDir, by
+	// expanding opts.Pattern for every frame or, without a pattern, by pairing
+	// the frames in frame number order with the images in file name order.
+	// Frames that do not match opts.Where are left out of the plan.
+	Plan(
+		ctx context.Context,
+		efdFile string,
+		scanDir string,
+		opts Options,
+	) (Plan, error)
+
+	// Write writes the EXIF data of every entry of plan to its scan, running
+	// exiftool once for all of them, carrying on past failures and reporting
+	// the outcome of each file to w.
+	Write(
+		ctx context.Context,
+		w io.Writer,
+		plan Plan,
+		strict bool,
+		backend exif.Backend,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch <efd_file> <scan_dir>",
+		Short: "Write EXIF metadata from EFD file to a directory of scans",
+		Long: `Write the EXIF data of every frame of a roll to its scan in a directory, parsing the
+EFD file once.
+
+With --pattern, the scan of each frame is named by a template: {frame} is replaced
+by the frame number and {index} by the position of the frame on the roll, starting
+at 1. A width pads the number with zeros, so {frame:02} names frame 7 "07". The
+pattern may include subdirectories of scan_dir.
+
+Without --pattern, the images in scan_dir (JPEG, TIFF, PNG and DNG files) are
+sorted by file name and paired with the frames in frame number order, which suits
+scans numbered by the scanner.
+
+The planned mapping is shown and has to be confirmed before anything is written,
+unless --yes is given. exiftool is run once for all the scans. Every scan is
+attempted even if an earlier one fails, and the outcome of each is reported at the
+end.
+
+With --frames, only the frames with the given numbers are written, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+With --where, only the frames matching a filter expression are written; see the
+README for the fields and operators available.
+
+With --backend native, the scans are written without exiftool; only JPEG and TIFF
+scans are supported.`,
+		Example: `  # Write EXIF to scans named 01.tif, 02.tif, ...
+  meta1v exif batch data.efd scans --pattern '{frame:02}.tif'
+
+  # Pair the frames with the images in scans in file name order
+  meta1v exif batch data.efd scans
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll, without confirmation
+  meta1v exif batch data.efd scans --frames 1-5,12,30- --yes
+
+  # Write without exiftool
+  meta1v exif batch data.efd scans --backend native`,
+		Args: cobra.ExactArgs(numArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			strict, err := cmd.Flags().GetBool("strict")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
+			}
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			pattern, err := cmd.Flags().GetString("pattern")
+			if err != nil {
+				return errors.Join(ErrFailedToGetPatternFlag, err)
+			}
+
+			yes, err := cmd.Flags().GetBool("yes")
+			if err != nil {
+				return errors.Join(ErrFailedToGetYesFlag, err)
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
+			backend, err := cli.GetBackend(cmd, viper.GetViper())
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.String("scan_dir", args[1]),
+				slog.String("pattern", pattern),
+				slog.Bool("yes", yes),
+				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()),
+				slog.String("backend", string(backend)))
+
+			plan, err := uc.Plan(ctx, args[0], args[1], Options{
+				Pattern:  pattern,
+				Frames:   frames,
+				Where:    where,
+				Recovery: recovery,
+			})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			printPlan(out, plan)
+
+			if len(plan.Entries) == 0 {
+				return ErrNothingToWrite
+			}
+
+			if !yes && !confirm(cmd.InOrStdin(), out) {
+				fmt.Fprintln(out, "nothing written")
+
+				return nil
+			}
+
+			return uc.Write(ctx, out, plan, strict, backend)
+		},
+	}
+
+	cmd.Flags().String("pattern", "",
+		"file name template of the scans, e.g. '{frame:02}.tif'")
+	cmd.Flags().
+		BoolP("yes", "y", false, "write without asking for confirmation")
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+	cli.AddBackendFlag(cmd)
+
+	return cmd
+}
+
+func printPlan(w io.Writer, plan Plan) {
+	fmt.Fprintf(w, "%-*s %s\n", frameWidth, "FRAME", "FILE")
+
+	for _, e := range plan.Entries {
+		fmt.Fprintf(w, "%-*d %s\n", frameWidth, e.Frame.FrameNumber, e.File)
+	}
+
+	for _, n := range plan.Unmatched {
+		fmt.Fprintf(w, "frame %d: no scan\n", n)
+	}
+
+	for _, f := range plan.Unused {
+		fmt.Fprintf(w, "%s: no frame\n", f)
+	}
+}
+
+// confirm asks whether the plan should be written, reading the answer from r.
+// Anything but yes, including the end of the input, declines.
+func confirm(r io.Reader, w io.Writer) bool {
+	fmt.Fprint(w, "Write EXIF data to these files? [y/N] ")
+
+	answer, _ := bufio.NewReader(r).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,279 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package batch_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/exif/batch"
+	batch_test "github.com/ma-tf/meta1v/internal/cli/exif/batch/mocks"
+	"github.com/ma-tf/meta1v/internal/records"
+	exifservice "github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newPlan() batch.Plan {
+	return batch.Plan{
+		Entries: []batch.Entry{
+			{Frame: records.EFRM{FrameNumber: 1}, File: "scans/01.tif"},
+			{Frame: records.EFRM{FrameNumber: 2}, File: "scans/02.tif"},
+		},
+		Unmatched: []uint32{3},
+		Unused:    []string{"scans/extra.tif"},
+	}
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_CommandRun(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name            string
+		args            []string
+		stdin           string
+		registerStrict  bool
+		registerRecover bool
+		expect          func(uc *batch_test.MockUseCase, tt testcase)
+		expectedOutput  []string
+		expectedError   error
+	}
+
+	tests := []testcase{
+		{
+			name:           "strict flag not registered",
+			args:           []string{"file.efd", "scans"},
+			registerStrict: false,
+			expectedError:  cli.ErrFailedToGetStrictFlag,
+		},
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd", "scans"},
+			registerStrict:  true,
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "invalid frame range",
+			args:            []string{"file.efd", "scans", "--frames", "5-2"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   cli.ErrInvalidFrameRange,
+		},
+		{
+			name:            "invalid where expression",
+			args:            []string{"file.efd", "scans", "--where", "av <="},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   framefilter.ErrInvalidExpression,
+		},
+		{
+			name:            "failed to plan",
+			args:            []string{"file.efd", "scans"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(uc *batch_test.MockUseCase, _ testcase) {
+				uc.EXPECT().
+					Plan(gomock.Any(), "file.efd", "scans", gomock.Any()).
+					Return(batch.Plan{}, errExample)
+			},
+			expectedError: errExample,
+		},
+		{
+			name:            "nothing to write",
+			args:            []string{"file.efd", "scans"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(uc *batch_test.MockUseCase, _ testcase) {
+				uc.EXPECT().
+					Plan(gomock.Any(), "file.efd", "scans", gomock.Any()).
+					Return(batch.Plan{Unmatched: []uint32{1}}, nil)
+			},
+			expectedOutput: []string{"frame 1: no scan"},
+			expectedError:  batch.ErrNothingToWrite,
+		},
+		{
+			name: "confirmed",
+			args: []string{
+				"file.efd",
+				"scans",
+				"--pattern",
+				"{frame:02}.tif",
+				"--frames",
+				"1-3",
+			},
+			stdin:           "y\n",
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(uc *batch_test.MockUseCase, _ testcase) {
+				uc.EXPECT().
+					Plan(gomock.Any(), "file.efd", "scans", batch.Options{
+						Pattern:  "{frame:02}.tif",
+						Frames:   cli.FrameRanges{{First: 1, Last: 3}},
+						Where:    framefilter.Filter{},
+						Recovery: false,
+					}).
+					Return(newPlan(), nil)
+				uc.EXPECT().
+					Write(
+						gomock.Any(),
+						gomock.Any(),
+						newPlan(),
+						false,
+						exifservice.BackendExiftool,
+					).
+					DoAndReturn(func(
+						_ context.Context,
+						w io.Writer,
+						_ batch.Plan,
+						_ bool,
+						_ exifservice.Backend,
+					) error {
+						fmt.Fprintln(w, "2 written, 0 failed")
+
+						return nil
+					})
+			},
+			expectedOutput: []string{
+				"FRAME  FILE",
+				"1      scans/01.tif",
+				"2      scans/02.tif",
+				"frame 3: no scan",
+				"scans/extra.tif: no frame",
+				"[y/N]",
+				"2 written, 0 failed",
+			},
+		},
+		{
+			name:            "declined",
+			args:            []string{"file.efd", "scans"},
+			stdin:           "n\n",
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(uc *batch_test.MockUseCase, _ testcase) {
+				uc.EXPECT().
+					Plan(gomock.Any(), "file.efd", "scans", gomock.Any()).
+					Return(newPlan(), nil)
+			},
+			expectedOutput: []string{"nothing written"},
+		},
+		{
+			name:            "no answer",
+			args:            []string{"file.efd", "scans"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(uc *batch_test.MockUseCase, _ testcase) {
+				uc.EXPECT().
+					Plan(gomock.Any(), "file.efd", "scans", gomock.Any()).
+					Return(newPlan(), nil)
+			},
+			expectedOutput: []string{"nothing written"},
+		},
+		{
+			name: "yes flag skips confirmation",
+			args: []string{
+				"file.efd",
+				"scans",
+				"--yes",
+				"--strict",
+				"--backend",
+				"native",
+			},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(uc *batch_test.MockUseCase, _ testcase) {
+				uc.EXPECT().
+					Plan(gomock.Any(), "file.efd", "scans", gomock.Any()).
+					Return(newPlan(), nil)
+				uc.EXPECT().
+					Write(
+						gomock.Any(),
+						gomock.Any(),
+						newPlan(),
+						true,
+						exifservice.BackendNative,
+					).
+					Return(errExample)
+			},
+			expectedError: errExample,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := batch_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase, tt)
+			}
+
+			cmd := batch.NewCommand(logger, mockUseCase)
+			if tt.registerStrict {
+				cmd.Flags().Bool("strict", false, "enable strict mode")
+			}
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetIn(strings.NewReader(tt.stdin))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			for _, want := range tt.expectedOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s",
+						want, out.String())
+				}
+			}
+		})
+	}
+}
@@ -22,40 +22,106 @@ package exif
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
-	"strconv"
 
 	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/exif/batch"
+	"github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 const requiredArgsCount = 3
 
-var ErrInvalidFrameNumber = errors.New("invalid specified frame number")
+var (
+	ErrInvalidFrameNumber     = errors.New("invalid specified frame number")
+	ErrFailedToGetSidecarFlag = errors.New("failed to get sidecar flag")
+)
 
 // UseCase defines the business logic for exporting EXIF metadata from EFD files.
 type UseCase interface {
-	// ExportExif writes EXIF metadata from a specific frame to a target image file.
+	// ExportExif writes EXIF metadata from the frames within frames to target
+	// image files. The target file names the image of a single frame, or with
+	// {frame} or {index} placeholders the image of every frame. Frames that do
+	// not match where leave their target file unchanged and are reported to w.
 	ExportExif(
 		ctx context.Context,
+		w io.Writer,
 		efdFile string,
-		frame int,
+		frames cli.FrameRanges,
 		targetFile string,
 		strict bool,
+		recovery bool,
+		where framefilter.Filter,
+		backend exif.Backend,
+	) error
+
+	// ExportXMP writes the metadata ExportExif would write to XMP sidecar
+	// files instead, leaving the images untouched. Frames that do not match
+	// where leave their sidecar file unchanged and are reported to w.
+	ExportXMP(
+		ctx context.Context,
+		w io.Writer,
+		efdFile string,
+		frames cli.FrameRanges,
+		sidecarFile string,
+		strict bool,
+		recovery bool,
+		where framefilter.Filter,
 	) error
 }
 
-func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+func NewCommand(
+	log *slog.Logger,
+	uc UseCase,
+	batchUseCase batch.UseCase,
+) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "exif <efd_file> <frame_number> <target_file>",
+		Use:   "exif <efd_file> <frames> <target_file>",
 		Short: "Write EXIF metadata from EFD file to target image file",
 		Long: `Extract exposure metadata (Tv, Av, ISO, exposure compensation) from a specific 
-frame in an EFD file and write it as EXIF data to a target image file.`,
+frame in an EFD file and write it as EXIF data to a target image file.
+
+The frame is given by its number, or as frame numbers and ranges as --frames takes
+them elsewhere, e.g. 1-5,12,30- where 30- runs to the end of the roll. Any missing
+frame of a closed range fails, as does a range like 30- without any frame. When
+more than one frame is selected, the target file is a pattern as exif batch takes
+it: {frame} is replaced by the frame number and {index} by the position of the frame
+on the roll, and a width pads the number with zeros, so {frame:02} names frame 7 "07".
+
+With --where, the EXIF data is only written if the frame matches a filter expression,
+so a script can run over a whole roll and only touch the frames of interest; see
+the README for the fields and operators available.
+
+By default the EXIF data is written with exiftool, which has to be installed. With
+--backend native it is written by meta1v itself, which supports JPEG and TIFF files.
+The backend can also be set with the exif.backend configuration key.
+
+With --sidecar, the target image is left untouched and the metadata is written to
+an XMP sidecar next to it instead, named after the image with an .xmp extension,
+for asset managers such as digiKam and Lightroom. To name the sidecar yourself,
+use xmp.
+
+To write a whole roll to a directory of scans at once, use exif batch.`,
 		Example: `  # Write EXIF from frame 1 to an image file
   meta1v exif data.efd 1 image.jpg
 
   # Write EXIF with strict mode enabled
-  meta1v exif data.efd 12 photo.jpg --strict`,
+  meta1v exif data.efd 12 photo.jpg --strict
+
+  # Write EXIF from frames 1 to 5 to 01.jpg to 05.jpg
+  meta1v exif data.efd 1-5 '{frame:02}.jpg'
+
+  # Only write EXIF if the frame was shot with flash
+  meta1v exif data.efd 12 photo.jpg --where 'flash != "OFF"'
+
+  # Write EXIF to a TIFF scan without exiftool
+  meta1v exif data.efd 3 scan.tif --backend native
+
+  # Write an XMP sidecar scan.xmp next to scan.tif
+  meta1v exif data.efd 3 scan.tif --sidecar`,
 		Args: cobra.ExactArgs(requiredArgsCount),
 		RunE: func(command *cobra.Command, args []string) error {
 			ctx := command.Context()
@@ -65,21 +131,76 @@ frame in an EFD file and write it as EXIF data to a target image file.`,
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := command.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			where, err := cli.GetWhere(command)
+			if err != nil {
+				return err
+			}
+
+			backend, err := cli.GetBackend(command, viper.GetViper())
+			if err != nil {
+				return err
+			}
+
+			sidecar, err := command.Flags().GetBool("sidecar")
+			if err != nil {
+				return errors.Join(ErrFailedToGetSidecarFlag, err)
+			}
+
 			log.DebugContext(ctx, "exif arguments:",
 				slog.String("efd_file", args[0]),
-				slog.String("frame_number", args[1]),
+				slog.String("frames", args[1]),
 				slog.String("target_file", args[2]),
-				slog.Bool("strict", strict))
+				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.String("where", where.String()),
+				slog.String("backend", string(backend)),
+				slog.Bool("sidecar", sidecar))
 
-			frame, err := strconv.Atoi(args[1])
+			frames, err := cli.ParseFrameRanges(args[1])
 			if err != nil {
 				return errors.Join(ErrInvalidFrameNumber, err)
 			}
 
-			return uc.ExportExif(ctx, args[0], frame, args[2], strict)
+			if sidecar {
+				return uc.ExportXMP(
+					ctx,
+					command.OutOrStdout(),
+					args[0],
+					frames,
+					exif.SidecarPath(args[2]),
+					strict,
+					recovery,
+					where,
+				)
+			}
+
+			return uc.ExportExif(
+				ctx,
+				command.OutOrStdout(),
+				args[0],
+				frames,
+				args[2],
+				strict,
+				recovery,
+				where,
+				backend,
+			)
 		},
 	}
 
+	cli.AddWhereFlag(cmd)
+	cli.AddBackendFlag(cmd)
+	cmd.Flags().Bool("sidecar", false,
+		"write an XMP sidecar next to the target image instead of the image")
+	cmd.MarkFlagsMutuallyExclusive("sidecar", "backend")
+
+	cmd.AddCommand(batch.NewCommand(log, batchUseCase))
+
 	return cmd
 }
 
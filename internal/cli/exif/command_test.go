@@ -24,7 +24,10 @@ import (
 
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/exif"
+	batch_test "github.com/ma-tf/meta1v/internal/cli/exif/batch/mocks"
 	exif_test "github.com/ma-tf/meta1v/internal/cli/exif/mocks"
+	exifservice "github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"go.uber.org/mock/gomock"
 )
 
@@ -44,11 +47,12 @@ func Test_NewCommand(t *testing.T) {
 	}))
 
 	type testcase struct {
-		name           string
-		args           []string
-		registerStrict bool
-		expect         func(mockUseCase *exif_test.MockUseCase, tc testcase)
-		expectedError  error
+		name            string
+		args            []string
+		registerStrict  bool
+		registerRecover bool
+		expect          func(mockUseCase *exif_test.MockUseCase, tc testcase)
+		expectedError   error
 	}
 
 	tests := []testcase{
@@ -58,6 +62,13 @@ func Test_NewCommand(t *testing.T) {
 			registerStrict: false,
 			expectedError:  cli.ErrFailedToGetStrictFlag,
 		},
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd", "1", "target.jpg"},
+			registerStrict:  true,
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
 		{
 			name: "invalid frame number argument",
 			args: []string{
@@ -65,13 +76,22 @@ func Test_NewCommand(t *testing.T) {
 				"invalid_frame_number",
 				"target.jpg",
 			},
-			registerStrict: true,
-			expectedError:  exif.ErrInvalidFrameNumber,
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   exif.ErrInvalidFrameNumber,
 		},
 		{
-			name:           "valid arguments",
-			args:           []string{"file.efd", "1", "target.jpg"},
-			registerStrict: true,
+			name:            "invalid frame range argument",
+			args:            []string{"file.efd", "5-1", "target.jpg"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   cli.ErrInvalidFrameRange,
+		},
+		{
+			name:            "valid arguments",
+			args:            []string{"file.efd", "1", "target.jpg"},
+			registerStrict:  true,
+			registerRecover: true,
 			expect: func(
 				mockUseCase *exif_test.MockUseCase,
 				tc testcase,
@@ -79,15 +99,117 @@ func Test_NewCommand(t *testing.T) {
 				mockUseCase.
 					EXPECT().
 					ExportExif(
+						gomock.Any(),
 						gomock.Any(),
 						tc.args[0],
-						1,
+						cli.FrameRanges{{First: 1, Last: 1}},
 						tc.args[2],
 						false,
+						false,
+						framefilter.Filter{},
+						exifservice.BackendExiftool,
 					).
 					Return(nil)
 			},
 		},
+		{
+			name:            "frame range",
+			args:            []string{"file.efd", "1-5,12", "{frame:02}.jpg"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(
+				mockUseCase *exif_test.MockUseCase,
+				tc testcase,
+			) {
+				mockUseCase.
+					EXPECT().
+					ExportExif(
+						gomock.Any(),
+						gomock.Any(),
+						tc.args[0],
+						cli.FrameRanges{{First: 1, Last: 5}, {First: 12, Last: 12}},
+						tc.args[2],
+						false,
+						false,
+						framefilter.Filter{},
+						exifservice.BackendExiftool,
+					).
+					Return(nil)
+			},
+		},
+		{
+			name: "native backend",
+			args: []string{
+				"file.efd",
+				"1",
+				"target.tif",
+				"--backend",
+				"native",
+			},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(
+				mockUseCase *exif_test.MockUseCase,
+				tc testcase,
+			) {
+				mockUseCase.
+					EXPECT().
+					ExportExif(
+						gomock.Any(),
+						gomock.Any(),
+						tc.args[0],
+						cli.FrameRanges{{First: 1, Last: 1}},
+						tc.args[2],
+						false,
+						false,
+						framefilter.Filter{},
+						exifservice.BackendNative,
+					).
+					Return(nil)
+			},
+		},
+		{
+			name: "sidecar",
+			args: []string{
+				"file.efd",
+				"1",
+				"scans/target.tif",
+				"--sidecar",
+			},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(
+				mockUseCase *exif_test.MockUseCase,
+				tc testcase,
+			) {
+				mockUseCase.
+					EXPECT().
+					ExportXMP(
+						gomock.Any(),
+						gomock.Any(),
+						tc.args[0],
+						cli.FrameRanges{{First: 1, Last: 1}},
+						"scans/target.xmp",
+						false,
+						false,
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name: "unsupported backend",
+			args: []string{
+				"file.efd",
+				"1",
+				"target.jpg",
+				"--backend",
+				"perl",
+			},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   exifservice.ErrUnsupportedBackend,
+		},
 	}
 
 	assertError := func(t *testing.T, expected, got error) {
@@ -123,13 +245,21 @@ func Test_NewCommand(t *testing.T) {
 				tt.expect(mockUseCase, tt)
 			}
 
-			cmd := exif.NewCommand(logger, mockUseCase)
+			cmd := exif.NewCommand(
+				logger,
+				mockUseCase,
+				batch_test.NewMockUseCase(ctrl),
+			)
 			cmd.SilenceUsage = true
 
 			if tt.registerStrict {
 				cmd.Flags().Bool("strict", false, "enable strict mode")
 			}
 
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
 			cmd.SetArgs(tt.args)
 
 			err := cmd.Execute()
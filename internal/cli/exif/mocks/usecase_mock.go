@@ -11,8 +11,12 @@ package exif_test
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
+	cli "github.com/ma-tf/meta1v/internal/cli"
+	exif "github.com/ma-tf/meta1v/internal/service/exif"
+	framefilter "github.com/ma-tf/meta1v/internal/service/framefilter"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,15 +45,29 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 }
 
 // ExportExif mocks base method.
-func (m *MockUseCase) ExportExif(ctx context.Context, efdFile string, frame int, targetFile string, strict bool) error {
+func (m *MockUseCase) ExportExif(ctx context.Context, w io.Writer, efdFile string, frames cli.FrameRanges, targetFile string, strict, recovery bool, where framefilter.Filter, backend exif.Backend) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExportExif", ctx, efdFile, frame, targetFile, strict)
+	ret := m.ctrl.Call(m, "ExportExif", ctx, w, efdFile, frames, targetFile, strict, recovery, where, backend)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ExportExif indicates an expected call of ExportExif.
-func (mr *MockUseCaseMockRecorder) ExportExif(ctx, efdFile, frame, targetFile, strict any) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) ExportExif(ctx, w, efdFile, frames, targetFile, strict, recovery, where, backend any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportExif", reflect.TypeOf((*MockUseCase)(nil).ExportExif), ctx, efdFile, frame, targetFile, strict)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportExif", reflect.TypeOf((*MockUseCase)(nil).ExportExif), ctx, w, efdFile, frames, targetFile, strict, recovery, where, backend)
+}
+
+// ExportXMP mocks base method.
+func (m *MockUseCase) ExportXMP(ctx context.Context, w io.Writer, efdFile string, frames cli.FrameRanges, sidecarFile string, strict, recovery bool, where framefilter.Filter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportXMP", ctx, w, efdFile, frames, sidecarFile, strict, recovery, where)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportXMP indicates an expected call of ExportXMP.
+func (mr *MockUseCaseMockRecorder) ExportXMP(ctx, w, efdFile, frames, sidecarFile, strict, recovery, where any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportXMP", reflect.TypeOf((*MockUseCase)(nil).ExportXMP), ctx, w, efdFile, frames, sidecarFile, strict, recovery, where)
 }
@@ -17,98 +17,599 @@
 package exif
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/exif/batch"
 	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/display"
 	"github.com/ma-tf/meta1v/internal/service/efd"
 	"github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
 )
 
 var (
 	ErrFailedToInterpretEFD = errors.New("failed to interpret EFD file")
-	ErrDuplicateFrameNumber = errors.New("duplicate frame number in EFD file")
-	ErrFrameNumberNotFound  = errors.New("frame number not found in EFD file")
+	ErrDuplicateFrameNumber = cli.ErrDuplicateFrameNumber
+	ErrFrameNumberNotFound  = cli.ErrFrameNumberNotFound
 	ErrWriteEXIFFailed      = errors.New("failed to write EXIF data")
+	ErrWriteXMPFailed       = errors.New("failed to write XMP sidecar")
+	ErrInvalidPattern       = errors.New("invalid scan file name pattern")
+	ErrFailedToReadScanDir  = errors.New("failed to read scan directory")
+	ErrBatchFailed          = errors.New("failed to write EXIF data to scans")
+	ErrSingleTarget         = errors.New(
+		"target names a single file, use a {frame} or {index} placeholder",
+	)
+)
+
+const maxPatternWidth = 10
+
+var (
+	// placeholderPattern matches the placeholders of a scan file name pattern,
+	// capturing the name and the optional width.
+	//nolint:gochecknoglobals // compiled once
+	placeholderPattern = regexp.MustCompile(`\{([^{}:]*)(?::([^{}]*))?\}`)
+
+	// scanExtensions are the image files paired with frames without a pattern.
+	//nolint:gochecknoglobals // fixed set of extensions
+	scanExtensions = []string{".jpg", ".jpeg", ".tif", ".tiff", ".png", ".dng"}
 )
 
 type exportUseCase struct {
-	log         *slog.Logger
-	efdService  efd.Service
-	exifService exif.Service
+	log          *slog.Logger
+	efdService   efd.Service
+	exifService  exif.Service
+	frameBuilder display.Builder
 }
 
 func NewUseCase(
 	log *slog.Logger,
 	efdService efd.Service,
 	exifService exif.Service,
+	frameBuilder display.Builder,
 ) UseCase {
 	return exportUseCase{
-		log:         log,
-		efdService:  efdService,
-		exifService: exifService,
+		log:          log,
+		efdService:   efdService,
+		exifService:  exifService,
+		frameBuilder: frameBuilder,
 	}
 }
 
 func (uc exportUseCase) ExportExif(
 	ctx context.Context,
+	w io.Writer,
 	efdFile string,
-	frame int,
+	frames cli.FrameRanges,
 	targetFile string,
 	strict bool,
+	recovery bool,
+	where framefilter.Filter,
+	backend exif.Backend,
 ) error {
 	uc.log.InfoContext(ctx, "starting exif export",
 		slog.String("efd_file", efdFile),
-		slog.Int("frame", frame),
+		slog.String("frames", frames.String()),
 		slog.String("target_file", targetFile),
-		slog.Bool("strict", strict))
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.String("where", where.String()),
+		slog.String("backend", string(backend)))
 
-	root, err := uc.efdService.RecordsFromFile(ctx, efdFile)
+	entries, err := uc.targets(ctx, w, efdFile, frames, targetFile, recovery,
+		where)
 	if err != nil {
-		return fmt.Errorf("%w %q: %w", ErrFailedToInterpretEFD, efdFile, err)
+		return err
 	}
 
-	uc.log.DebugContext(ctx, "efd file parsed",
-		slog.Int("frame_count", len(root.EFRMs)))
+	for _, e := range entries {
+		err = uc.exifService.WriteEXIF(ctx, e.Frame, e.File, strict, backend)
+		if err != nil {
+			return fmt.Errorf("%w on %q: %w", ErrWriteEXIFFailed, e.File, err)
+		}
+	}
 
-	var (
-		efrm  records.EFRM
-		found bool
-	)
+	uc.log.InfoContext(ctx, "exif export completed successfully",
+		slog.Int("written", len(entries)))
+
+	return nil
+}
+
+func (uc exportUseCase) ExportXMP(
+	ctx context.Context,
+	w io.Writer,
+	efdFile string,
+	frames cli.FrameRanges,
+	sidecarFile string,
+	strict bool,
+	recovery bool,
+	where framefilter.Filter,
+) error {
+	uc.log.InfoContext(ctx, "starting xmp export",
+		slog.String("efd_file", efdFile),
+		slog.String("frames", frames.String()),
+		slog.String("sidecar_file", sidecarFile),
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.String("where", where.String()))
+
+	entries, err := uc.targets(ctx, w, efdFile, frames, sidecarFile, recovery,
+		where)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		err = uc.exifService.WriteSidecar(ctx, e.Frame, e.File, strict)
+		if err != nil {
+			return fmt.Errorf("%w on %q: %w", ErrWriteXMPFailed, e.File, err)
+		}
+	}
+
+	uc.log.InfoContext(ctx, "xmp export completed successfully",
+		slog.Int("written", len(entries)))
+
+	return nil
+}
+
+// targets pairs the frames within frames with the file each is written to. A
+// target with placeholders is expanded for every frame as exif batch expands
+// its pattern, otherwise it names the file of a single frame. Frames that do not
+// match where are reported to w as skipped and left out.
+func (uc exportUseCase) targets(
+	ctx context.Context,
+	w io.Writer,
+	efdFile string,
+	frames cli.FrameRanges,
+	target string,
+	recovery bool,
+	where framefilter.Filter,
+) ([]batch.Entry, error) {
+	isPattern := placeholderPattern.MatchString(target)
+	if isPattern {
+		if err := checkPattern(target); err != nil {
+			return nil, err
+		}
+	}
+
+	efrms, err := readFrames(ctx, uc.log, uc.efdService, efdFile, recovery, frames)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = frames.Check(efrms); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrFailedToInterpretEFD, efdFile, err)
+	}
+
+	var selected []batch.Entry
+
+	for i, efrm := range efrms {
+		if !frames.Contains(efrm.FrameNumber) {
+			continue
+		}
+
+		file := target
+		if isPattern {
+			file = expandPattern(target, efrm, i+1)
+		}
+
+		selected = append(selected, batch.Entry{Frame: efrm, File: file})
+	}
+
+	if len(selected) > 1 && !isPattern {
+		return nil, fmt.Errorf("%w %q: frames %s select %d frames",
+			ErrSingleTarget, target, frames, len(selected))
+	}
+
+	uc.log.DebugContext(ctx, "frames selected",
+		slog.Int("frame_count", len(efrms)),
+		slog.Int("selected", len(selected)))
+
+	entries := selected[:0]
+
+	for _, e := range selected {
+		match, errMatch := cli.MatchFrame(ctx, uc.frameBuilder, where, e.Frame)
+		if errMatch != nil {
+			return nil, fmt.Errorf("%w %q: %w",
+				ErrFailedToInterpretEFD, efdFile, errMatch)
+		}
+
+		if !match {
+			skipped(ctx, uc.log, w, e.File, e.Frame.FrameNumber)
+
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
 
-	for _, e := range root.EFRMs {
-		if int(e.FrameNumber) == frame {
-			if found {
-				return fmt.Errorf("%w: frame number %d",
-					ErrDuplicateFrameNumber,
-					frame,
-				)
+// skipped reports to w that file was left unchanged as its frame does not
+// match the filter.
+func skipped(
+	ctx context.Context,
+	log *slog.Logger,
+	w io.Writer,
+	file string,
+	frame uint32,
+) {
+	log.InfoContext(ctx, "frame does not match the filter, skipped",
+		slog.String("target_file", file))
+	fmt.Fprintf(w, "%s: frame %d does not match the filter, skipped\n",
+		file, frame)
+}
+
+// readFrames returns the frame records of efdFile in frame number order,
+// failing if a frame number within frames is recorded more than once as the
+// file it is written to would be ambiguous.
+func readFrames(
+	ctx context.Context,
+	log *slog.Logger,
+	efdService efd.Service,
+	efdFile string,
+	recovery bool,
+	frames cli.FrameRanges,
+) ([]records.EFRM, error) {
+	var efrms []records.EFRM
+
+	for record, err := range frameRecords(ctx, log, efdService, efdFile, recovery) {
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w",
+				ErrFailedToInterpretEFD, efdFile, err)
+		}
+
+		if efrm, ok := record.(records.EFRM); ok {
+			efrms = append(efrms, efrm)
+		}
+	}
+
+	slices.SortStableFunc(efrms, func(a, b records.EFRM) int {
+		return cmp.Compare(a.FrameNumber, b.FrameNumber)
+	})
+
+	for i := 1; i < len(efrms); i++ {
+		if efrms[i].FrameNumber == efrms[i-1].FrameNumber &&
+			frames.Contains(efrms[i].FrameNumber) {
+			return nil, fmt.Errorf("%w: frame number %d",
+				ErrDuplicateFrameNumber, efrms[i].FrameNumber)
+		}
+	}
+
+	log.DebugContext(ctx, "efd file parsed", slog.Int("frame_count", len(efrms)))
+
+	return efrms, nil
+}
+
+// frameRecords yields the frame records of an EFD file. Only frame records
+// are decoded when streaming, thumbnails are skipped unread. Recovery has to
+// scan the whole file to resynchronise past damaged regions.
+func frameRecords(
+	ctx context.Context,
+	log *slog.Logger,
+	efdService efd.Service,
+	efdFile string,
+	recovery bool,
+) iter.Seq2[records.Record, error] {
+	if !recovery {
+		return efdService.Records(ctx, efdFile, records.MagicEFRM)
+	}
+
+	return func(yield func(records.Record, error) bool) {
+		root, err := cli.ReadRecords(ctx, log, efdService, efdFile, true)
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		for _, efrm := range root.EFRMs {
+			if !yield(efrm, nil) {
+				return
 			}
+		}
+	}
+}
+
+type batchUseCase struct {
+	log          *slog.Logger
+	efdService   efd.Service
+	exifService  exif.Service
+	fs           osfs.FileSystem
+	frameBuilder display.Builder
+}
 
-			efrm = e
-			found = true
+func NewBatchUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	exifService exif.Service,
+	fs osfs.FileSystem,
+	frameBuilder display.Builder,
+) batch.UseCase {
+	return batchUseCase{
+		log:          log,
+		efdService:   efdService,
+		exifService:  exifService,
+		fs:           fs,
+		frameBuilder: frameBuilder,
+	}
+}
+
+func (uc batchUseCase) Plan(
+	ctx context.Context,
+	efdFile string,
+	scanDir string,
+	opts batch.Options,
+) (batch.Plan, error) {
+	uc.log.InfoContext(ctx, "planning exif batch",
+		slog.String("efd_file", efdFile),
+		slog.String("scan_dir", scanDir),
+		slog.String("pattern", opts.Pattern),
+		slog.Bool("recover", opts.Recovery),
+		slog.String("frames", opts.Frames.String()),
+		slog.String("where", opts.Where.String()))
+
+	if opts.Pattern != "" {
+		if err := checkPattern(opts.Pattern); err != nil {
+			return batch.Plan{}, err
 		}
 	}
 
-	if !found {
-		return fmt.Errorf("%w: frame number %d",
-			ErrFrameNumberNotFound,
-			frame,
+	efrms, err := readFrames(
+		ctx,
+		uc.log,
+		uc.efdService,
+		efdFile,
+		opts.Recovery,
+		opts.Frames,
+	)
+	if err != nil {
+		return batch.Plan{}, err
+	}
+
+	if err = opts.Frames.Check(efrms); err != nil {
+		return batch.Plan{}, fmt.Errorf("%w %q: %w",
+			ErrFailedToInterpretEFD, efdFile, err)
+	}
+
+	var plan batch.Plan
+	if opts.Pattern != "" {
+		plan, err = uc.planPattern(scanDir, opts.Pattern, opts.Frames, efrms)
+	} else {
+		plan, err = uc.planSorted(scanDir, opts.Frames, efrms)
+	}
+
+	if err != nil {
+		return batch.Plan{}, err
+	}
+
+	entries := plan.Entries[:0]
+
+	for _, e := range plan.Entries {
+		match, errMatch := cli.MatchFrame(
+			ctx,
+			uc.frameBuilder,
+			opts.Where,
+			e.Frame,
 		)
+		if errMatch != nil {
+			return batch.Plan{}, fmt.Errorf("%w %q: %w",
+				ErrFailedToInterpretEFD, efdFile, errMatch)
+		}
+
+		if match {
+			entries = append(entries, e)
+		}
+	}
+
+	plan.Entries = entries
+
+	uc.log.DebugContext(ctx, "exif batch planned",
+		slog.Int("entries", len(plan.Entries)),
+		slog.Int("unmatched", len(plan.Unmatched)),
+		slog.Int("unused", len(plan.Unused)))
+
+	return plan, nil
+}
+
+func (uc batchUseCase) Write(
+	ctx context.Context,
+	w io.Writer,
+	plan batch.Plan,
+	strict bool,
+	backend exif.Backend,
+) error {
+	uc.log.InfoContext(ctx, "starting exif batch write",
+		slog.Int("entries", len(plan.Entries)),
+		slog.Bool("strict", strict),
+		slog.String("backend", string(backend)))
+
+	targets := make([]exif.Target, len(plan.Entries))
+	for i, e := range plan.Entries {
+		targets[i] = exif.Target{Frame: e.Frame, File: e.File}
 	}
 
-	uc.log.DebugContext(ctx, "frame located",
-		slog.Uint64("frame_number", uint64(efrm.FrameNumber)))
+	results := uc.exifService.WriteEXIFBatch(ctx, targets, strict, backend)
+
+	failed := 0
 
-	err = uc.exifService.WriteEXIF(ctx, efrm, targetFile, strict)
+	for i, e := range plan.Entries {
+		if err := results[i]; err != nil {
+			failed++
+
+			uc.log.WarnContext(ctx, "failed to write exif data",
+				slog.String("target_file", e.File),
+				slog.Any("error", err))
+			fmt.Fprintf(w, "%s: frame %d failed: %v\n",
+				e.File, e.Frame.FrameNumber, err)
+
+			continue
+		}
+
+		fmt.Fprintf(w, "%s: frame %d written\n", e.File, e.Frame.FrameNumber)
+	}
+
+	fmt.Fprintf(w, "%d written, %d failed\n", len(plan.Entries)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d file(s)",
+			ErrBatchFailed, failed, len(plan.Entries))
+	}
+
+	uc.log.InfoContext(ctx, "exif batch write completed successfully")
+
+	return nil
+}
+
+// planPattern maps every selected frame to the scan its pattern expands to,
+// numbering the frames by their position on the whole roll.
+func (uc batchUseCase) planPattern(
+	scanDir string,
+	pattern string,
+	frames cli.FrameRanges,
+	efrms []records.EFRM,
+) (batch.Plan, error) {
+	var plan batch.Plan
+
+	for i, efrm := range efrms {
+		if !frames.Contains(efrm.FrameNumber) {
+			continue
+		}
+
+		file := filepath.Join(scanDir, expandPattern(pattern, efrm, i+1))
+
+		info, err := uc.fs.Stat(file)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			plan.Unmatched = append(plan.Unmatched, efrm.FrameNumber)
+		case err != nil:
+			return batch.Plan{}, fmt.Errorf("%w %q: %w",
+				ErrFailedToReadScanDir, scanDir, err)
+		case info.IsDir():
+			plan.Unmatched = append(plan.Unmatched, efrm.FrameNumber)
+		default:
+			plan.Entries = append(plan.Entries, batch.Entry{
+				Frame: efrm,
+				File:  file,
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// planSorted pairs the selected frames in frame number order with the images
+// in scanDir in file name order.
+func (uc batchUseCase) planSorted(
+	scanDir string,
+	frames cli.FrameRanges,
+	efrms []records.EFRM,
+) (batch.Plan, error) {
+	entries, err := uc.fs.ReadDir(scanDir)
 	if err != nil {
-		return fmt.Errorf("%w on %q: %w", ErrWriteEXIFFailed, targetFile, err)
+		return batch.Plan{}, fmt.Errorf("%w %q: %w",
+			ErrFailedToReadScanDir, scanDir, err)
 	}
 
-	uc.log.InfoContext(ctx, "exif export completed successfully",
-		slog.String("target_file", targetFile))
+	var scans []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+
+		if entry.IsDir() || strings.HasPrefix(name, ".") ||
+			!slices.Contains(scanExtensions, ext) {
+			continue
+		}
+
+		scans = append(scans, filepath.Join(scanDir, name))
+	}
+
+	var plan batch.Plan
+
+	for _, efrm := range efrms {
+		if !frames.Contains(efrm.FrameNumber) {
+			continue
+		}
+
+		if len(scans) == 0 {
+			plan.Unmatched = append(plan.Unmatched, efrm.FrameNumber)
+
+			continue
+		}
+
+		plan.Entries = append(plan.Entries, batch.Entry{
+			Frame: efrm,
+			File:  scans[0],
+		})
+		scans = scans[1:]
+	}
+
+	if len(scans) > 0 {
+		plan.Unused = scans
+	}
+
+	return plan, nil
+}
+
+// checkPattern reports whether pattern names a different scan for every frame,
+// using only the placeholders expandPattern knows.
+func checkPattern(pattern string) error {
+	placeholders := placeholderPattern.FindAllStringSubmatch(pattern, -1)
+	if len(placeholders) == 0 {
+		return fmt.Errorf("%w %q: no {frame} or {index} placeholder",
+			ErrInvalidPattern, pattern)
+	}
+
+	for _, p := range placeholders {
+		if p[1] != "frame" && p[1] != "index" {
+			return fmt.Errorf("%w %q: unknown placeholder %s",
+				ErrInvalidPattern, pattern, p[0])
+		}
+
+		if p[2] == "" {
+			continue
+		}
+
+		width, err := strconv.Atoi(p[2])
+		if err != nil || width < 1 || width > maxPatternWidth {
+			return fmt.Errorf("%w %q: invalid width in %s",
+				ErrInvalidPattern, pattern, p[0])
+		}
+	}
 
 	return nil
 }
+
+// expandPattern replaces the placeholders of a checked pattern with the
+// number and position of efrm, zero padded to their width.
+func expandPattern(pattern string, efrm records.EFRM, index int) string {
+	expand := func(p string) string {
+		m := placeholderPattern.FindStringSubmatch(p)
+
+		n := index
+		if m[1] == "frame" {
+			n = int(efrm.FrameNumber)
+		}
+
+		width, _ := strconv.Atoi(m[2])
+
+		return fmt.Sprintf("%0*d", width, n)
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(pattern, expand)
+}
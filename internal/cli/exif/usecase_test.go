@@ -19,13 +19,27 @@ package exif_test
 import (
 	"bytes"
 	"errors"
+	"io"
+	"iter"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/exif"
+	"github.com/ma-tf/meta1v/internal/cli/exif/batch"
 	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/display"
+	display_test "github.com/ma-tf/meta1v/internal/service/display/mocks"
+	"github.com/ma-tf/meta1v/internal/service/efd"
 	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	exifservice "github.com/ma-tf/meta1v/internal/service/exif"
 	exif_test "github.com/ma-tf/meta1v/internal/service/exif/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
 	"go.uber.org/mock/gomock"
 )
 
@@ -46,6 +60,21 @@ func newTestLogger() *slog.Logger {
 	}))
 }
 
+// efrmSeq streams efrms as the EFD service would, followed by err if it is not nil.
+func efrmSeq(efrms []records.EFRM, err error) iter.Seq2[records.Record, error] {
+	return func(yield func(records.Record, error) bool) {
+		for _, efrm := range efrms {
+			if !yield(efrm, nil) {
+				return
+			}
+		}
+
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
 //nolint:exhaustruct // only partial is needed
 func Test_ExportExif(t *testing.T) {
 	t.Parallel()
@@ -53,9 +82,10 @@ func Test_ExportExif(t *testing.T) {
 	type testcase struct {
 		name       string
 		efdFile    string
-		frame      int
+		frames     cli.FrameRanges
 		targetFile string
 		strict     bool
+		recovery   bool
 		root       records.Root
 		expect     func(
 			efdTestMock efd_test.MockService,
@@ -75,15 +105,15 @@ func Test_ExportExif(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(records.Root{}, errExample)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFRM).
+					Return(efrmSeq(nil, errExample))
 			},
 			expectedError: exif.ErrFailedToInterpretEFD,
 		},
 		{
 			name:    "duplicate frame number",
 			efdFile: "file.efd",
-			frame:   1,
+			frames:  cli.FrameRanges{{First: 1, Last: 1}},
 			root: records.Root{
 				EFRMs: []records.EFRM{
 					{FrameNumber: 1},
@@ -96,15 +126,15 @@ func Test_ExportExif(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.root, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFRM).
+					Return(efrmSeq(tt.root.EFRMs, nil))
 			},
 			expectedError: exif.ErrDuplicateFrameNumber,
 		},
 		{
 			name:    "frame number not found",
 			efdFile: "file.efd",
-			frame:   2,
+			frames:  cli.FrameRanges{{First: 2, Last: 2}},
 			root: records.Root{
 				EFRMs: []records.EFRM{
 					{FrameNumber: 1},
@@ -117,15 +147,15 @@ func Test_ExportExif(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.root, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFRM).
+					Return(efrmSeq(tt.root.EFRMs, nil))
 			},
 			expectedError: exif.ErrFrameNumberNotFound,
 		},
 		{
 			name:       "write EXIF failed",
 			efdFile:    "file.efd",
-			frame:      1,
+			frames:     cli.FrameRanges{{First: 1, Last: 1}},
 			targetFile: "target.jpg",
 			strict:     true,
 			root: records.Root{
@@ -139,8 +169,8 @@ func Test_ExportExif(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.root, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFRM).
+					Return(efrmSeq(tt.root.EFRMs, nil))
 
 				mockEXIFService.EXPECT().
 					WriteEXIF(
@@ -148,6 +178,7 @@ func Test_ExportExif(t *testing.T) {
 						tt.root.EFRMs[0],
 						tt.targetFile,
 						tt.strict,
+						exifservice.BackendExiftool,
 					).
 					Return(errExample)
 			},
@@ -156,7 +187,7 @@ func Test_ExportExif(t *testing.T) {
 		{
 			name:       "successful EXIF export",
 			efdFile:    "file.efd",
-			frame:      1,
+			frames:     cli.FrameRanges{{First: 1, Last: 1}},
 			targetFile: "target.jpg",
 			strict:     true,
 			root: records.Root{
@@ -170,8 +201,8 @@ func Test_ExportExif(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.root, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFRM).
+					Return(efrmSeq(tt.root.EFRMs, nil))
 
 				mockEXIFService.EXPECT().
 					WriteEXIF(
@@ -179,6 +210,59 @@ func Test_ExportExif(t *testing.T) {
 						tt.root.EFRMs[0],
 						tt.targetFile,
 						tt.strict,
+						exifservice.BackendExiftool,
+					).
+					Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "recovery failed to interpret EFD",
+			efdFile:    "file.efd",
+			frames:     cli.FrameRanges{{First: 1, Last: 1}},
+			targetFile: "target.jpg",
+			recovery:   true,
+			expect: func(
+				mockEFDService efd_test.MockService,
+				_ exif_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecoverRecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(records.Root{}, nil, errExample)
+			},
+			expectedError: exif.ErrFailedToInterpretEFD,
+		},
+		{
+			name:       "successful EXIF export in recovery mode",
+			efdFile:    "file.efd",
+			frames:     cli.FrameRanges{{First: 3, Last: 3}},
+			targetFile: "target.jpg",
+			recovery:   true,
+			root: records.Root{
+				EFRMs: []records.EFRM{
+					{FrameNumber: 1},
+					{FrameNumber: 3},
+				},
+			},
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockEXIFService exif_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecoverRecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(tt.root, []efd.Diagnostic{
+						{Offset: 4, Length: 12, Reason: errExample},
+					}, nil)
+
+				mockEXIFService.EXPECT().
+					WriteEXIF(
+						gomock.Any(),
+						tt.root.EFRMs[1],
+						tt.targetFile,
+						tt.strict,
+						exifservice.BackendExiftool,
 					).
 					Return(nil)
 			},
@@ -209,14 +293,19 @@ func Test_ExportExif(t *testing.T) {
 			useCase := exif.NewUseCase(newTestLogger(),
 				mockEFDService,
 				mockEXIFService,
+				display_test.NewMockBuilder(mockCtrl),
 			)
 
 			err := useCase.ExportExif(
 				ctx,
+				io.Discard,
 				tt.efdFile,
-				tt.frame,
+				tt.frames,
 				tt.targetFile,
 				tt.strict,
+				tt.recovery,
+				framefilter.Filter{},
+				exifservice.BackendExiftool,
 			)
 
 			if tt.expectedError != nil {
@@ -241,3 +330,705 @@ func Test_ExportExif(t *testing.T) {
 		})
 	}
 }
+
+//nolint:exhaustruct // only partial is needed
+func Test_ExportExif_Frames(t *testing.T) {
+	t.Parallel()
+
+	roll := []records.EFRM{
+		{FrameNumber: 3},
+		{FrameNumber: 1},
+		{FrameNumber: 2},
+		{FrameNumber: 3},
+	}
+
+	tests := []struct {
+		name          string
+		frames        cli.FrameRanges
+		target        string
+		expected      map[uint32]string
+		expectedError error
+	}{
+		{
+			name:     "pattern for every frame",
+			frames:   cli.FrameRanges{{First: 1, Last: 2}},
+			target:   "scan-{frame:02}-{index}.jpg",
+			expected: map[uint32]string{1: "scan-01-1.jpg", 2: "scan-02-2.jpg"},
+		},
+		{
+			name:     "pattern for a single frame",
+			frames:   cli.FrameRanges{{First: 2, Last: 2}},
+			target:   "{frame:03}.jpg",
+			expected: map[uint32]string{2: "002.jpg"},
+		},
+		{
+			name:          "single target for several frames",
+			frames:        cli.FrameRanges{{First: 1, Last: 2}},
+			target:        "target.jpg",
+			expectedError: exif.ErrSingleTarget,
+		},
+		{
+			name:          "invalid pattern",
+			frames:        cli.FrameRanges{{First: 1, Last: 2}},
+			target:        "{roll}.jpg",
+			expectedError: exif.ErrInvalidPattern,
+		},
+		{
+			name:          "duplicate selected frame",
+			frames:        cli.FrameRanges{{First: 2, Last: 3}},
+			target:        "{frame}.jpg",
+			expectedError: exif.ErrDuplicateFrameNumber,
+		},
+		{
+			name:          "missing selected frames",
+			frames:        cli.FrameRanges{{First: 1, Last: 1}, {First: 4, Last: 5}},
+			target:        "{frame}.jpg",
+			expectedError: exif.ErrFrameNumberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockEXIFService := exif_test.NewMockService(ctrl)
+
+			mockEFDService.EXPECT().
+				Records(gomock.Any(), "file.efd", records.MagicEFRM).
+				Return(efrmSeq(roll, nil)).
+				AnyTimes()
+
+			for n, file := range tt.expected {
+				mockEXIFService.EXPECT().
+					WriteEXIF(
+						gomock.Any(),
+						records.EFRM{FrameNumber: n},
+						file,
+						false,
+						exifservice.BackendExiftool,
+					).
+					Return(nil)
+			}
+
+			useCase := exif.NewUseCase(newTestLogger(),
+				mockEFDService,
+				mockEXIFService,
+				display_test.NewMockBuilder(ctrl),
+			)
+
+			err := useCase.ExportExif(
+				t.Context(),
+				io.Discard,
+				"file.efd",
+				tt.frames,
+				tt.target,
+				false,
+				false,
+				framefilter.Filter{},
+				exifservice.BackendExiftool,
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_ExportExif_Where(t *testing.T) {
+	t.Parallel()
+
+	efrms := []records.EFRM{{FrameNumber: 1}}
+	where := mustParseFilter(t, "flash != OFF")
+
+	tests := []struct {
+		name           string
+		frame          display.DisplayableFrame
+		buildErr       error
+		write          bool
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name:          "failed to decode frame",
+			buildErr:      errExample,
+			expectedError: exif.ErrFailedToInterpretEFD,
+		},
+		{
+			name:  "frame does not match",
+			frame: display.DisplayableFrame{FlashMode: "OFF"},
+			expectedOutput: "target.jpg: frame 1 does not match the filter, " +
+				"skipped\n",
+		},
+		{
+			name:  "frame matches",
+			frame: display.DisplayableFrame{FlashMode: "E-TTL"},
+			write: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockEXIFService := exif_test.NewMockService(ctrl)
+			mockBuilder := display_test.NewMockBuilder(ctrl)
+
+			mockEFDService.EXPECT().
+				Records(gomock.Any(), "file.efd", records.MagicEFRM).
+				Return(efrmSeq(efrms, nil))
+			mockBuilder.EXPECT().
+				Build(gomock.Any(), efrms[0], gomock.Nil(), false).
+				Return(tt.frame, tt.buildErr)
+
+			if tt.write {
+				mockEXIFService.EXPECT().
+					WriteEXIF(
+						gomock.Any(),
+						efrms[0],
+						"target.jpg",
+						false,
+						exifservice.BackendExiftool,
+					).
+					Return(nil)
+			}
+
+			useCase := exif.NewUseCase(newTestLogger(),
+				mockEFDService,
+				mockEXIFService,
+				mockBuilder,
+			)
+
+			var out bytes.Buffer
+
+			err := useCase.ExportExif(
+				t.Context(),
+				&out,
+				"file.efd",
+				cli.FrameRanges{{First: 1, Last: 1}},
+				"target.jpg",
+				false,
+				false,
+				where,
+				exifservice.BackendExiftool,
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if out.String() != tt.expectedOutput {
+				t.Errorf("expected output %q, got %q",
+					tt.expectedOutput, out.String())
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_ExportXMP(t *testing.T) {
+	t.Parallel()
+
+	efrms := []records.EFRM{{FrameNumber: 1}, {FrameNumber: 2}}
+
+	tests := []struct {
+		name          string
+		frames        cli.FrameRanges
+		where         framefilter.Filter
+		displayable   display.DisplayableFrame
+		writeErr      error
+		write         bool
+		expectedError error
+	}{
+		{
+			name:          "frame number not found",
+			frames:        cli.FrameRanges{{First: 3, Last: 3}},
+			expectedError: exif.ErrFrameNumberNotFound,
+		},
+		{
+			name:          "write sidecar failed",
+			frames:        cli.FrameRanges{{First: 2, Last: 2}},
+			writeErr:      errExample,
+			write:         true,
+			expectedError: exif.ErrWriteXMPFailed,
+		},
+		{
+			name:   "successful XMP export",
+			frames: cli.FrameRanges{{First: 2, Last: 2}},
+			write:  true,
+		},
+		{
+			name:        "frame does not match",
+			frames:      cli.FrameRanges{{First: 2, Last: 2}},
+			where:       mustParseFilter(t, "flash != OFF"),
+			displayable: display.DisplayableFrame{FlashMode: "OFF"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockEXIFService := exif_test.NewMockService(ctrl)
+			mockBuilder := display_test.NewMockBuilder(ctrl)
+
+			mockEFDService.EXPECT().
+				Records(gomock.Any(), "file.efd", records.MagicEFRM).
+				Return(efrmSeq(efrms, nil))
+
+			if !tt.where.IsZero() {
+				mockBuilder.EXPECT().
+					Build(gomock.Any(), efrms[1], gomock.Nil(), false).
+					Return(tt.displayable, nil)
+			}
+
+			if tt.write {
+				mockEXIFService.EXPECT().
+					WriteSidecar(gomock.Any(), efrms[1], "scan.xmp", true).
+					Return(tt.writeErr)
+			}
+
+			useCase := exif.NewUseCase(newTestLogger(),
+				mockEFDService,
+				mockEXIFService,
+				mockBuilder,
+			)
+
+			err := useCase.ExportXMP(
+				t.Context(),
+				io.Discard,
+				"file.efd",
+				tt.frames,
+				"scan.xmp",
+				true,
+				false,
+				tt.where,
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+// dirEntries creates the named files in a temporary directory, or directories
+// for names ending in a slash, and returns its entries.
+func dirEntries(t *testing.T, names ...string) []os.DirEntry {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for _, name := range names {
+		var err error
+		if dirName, ok := strings.CutSuffix(name, "/"); ok {
+			err = os.Mkdir(filepath.Join(dir, dirName), 0o700)
+		} else {
+			err = os.WriteFile(filepath.Join(dir, name), nil, 0o600)
+		}
+
+		if err != nil {
+			t.Fatalf("failed to create %q: %v", name, err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read %q: %v", dir, err)
+	}
+
+	return entries
+}
+
+func fileInfo(t *testing.T, dir bool) os.FileInfo {
+	t.Helper()
+
+	name := t.TempDir()
+	if !dir {
+		name = filepath.Join(name, "scan.tif")
+		if err := os.WriteFile(name, nil, 0o600); err != nil {
+			t.Fatalf("failed to create %q: %v", name, err)
+		}
+	}
+
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatalf("failed to stat %q: %v", name, err)
+	}
+
+	return info
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_BatchPlan(t *testing.T) {
+	t.Parallel()
+
+	roll := []records.EFRM{
+		{FrameNumber: 2},
+		{FrameNumber: 1},
+		{FrameNumber: 3},
+	}
+
+	tests := []struct {
+		name          string
+		opts          batch.Options
+		efrms         []records.EFRM
+		efdErr        error
+		skipEFD       bool
+		expectFS      func(t *testing.T, fs *osfs_test.MockFileSystem)
+		expected      batch.Plan
+		expectedError error
+	}{
+		{
+			name:          "pattern without placeholder",
+			opts:          batch.Options{Pattern: "scan.tif"},
+			skipEFD:       true,
+			expectedError: exif.ErrInvalidPattern,
+		},
+		{
+			name:          "unknown placeholder",
+			opts:          batch.Options{Pattern: "{roll}-{frame}.tif"},
+			skipEFD:       true,
+			expectedError: exif.ErrInvalidPattern,
+		},
+		{
+			name:          "invalid placeholder width",
+			opts:          batch.Options{Pattern: "{frame:x}.tif"},
+			skipEFD:       true,
+			expectedError: exif.ErrInvalidPattern,
+		},
+		{
+			name:          "failed to interpret EFD",
+			efdErr:        errExample,
+			expectedError: exif.ErrFailedToInterpretEFD,
+		},
+		{
+			name:          "duplicate frame number",
+			efrms:         []records.EFRM{{FrameNumber: 1}, {FrameNumber: 1}},
+			expectedError: exif.ErrDuplicateFrameNumber,
+		},
+		{
+			name: "selected frame not in the roll",
+			opts: batch.Options{
+				Frames: cli.FrameRanges{{First: 7, Last: 7}},
+			},
+			efrms:         roll,
+			expectedError: exif.ErrFrameNumberNotFound,
+		},
+		{
+			name:  "pattern",
+			opts:  batch.Options{Pattern: "{frame:02}-{index}.tif"},
+			efrms: roll,
+			expectFS: func(t *testing.T, fs *osfs_test.MockFileSystem) {
+				t.Helper()
+
+				fs.EXPECT().
+					Stat(filepath.Join("scans", "01-1.tif")).
+					Return(fileInfo(t, false), nil)
+				fs.EXPECT().
+					Stat(filepath.Join("scans", "02-2.tif")).
+					Return(nil, os.ErrNotExist)
+				fs.EXPECT().
+					Stat(filepath.Join("scans", "03-3.tif")).
+					Return(fileInfo(t, true), nil)
+			},
+			expected: batch.Plan{
+				Entries: []batch.Entry{
+					{
+						Frame: records.EFRM{FrameNumber: 1},
+						File:  filepath.Join("scans", "01-1.tif"),
+					},
+				},
+				Unmatched: []uint32{2, 3},
+			},
+		},
+		{
+			name: "pattern with selected frames",
+			opts: batch.Options{
+				Pattern: "{frame}.tif",
+				Frames:  cli.FrameRanges{{First: 3, Last: 3}},
+			},
+			efrms: roll,
+			expectFS: func(t *testing.T, fs *osfs_test.MockFileSystem) {
+				t.Helper()
+
+				fs.EXPECT().
+					Stat(filepath.Join("scans", "3.tif")).
+					Return(fileInfo(t, false), nil)
+			},
+			expected: batch.Plan{
+				Entries: []batch.Entry{
+					{
+						Frame: records.EFRM{FrameNumber: 3},
+						File:  filepath.Join("scans", "3.tif"),
+					},
+				},
+			},
+		},
+		{
+			name:  "failed to stat scan",
+			opts:  batch.Options{Pattern: "{frame}.tif"},
+			efrms: roll,
+			expectFS: func(_ *testing.T, fs *osfs_test.MockFileSystem) {
+				fs.EXPECT().Stat(gomock.Any()).Return(nil, errExample)
+			},
+			expectedError: exif.ErrFailedToReadScanDir,
+		},
+		{
+			name:  "sorted order",
+			efrms: roll,
+			expectFS: func(t *testing.T, fs *osfs_test.MockFileSystem) {
+				t.Helper()
+
+				fs.EXPECT().
+					ReadDir("scans").
+					Return(dirEntries(t,
+						"a.TIF",
+						"b.jpg",
+						"notes.txt",
+						".hidden.jpg",
+						"raw/",
+					), nil)
+			},
+			expected: batch.Plan{
+				Entries: []batch.Entry{
+					{
+						Frame: records.EFRM{FrameNumber: 1},
+						File:  filepath.Join("scans", "a.TIF"),
+					},
+					{
+						Frame: records.EFRM{FrameNumber: 2},
+						File:  filepath.Join("scans", "b.jpg"),
+					},
+				},
+				Unmatched: []uint32{3},
+			},
+		},
+		{
+			name: "sorted order with selected frames",
+			opts: batch.Options{
+				Frames: cli.FrameRanges{{First: 2, Last: 2}},
+			},
+			efrms: roll,
+			expectFS: func(t *testing.T, fs *osfs_test.MockFileSystem) {
+				t.Helper()
+
+				fs.EXPECT().
+					ReadDir("scans").
+					Return(dirEntries(t, "a.png", "b.dng"), nil)
+			},
+			expected: batch.Plan{
+				Entries: []batch.Entry{
+					{
+						Frame: records.EFRM{FrameNumber: 2},
+						File:  filepath.Join("scans", "a.png"),
+					},
+				},
+				Unused: []string{filepath.Join("scans", "b.dng")},
+			},
+		},
+		{
+			name:  "failed to read scan directory",
+			efrms: roll,
+			expectFS: func(_ *testing.T, fs *osfs_test.MockFileSystem) {
+				fs.EXPECT().ReadDir("scans").Return(nil, errExample)
+			},
+			expectedError: exif.ErrFailedToReadScanDir,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockFS := osfs_test.NewMockFileSystem(ctrl)
+
+			if !tt.skipEFD {
+				mockEFDService.EXPECT().
+					Records(gomock.Any(), "file.efd", records.MagicEFRM).
+					Return(efrmSeq(tt.efrms, tt.efdErr))
+			}
+
+			if tt.expectFS != nil {
+				tt.expectFS(t, mockFS)
+			}
+
+			useCase := exif.NewBatchUseCase(newTestLogger(),
+				mockEFDService,
+				exif_test.NewMockService(ctrl),
+				mockFS,
+				display_test.NewMockBuilder(ctrl),
+			)
+
+			plan, err := useCase.Plan(t.Context(), "file.efd", "scans", tt.opts)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(plan, tt.expected) {
+				t.Errorf("expected plan %+v, got %+v", tt.expected, plan)
+			}
+		})
+	}
+}
+
+func Test_BatchPlan_Where(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	efrms := []records.EFRM{{FrameNumber: 1}, {FrameNumber: 2}}
+
+	mockEFDService := efd_test.NewMockService(ctrl)
+	mockFS := osfs_test.NewMockFileSystem(ctrl)
+	mockBuilder := display_test.NewMockBuilder(ctrl)
+
+	mockEFDService.EXPECT().
+		Records(gomock.Any(), "file.efd", records.MagicEFRM).
+		Return(efrmSeq(efrms, nil))
+	mockFS.EXPECT().
+		ReadDir("scans").
+		Return(dirEntries(t, "a.jpg", "b.jpg"), nil)
+	mockBuilder.EXPECT().
+		Build(gomock.Any(), efrms[0], gomock.Nil(), false).
+		Return(display.DisplayableFrame{FlashMode: "OFF"}, nil)
+	mockBuilder.EXPECT().
+		Build(gomock.Any(), efrms[1], gomock.Nil(), false).
+		Return(display.DisplayableFrame{FlashMode: "E-TTL"}, nil)
+
+	useCase := exif.NewBatchUseCase(newTestLogger(),
+		mockEFDService,
+		exif_test.NewMockService(ctrl),
+		mockFS,
+		mockBuilder,
+	)
+
+	//nolint:exhaustruct // only the filter is needed
+	plan, err := useCase.Plan(t.Context(), "file.efd", "scans", batch.Options{
+		Where: mustParseFilter(t, "flash != OFF"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// frames are paired with scans before they are filtered
+	expected := []batch.Entry{
+		{Frame: efrms[1], File: filepath.Join("scans", "b.jpg")},
+	}
+	if !reflect.DeepEqual(plan.Entries, expected) {
+		t.Errorf("expected entries %+v, got %+v", expected, plan.Entries)
+	}
+}
+
+func Test_BatchWrite(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // only partial is needed
+	plan := batch.Plan{
+		Entries: []batch.Entry{
+			{Frame: records.EFRM{FrameNumber: 1}, File: "01.tif"},
+			{Frame: records.EFRM{FrameNumber: 2}, File: "02.tif"},
+			{Frame: records.EFRM{FrameNumber: 3}, File: "03.tif"},
+		},
+	}
+
+	targets := make([]exifservice.Target, len(plan.Entries))
+	for i, e := range plan.Entries {
+		targets[i] = exifservice.Target{Frame: e.Frame, File: e.File}
+	}
+
+	tests := []struct {
+		name           string
+		results        []error
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name:    "all written",
+			results: []error{nil, nil, nil},
+			expectedOutput: "01.tif: frame 1 written\n" +
+				"02.tif: frame 2 written\n" +
+				"03.tif: frame 3 written\n" +
+				"3 written, 0 failed\n",
+		},
+		{
+			name:    "carries on past a failure",
+			results: []error{nil, errExample, nil},
+			expectedOutput: "01.tif: frame 1 written\n" +
+				"02.tif: frame 2 failed: example error\n" +
+				"03.tif: frame 3 written\n" +
+				"2 written, 1 failed\n",
+			expectedError: exif.ErrBatchFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEXIFService := exif_test.NewMockService(ctrl)
+			mockEXIFService.EXPECT().
+				WriteEXIFBatch(
+					gomock.Any(),
+					targets,
+					true,
+					exifservice.BackendNative,
+				).
+				Return(tt.results)
+
+			useCase := exif.NewBatchUseCase(newTestLogger(),
+				efd_test.NewMockService(ctrl),
+				mockEXIFService,
+				osfs_test.NewMockFileSystem(ctrl),
+				display_test.NewMockBuilder(ctrl),
+			)
+
+			var out bytes.Buffer
+
+			err := useCase.Write(
+				t.Context(),
+				&out,
+				plan,
+				true,
+				exifservice.BackendNative,
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if out.String() != tt.expectedOutput {
+				t.Errorf("expected output %q, got %q",
+					tt.expectedOutput, out.String())
+			}
+		})
+	}
+}
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
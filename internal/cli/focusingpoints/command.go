@@ -14,13 +14,15 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-// Package focusingpoints provides business logic for displaying focusing point grids from EFD files.
+// Package focusingpoints provides business logic for displaying and rendering focusing point grids from EFD files.
 package focusingpoints
 
 import (
 	"log/slog"
 
 	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/ls"
+	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/overlay"
+	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/render"
 	"github.com/ma-tf/meta1v/internal/container"
 	"github.com/spf13/cobra"
 )
@@ -28,8 +30,9 @@ import (
 func NewCommand(log *slog.Logger, ctr *container.Container) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "focusingpoints <command>",
-		Short: "Display autofocus point grids from EFD files",
-		Long: `Display rendered grids of autofocus points used when capturing each photograph.
+		Short: "Display, render or overlay autofocus point grids from EFD files",
+		Long: `Display rendered grids of autofocus points used when capturing each photograph,
+render them as images, or draw them over scans of the frames.
 
 For setting autofocus points on the camera, refer to the Canon EOS-1V manual.`,
 		Aliases: []string{"fp"},
@@ -40,9 +43,29 @@ For setting autofocus points on the camera, refer to the Canon EOS-1V manual.`,
 		ctr.EFDService,
 		ctr.DisplayableRollFactory,
 		ctr.DisplayService,
+		ctr.JSONService,
+		ctr.TemplateService,
+	)
+
+	renderUseCase := NewRenderUseCase(
+		log,
+		ctr.EFDService,
+		ctr.FocusPointsService,
+		ctr.FileSystem,
+		ctr.FrameBuilder,
+	)
+
+	overlayUseCase := NewOverlayUseCase(
+		log,
+		ctr.EFDService,
+		ctr.FocusPointsService,
+		ctr.ThumbnailService,
+		ctr.FileSystem,
 	)
 
 	cmd.AddCommand(ls.NewCommand(log, uc))
+	cmd.AddCommand(render.NewCommand(log, renderUseCase))
+	cmd.AddCommand(overlay.NewCommand(log, overlayUseCase))
 
 	return cmd
 }
@@ -46,16 +46,13 @@ func Test_NewCommand(t *testing.T) {
 	}))
 
 	mockLookPath := osexec_test.NewMockLookPath(ctrl)
-	mockLookPath.EXPECT().
-		LookPath("exiftool").
-		Return("/usr/bin/exiftool", nil)
 
 	ctr := container.New(logger, mockLookPath)
 	cmd := focusingpoints.NewCommand(logger, ctr)
 
-	const expectedSubcommands = 1
+	const expectedSubcommands = 3
 	if len(cmd.Commands()) != expectedSubcommands {
-		t.Fatalf("expected %d subcommand to be registered, got %d",
+		t.Fatalf("expected %d subcommands to be registered, got %d",
 			expectedSubcommands, len(cmd.Commands()))
 	}
 }
@@ -25,22 +25,47 @@ import (
 	"log/slog"
 
 	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
 )
 
 // UseCase defines the business logic for listing focusing point grids from EFD files.
 type UseCase interface {
-	// List reads an EFD file and prints a grid of focusing points used by the frames in a human-readable format.
-	List(ctx context.Context, filename string, strict bool) error
+	// List reads an EFD file and prints a grid of focusing points used by the frames in a human-readable format,
+	// as a JSON document if the output format is cli.OutputJSON, or through the output template if set.
+	// Only the frames matching where are listed.
+	List(
+		ctx context.Context,
+		filename string,
+		strict bool,
+		recovery bool,
+		output cli.Output,
+		frames cli.FrameRanges,
+		where framefilter.Filter,
+	) error
 }
 
 func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list <filename>",
 		Short: "Display autofocus point grids in human-readable format",
 		Long: `Display rendered grids of autofocus points used when capturing each photograph.
+Below each grid the active points are listed by row and column, counting from 0 at the
+top left, together with how the focusing point was selected.
 
-For setting autofocus points on the camera, refer to the Canon EOS-1V manual.`,
+For setting autofocus points on the camera, refer to the Canon EOS-1V manual.
+
+With --output json, a JSON document following the versioned schema described in
+the README is written instead, for use with tools such as jq. With --template,
+the roll is written through a Go text/template given inline or as a file; see the
+README for the helper functions available.
+
+With --frames, only the frames with the given numbers are listed, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are listed; see the
+README for the fields and operators available.`,
 		Example: `  # Display focusing points information
   meta1v focusingpoints list data.efd
 
@@ -48,7 +73,19 @@ For setting autofocus points on the camera, refer to the Canon EOS-1V manual.`,
   meta1v focusingpoints ls data.efd
 
   # With strict mode
-  meta1v fp ls data.efd --strict`,
+  meta1v fp ls data.efd --strict
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll
+  meta1v fp ls data.efd --frames 1-5,12,30-
+
+  # Only frames focused in AI Servo
+  meta1v fp ls data.efd --where 'afMode == "AI Servo AF"'
+
+  # As JSON
+  meta1v fp ls data.efd --output json
+
+  # Through a template
+  meta1v fp ls data.efd --template '{{range .Frames}}{{.FrameNumber}}: {{.FocusPoints.Describe}}{{"\n"}}{{end}}'`,
 		Aliases: []string{"ls"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -59,12 +96,51 @@ For setting autofocus points on the camera, refer to the Canon EOS-1V manual.`,
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			output, err := cli.GetOutput(cmd, cli.OutputTable, cli.OutputJSON)
+			if err != nil {
+				return err
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
 			log.DebugContext(ctx, "arguments:",
 				slog.String("filename", args[0]),
 				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.String("output", output.Format),
+				slog.String("template", output.Template),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()),
 			)
 
-			return uc.List(ctx, args[0], strict)
+			return uc.List(
+				ctx,
+				args[0],
+				strict,
+				recovery,
+				output,
+				frames,
+				where,
+			)
 		},
 	}
+
+	cli.AddOutputFlags(cmd, cli.OutputTable, cli.OutputJSON)
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+
+	return cmd
 }
@@ -0,0 +1,233 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=overlay_test github.com/ma-tf/meta1v/internal/cli/focusingpoints/overlay UseCase
+
+// Package overlay provides the CLI command for drawing focusing points over a scanned frame.
+package overlay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/focuspoints"
+	"github.com/ma-tf/meta1v/internal/service/thumbnail"
+	"github.com/spf13/cobra"
+)
+
+const (
+	numArgs         = 4
+	frameArgIndex   = 1
+	scanArgIndex    = 2
+	targetArgIndex  = 3
+	frameNumberBits = 32
+	cropValues      = 4
+)
+
+var (
+	ErrInvalidFrameNumber      = errors.New("invalid specified frame number")
+	ErrInvalidCrop             = errors.New("invalid crop, expected x,y,w,h")
+	ErrUnsupportedFormat       = errors.New("unsupported image format")
+	ErrFailedToGetOverlayFlags = errors.New("failed to get overlay flags")
+)
+
+// Options controls how the scan lies on the frame and how the image is written.
+type Options struct {
+	Placement focuspoints.Placement
+	Format    string // thumbnail.FormatPNG or thumbnail.FormatJPEG
+	Force     bool
+}
+
+// UseCase defines the business logic for drawing focusing points over scans.
+type UseCase interface {
+	// Overlay draws the focusing points of the frame with the given frame number
+	// of efdFile over the image in scanFile and writes the result to targetFile.
+	Overlay(
+		ctx context.Context,
+		efdFile string,
+		frameNumber uint32,
+		scanFile string,
+		targetFile string,
+		opts Options,
+		recovery bool,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "overlay <efd_file> <frame_number> <scan_file> <target_file>",
+		Short: "Draw autofocus points over a scanned frame",
+		Long: `Draw the 45-point autofocus layout of a frame over its scan, to see what the camera
+focused on. Active points are filled red and the other points outlined in white, at
+their approximate position in the viewfinder scaled to the 36x24mm frame.
+
+PNG, JPEG and TIFF scans are read. The image is written as PNG or JPEG, depending on
+the extension of the target file.
+
+By default the scan is taken to show exactly the whole frame, the right way up. Use
+--crop to give the part of the frame the scan shows, in millimetres from the top left
+corner of the frame as seen in landscape orientation. It may reach past the frame for
+scans that include the film border. Use --rotate for scans turned from landscape, in
+degrees clockwise.`,
+		Example: `  # Show where frame 12 was focused
+  meta1v focusingpoints overlay data.efd 12 scans/12.tif 12_af.jpg
+
+  # A portrait scan, turned clockwise, cropped to the middle of the frame
+  meta1v fp overlay data.efd 12 scans/12.tif 12_af.png --rotate 90 --crop 3,2,30,20`,
+		Args: cobra.ExactArgs(numArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			frameNumber, err := strconv.ParseUint(
+				args[frameArgIndex],
+				10, //nolint:mnd // decimal
+				frameNumberBits,
+			)
+			if err != nil {
+				return errors.Join(ErrInvalidFrameNumber, err)
+			}
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			opts, err := getOptions(cmd, args[targetArgIndex])
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.Uint64("frame_number", frameNumber),
+				slog.String("scan_file", args[scanArgIndex]),
+				slog.String("target_file", args[targetArgIndex]),
+				slog.Any("crop", opts.Placement.Crop),
+				slog.Int("rotate", opts.Placement.Rotate),
+				slog.String("format", opts.Format),
+				slog.Bool("recover", recovery),
+				slog.Bool("force", opts.Force),
+			)
+
+			return uc.Overlay(
+				ctx,
+				args[0],
+				uint32(frameNumber),
+				args[scanArgIndex],
+				args[targetArgIndex],
+				opts,
+				recovery,
+			)
+		},
+	}
+
+	cmd.Flags().String(
+		"crop",
+		"",
+		"part of the frame shown, as x,y,w,h in mm (default: 0,0,36,24)",
+	)
+	cmd.Flags().Int(
+		"rotate",
+		0,
+		"degrees the scan is turned clockwise (0, 90, 180, 270)",
+	)
+	cmd.Flags().
+		BoolP("force", "F", false, "overwrite output file if it exists")
+
+	return cmd
+}
+
+func getOptions(cmd *cobra.Command, targetFile string) (Options, error) {
+	format, err := formatOf(targetFile)
+	if err != nil {
+		return Options{}, err
+	}
+
+	crop, err := cmd.Flags().GetString("crop")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetOverlayFlags, err)
+	}
+
+	area := focuspoints.FullFrame()
+	if crop != "" {
+		if area, err = parseCrop(crop); err != nil {
+			return Options{}, err
+		}
+	}
+
+	rotate, err := cmd.Flags().GetInt("rotate")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetOverlayFlags, err)
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return Options{}, errors.Join(cli.ErrFailedToGetForceFlag, err)
+	}
+
+	return Options{
+		Placement: focuspoints.Placement{Crop: area, Rotate: rotate},
+		Format:    format,
+		Force:     force,
+	}, nil
+}
+
+// formatOf picks the image format from the extension of the target file.
+func formatOf(targetFile string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(targetFile))
+
+	switch ext {
+	case ".png":
+		return thumbnail.FormatPNG, nil
+	case ".jpg", ".jpeg":
+		return thumbnail.FormatJPEG, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, ext)
+	}
+}
+
+// parseCrop parses an area given as x,y,w,h in millimetres.
+func parseCrop(s string) (focuspoints.Area, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != cropValues {
+		return focuspoints.Area{}, fmt.Errorf("%w: %q", ErrInvalidCrop, s)
+	}
+
+	values := make([]float64, len(parts))
+
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return focuspoints.Area{}, fmt.Errorf(
+				"%w: %q: %w", ErrInvalidCrop, s, err)
+		}
+
+		values[i] = v
+	}
+
+	return focuspoints.Area{
+		X:      values[0],
+		Y:      values[1],
+		Width:  values[2],
+		Height: values[3],
+	}, nil
+}
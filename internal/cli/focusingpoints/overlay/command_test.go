@@ -0,0 +1,191 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package overlay_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/overlay"
+	overlay_test "github.com/ma-tf/meta1v/internal/cli/focusingpoints/overlay/mocks"
+	"github.com/ma-tf/meta1v/internal/service/focuspoints"
+	"github.com/ma-tf/meta1v/internal/service/thumbnail"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name            string
+		args            []string
+		registerRecover bool
+		expect          func(mockUseCase *overlay_test.MockUseCase)
+		expectedError   error
+	}
+
+	tests := []testcase{
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd", "12", "scan.tif", "out.png"},
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name: "invalid frame number",
+			args: []string{
+				"file.efd", "twelve", "scan.tif", "out.png",
+			},
+			registerRecover: true,
+			expectedError:   overlay.ErrInvalidFrameNumber,
+		},
+		{
+			name:            "defaults",
+			args:            []string{"file.efd", "12", "scan.tif", "out.png"},
+			registerRecover: true,
+			expect: func(mockUseCase *overlay_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Overlay(
+						gomock.Any(),
+						"file.efd",
+						uint32(12),
+						"scan.tif",
+						"out.png",
+						overlay.Options{
+							Placement: focuspoints.Placement{
+								Crop: focuspoints.FullFrame(),
+							},
+							Format: thumbnail.FormatPNG,
+						},
+						false,
+					).
+					Return(nil)
+			},
+		},
+		{
+			name: "all options",
+			args: []string{
+				"file.efd", "12", "scan.tif", "out.JPEG",
+				"--crop", "1.5, 2, 30,20",
+				"--rotate", "-90",
+				"--force",
+			},
+			registerRecover: true,
+			expect: func(mockUseCase *overlay_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Overlay(
+						gomock.Any(),
+						"file.efd",
+						uint32(12),
+						"scan.tif",
+						"out.JPEG",
+						overlay.Options{
+							Placement: focuspoints.Placement{
+								Crop: focuspoints.Area{
+									X:      1.5,
+									Y:      2,
+									Width:  30,
+									Height: 20,
+								},
+								Rotate: -90,
+							},
+							Format: thumbnail.FormatJPEG,
+							Force:  true,
+						},
+						false,
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "unsupported format",
+			args:            []string{"file.efd", "12", "scan.tif", "out.svg"},
+			registerRecover: true,
+			expectedError:   overlay.ErrUnsupportedFormat,
+		},
+		{
+			name: "crop with too few values",
+			args: []string{
+				"file.efd", "12", "scan.tif", "out.png", "--crop", "0,0,36",
+			},
+			registerRecover: true,
+			expectedError:   overlay.ErrInvalidCrop,
+		},
+		{
+			name: "crop that is not a number",
+			args: []string{
+				"file.efd", "12", "scan.tif", "out.png", "--crop", "0,0,36,x",
+			},
+			registerRecover: true,
+			expectedError:   overlay.ErrInvalidCrop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := overlay_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase)
+			}
+
+			cmd := overlay.NewCommand(logger, mockUseCase)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=render_test github.com/ma-tf/meta1v/internal/cli/focusingpoints/render UseCase
+
+// Package render provides the CLI command for rendering focusing point grids as images.
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/focuspoints"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrUnsupportedFormat      = errors.New("unsupported image format")
+	ErrFailedToGetRenderFlags = errors.New("failed to get render flags")
+)
+
+// Options controls which grids are rendered and how the files are written.
+type Options struct {
+	Format  string
+	Frames  cli.FrameRanges    // nil renders every frame
+	Where   framefilter.Filter // the zero filter renders every frame
+	Montage bool               // a single image instead of one per frame
+	Force   bool
+}
+
+// UseCase defines the business logic for rendering focusing point grids as images.
+type UseCase interface {
+	// Render writes the focusing point grid of every selected frame of efdFile
+	// to target, as one image per frame in the target directory or, for a
+	// montage, as a single image file.
+	Render(
+		ctx context.Context,
+		efdFile string,
+		target string,
+		opts Options,
+		recovery bool,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render <efd_file> <target>",
+		Short: "Render autofocus point grids as PNG or SVG images",
+		Long: `Render the 45-point autofocus grid of each frame as an image, for web galleries and
+printed notes.
+
+Points are drawn as in the list view: active points filled red, inactive points on the
+edge of the grid outlined red and inactive interior points outlined grey. Frames that
+recorded no focusing point are drawn in light grey.
+
+By default one image per frame is written to the target directory, named
+<efd_file>_<frame>_af.<format>. With --montage the grids of all frames are written to
+the target file as a single image, labelled with their frame numbers and laid out with
+as many frames to a row as the roll's contact sheet layout.
+
+With --frames, only the frames with the given numbers are rendered, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are rendered; see the
+README for the fields and operators available.`,
+		Example: `  # One PNG per frame in the af directory
+  meta1v focusingpoints render data.efd af/
+
+  # SVG images of frames 1 to 5 and 12
+  meta1v focusingpoints render data.efd af/ --format svg --frames 1-5,12
+
+  # Only frames focused in AI Servo
+  meta1v fp render data.efd af/ --where 'afMode == "AI Servo AF"'
+
+  # The whole roll in one image
+  meta1v fp render data.efd roll_af.png --montage`,
+		Args: cobra.ExactArgs(2), //nolint:mnd // source and target
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			opts, err := getOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.String("target", args[1]),
+				slog.String("format", opts.Format),
+				slog.String("frames", opts.Frames.String()),
+				slog.String("where", opts.Where.String()),
+				slog.Bool("montage", opts.Montage),
+				slog.Bool("recover", recovery),
+				slog.Bool("force", opts.Force),
+			)
+
+			return uc.Render(ctx, args[0], args[1], opts, recovery)
+		},
+	}
+
+	cmd.Flags().
+		String("format", focuspoints.FormatPNG, "image format (png, svg)")
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+	cmd.Flags().Bool("montage", false, "render all frames into a single image")
+	cmd.Flags().
+		BoolP("force", "F", false, "overwrite output files if they exist")
+
+	return cmd
+}
+
+func getOptions(cmd *cobra.Command) (Options, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetRenderFlags, err)
+	}
+
+	formats := []string{focuspoints.FormatPNG, focuspoints.FormatSVG}
+	if !slices.Contains(formats, format) {
+		return Options{}, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	frames, err := cli.GetFrames(cmd)
+	if err != nil {
+		return Options{}, err
+	}
+
+	where, err := cli.GetWhere(cmd)
+	if err != nil {
+		return Options{}, err
+	}
+
+	montage, err := cmd.Flags().GetBool("montage")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetRenderFlags, err)
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return Options{}, errors.Join(cli.ErrFailedToGetForceFlag, err)
+	}
+
+	return Options{
+		Format:  format,
+		Frames:  frames,
+		Where:   where,
+		Montage: montage,
+		Force:   force,
+	}, nil
+}
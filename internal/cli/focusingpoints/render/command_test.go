@@ -0,0 +1,151 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package render_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/render"
+	render_test "github.com/ma-tf/meta1v/internal/cli/focusingpoints/render/mocks"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/focuspoints"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name            string
+		args            []string
+		registerRecover bool
+		expect          func(mockUseCase *render_test.MockUseCase)
+		expectedError   error
+	}
+
+	tests := []testcase{
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd", "out"},
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "defaults",
+			args:            []string{"file.efd", "out"},
+			registerRecover: true,
+			expect: func(mockUseCase *render_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Render(gomock.Any(), "file.efd", "out", render.Options{
+						Format: focuspoints.FormatPNG,
+					}, false).
+					Return(nil)
+			},
+		},
+		{
+			name: "all options",
+			args: []string{
+				"file.efd", "af.svg",
+				"--format", "svg",
+				"--frames", "1-5,12",
+				"--montage",
+				"--force",
+			},
+			registerRecover: true,
+			expect: func(mockUseCase *render_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Render(gomock.Any(), "file.efd", "af.svg", render.Options{
+						Format: focuspoints.FormatSVG,
+						Frames: []domain.FrameRange{
+							{First: 1, Last: 5},
+							{First: 12, Last: 12},
+						},
+						Montage: true,
+						Force:   true,
+					}, false).
+					Return(nil)
+			},
+		},
+		{
+			name:            "unsupported format",
+			args:            []string{"file.efd", "out", "--format", "jpeg"},
+			registerRecover: true,
+			expectedError:   render.ErrUnsupportedFormat,
+		},
+		{
+			name:            "invalid frames",
+			args:            []string{"file.efd", "out", "--frames", "5-1"},
+			registerRecover: true,
+			expectedError:   cli.ErrInvalidFrameRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := render_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase)
+			}
+
+			cmd := render.NewCommand(logger, mockUseCase)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
@@ -20,12 +20,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"image"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/ls"
+	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/overlay"
+	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/render"
+	"github.com/ma-tf/meta1v/internal/records"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/focuspoints"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/ma-tf/meta1v/internal/service/jsonexport"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/thumbnail"
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
+)
+
+const (
+	permission = 0o666 // rw-rw-rw-
+
+	// grids to a row of a montage when the roll does not record a layout
+	defaultColumns = 6
 )
 
 var (
@@ -33,6 +53,13 @@ var (
 	ErrFailedToParseFile = errors.New(
 		"failed to parse file for focusing points",
 	)
+	ErrTargetNotDirectory = errors.New("target is not a directory")
+	ErrNoFramesSelected   = errors.New("no frames selected")
+	ErrFailedToCreateFile = errors.New("failed to create focus point image")
+	ErrFailedToRender     = errors.New("failed to render focus points")
+	ErrFailedToReadImage  = errors.New("failed to read scan")
+	ErrFailedToOverlay    = errors.New("failed to overlay focus points")
+	ErrFailedToList       = errors.New("failed to list focusing points")
 )
 
 type listUseCase struct {
@@ -40,6 +67,8 @@ type listUseCase struct {
 	efdService             efd.Service
 	displayableRollFactory display.DisplayableRollFactory
 	displayService         display.Service
+	jsonService            jsonexport.Service
+	templateService        usertemplate.Service
 }
 
 func NewListUseCase(
@@ -47,12 +76,16 @@ func NewListUseCase(
 	efdService efd.Service,
 	displayableRollFactory display.DisplayableRollFactory,
 	displayService display.Service,
+	jsonService jsonexport.Service,
+	templateService usertemplate.Service,
 ) ls.UseCase {
 	return listUseCase{
 		log:                    log,
 		efdService:             efdService,
 		displayableRollFactory: displayableRollFactory,
 		displayService:         displayService,
+		jsonService:            jsonService,
+		templateService:        templateService,
 	}
 }
 
@@ -60,12 +93,32 @@ func (uc listUseCase) List(
 	ctx context.Context,
 	filename string,
 	strict bool,
+	recovery bool,
+	output cli.Output,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
 ) error {
 	uc.log.InfoContext(ctx, "starting focusing points list",
 		slog.String("file", filename),
-		slog.Bool("strict", strict))
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.String("output", output.Format),
+		slog.String("template", output.Template),
+		slog.String("frames", frames.String()),
+		slog.String("where", where.String()))
+
+	tmpl, err := cli.ParseTemplate(ctx, uc.templateService, output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
 
-	records, err := uc.efdService.RecordsFromFile(ctx, filename)
+	records, err := cli.ReadRecords(
+		ctx,
+		uc.log,
+		uc.efdService,
+		filename,
+		recovery,
+	)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, filename, err)
 	}
@@ -78,12 +131,317 @@ func (uc listUseCase) List(
 		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
 	}
 
+	dr, err = frames.Select(dr)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
+	}
+
+	dr = where.Apply(dr)
+
 	uc.log.DebugContext(ctx, "displayable focusing points created",
 		slog.Int("frame_count", len(dr.Frames)))
 
-	uc.displayService.DisplayFocusingPoints(ctx, os.Stdout, dr)
+	switch {
+	case tmpl != nil:
+		err = uc.templateService.Execute(ctx, os.Stdout, tmpl, dr)
+	case output.Format == cli.OutputJSON:
+		err = uc.jsonService.ExportFocusingPoints(ctx, os.Stdout, dr)
+	default:
+		uc.displayService.DisplayFocusingPoints(ctx, os.Stdout, dr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
 
 	uc.log.InfoContext(ctx, "focusing points list completed successfully")
 
 	return nil
 }
+
+type renderUseCase struct {
+	log                *slog.Logger
+	efdService         efd.Service
+	focuspointsService focuspoints.Service
+	fs                 osfs.FileSystem
+	frameBuilder       display.Builder
+}
+
+func NewRenderUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	focuspointsService focuspoints.Service,
+	fs osfs.FileSystem,
+	frameBuilder display.Builder,
+) render.UseCase {
+	return renderUseCase{
+		log:                log,
+		efdService:         efdService,
+		focuspointsService: focuspointsService,
+		fs:                 fs,
+		frameBuilder:       frameBuilder,
+	}
+}
+
+func (uc renderUseCase) Render(
+	ctx context.Context,
+	efdFile string,
+	target string,
+	opts render.Options,
+	recovery bool,
+) error {
+	uc.log.InfoContext(ctx, "starting focusing points render",
+		slog.String("efd_file", efdFile),
+		slog.String("target", target),
+		slog.String("format", opts.Format),
+		slog.Bool("montage", opts.Montage),
+		slog.String("frames", opts.Frames.String()),
+		slog.String("where", opts.Where.String()),
+		slog.Bool("recover", recovery),
+		slog.Bool("force", opts.Force))
+
+	if !opts.Montage {
+		info, err := uc.fs.Stat(target)
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("%w: %q", ErrTargetNotDirectory, target)
+		}
+	}
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	if err = opts.Frames.Check(root.EFRMs); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
+	}
+
+	grids, err := uc.selectGrids(ctx, root.EFRMs, opts)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
+	}
+
+	if len(grids) == 0 {
+		return fmt.Errorf("%w in %q", ErrNoFramesSelected, efdFile)
+	}
+
+	uc.log.DebugContext(ctx, "frames selected", slog.Int("frames", len(grids)))
+
+	if opts.Montage {
+		err = uc.renderMontage(ctx, target, grids, root.EFDF.PerRow, opts)
+	} else {
+		err = uc.renderFrames(ctx, efdFile, target, grids, opts)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	uc.log.InfoContext(ctx, "focusing points render completed successfully")
+
+	return nil
+}
+
+// renderMontage writes all grids to target, as many to a row as the roll's
+// contact sheet layout.
+func (uc renderUseCase) renderMontage(
+	ctx context.Context,
+	target string,
+	grids []focuspoints.Grid,
+	perRow uint8,
+	opts render.Options,
+) error {
+	columns := int(perRow)
+	if columns == 0 {
+		columns = defaultColumns
+	}
+
+	err := writeFile(uc.fs, target, opts.Force, func(f osfs.File) error {
+		return uc.focuspointsService.RenderMontage(
+			ctx, f, grids, columns, opts.Format)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: %d frame(s)\n", target, len(grids))
+
+	return nil
+}
+
+// renderFrames writes each grid to its own file in targetDir.
+func (uc renderUseCase) renderFrames(
+	ctx context.Context,
+	efdFile string,
+	targetDir string,
+	grids []focuspoints.Grid,
+	opts render.Options,
+) error {
+	roll := strings.TrimSuffix(filepath.Base(efdFile), filepath.Ext(efdFile))
+
+	for _, g := range grids {
+		name := fmt.Sprintf("%s_%02d_af.%s", roll, g.FrameNumber, opts.Format)
+		target := filepath.Join(targetDir, name)
+
+		err := writeFile(uc.fs, target, opts.Force, func(f osfs.File) error {
+			return uc.focuspointsService.RenderFrame(ctx, f, g, opts.Format)
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stdout, "%s: frame %d\n", target, g.FrameNumber)
+	}
+
+	return nil
+}
+
+// selectGrids returns the grids of the frames selected by the frame ranges
+// and filter of opts.
+func (uc renderUseCase) selectGrids(
+	ctx context.Context,
+	efrms []records.EFRM,
+	opts render.Options,
+) ([]focuspoints.Grid, error) {
+	grids := make([]focuspoints.Grid, 0, len(efrms))
+
+	for _, efrm := range efrms {
+		if !opts.Frames.Contains(efrm.FrameNumber) {
+			continue
+		}
+
+		match, err := cli.MatchFrame(ctx, uc.frameBuilder, opts.Where, efrm)
+		if err != nil {
+			return nil, err
+		}
+
+		if match {
+			grids = append(grids, focuspoints.NewGrid(efrm))
+		}
+	}
+
+	return grids, nil
+}
+
+type overlayUseCase struct {
+	log                *slog.Logger
+	efdService         efd.Service
+	focuspointsService focuspoints.Service
+	thumbnailService   thumbnail.Service
+	fs                 osfs.FileSystem
+}
+
+func NewOverlayUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	focuspointsService focuspoints.Service,
+	thumbnailService thumbnail.Service,
+	fs osfs.FileSystem,
+) overlay.UseCase {
+	return overlayUseCase{
+		log:                log,
+		efdService:         efdService,
+		focuspointsService: focuspointsService,
+		thumbnailService:   thumbnailService,
+		fs:                 fs,
+	}
+}
+
+func (uc overlayUseCase) Overlay(
+	ctx context.Context,
+	efdFile string,
+	frameNumber uint32,
+	scanFile string,
+	targetFile string,
+	opts overlay.Options,
+	recovery bool,
+) error {
+	uc.log.InfoContext(ctx, "starting focusing points overlay",
+		slog.String("efd_file", efdFile),
+		slog.Uint64("frame_number", uint64(frameNumber)),
+		slog.String("scan_file", scanFile),
+		slog.String("target_file", targetFile),
+		slog.Bool("recover", recovery),
+		slog.Bool("force", opts.Force))
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	i, err := cli.FindFrame(root.EFRMs, frameNumber)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToOverlay, efdFile, err)
+	}
+
+	scan, err := uc.readImage(ctx, scanFile)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadImage, scanFile, err)
+	}
+
+	img, err := uc.focuspointsService.Overlay(
+		ctx,
+		scan,
+		focuspoints.NewGrid(root.EFRMs[i]),
+		opts.Placement,
+	)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToOverlay, scanFile, err)
+	}
+
+	err = writeFile(uc.fs, targetFile, opts.Force, func(f osfs.File) error {
+		return uc.thumbnailService.Encode(ctx, f, img, opts.Format)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: frame %d\n", targetFile, frameNumber)
+
+	uc.log.InfoContext(ctx, "focusing points overlay completed successfully")
+
+	return nil
+}
+
+func (uc overlayUseCase) readImage(
+	ctx context.Context,
+	scanFile string,
+) (image.Image, error) {
+	file, err := uc.fs.Open(scanFile)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by caller
+	}
+	defer file.Close()
+
+	//nolint:wrapcheck // wrapped by caller
+	return uc.thumbnailService.Decode(ctx, file)
+}
+
+// writeFile creates target, or truncates it if force is set, and draws into it.
+func writeFile(
+	fs osfs.FileSystem,
+	target string,
+	force bool,
+	draw func(osfs.File) error,
+) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	f, err := fs.OpenFile(target, flags, permission)
+	if err != nil {
+		if !force && errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%w: %q", cli.ErrOutputFileAlreadyExists, target)
+		}
+
+		return fmt.Errorf("%w %q: %w", ErrFailedToCreateFile, target, err)
+	}
+	defer f.Close()
+
+	if err = draw(f); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToRender, target, err)
+	}
+
+	return nil
+}
@@ -19,14 +19,30 @@ package focusingpoints_test
 import (
 	"bytes"
 	"errors"
+	"image"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
+	"text/template"
 
+	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/focusingpoints"
+	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/overlay"
+	"github.com/ma-tf/meta1v/internal/cli/focusingpoints/render"
+	"github.com/ma-tf/meta1v/internal/domain"
 	"github.com/ma-tf/meta1v/internal/records"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	display_test "github.com/ma-tf/meta1v/internal/service/display/mocks"
 	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	"github.com/ma-tf/meta1v/internal/service/focuspoints"
+	focuspoints_test "github.com/ma-tf/meta1v/internal/service/focuspoints/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	jsonexport_test "github.com/ma-tf/meta1v/internal/service/jsonexport/mocks"
+	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	"github.com/ma-tf/meta1v/internal/service/thumbnail"
+	thumbnail_test "github.com/ma-tf/meta1v/internal/service/thumbnail/mocks"
+	usertemplate_test "github.com/ma-tf/meta1v/internal/service/usertemplate/mocks"
 	"go.uber.org/mock/gomock"
 )
 
@@ -178,9 +194,12 @@ func Test_List(t *testing.T) {
 				mockEFDService,
 				mockDisplayableRollFactory,
 				mockDisplayService,
+				jsonexport_test.NewMockService(ctrl),
+				usertemplate_test.NewMockService(ctrl),
 			)
 
-			err := uc.List(ctx, tt.filename, tt.strict)
+			err := uc.List(ctx, tt.filename, tt.strict, false,
+				cli.Output{Format: cli.OutputTable}, nil, framefilter.Filter{})
 
 			if tt.expectedError != nil {
 				if err == nil {
@@ -204,3 +223,644 @@ func Test_List(t *testing.T) {
 		})
 	}
 }
+
+func stat(t *testing.T, name string) os.FileInfo {
+	t.Helper()
+
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatalf("failed to stat %q: %v", name, err)
+	}
+
+	return info
+}
+
+//nolint:exhaustruct,funlen,maintidx // only partial is needed, table driven test
+func Test_Render(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dirInfo := stat(t, dir)
+	fileInfo := stat(t, "usecase_test.go")
+
+	root := records.Root{
+		EFDF: records.EFDF{PerRow: 5},
+		EFRMs: []records.EFRM{
+			{FrameNumber: 5, FocusPoints1: 0b1000},
+			{FrameNumber: 7, FocusingPoint: 1},
+		},
+	}
+	first := focuspoints.NewGrid(root.EFRMs[0])
+	second := focuspoints.NewGrid(root.EFRMs[1])
+
+	const (
+		create    = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		overwrite = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	)
+
+	perm := gomock.Any()
+
+	type mocks struct {
+		efd         *efd_test.MockService
+		focuspoints *focuspoints_test.MockService
+		fs          *osfs_test.MockFileSystem
+		file        *osfs_test.MockFile
+		builder     *display_test.MockBuilder
+	}
+
+	readRoot := func(m mocks) {
+		m.efd.EXPECT().
+			RecordsFromFile(gomock.Any(), "in/roll.efd").
+			Return(root, nil)
+	}
+
+	tests := []struct {
+		name          string
+		target        string
+		opts          render.Options
+		expect        func(m mocks)
+		expectedError error
+	}{
+		{
+			name:   "target is not a directory",
+			target: dir,
+			opts:   render.Options{Format: focuspoints.FormatPNG},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(fileInfo, nil)
+			},
+			expectedError: focusingpoints.ErrTargetNotDirectory,
+		},
+		{
+			name:   "failed to read file",
+			target: dir,
+			opts:   render.Options{Format: focuspoints.FormatPNG},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in/roll.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: focusingpoints.ErrFailedToReadFile,
+		},
+		{
+			name:   "frames not in the roll",
+			target: dir,
+			opts: render.Options{
+				Format: focuspoints.FormatPNG,
+				Frames: []domain.FrameRange{
+					{First: 5, Last: 5},
+					{First: 8, Last: 9},
+				},
+			},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				readRoot(m)
+			},
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+		{
+			name:   "no frames selected",
+			target: dir,
+			opts: render.Options{
+				Format: focuspoints.FormatPNG,
+				Where:  mustParseFilter(t, "flash != OFF"),
+			},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				readRoot(m)
+				m.builder.EXPECT().
+					Build(gomock.Any(), gomock.Any(), gomock.Nil(), false).
+					Return(display.DisplayableFrame{FlashMode: "OFF"}, nil).
+					Times(2)
+			},
+			expectedError: focusingpoints.ErrNoFramesSelected,
+		},
+		{
+			name:   "file exists",
+			target: dir,
+			opts:   render.Options{Format: focuspoints.FormatPNG},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				readRoot(m)
+				m.fs.EXPECT().
+					OpenFile(filepath.Join(dir, "roll_05_af.png"), create, perm).
+					Return(nil, os.ErrExist)
+			},
+			expectedError: cli.ErrOutputFileAlreadyExists,
+		},
+		{
+			name:   "failed to render",
+			target: dir,
+			opts:   render.Options{Format: focuspoints.FormatPNG},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				readRoot(m)
+				m.fs.EXPECT().
+					OpenFile(filepath.Join(dir, "roll_05_af.png"), create, perm).
+					Return(m.file, nil)
+				m.focuspoints.EXPECT().
+					RenderFrame(gomock.Any(), m.file, first, "png").
+					Return(errExample)
+				m.file.EXPECT().Close().Return(nil)
+			},
+			expectedError: focusingpoints.ErrFailedToRender,
+		},
+		{
+			name:   "selected frames overwriting existing files",
+			target: dir,
+			opts: render.Options{
+				Format: focuspoints.FormatSVG,
+				Frames: []domain.FrameRange{{First: 7, Last: 7}},
+				Force:  true,
+			},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				readRoot(m)
+				m.fs.EXPECT().
+					OpenFile(
+						filepath.Join(dir, "roll_07_af.svg"),
+						overwrite,
+						gomock.Any(),
+					).
+					Return(m.file, nil)
+				m.focuspoints.EXPECT().
+					RenderFrame(gomock.Any(), m.file, second, "svg").
+					Return(nil)
+				m.file.EXPECT().Close().Return(nil)
+			},
+		},
+		{
+			name:   "failed to decode frame for filter",
+			target: dir,
+			opts: render.Options{
+				Format: focuspoints.FormatPNG,
+				Where:  mustParseFilter(t, "flash == OFF"),
+			},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				readRoot(m)
+				m.builder.EXPECT().
+					Build(gomock.Any(), root.EFRMs[0], gomock.Nil(), false).
+					Return(display.DisplayableFrame{}, errExample)
+			},
+			expectedError: focusingpoints.ErrFailedToParseFile,
+		},
+		{
+			name:   "frames matching the filter",
+			target: dir,
+			opts: render.Options{
+				Format: focuspoints.FormatPNG,
+				Where:  mustParseFilter(t, "flash == OFF"),
+			},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				readRoot(m)
+				m.builder.EXPECT().
+					Build(gomock.Any(), root.EFRMs[0], gomock.Nil(), false).
+					Return(display.DisplayableFrame{FlashMode: "OFF"}, nil)
+				m.builder.EXPECT().
+					Build(gomock.Any(), root.EFRMs[1], gomock.Nil(), false).
+					Return(display.DisplayableFrame{FlashMode: "E-TTL"}, nil)
+				m.fs.EXPECT().
+					OpenFile(filepath.Join(dir, "roll_05_af.png"), create, perm).
+					Return(m.file, nil)
+				m.focuspoints.EXPECT().
+					RenderFrame(gomock.Any(), m.file, first, "png").
+					Return(nil)
+				m.file.EXPECT().Close().Return(nil)
+			},
+		},
+		{
+			name:   "montage in rows of the roll layout",
+			target: "af.png",
+			opts: render.Options{
+				Format:  focuspoints.FormatPNG,
+				Montage: true,
+			},
+			expect: func(m mocks) {
+				readRoot(m)
+				m.fs.EXPECT().
+					OpenFile("af.png", create, gomock.Any()).
+					Return(m.file, nil)
+				m.focuspoints.EXPECT().
+					RenderMontage(
+						gomock.Any(),
+						m.file,
+						[]focuspoints.Grid{first, second},
+						5,
+						focuspoints.FormatPNG,
+					).
+					Return(nil)
+				m.file.EXPECT().Close().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				efd:         efd_test.NewMockService(ctrl),
+				focuspoints: focuspoints_test.NewMockService(ctrl),
+				fs:          osfs_test.NewMockFileSystem(ctrl),
+				file:        osfs_test.NewMockFile(ctrl),
+				builder:     display_test.NewMockBuilder(ctrl),
+			}
+			tt.expect(m)
+
+			uc := focusingpoints.NewRenderUseCase(
+				newTestLogger(),
+				m.efd,
+				m.focuspoints,
+				m.fs,
+				m.builder,
+			)
+
+			err := uc.Render(
+				t.Context(),
+				"in/roll.efd",
+				tt.target,
+				tt.opts,
+				false,
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Overlay(t *testing.T) {
+	t.Parallel()
+
+	root := records.Root{
+		EFRMs: []records.EFRM{
+			{FrameNumber: 5, FocusPoints1: 0b1000},
+			{FrameNumber: 7, FocusingPoint: 1},
+		},
+	}
+	grid := focuspoints.NewGrid(root.EFRMs[1])
+
+	scan := image.NewRGBA(image.Rect(0, 0, 36, 24))
+	result := image.NewRGBA(image.Rect(0, 0, 36, 24))
+
+	placement := focuspoints.Placement{Crop: focuspoints.FullFrame()}
+	opts := overlay.Options{
+		Placement: placement,
+		Format:    thumbnail.FormatJPEG,
+	}
+
+	const (
+		create    = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		overwrite = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	)
+
+	type mocks struct {
+		efd         *efd_test.MockService
+		focuspoints *focuspoints_test.MockService
+		thumbnail   *thumbnail_test.MockService
+		fs          *osfs_test.MockFileSystem
+		scan        *osfs_test.MockFile
+		file        *osfs_test.MockFile
+	}
+
+	readRoot := func(m mocks) {
+		m.efd.EXPECT().
+			RecordsFromFile(gomock.Any(), "roll.efd").
+			Return(root, nil)
+	}
+
+	readScan := func(m mocks) {
+		m.fs.EXPECT().Open("7.tif").Return(m.scan, nil)
+		m.thumbnail.EXPECT().Decode(gomock.Any(), m.scan).Return(scan, nil)
+		m.scan.EXPECT().Close().Return(nil)
+	}
+
+	tests := []struct {
+		name          string
+		frameNumber   uint32
+		opts          overlay.Options
+		expect        func(m mocks)
+		expectedError error
+	}{
+		{
+			name:        "failed to read file",
+			frameNumber: 7,
+			opts:        opts,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: focusingpoints.ErrFailedToReadFile,
+		},
+		{
+			name:          "frame not found",
+			frameNumber:   6,
+			opts:          opts,
+			expect:        readRoot,
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+		{
+			name:        "failed to read scan",
+			frameNumber: 7,
+			opts:        opts,
+			expect: func(m mocks) {
+				readRoot(m)
+				m.fs.EXPECT().Open("7.tif").Return(nil, errExample)
+			},
+			expectedError: focusingpoints.ErrFailedToReadImage,
+		},
+		{
+			name:        "invalid placement",
+			frameNumber: 7,
+			opts:        opts,
+			expect: func(m mocks) {
+				readRoot(m)
+				readScan(m)
+				m.focuspoints.EXPECT().
+					Overlay(gomock.Any(), scan, grid, placement).
+					Return(nil, focuspoints.ErrInvalidPlacement)
+			},
+			expectedError: focuspoints.ErrInvalidPlacement,
+		},
+		{
+			name:        "file exists",
+			frameNumber: 7,
+			opts:        opts,
+			expect: func(m mocks) {
+				readRoot(m)
+				readScan(m)
+				m.focuspoints.EXPECT().
+					Overlay(gomock.Any(), scan, grid, placement).
+					Return(result, nil)
+				m.fs.EXPECT().
+					OpenFile("7_af.jpg", create, gomock.Any()).
+					Return(nil, os.ErrExist)
+			},
+			expectedError: cli.ErrOutputFileAlreadyExists,
+		},
+		{
+			name:        "failed to encode",
+			frameNumber: 7,
+			opts:        opts,
+			expect: func(m mocks) {
+				readRoot(m)
+				readScan(m)
+				m.focuspoints.EXPECT().
+					Overlay(gomock.Any(), scan, grid, placement).
+					Return(result, nil)
+				m.fs.EXPECT().
+					OpenFile("7_af.jpg", create, gomock.Any()).
+					Return(m.file, nil)
+				m.thumbnail.EXPECT().
+					Encode(gomock.Any(), m.file, result, thumbnail.FormatJPEG).
+					Return(errExample)
+				m.file.EXPECT().Close().Return(nil)
+			},
+			expectedError: focusingpoints.ErrFailedToRender,
+		},
+		{
+			name:        "overwriting existing file",
+			frameNumber: 7,
+			opts: overlay.Options{
+				Placement: placement,
+				Format:    thumbnail.FormatJPEG,
+				Force:     true,
+			},
+			expect: func(m mocks) {
+				readRoot(m)
+				readScan(m)
+				m.focuspoints.EXPECT().
+					Overlay(gomock.Any(), scan, grid, placement).
+					Return(result, nil)
+				m.fs.EXPECT().
+					OpenFile("7_af.jpg", overwrite, gomock.Any()).
+					Return(m.file, nil)
+				m.thumbnail.EXPECT().
+					Encode(gomock.Any(), m.file, result, thumbnail.FormatJPEG).
+					Return(nil)
+				m.file.EXPECT().Close().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				efd:         efd_test.NewMockService(ctrl),
+				focuspoints: focuspoints_test.NewMockService(ctrl),
+				thumbnail:   thumbnail_test.NewMockService(ctrl),
+				fs:          osfs_test.NewMockFileSystem(ctrl),
+				scan:        osfs_test.NewMockFile(ctrl),
+				file:        osfs_test.NewMockFile(ctrl),
+			}
+			tt.expect(m)
+
+			uc := focusingpoints.NewOverlayUseCase(
+				newTestLogger(),
+				m.efd,
+				m.focuspoints,
+				m.thumbnail,
+				m.fs,
+			)
+
+			err := uc.Overlay(
+				t.Context(),
+				"roll.efd",
+				tt.frameNumber,
+				"7.tif",
+				"7_af.jpg",
+				tt.opts,
+				false,
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FocusingPointsListUseCase_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedError error
+	}{
+		{
+			name:          "successfully write JSON",
+			err:           nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to write JSON",
+			err:           errExample,
+			expectedError: focusingpoints.ErrFailedToList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockJSONService := jsonexport_test.NewMockService(ctrl)
+
+			mockEFDService.EXPECT().
+				RecordsFromFile(gomock.Any(), "file.efd").
+				Return(root, nil)
+			mockDisplayableRollFactory.EXPECT().
+				Create(gomock.Any(), root, false).
+				Return(dr, nil)
+			mockJSONService.EXPECT().
+				ExportFocusingPoints(gomock.Any(), gomock.Any(), dr).
+				Return(tt.err)
+
+			uc := focusingpoints.NewListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				mockJSONService,
+				usertemplate_test.NewMockService(ctrl),
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				cli.Output{Format: cli.OutputJSON},
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FocusingPointsListUseCase_Template(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		parseErr      error
+		executeErr    error
+		expectedError error
+	}{
+		{
+			name:          "successfully execute template",
+			parseErr:      nil,
+			executeErr:    nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to parse template",
+			parseErr:      errExample,
+			executeErr:    nil,
+			expectedError: focusingpoints.ErrFailedToList,
+		},
+		{
+			name:          "failed to execute template",
+			parseErr:      nil,
+			executeErr:    errExample,
+			expectedError: focusingpoints.ErrFailedToList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockTemplateService := usertemplate_test.NewMockService(ctrl)
+			tmpl := template.Must(template.New("template").Parse("{{.FilmID}}"))
+
+			mockTemplateService.EXPECT().
+				Parse(gomock.Any(), "{{.FilmID}}").
+				Return(tmpl, tt.parseErr)
+
+			if tt.parseErr == nil {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), root, false).
+					Return(dr, nil)
+				mockTemplateService.EXPECT().
+					Execute(gomock.Any(), gomock.Any(), tmpl, dr).
+					Return(tt.executeErr)
+			}
+
+			uc := focusingpoints.NewListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				jsonexport_test.NewMockService(ctrl),
+				mockTemplateService,
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				cli.Output{
+					Format:   cli.OutputTable,
+					Template: "{{.FilmID}}",
+				},
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
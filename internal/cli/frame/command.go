@@ -41,6 +41,8 @@ and user-provided remarks.`,
 		ctr.EFDService,
 		ctr.DisplayableRollFactory,
 		ctr.DisplayService,
+		ctr.JSONService,
+		ctr.TemplateService,
 	)
 
 	exportUseCase := NewExportUseCase(
@@ -49,6 +51,7 @@ and user-provided remarks.`,
 		ctr.DisplayableRollFactory,
 		ctr.CSVService,
 		ctr.FileSystem,
+		ctr.TemplateService,
 	)
 
 	cmd.AddCommand(ls.NewCommand(log, listUseCase))
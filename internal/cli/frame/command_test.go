@@ -46,9 +46,6 @@ func Test_NewCommand(t *testing.T) {
 	}))
 
 	mockLookPath := osexec_test.NewMockLookPath(ctrl)
-	mockLookPath.EXPECT().
-		LookPath("exiftool").
-		Return("/usr/bin/exiftool", nil)
 
 	ctr := container.New(logger, mockLookPath)
 	cmd := frame.NewCommand(logger, ctr)
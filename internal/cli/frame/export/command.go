@@ -25,7 +25,9 @@ import (
 	"log/slog"
 
 	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -37,12 +39,18 @@ const (
 // UseCase defines the business logic for exporting frame information from EFD files.
 type UseCase interface {
 	// Export reads an EFD file and exports frame information in CSV format to stdout or a specified file.
+	// The output is written through the output template instead if one is set. The CSV has the output
+	// columns, or the default columns if there are none. Only the frames matching where are exported.
 	Export(
 		ctx context.Context,
 		efdFile string,
 		outputFile *string,
 		strict bool,
+		recovery bool,
 		force bool,
+		output cli.Output,
+		frames cli.FrameRanges,
+		where framefilter.Filter,
 	) error
 }
 
@@ -52,8 +60,21 @@ func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
 		Args:  cobra.RangeArgs(minArgs, maxArgs),
 		Short: "Export frame information to CSV format",
 		Long: `Export detailed frame information to CSV format, including frame number, exposure 
-settings (Tv, Av, ISO), exposure compensation, and user-provided remarks. Output can be 
-directed to stdout or saved to a specified file.`,
+settings (Tv, Av, ISO), exposure compensation, user-provided remarks and a description
+of the active focusing points. Output can be directed to stdout or saved to a specified file.
+
+With --template, the roll is written through a Go text/template given inline or as a
+file instead of as CSV; see the README for the helper functions available.
+
+With --columns, or the frame.columns setting of the config file, only the named
+columns are exported, in the order given.
+
+With --frames, only the frames with the given numbers are exported, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are exported; see the
+README for the fields and operators available.`,
 		Example: `  # Export frame data to stdout
   meta1v frame export data.efd
 
@@ -61,7 +82,19 @@ directed to stdout or saved to a specified file.`,
   meta1v frame export data.efd output.csv
 
   # Overwrite existing file
-  meta1v f export data.efd output.csv --force`,
+  meta1v f export data.efd output.csv --force
+
+  # Only some columns, in this order
+  meta1v f export data.efd output.csv --columns frame,tv,av,ec,takenAt
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll
+  meta1v frame export data.efd frames.csv --frames 1-5,12,30-
+
+  # Only frames shot with flash at f/2.8 or wider
+  meta1v f export data.efd --where 'av <= 2.8 && flash != "OFF"'
+
+  # Through a template saved in a file
+  meta1v f export data.efd log.txt --template log.tmpl`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
@@ -70,11 +103,36 @@ directed to stdout or saved to a specified file.`,
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
 			force, err := cmd.Flags().GetBool("force")
 			if err != nil {
 				return errors.Join(cli.ErrFailedToGetForceFlag, err)
 			}
 
+			tmpl, err := cli.GetTemplate(cmd)
+			if err != nil {
+				return err
+			}
+
+			columns, err := cli.GetColumns(cmd, viper.GetViper())
+			if err != nil {
+				return err
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
 			var targetFile *string
 			if len(args) == maxArgs {
 				targetFile = &args[targetFileIndex]
@@ -88,13 +146,30 @@ directed to stdout or saved to a specified file.`,
 				slog.String("efd_file", args[0]),
 				slog.Any("target_file", targetFile),
 				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
 				slog.Bool("force", force),
+				slog.String("template", tmpl),
+				slog.Any("columns", columns),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()),
 			)
 
-			return uc.Export(ctx, args[0], targetFile, strict, force)
+			return uc.Export(ctx, args[0], targetFile, strict, recovery, force,
+				cli.Output{
+					Format:   cli.OutputCSV,
+					Template: tmpl,
+					Columns:  columns,
+				},
+				frames,
+				where,
+			)
 		},
 	}
 
+	cli.AddTemplateFlag(cmd)
+	cli.AddColumnsFlag(cmd)
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
 	cmd.Flags().BoolP("force", "F", false, "overwrite output file if it exists")
 
 	return cmd
@@ -25,6 +25,7 @@ import (
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/frame/export"
 	export_test "github.com/ma-tf/meta1v/internal/cli/frame/export/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
 	"go.uber.org/mock/gomock"
 )
@@ -56,10 +57,23 @@ func Test_NewCommand(t *testing.T) {
 		return &b
 	}
 
+	setString := func(s string) *string {
+		return &s
+	}
+
+	setColumns := func(c ...string) *[]string {
+		return &c
+	}
+
 	type testcase struct {
 		name          string
 		strict        *bool
+		recovery      *bool
 		force         *bool
+		template      *string
+		columns       *[]string
+		frames        *string
+		where         *string
 		args          []string
 		expect        func(uc export_test.MockUseCase, tt testcase)
 		expectedError error
@@ -73,42 +87,291 @@ func Test_NewCommand(t *testing.T) {
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrFailedToGetStrictFlag,
 		},
+		{
+			name:          "failed to get recover flag",
+			strict:        setTrue(),
+			recovery:      nil,
+			force:         setFalse(),
+			template:      setString(""),
+			columns:       setColumns(),
+			frames:        setString(""),
+			where:         setString(""),
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetRecoverFlag,
+		},
 		{
 			name:          "failed to get force flag",
 			strict:        setTrue(),
+			recovery:      setFalse(),
 			force:         nil,
 			args:          []string{"file.efd", "output.csv"},
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrFailedToGetForceFlag,
 		},
+		{
+			name:          "failed to get template flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			template:      nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetTemplateFlag,
+		},
+		{
+			name:          "failed to get columns flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			template:      setString(""),
+			columns:       nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetColumnsFlag,
+		},
+		{
+			name:          "failed to get frames flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			template:      setString(""),
+			columns:       setColumns(),
+			frames:        nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetFramesFlag,
+		},
+		{
+			name:          "failed to get where flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			template:      setString(""),
+			columns:       setColumns(),
+			frames:        setString(""),
+			where:         nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetWhereFlag,
+		},
+		{
+			name:          "invalid where expression",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			template:      setString(""),
+			columns:       setColumns(),
+			frames:        setString(""),
+			where:         setString("av <="),
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: framefilter.ErrInvalidExpression,
+		},
 		{
 			name:          "force flag without target file",
 			strict:        setTrue(),
+			recovery:      setFalse(),
 			force:         setTrue(),
+			template:      setString(""),
+			columns:       setColumns(),
+			frames:        setString(""),
+			where:         setString(""),
 			args:          []string{"file.efd"},
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrForceFlagRequiresTargetFile,
 		},
 		{
-			name:   "successful export to stdout (1 arg)",
-			strict: setTrue(),
-			force:  setFalse(),
-			args:   []string{"file.efd"},
+			name:     "successful export to stdout (1 arg)",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			template: setString(""),
+			columns:  setColumns(),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd"},
+			expect: func(uc export_test.MockUseCase, tt testcase) {
+				uc.EXPECT().
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+							Columns:  nil,
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "successful export with target file",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setTrue(),
+			template: setString(""),
+			columns:  setColumns(),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd", "output.csv"},
 			expect: func(uc export_test.MockUseCase, tt testcase) {
 				uc.EXPECT().
-					Export(gomock.Any(), tt.args[0], nil, *tt.strict, *tt.force).
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						&tt.args[1],
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+							Columns:  nil,
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
 					Return(nil)
 			},
 			expectedError: nil,
 		},
 		{
-			name:   "successful export with target file",
-			strict: setTrue(),
-			force:  setTrue(),
-			args:   []string{"file.efd", "output.csv"},
+			name:     "successful export through a template",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			template: setString("{{.FilmID}}"),
+			columns:  setColumns(),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd"},
 			expect: func(uc export_test.MockUseCase, tt testcase) {
 				uc.EXPECT().
-					Export(gomock.Any(), tt.args[0], &tt.args[1], *tt.strict, *tt.force).
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+							Columns:  nil,
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "successful export with columns",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			template: setString(""),
+			columns:  setColumns(),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd", "--columns", "frame,tv"},
+			expect: func(uc export_test.MockUseCase, tt testcase) {
+				uc.EXPECT().
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+							Columns:  []string{"frame", "tv"},
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "successful export of filtered frames",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			template: setString(""),
+			columns:  setColumns(),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd", "--where", "av <= 2.8"},
+			expect: func(uc export_test.MockUseCase, tt testcase) {
+				uc.EXPECT().
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{Format: cli.OutputCSV},
+						gomock.Nil(),
+						mustParseFilter(t, "av <= 2.8"),
+					).
+					Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "invalid frame range",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			template:      setString(""),
+			columns:       setColumns(),
+			frames:        setString("5-1"),
+			where:         setString(""),
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrInvalidFrameRange,
+		},
+		{
+			name:     "successful export of selected frames",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			template: setString(""),
+			columns:  setColumns(),
+			frames:   setString(""),
+			where:    setString(""),
+			args:     []string{"file.efd", "--frames", "1-5,12,30-"},
+			expect: func(uc export_test.MockUseCase, tt testcase) {
+				uc.EXPECT().
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{Format: cli.OutputCSV},
+						cli.FrameRanges{
+							{First: 1, Last: 5},
+							{First: 12, Last: 12},
+							{First: 30, Open: true},
+						},
+						framefilter.Filter{},
+					).
 					Return(nil)
 			},
 			expectedError: nil,
@@ -123,10 +386,30 @@ func Test_NewCommand(t *testing.T) {
 			cmd.Flags().Bool("strict", *tt.strict, "enable strict mode")
 		}
 
+		if tt.recovery != nil {
+			cmd.Flags().Bool("recover", *tt.recovery, "enable recovery mode")
+		}
+
 		if tt.force != nil {
 			cmd.Flags().Bool("force", *tt.force, "enable force mode")
 		}
 
+		if tt.template != nil {
+			cmd.Flags().String("template", *tt.template, "template")
+		}
+
+		if tt.columns != nil {
+			cmd.Flags().StringSlice("columns", *tt.columns, "columns")
+		}
+
+		if tt.frames != nil {
+			cmd.Flags().String("frames", *tt.frames, "frames")
+		}
+
+		if tt.where != nil {
+			cmd.Flags().String("where", *tt.where, "where")
+		}
+
 		cmd.SetArgs(tt.args)
 
 		return cmd
@@ -179,3 +462,14 @@ func Test_NewCommand(t *testing.T) {
 		})
 	}
 }
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
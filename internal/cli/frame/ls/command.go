@@ -25,21 +25,49 @@ import (
 	"log/slog"
 
 	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // UseCase defines the business logic for listing frame information from EFD files.
 type UseCase interface {
-	// List reads an EFD file and prints frame information in a human-readable format.
-	List(ctx context.Context, filename string, strict bool) error
+	// List reads an EFD file and prints frame information in a human-readable format,
+	// as a JSON document if the output format is cli.OutputJSON, or through the output template if set.
+	// The table has the output columns, or the default columns if there are none.
+	// Only the frames matching where are listed.
+	List(
+		ctx context.Context,
+		filename string,
+		strict bool,
+		recovery bool,
+		output cli.Output,
+		frames cli.FrameRanges,
+		where framefilter.Filter,
+	) error
 }
 
 func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list <filename>",
 		Short: "Display frame information in human-readable format",
 		Long: `Display detailed information about frames including exposure settings (Tv, Av, ISO), 
-exposure compensation, focus points, custom functions, and user-provided remarks.`,
+exposure compensation, focus points, custom functions, and user-provided remarks.
+
+With --output json, a JSON document following the versioned schema described in
+the README is written instead, for use with tools such as jq. With --template,
+the roll is written through a Go text/template given inline or as a file; see the
+README for the helper functions available.
+
+With --columns, or the frame.columns setting of the config file, the table only
+shows the named columns, in the order given.
+
+With --frames, only the frames with the given numbers are listed, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are listed; see the
+README for the fields and operators available.`,
 		Example: `  # Display frame information
   meta1v frame list data.efd
 
@@ -47,7 +75,25 @@ exposure compensation, focus points, custom functions, and user-provided remarks
   meta1v frame ls data.efd
 
   # With strict mode
-  meta1v f ls data.efd --strict`,
+  meta1v f ls data.efd --strict
+
+  # Only some columns, in this order
+  meta1v f ls data.efd --columns frame,tv,av,ec,takenAt
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll
+  meta1v f ls data.efd --frames 1-5,12,30-
+
+  # Only frames shot with flash at f/2.8 or wider
+  meta1v f ls data.efd --where 'av <= 2.8 && flash != "OFF"'
+
+  # Frames shot after 18:00
+  meta1v f ls data.efd --where 'time >= 18:00'
+
+  # As JSON
+  meta1v f ls data.efd --output json
+
+  # Through a template
+  meta1v f ls data.efd --template '{{range .Frames}}{{padLeft 2 .FrameNumber}} {{.Tv}} {{.Av}} EV {{ev .Av .Tv}}{{"\n"}}{{end}}'`,
 		Aliases: []string{"ls"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -58,12 +104,58 @@ exposure compensation, focus points, custom functions, and user-provided remarks
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			output, err := cli.GetOutput(cmd, cli.OutputTable, cli.OutputJSON)
+			if err != nil {
+				return err
+			}
+
+			output.Columns, err = cli.GetColumns(cmd, viper.GetViper())
+			if err != nil {
+				return err
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
 			log.DebugContext(ctx, "arguments:",
 				slog.String("filename", args[0]),
 				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.String("output", output.Format),
+				slog.String("template", output.Template),
+				slog.Any("columns", output.Columns),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()),
 			)
 
-			return uc.List(ctx, args[0], strict)
+			return uc.List(
+				ctx,
+				args[0],
+				strict,
+				recovery,
+				output,
+				frames,
+				where,
+			)
 		},
 	}
+
+	cli.AddOutputFlags(cmd, cli.OutputTable, cli.OutputJSON)
+	cli.AddColumnsFlag(cmd)
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+
+	return cmd
 }
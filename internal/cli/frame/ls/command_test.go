@@ -25,6 +25,7 @@ import (
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/frame/ls"
 	ls_test "github.com/ma-tf/meta1v/internal/cli/frame/ls/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"go.uber.org/mock/gomock"
 )
 
@@ -44,11 +45,12 @@ func Test_CommandRun(t *testing.T) {
 	}))
 
 	type testcase struct {
-		name           string
-		args           []string
-		registerStrict bool
-		expect         func(uc ls_test.MockUseCase, tt testcase)
-		expectedError  error
+		name            string
+		args            []string
+		registerStrict  bool
+		registerRecover bool
+		expect          func(uc ls_test.MockUseCase, tt testcase)
+		expectedError   error
 	}
 
 	tests := []testcase{
@@ -59,12 +61,153 @@ func Test_CommandRun(t *testing.T) {
 			expectedError:  cli.ErrFailedToGetStrictFlag,
 		},
 		{
-			name:           "successful execution",
-			args:           []string{"file.efd"},
-			registerStrict: true,
+			name:            "recover flag not registered",
+			args:            []string{"file.efd"},
+			registerStrict:  true,
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "successful execution",
+			args:            []string{"file.efd"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputTable},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "unsupported output",
+			args:            []string{"file.efd", "--output", "csv"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   cli.ErrUnsupportedOutput,
+		},
+		{
+			name:            "json output",
+			args:            []string{"file.efd", "--output", "json"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputJSON},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "selected columns",
+			args:            []string{"file.efd", "--columns", "frame,tv"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{
+							Format:  cli.OutputTable,
+							Columns: []string{"frame", "tv"},
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "template output",
+			args:            []string{"file.efd", "--template", "{{.FilmID}}"},
+			registerStrict:  true,
+			registerRecover: true,
 			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
 				mockUseCase.EXPECT().
-					List(gomock.Any(), tt.args[0], gomock.Any()).
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{
+							Format:   cli.OutputTable,
+							Template: "{{.FilmID}}",
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "invalid where expression",
+			args:            []string{"file.efd", "--where", "av <="},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   framefilter.ErrInvalidExpression,
+		},
+		{
+			name:            "filtered frames",
+			args:            []string{"file.efd", "--where", "av <= 2.8"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputTable},
+						gomock.Nil(),
+						mustParseFilter(t, "av <= 2.8"),
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "invalid frame range",
+			args:            []string{"file.efd", "--frames", "0-3"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   cli.ErrInvalidFrameRange,
+		},
+		{
+			name:            "selected frames",
+			args:            []string{"file.efd", "--frames", "2-4,9"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputTable},
+						cli.FrameRanges{
+							{First: 2, Last: 4},
+							{First: 9, Last: 9},
+						},
+						framefilter.Filter{},
+					).
 					Return(nil)
 			},
 		},
@@ -112,6 +255,10 @@ func Test_CommandRun(t *testing.T) {
 				cmd.Flags().Bool("strict", false, "enable strict mode")
 			}
 
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
 			cmd.SetArgs(tt.args)
 
 			err := cmd.Execute()
@@ -120,3 +267,14 @@ func Test_CommandRun(t *testing.T) {
 		})
 	}
 }
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
@@ -29,7 +29,10 @@ import (
 	"github.com/ma-tf/meta1v/internal/service/csvexport"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/ma-tf/meta1v/internal/service/jsonexport"
 	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
 )
 
 const permission = 0o666 // rw-rw-rw-
@@ -51,6 +54,8 @@ type listUseCase struct {
 	efdService             efd.Service
 	displayableRollFactory display.DisplayableRollFactory
 	displayService         display.Service
+	jsonService            jsonexport.Service
+	templateService        usertemplate.Service
 }
 
 func NewListUseCase(
@@ -58,12 +63,16 @@ func NewListUseCase(
 	efdService efd.Service,
 	displayableRollFactory display.DisplayableRollFactory,
 	displayService display.Service,
+	jsonService jsonexport.Service,
+	templateService usertemplate.Service,
 ) ls.UseCase {
 	return listUseCase{
 		log:                    log,
 		efdService:             efdService,
 		displayableRollFactory: displayableRollFactory,
 		displayService:         displayService,
+		jsonService:            jsonService,
+		templateService:        templateService,
 	}
 }
 
@@ -71,12 +80,38 @@ func (uc listUseCase) List(
 	ctx context.Context,
 	filename string,
 	strict bool,
+	recovery bool,
+	output cli.Output,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
 ) error {
 	uc.log.InfoContext(ctx, "starting frame list",
 		slog.String("file", filename),
-		slog.Bool("strict", strict))
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.String("output", output.Format),
+		slog.String("template", output.Template),
+		slog.Any("columns", output.Columns),
+		slog.String("frames", frames.String()),
+		slog.String("where", where.String()))
 
-	records, err := uc.efdService.RecordsFromFile(ctx, filename)
+	tmpl, err := cli.ParseTemplate(ctx, uc.templateService, output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
+
+	columns, err := display.LookupFrameColumns(output.Columns)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
+
+	records, err := cli.ReadRecords(
+		ctx,
+		uc.log,
+		uc.efdService,
+		filename,
+		recovery,
+	)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, filename, err)
 	}
@@ -89,10 +124,28 @@ func (uc listUseCase) List(
 		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
 	}
 
+	dr, err = frames.Select(dr)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
+	}
+
+	dr = where.Apply(dr)
+
 	uc.log.DebugContext(ctx, "displayable frames created",
 		slog.Int("frame_count", len(dr.Frames)))
 
-	uc.displayService.DisplayFrames(ctx, os.Stdout, dr)
+	switch {
+	case tmpl != nil:
+		err = uc.templateService.Execute(ctx, os.Stdout, tmpl, dr)
+	case output.Format == cli.OutputJSON:
+		err = uc.jsonService.ExportFrames(ctx, os.Stdout, dr)
+	default:
+		uc.displayService.DisplayFrames(ctx, os.Stdout, dr, columns)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
 
 	uc.log.InfoContext(ctx, "frame list completed successfully")
 
@@ -105,6 +158,7 @@ type exportUseCase struct {
 	displayableRollFactory display.DisplayableRollFactory
 	csvService             csvexport.Service
 	fs                     osfs.FileSystem
+	templateService        usertemplate.Service
 }
 
 func NewExportUseCase(
@@ -113,6 +167,7 @@ func NewExportUseCase(
 	displayableRollFactory display.DisplayableRollFactory,
 	csvService csvexport.Service,
 	fs osfs.FileSystem,
+	templateService usertemplate.Service,
 ) export.UseCase {
 	return exportUseCase{
 		log:                    log,
@@ -120,6 +175,7 @@ func NewExportUseCase(
 		displayableRollFactory: displayableRollFactory,
 		csvService:             csvService,
 		fs:                     fs,
+		templateService:        templateService,
 	}
 }
 
@@ -128,15 +184,40 @@ func (uc exportUseCase) Export(
 	efdFile string,
 	outputFile *string,
 	strict bool,
+	recovery bool,
 	force bool,
+	output cli.Output,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
 ) error {
 	uc.log.InfoContext(ctx, "starting frame export",
 		slog.String("efd_file", efdFile),
 		slog.Any("output_file", outputFile),
 		slog.Bool("strict", strict),
-		slog.Bool("force", force))
+		slog.Bool("recover", recovery),
+		slog.Bool("force", force),
+		slog.String("template", output.Template),
+		slog.Any("columns", output.Columns),
+		slog.String("frames", frames.String()),
+		slog.String("where", where.String()))
 
-	records, err := uc.efdService.RecordsFromFile(ctx, efdFile)
+	tmpl, err := cli.ParseTemplate(ctx, uc.templateService, output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToExport, err)
+	}
+
+	columns, err := display.LookupFrameColumns(output.Columns)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToExport, err)
+	}
+
+	records, err := cli.ReadRecords(
+		ctx,
+		uc.log,
+		uc.efdService,
+		efdFile,
+		recovery,
+	)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
 	}
@@ -149,6 +230,13 @@ func (uc exportUseCase) Export(
 		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
 	}
 
+	dr, err = frames.Select(dr)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
+	}
+
+	dr = where.Apply(dr)
+
 	uc.log.DebugContext(ctx, "displayable frames created",
 		slog.Int("frame_count", len(dr.Frames)))
 
@@ -176,7 +264,13 @@ func (uc exportUseCase) Export(
 			slog.String("file", *outputFile))
 	}
 
-	if err = uc.csvService.ExportFrames(ctx, writer, dr); err != nil {
+	if tmpl != nil {
+		err = uc.templateService.Execute(ctx, writer, tmpl, dr)
+	} else {
+		err = uc.csvService.ExportFrames(ctx, writer, dr, columns)
+	}
+
+	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailedToExport, err)
 	}
 
@@ -22,6 +22,7 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"text/template"
 
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/frame"
@@ -30,7 +31,10 @@ import (
 	"github.com/ma-tf/meta1v/internal/service/display"
 	display_test "github.com/ma-tf/meta1v/internal/service/display/mocks"
 	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	jsonexport_test "github.com/ma-tf/meta1v/internal/service/jsonexport/mocks"
 	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	usertemplate_test "github.com/ma-tf/meta1v/internal/service/usertemplate/mocks"
 	"go.uber.org/mock/gomock"
 )
 
@@ -67,6 +71,9 @@ func Test_FrameListUseCase(t *testing.T) {
 		records       records.Root
 		roll          display.DisplayableRoll
 		strict        bool
+		columns       []string
+		frames        cli.FrameRanges
+		where         framefilter.Filter
 		expectedError error
 	}
 
@@ -144,7 +151,7 @@ func Test_FrameListUseCase(t *testing.T) {
 					)
 
 				mockDisplayService.EXPECT().
-					DisplayFrames(gomock.Any(), gomock.Any(), tt.roll)
+					DisplayFrames(gomock.Any(), gomock.Any(), tt.roll, gomock.Nil())
 			},
 			filename: "file.efd",
 			records: records.Root{
@@ -162,6 +169,95 @@ func Test_FrameListUseCase(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "unknown column",
+			filename:      "file.efd",
+			columns:       []string{"frame", "shutter"},
+			expectedError: display.ErrUnknownColumn,
+		},
+		{
+			name: "successfully display selected columns",
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockDisplayableRollFactory display_test.MockDisplayableRollFactory,
+				mockDisplayService display_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.filename).
+					Return(tt.records, nil)
+
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), tt.records, tt.strict).
+					Return(tt.roll, nil)
+
+				mockDisplayService.EXPECT().
+					DisplayFrames(gomock.Any(), gomock.Any(), tt.roll, gomock.Len(2))
+			},
+			filename: "file.efd",
+			columns:  []string{"frame", "tv"},
+		},
+		{
+			name: "successfully display filtered frames",
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockDisplayableRollFactory display_test.MockDisplayableRollFactory,
+				mockDisplayService display_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.filename).
+					Return(tt.records, nil)
+
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), tt.records, tt.strict).
+					Return(tt.roll, nil)
+
+				mockDisplayService.EXPECT().
+					DisplayFrames(
+						gomock.Any(),
+						gomock.Any(),
+						display.DisplayableRoll{
+							Frames: []display.DisplayableFrame{
+								{FrameNumber: 2},
+							},
+						},
+						gomock.Nil(),
+					)
+			},
+			filename: "file.efd",
+			roll: display.DisplayableRoll{
+				Frames: []display.DisplayableFrame{
+					{FrameNumber: 1},
+					{FrameNumber: 2},
+					{FrameNumber: 3},
+				},
+			},
+			where: mustParseFilter(t, "frame == 2"),
+		},
+		{
+			name: "selected frame not in the roll",
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockDisplayableRollFactory display_test.MockDisplayableRollFactory,
+				_ display_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.filename).
+					Return(tt.records, nil)
+
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), tt.records, tt.strict).
+					Return(tt.roll, nil)
+			},
+			filename: "file.efd",
+			roll: display.DisplayableRoll{
+				Frames: []display.DisplayableFrame{{FrameNumber: 1}},
+			},
+			frames:        cli.FrameRanges{{First: 2, Last: 2}},
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
 	}
 
 	for _, tt := range tests {
@@ -192,9 +288,19 @@ func Test_FrameListUseCase(t *testing.T) {
 				mockEFDService,
 				mockDisplayableRollFactory,
 				mockDisplayService,
+				jsonexport_test.NewMockService(ctrl),
+				usertemplate_test.NewMockService(ctrl),
 			)
 
-			err := uc.List(ctx, tt.filename, tt.strict)
+			err := uc.List(
+				ctx,
+				tt.filename,
+				tt.strict,
+				false,
+				cli.Output{Format: cli.OutputTable, Columns: tt.columns},
+				tt.frames,
+				tt.where,
+			)
 
 			if tt.expectedError != nil {
 				if err == nil {
@@ -399,7 +505,7 @@ func Test_FrameExportUseCase(t *testing.T) {
 					Return(mockFile, nil)
 
 				mockCSVService.EXPECT().
-					ExportFrames(gomock.Any(), mockFile, tt.displayableRoll).
+					ExportFrames(gomock.Any(), mockFile, tt.displayableRoll, gomock.Nil()).
 					Return(errExample)
 			},
 			expectedError: frame.ErrFailedToExport,
@@ -463,6 +569,7 @@ func Test_FrameExportUseCase(t *testing.T) {
 				mockDisplayableRollFactory,
 				mockCSVService,
 				mockFS,
+				usertemplate_test.NewMockService(ctrl),
 			)
 
 			err := uc.Export(
@@ -470,7 +577,11 @@ func Test_FrameExportUseCase(t *testing.T) {
 				tt.efdFile,
 				tt.outputFile,
 				tt.strict,
+				false,
 				tt.force,
+				cli.Output{Format: cli.OutputCSV},
+				nil,
+				framefilter.Filter{},
 			)
 
 			assertErrors(t, err, tt.expectedError)
@@ -554,7 +665,7 @@ func Test_FrameExportUseCase_Success(t *testing.T) {
 					Return(mockFile, nil)
 
 				mockCSVService.EXPECT().
-					ExportFrames(gomock.Any(), mockFile, tt.displayableRoll).
+					ExportFrames(gomock.Any(), mockFile, tt.displayableRoll, gomock.Nil()).
 					Return(nil)
 			},
 		},
@@ -617,6 +728,7 @@ func Test_FrameExportUseCase_Success(t *testing.T) {
 				mockDisplayableRollFactory,
 				mockCSVService,
 				mockFS,
+				usertemplate_test.NewMockService(ctrl),
 			)
 
 			err := uc.Export(
@@ -624,10 +736,188 @@ func Test_FrameExportUseCase_Success(t *testing.T) {
 				tt.efdFile,
 				tt.outputFile,
 				tt.strict,
+				false,
 				tt.force,
+				cli.Output{Format: cli.OutputCSV},
+				nil,
+				framefilter.Filter{},
 			)
 
 			assertErrors(t, err, tt.expectedError)
 		})
 	}
 }
+
+//nolint:exhaustruct // only partial is needed
+func Test_FrameListUseCase_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedError error
+	}{
+		{
+			name:          "successfully write JSON",
+			err:           nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to write JSON",
+			err:           errExample,
+			expectedError: frame.ErrFailedToList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockJSONService := jsonexport_test.NewMockService(ctrl)
+
+			mockEFDService.EXPECT().
+				RecordsFromFile(gomock.Any(), "file.efd").
+				Return(root, nil)
+			mockDisplayableRollFactory.EXPECT().
+				Create(gomock.Any(), root, false).
+				Return(dr, nil)
+			mockJSONService.EXPECT().
+				ExportFrames(gomock.Any(), gomock.Any(), dr).
+				Return(tt.err)
+
+			uc := frame.NewListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				mockJSONService,
+				usertemplate_test.NewMockService(ctrl),
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				cli.Output{Format: cli.OutputJSON},
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FrameListUseCase_Template(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		parseErr      error
+		executeErr    error
+		expectedError error
+	}{
+		{
+			name:          "successfully execute template",
+			parseErr:      nil,
+			executeErr:    nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to parse template",
+			parseErr:      errExample,
+			executeErr:    nil,
+			expectedError: frame.ErrFailedToList,
+		},
+		{
+			name:          "failed to execute template",
+			parseErr:      nil,
+			executeErr:    errExample,
+			expectedError: frame.ErrFailedToList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockTemplateService := usertemplate_test.NewMockService(ctrl)
+			tmpl := template.Must(template.New("template").Parse("{{.FilmID}}"))
+
+			mockTemplateService.EXPECT().
+				Parse(gomock.Any(), "{{.FilmID}}").
+				Return(tmpl, tt.parseErr)
+
+			if tt.parseErr == nil {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), root, false).
+					Return(dr, nil)
+				mockTemplateService.EXPECT().
+					Execute(gomock.Any(), gomock.Any(), tmpl, dr).
+					Return(tt.executeErr)
+			}
+
+			uc := frame.NewListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				jsonexport_test.NewMockService(ctrl),
+				mockTemplateService,
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				cli.Output{
+					Format:   cli.OutputTable,
+					Template: "{{.FilmID}}",
+				},
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
@@ -0,0 +1,230 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/display"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrInvalidFrameRange     = errors.New("invalid frame range")
+	ErrFailedToGetFramesFlag = errors.New("failed to get frames flag")
+)
+
+// FrameRanges selects frames by their recorded frame number, as given to the
+// --frames flag. A nil FrameRanges selects every frame.
+type FrameRanges []domain.FrameRange
+
+// ParseFrameRanges parses comma separated frame numbers and inclusive ranges
+// such as "1-12,20,30-", as given to the --frames flag. A range without an end
+// runs to the last frame of the roll.
+func ParseFrameRanges(s string) (FrameRanges, error) {
+	parts := strings.Split(s, ",")
+	ranges := make(FrameRanges, 0, len(parts))
+
+	for _, part := range parts {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		open := isRange && last == ""
+
+		if !isRange || open {
+			last = first
+		}
+
+		a, errA := strconv.ParseUint(first, 10, 32)
+		b, errB := strconv.ParseUint(last, 10, 32)
+
+		if errA != nil || errB != nil || a == 0 || a > b {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFrameRange, part)
+		}
+
+		if open {
+			b = 0 // unused, see domain.FrameRange
+		}
+
+		ranges = append(ranges, domain.FrameRange{
+			First: uint32(a),
+			Last:  uint32(b),
+			Open:  open,
+		})
+	}
+
+	return ranges, nil
+}
+
+// AddFramesFlag adds the --frames flag, selecting frames by number, to cmd.
+func AddFramesFlag(cmd *cobra.Command) {
+	cmd.Flags().String("frames", "",
+		"comma separated frame numbers or ranges, e.g. 1-5,12,30-")
+}
+
+// GetFrames returns the frame ranges given to the --frames flag of cmd, or nil
+// to select every frame if there are none.
+func GetFrames(cmd *cobra.Command) (FrameRanges, error) {
+	frames, err := cmd.Flags().GetString("frames")
+	if err != nil {
+		return nil, errors.Join(ErrFailedToGetFramesFlag, err)
+	}
+
+	if frames == "" {
+		return nil, nil
+	}
+
+	return ParseFrameRanges(frames)
+}
+
+// String returns the ranges in the form ParseFrameRanges reads them.
+func (fr FrameRanges) String() string {
+	parts := make([]string, 0, len(fr))
+
+	for _, r := range fr {
+		parts = append(parts, formatRange(r))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Contains reports whether the frame number falls within any of the ranges.
+func (fr FrameRanges) Contains(frameNumber uint32) bool {
+	return fr == nil ||
+		slices.ContainsFunc(fr, func(r domain.FrameRange) bool {
+			return r.Contains(frameNumber)
+		})
+}
+
+// Check fails with ErrFrameNumberNotFound if any frame number of a closed range,
+// or every frame number of a range without an end, is not among the frames of
+// efrms, for commands that work on the records rather than the decoded roll.
+func (fr FrameRanges) Check(efrms []records.EFRM) error {
+	numbers := make([]uint32, 0, len(efrms))
+	for _, efrm := range efrms {
+		numbers = append(numbers, efrm.FrameNumber)
+	}
+
+	return fr.check(numbers)
+}
+
+// Select returns a copy of r with only the frames within the ranges, failing
+// with ErrFrameNumberNotFound for requested frames that are not in r as Check does.
+func (fr FrameRanges) Select(
+	r display.DisplayableRoll,
+) (display.DisplayableRoll, error) {
+	if fr == nil {
+		return r, nil
+	}
+
+	numbers := make([]uint32, 0, len(r.Frames))
+	for _, frame := range r.Frames {
+		//nolint:gosec // decoded from the uint32 frame number of the record
+		numbers = append(numbers, uint32(frame.FrameNumber))
+	}
+
+	if err := fr.check(numbers); err != nil {
+		return display.DisplayableRoll{}, err
+	}
+
+	frames := make([]display.DisplayableFrame, 0, len(r.Frames))
+
+	for i, frame := range r.Frames {
+		if fr.Contains(numbers[i]) {
+			frames = append(frames, frame)
+		}
+	}
+
+	r.Frames = frames
+
+	return r, nil
+}
+
+// check names every frame number of a closed range that is not among numbers,
+// and every range without an end that matches none of them.
+func (fr FrameRanges) check(numbers []uint32) error {
+	present := slices.Compact(slices.Sorted(slices.Values(numbers)))
+
+	var missing FrameRanges
+
+	for _, r := range fr {
+		if r.Open {
+			if !slices.ContainsFunc(present, r.Contains) {
+				missing = append(missing, r)
+			}
+
+			continue
+		}
+
+		next, covered := r.First, false
+
+		for _, n := range present {
+			if n > r.Last {
+				break
+			}
+
+			if n < next {
+				continue
+			}
+
+			if n > next {
+				missing = append(missing, closedRange(next, n-1))
+			}
+
+			if n == r.Last {
+				covered = true
+
+				break
+			}
+
+			next = n + 1 // n < r.Last
+		}
+
+		if !covered {
+			missing = append(missing, closedRange(next, r.Last))
+		}
+	}
+
+	switch {
+	case len(missing) == 0:
+		return nil
+	case len(missing) == 1 && missing[0].First == missing[0].Last:
+		return fmt.Errorf("%w: frame number %d",
+			ErrFrameNumberNotFound, missing[0].First)
+	default:
+		return fmt.Errorf("%w: frame numbers %s", ErrFrameNumberNotFound, missing)
+	}
+}
+
+func closedRange(first, last uint32) domain.FrameRange {
+	return domain.FrameRange{First: first, Last: last, Open: false}
+}
+
+func formatRange(r domain.FrameRange) string {
+	switch {
+	case r.Open:
+		return fmt.Sprintf("%d-", r.First)
+	case r.First == r.Last:
+		return strconv.FormatUint(uint64(r.First), 10)
+	default:
+		return fmt.Sprintf("%d-%d", r.First, r.Last)
+	}
+}
@@ -0,0 +1,255 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+func Test_ParseFrameRanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		s             string
+		expected      cli.FrameRanges
+		expectedError error
+	}{
+		{
+			name: "frames and ranges",
+			s:    "1-12, 20,30-30",
+			expected: cli.FrameRanges{
+				{First: 1, Last: 12},
+				{First: 20, Last: 20},
+				{First: 30, Last: 30},
+			},
+		},
+		{
+			name: "open ended range",
+			s:    "5,30-",
+			expected: cli.FrameRanges{
+				{First: 5, Last: 5},
+				{First: 30, Open: true},
+			},
+		},
+		{
+			name:     "last frame number",
+			s:        "4294967295",
+			expected: cli.FrameRanges{{First: math.MaxUint32, Last: math.MaxUint32}},
+		},
+		{name: "backwards", s: "12-1", expectedError: cli.ErrInvalidFrameRange},
+		{name: "frame zero", s: "0", expectedError: cli.ErrInvalidFrameRange},
+		{name: "not a number", s: "1,x", expectedError: cli.ErrInvalidFrameRange},
+		{name: "empty part", s: "1,,2", expectedError: cli.ErrInvalidFrameRange},
+		{name: "no start", s: "-5", expectedError: cli.ErrInvalidFrameRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := cli.ParseFrameRanges(tt.s)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_FrameRanges_String(t *testing.T) {
+	t.Parallel()
+
+	ranges, err := cli.ParseFrameRanges("1-5, 12,30-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ranges.String(); got != "1-5,12,30-" {
+		t.Errorf("expected %q, got %q", "1-5,12,30-", got)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FrameRanges_Select(t *testing.T) {
+	t.Parallel()
+
+	// frames are selected by their recorded number, not their position
+	roll := display.DisplayableRoll{
+		FilmID: "12-345",
+		Frames: []display.DisplayableFrame{
+			{FrameNumber: 3},
+			{FrameNumber: 4},
+			{FrameNumber: 12},
+			{FrameNumber: 31},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		ranges        cli.FrameRanges
+		expected      []uint
+		expectedError error
+	}{
+		{name: "every frame", ranges: nil, expected: []uint{3, 4, 12, 31}},
+		{
+			name: "frames and ranges",
+			ranges: cli.FrameRanges{
+				{First: 3, Last: 4},
+				{First: 12, Last: 12},
+				{First: 30, Open: true},
+			},
+			expected: []uint{3, 4, 12, 31},
+		},
+		{
+			name:          "missing frame",
+			ranges:        cli.FrameRanges{{First: 3, Last: 3}, {First: 5, Last: 5}},
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+		{
+			name:          "range with a missing frame",
+			ranges:        cli.FrameRanges{{First: 3, Last: 5}},
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+		{
+			name:          "range without frames",
+			ranges:        cli.FrameRanges{{First: 32, Open: true}},
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.ranges.Select(roll)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			numbers := make([]uint, 0, len(got.Frames))
+			for _, fr := range got.Frames {
+				numbers = append(numbers, fr.FrameNumber)
+			}
+
+			if !slices.Equal(numbers, tt.expected) || got.FilmID != roll.FilmID {
+				t.Errorf("expected frames %v, got %v", tt.expected, numbers)
+			}
+		})
+	}
+
+	if len(roll.Frames) != 4 {
+		t.Error("expected the original roll to be left as it was")
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FrameRanges_Check(t *testing.T) {
+	t.Parallel()
+
+	efrms := []records.EFRM{{FrameNumber: 2}, {FrameNumber: 7}}
+
+	ranges := cli.FrameRanges{{First: 2, Last: 2}, {First: 7, Last: 7}}
+	if err := ranges.Check(efrms); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if !ranges.Contains(7) || ranges.Contains(5) {
+		t.Error("expected only the frames within the ranges to be contained")
+	}
+
+	missing := cli.FrameRanges{{First: 3, Last: 6}}
+	if err := missing.Check(efrms); !errors.Is(err, cli.ErrFrameNumberNotFound) {
+		t.Errorf("expected error %v, got %v", cli.ErrFrameNumberNotFound, err)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FrameRanges_Check_NamesMissingFrames(t *testing.T) {
+	t.Parallel()
+
+	efrms := []records.EFRM{
+		{FrameNumber: 3},
+		{FrameNumber: 4},
+		{FrameNumber: 12},
+		{FrameNumber: 31},
+	}
+
+	ranges, err := cli.ParseFrameRanges("1-5,12,13-14,8,30-,40-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = ranges.Check(efrms)
+	if !errors.Is(err, cli.ErrFrameNumberNotFound) {
+		t.Fatalf("expected error %v, got %v", cli.ErrFrameNumberNotFound, err)
+	}
+
+	if !strings.HasSuffix(err.Error(), "frame numbers 1-2,5,13-14,8,40-") {
+		t.Errorf("expected every missing frame to be named, got %q", err)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FrameRanges_Check_LastFrameNumber(t *testing.T) {
+	t.Parallel()
+
+	efrms := []records.EFRM{{FrameNumber: 1}, {FrameNumber: math.MaxUint32}}
+
+	ranges, err := cli.ParseFrameRanges("4294967295")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := ranges.Check(efrms); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if ranges.Contains(1) {
+		t.Error("expected frame 4294967295 alone, not a range without an end")
+	}
+
+	ranges, err = cli.ParseFrameRanges("4294967293-4294967295")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = ranges.Check(efrms)
+	if err == nil || !strings.HasSuffix(err.Error(), "frame numbers 4294967293-4294967294") {
+		t.Errorf("expected the missing frames to be named, got %q", err)
+	}
+
+	err = ranges.Check(efrms[:1])
+	if err == nil || !strings.HasSuffix(err.Error(), "frame numbers 4294967293-4294967295") {
+		t.Errorf("expected the missing frames to be named, got %q", err)
+	}
+}
@@ -0,0 +1,78 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=inspect_test github.com/ma-tf/meta1v/internal/cli/inspect UseCase
+
+// Package inspect provides the CLI command for dumping the raw structure of EFD files.
+package inspect
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+)
+
+var ErrFailedToGetPixelsFlag = errors.New("failed to get pixels flag")
+
+// UseCase defines the business logic for inspecting the raw structure of EFD files.
+type UseCase interface {
+	// Inspect prints a field by field hex dump of every record in an EFD file.
+	Inspect(ctx context.Context, efdFile string, pixels bool) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect <efd_file>",
+		Short: "Dump the raw records of an EFD file field by field",
+		Long: `Walk the raw records of an EFD file and print every field of each EFDF, EFRM and
+EFTP record: its byte offset in the file, its size, the raw bytes in hex and the decoded
+value. Fields whose purpose is unknown are marked with an asterisk.
+
+Records are framed without being decoded, so files the normal parser rejects can still be
+inspected. Bytes that cannot be framed as a record are dumped as an unreadable region up to
+the next EFDF, EFRM or EFTP magic number.`,
+		Example: `  # Dump every record
+  meta1v inspect data.efd
+
+  # Include the full pixel data of thumbnails
+  meta1v inspect data.efd --pixels
+
+  # Page through the unknown fields only
+  meta1v inspect data.efd | grep '^\*' | less`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			pixels, err := cmd.Flags().GetBool("pixels")
+			if err != nil {
+				return errors.Join(ErrFailedToGetPixelsFlag, err)
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.Bool("pixels", pixels),
+			)
+
+			return uc.Inspect(ctx, args[0], pixels)
+		},
+	}
+
+	cmd.Flags().Bool("pixels", false, "dump thumbnail pixel data in full")
+
+	return cmd
+}
@@ -0,0 +1,118 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package inspect_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli/inspect"
+	inspect_test "github.com/ma-tf/meta1v/internal/cli/inspect/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name          string
+		args          []string
+		expect        func(mockUseCase *inspect_test.MockUseCase, tc testcase)
+		expectedError error
+	}
+
+	tests := []testcase{
+		{
+			name: "inspect fails",
+			args: []string{"file.efd"},
+			expect: func(mockUseCase *inspect_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Inspect(gomock.Any(), tc.args[0], false).
+					Return(errExample)
+			},
+			expectedError: errExample,
+		},
+		{
+			name: "inspect",
+			args: []string{"file.efd"},
+			expect: func(mockUseCase *inspect_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Inspect(gomock.Any(), tc.args[0], false).
+					Return(nil)
+			},
+		},
+		{
+			name: "inspect with pixels",
+			args: []string{"file.efd", "--pixels"},
+			expect: func(mockUseCase *inspect_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Inspect(gomock.Any(), tc.args[0], true).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := inspect_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase, tt)
+			}
+
+			cmd := inspect.NewCommand(logger, mockUseCase)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package inspect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/inspect"
+)
+
+var ErrFailedToScanFile = errors.New("failed to scan EFD file")
+
+type inspectUseCase struct {
+	log            *slog.Logger
+	efdService     efd.Service
+	inspectService inspect.Service
+}
+
+func NewUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	inspectService inspect.Service,
+) UseCase {
+	return inspectUseCase{
+		log:            log,
+		efdService:     efdService,
+		inspectService: inspectService,
+	}
+}
+
+func (uc inspectUseCase) Inspect(
+	ctx context.Context,
+	efdFile string,
+	pixels bool,
+) error {
+	uc.log.InfoContext(ctx, "starting inspection",
+		slog.String("efd_file", efdFile),
+		slog.Bool("pixels", pixels))
+
+	regions, err := uc.efdService.ScanFile(ctx, efdFile)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToScanFile, efdFile, err)
+	}
+
+	uc.inspectService.Dump(ctx, os.Stdout, regions, pixels)
+
+	uc.log.InfoContext(ctx, "inspection completed",
+		slog.String("efd_file", efdFile),
+		slog.Int("regions", len(regions)))
+
+	return nil
+}
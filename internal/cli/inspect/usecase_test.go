@@ -0,0 +1,147 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package inspect_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli/inspect"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	inspectsvc_test "github.com/ma-tf/meta1v/internal/service/inspect/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Inspect(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name    string
+		efdFile string
+		pixels  bool
+		regions []efd.Region
+		expect  func(
+			efdTestMock efd_test.MockService,
+			inspectTestMock inspectsvc_test.MockService,
+			tc testcase,
+		)
+		expectedError error
+	}
+
+	expectDump := func(
+		mockEFDService efd_test.MockService,
+		mockInspectService inspectsvc_test.MockService,
+		tt testcase,
+	) {
+		mockEFDService.EXPECT().
+			ScanFile(gomock.Any(), tt.efdFile).
+			Return(tt.regions, nil)
+
+		mockInspectService.EXPECT().
+			Dump(gomock.Any(), gomock.Any(), tt.regions, tt.pixels)
+	}
+
+	tests := []testcase{
+		{
+			name:    "failed to scan file",
+			efdFile: "file.efd",
+			expect: func(
+				mockEFDService efd_test.MockService,
+				_ inspectsvc_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					ScanFile(gomock.Any(), tt.efdFile).
+					Return(nil, errExample)
+			},
+			expectedError: inspect.ErrFailedToScanFile,
+		},
+		{
+			name:    "dump regions",
+			efdFile: "file.efd",
+			regions: []efd.Region{
+				{Offset: 0, Bytes: []byte("EFDF")},
+				{Offset: 4, Bytes: []byte("junk"), Reason: errExample},
+			},
+			expect: expectDump,
+		},
+		{
+			name:    "dump regions with pixels",
+			efdFile: "file.efd",
+			pixels:  true,
+			regions: []efd.Region{{Offset: 0, Bytes: []byte("EFTP")}},
+			expect:  expectDump,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(mockCtrl)
+			mockInspectService := inspectsvc_test.NewMockService(mockCtrl)
+
+			tt.expect(*mockEFDService, *mockInspectService, tt)
+
+			uc := inspect.NewUseCase(
+				newTestLogger(),
+				mockEFDService,
+				mockInspectService,
+			)
+
+			err := uc.Inspect(t.Context(), tt.efdFile, tt.pixels)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf(
+						"expected error %v to be in chain, got %v",
+						tt.expectedError,
+						err,
+					)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=merge_test github.com/ma-tf/meta1v/internal/cli/merge UseCase
+
+// Package merge provides the CLI command for merging EFD files of the same roll.
+package merge
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+const minArgs = 3 // two sources and a target
+
+// UseCase defines the business logic for merging EFD files.
+type UseCase interface {
+	// Merge combines the frames and thumbnails of efdFiles into targetFile.
+	Merge(
+		ctx context.Context,
+		efdFiles []string,
+		targetFile string,
+		recovery bool,
+		force bool,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge <efd_file> <efd_file>... <target_file>",
+		Short: "Merge EFD files of the same roll into one",
+		Long: `Merge several EFD files holding pieces of the same roll into a single, valid EFD
+file. Frames are ordered by frame number and thumbnails stay with their frames.
+
+The roll headers must agree on film ID, film load date and DX ISO, and each frame number
+may only appear in one of the files. Title and remarks are taken from the first file that
+has them.`,
+		Example: `  # Merge two partial downloads
+  meta1v merge part1.efd part2.efd roll.efd
+
+  # Overwrite an existing file
+  meta1v merge part*.efd roll.efd --force`,
+		Args: cobra.MinimumNArgs(minArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetForceFlag, err)
+			}
+
+			efdFiles, targetFile := args[:len(args)-1], args[len(args)-1]
+
+			log.DebugContext(ctx, "arguments:",
+				slog.Any("efd_files", efdFiles),
+				slog.String("target_file", targetFile),
+				slog.Bool("recover", recovery),
+				slog.Bool("force", force),
+			)
+
+			return uc.Merge(ctx, efdFiles, targetFile, recovery, force)
+		},
+	}
+
+	cmd.Flags().BoolP("force", "F", false, "overwrite output file if it exists")
+
+	return cmd
+}
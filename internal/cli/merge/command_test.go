@@ -0,0 +1,119 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package merge_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/merge"
+	merge_test "github.com/ma-tf/meta1v/internal/cli/merge/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct // only partial is needed
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name            string
+		args            []string
+		registerRecover bool
+		expect          func(mockUseCase *merge_test.MockUseCase)
+		expectedError   error
+	}
+
+	tests := []testcase{
+		{
+			name:            "recover flag not registered",
+			args:            []string{"a.efd", "b.efd", "out.efd"},
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name: "merge",
+			args: []string{
+				"a.efd", "b.efd", "c.efd", "out.efd", "-F",
+			},
+			registerRecover: true,
+			expect: func(mockUseCase *merge_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Merge(
+						gomock.Any(),
+						[]string{"a.efd", "b.efd", "c.efd"},
+						"out.efd",
+						false,
+						true,
+					).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := merge_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase)
+			}
+
+			cmd := merge.NewCommand(logger, mockUseCase)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/splitmerge"
+)
+
+var (
+	ErrFailedToReadFile  = errors.New("failed to read file for merge")
+	ErrFailedToMerge     = errors.New("failed to merge rolls")
+	ErrFailedToWriteFile = errors.New("failed to write merged file")
+)
+
+type usecase struct {
+	log               *slog.Logger
+	efdService        efd.Service
+	splitmergeService splitmerge.Service
+	fs                osfs.FileSystem
+}
+
+func NewUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	splitmergeService splitmerge.Service,
+	fs osfs.FileSystem,
+) UseCase {
+	return usecase{
+		log:               log,
+		efdService:        efdService,
+		splitmergeService: splitmergeService,
+		fs:                fs,
+	}
+}
+
+func (uc usecase) Merge(
+	ctx context.Context,
+	efdFiles []string,
+	targetFile string,
+	recovery bool,
+	force bool,
+) error {
+	uc.log.InfoContext(ctx, "starting merge",
+		slog.Any("efd_files", efdFiles),
+		slog.String("target_file", targetFile),
+		slog.Bool("recover", recovery))
+
+	if _, err := uc.fs.Stat(targetFile); err == nil && !force {
+		return fmt.Errorf("%w: %q", cli.ErrOutputFileAlreadyExists, targetFile)
+	}
+
+	roots := make([]records.Root, 0, len(efdFiles))
+
+	for _, efdFile := range efdFiles {
+		root, err := cli.ReadRecords(
+			ctx,
+			uc.log,
+			uc.efdService,
+			efdFile,
+			recovery,
+		)
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+		}
+
+		roots = append(roots, root)
+	}
+
+	merged, err := uc.splitmergeService.Merge(ctx, roots)
+	if err != nil {
+		return errors.Join(ErrFailedToMerge, err)
+	}
+
+	if err = uc.efdService.RecordsToFile(ctx, targetFile, merged); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToWriteFile, targetFile, err)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: %d frame(s), %d thumbnail(s)\n",
+		targetFile, len(merged.EFRMs), len(merged.EFTPs))
+
+	uc.log.InfoContext(ctx, "merge completed successfully",
+		slog.String("target_file", targetFile))
+
+	return nil
+}
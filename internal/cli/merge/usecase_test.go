@@ -0,0 +1,178 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package merge_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/merge"
+	"github.com/ma-tf/meta1v/internal/records"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	"github.com/ma-tf/meta1v/internal/service/splitmerge"
+	splitmerge_test "github.com/ma-tf/meta1v/internal/service/splitmerge/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Merge(t *testing.T) {
+	t.Parallel()
+
+	fileInfo, err := os.Stat(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to stat temp dir: %v", err)
+	}
+
+	a := records.Root{EFRMs: []records.EFRM{{FrameNumber: 1}}}
+	b := records.Root{EFRMs: []records.EFRM{{FrameNumber: 2}}}
+	merged := records.Root{EFRMs: append(a.EFRMs, b.EFRMs...)}
+
+	type mocks struct {
+		efd        *efd_test.MockService
+		splitmerge *splitmerge_test.MockService
+		fs         *osfs_test.MockFileSystem
+	}
+
+	expectRead := func(m mocks) {
+		m.efd.EXPECT().RecordsFromFile(gomock.Any(), "a.efd").Return(a, nil)
+		m.efd.EXPECT().RecordsFromFile(gomock.Any(), "b.efd").Return(b, nil)
+	}
+
+	tests := []struct {
+		name          string
+		force         bool
+		expect        func(m mocks)
+		expectedError error
+	}{
+		{
+			name: "target file exists",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat("out.efd").Return(fileInfo, nil)
+			},
+			expectedError: cli.ErrOutputFileAlreadyExists,
+		},
+		{
+			name: "failed to read file",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat("out.efd").Return(nil, os.ErrNotExist)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "a.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: merge.ErrFailedToReadFile,
+		},
+		{
+			name: "headers disagree",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat("out.efd").Return(nil, os.ErrNotExist)
+				expectRead(m)
+				m.splitmerge.EXPECT().
+					Merge(gomock.Any(), []records.Root{a, b}).
+					Return(records.Root{}, splitmerge.ErrHeaderMismatch)
+			},
+			expectedError: splitmerge.ErrHeaderMismatch,
+		},
+		{
+			name: "failed to write file",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat("out.efd").Return(nil, os.ErrNotExist)
+				expectRead(m)
+				m.splitmerge.EXPECT().
+					Merge(gomock.Any(), []records.Root{a, b}).
+					Return(merged, nil)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), "out.efd", merged).
+					Return(errExample)
+			},
+			expectedError: merge.ErrFailedToWriteFile,
+		},
+		{
+			name:  "overwrite existing file",
+			force: true,
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat("out.efd").Return(fileInfo, nil)
+				expectRead(m)
+				m.splitmerge.EXPECT().
+					Merge(gomock.Any(), []records.Root{a, b}).
+					Return(merged, nil)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), "out.efd", merged).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				efd:        efd_test.NewMockService(ctrl),
+				splitmerge: splitmerge_test.NewMockService(ctrl),
+				fs:         osfs_test.NewMockFileSystem(ctrl),
+			}
+			tt.expect(m)
+
+			uc := merge.NewUseCase(newTestLogger(), m.efd, m.splitmerge, m.fs)
+
+			err := uc.Merge(
+				t.Context(),
+				[]string{"a.efd", "b.efd"},
+				"out.efd",
+				false,
+				tt.force,
+			)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
+	"github.com/spf13/cobra"
+)
+
+// Formats accepted by the --output flag.
+const (
+	OutputTable = "table"
+	OutputCSV   = "csv"
+	OutputJSON  = "json"
+)
+
+var (
+	ErrFailedToGetOutputFlag   = errors.New("failed to get output flag")
+	ErrFailedToGetTemplateFlag = errors.New("failed to get template flag")
+	ErrUnsupportedOutput       = errors.New("unsupported output format")
+)
+
+// Output is how a list or export command writes its result.
+type Output struct {
+	Format string // one of the formats accepted by the command
+
+	// Template is a text/template, or the file holding one, that is used
+	// instead of Format if set.
+	Template string
+
+	// Columns names the frame columns of table and CSV output, in order. The
+	// default columns are written if it is empty.
+	Columns []string
+}
+
+// AddOutputFlags adds the --output/-o flag to cmd, accepting any of formats
+// and defaulting to the first, and the --template flag that replaces it.
+func AddOutputFlags(cmd *cobra.Command, formats ...string) {
+	cmd.Flags().StringP("output", "o", formats[0],
+		fmt.Sprintf("output format (%s)", strings.Join(formats, ", ")))
+	AddTemplateFlag(cmd)
+	cmd.MarkFlagsMutuallyExclusive("output", "template")
+}
+
+// AddTemplateFlag adds the --template flag to cmd.
+func AddTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().String("template", "",
+		"text/template, or file holding one, to write the output with")
+}
+
+// GetOutput returns the format given to the --output flag of cmd, which must be
+// one of formats, and the template given to its --template flag.
+func GetOutput(cmd *cobra.Command, formats ...string) (Output, error) {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return Output{}, errors.Join(ErrFailedToGetOutputFlag, err)
+	}
+
+	if !slices.Contains(formats, format) {
+		return Output{}, fmt.Errorf("%w: %q", ErrUnsupportedOutput, format)
+	}
+
+	tmpl, err := GetTemplate(cmd)
+	if err != nil {
+		return Output{}, err
+	}
+
+	return Output{Format: format, Template: tmpl}, nil
+}
+
+// GetTemplate returns the template given to the --template flag of cmd.
+func GetTemplate(cmd *cobra.Command) (string, error) {
+	tmpl, err := cmd.Flags().GetString("template")
+	if err != nil {
+		return "", errors.Join(ErrFailedToGetTemplateFlag, err)
+	}
+
+	return tmpl, nil
+}
+
+// ParseTemplate parses the template of output with svc, returning nil if output
+// has no template and the command should write Format instead.
+func ParseTemplate(
+	ctx context.Context,
+	svc usertemplate.Service,
+	output Output,
+) (*template.Template, error) {
+	if output.Template == "" {
+		return nil, nil //nolint:nilnil // no template is not an error
+	}
+
+	//nolint:wrapcheck // wrapped by caller
+	return svc.Parse(ctx, output.Template)
+}
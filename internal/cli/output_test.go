@@ -0,0 +1,157 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"text/template"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	usertemplate_test "github.com/ma-tf/meta1v/internal/service/usertemplate/mocks"
+	"github.com/spf13/cobra"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected cli.Output
+		err      error
+	}{
+		{
+			name:     "default",
+			args:     nil,
+			expected: cli.Output{Format: cli.OutputTable, Template: ""},
+			err:      nil,
+		},
+		{
+			name:     "json",
+			args:     []string{"--output", "json"},
+			expected: cli.Output{Format: cli.OutputJSON, Template: ""},
+			err:      nil,
+		},
+		{
+			name:     "shorthand",
+			args:     []string{"-o", "json"},
+			expected: cli.Output{Format: cli.OutputJSON, Template: ""},
+			err:      nil,
+		},
+		{
+			name:     "unsupported",
+			args:     []string{"--output", "csv"},
+			expected: cli.Output{},
+			err:      cli.ErrUnsupportedOutput,
+		},
+		{
+			name: "template",
+			args: []string{"--template", "{{.Title}}"},
+			expected: cli.Output{
+				Format:   cli.OutputTable,
+				Template: "{{.Title}}",
+			},
+			err: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{}
+			cli.AddOutputFlags(cmd, cli.OutputTable, cli.OutputJSON)
+
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("unexpected error parsing flags: %v", err)
+			}
+
+			got, err := cli.GetOutput(cmd, cli.OutputTable, cli.OutputJSON)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("unexpected error: got %v, want %v", err, tt.err)
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("unexpected output: got %+v, want %+v",
+					got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := cli.GetOutput(&cobra.Command{}); !errors.Is(
+		err,
+		cli.ErrFailedToGetOutputFlag,
+	) {
+		t.Errorf("expected %v without the flag, got %v",
+			cli.ErrFailedToGetOutputFlag, err)
+	}
+
+	if _, err := cli.GetTemplate(&cobra.Command{}); !errors.Is(
+		err,
+		cli.ErrFailedToGetTemplateFlag,
+	) {
+		t.Errorf("expected %v without the flag, got %v",
+			cli.ErrFailedToGetTemplateFlag, err)
+	}
+}
+
+func Test_AddOutputFlags_MutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{RunE: func(*cobra.Command, []string) error {
+		return nil
+	}}
+	cli.AddOutputFlags(cmd, cli.OutputTable, cli.OutputJSON)
+	cmd.SetArgs([]string{"--output", "json", "--template", "{{.Title}}"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected an error for --output with --template")
+	}
+}
+
+func Test_ParseTemplate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplateService := usertemplate_test.NewMockService(ctrl)
+
+	tmpl, err := cli.ParseTemplate(t.Context(), mockTemplateService,
+		cli.Output{Format: cli.OutputJSON, Template: ""})
+	if tmpl != nil || err != nil {
+		t.Fatalf("expected no template without --template, got %v, %v",
+			tmpl, err)
+	}
+
+	want := template.Must(template.New("template").Parse("{{.Title}}"))
+	mockTemplateService.EXPECT().
+		Parse(gomock.Any(), "{{.Title}}").
+		Return(want, nil)
+
+	tmpl, err = cli.ParseTemplate(t.Context(), mockTemplateService,
+		cli.Output{Format: cli.OutputTable, Template: "{{.Title}}"})
+	if tmpl != want || err != nil {
+		t.Fatalf("expected the parsed template, got %v, %v", tmpl, err)
+	}
+}
@@ -0,0 +1,63 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/spf13/viper"
+)
+
+// PresetsKey is the configuration key custom function presets are read from,
+// each preset mapping custom function numbers to values, e.g.
+//
+//	customfunctions:
+//	  presets:
+//	    sports: {4: 2, 13: 1}
+const PresetsKey = "customfunctions.presets"
+
+var ErrFailedToReadPresets = errors.New(
+	"failed to read custom function presets",
+)
+
+// CustomFunctionPresets reads the custom function presets from the
+// configuration, sorted by name. No presets are configured by default.
+func CustomFunctionPresets(v *viper.Viper) (
+	[]domain.CustomFunctionPreset,
+	error,
+) {
+	var raw map[string]map[int]byte
+	if err := v.UnmarshalKey(PresetsKey, &raw); err != nil {
+		return nil, errors.Join(ErrFailedToReadPresets, err)
+	}
+
+	presets := make([]domain.CustomFunctionPreset, 0, len(raw))
+
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		p, err := domain.NewCustomFunctionPreset(name, raw[name])
+		if err != nil {
+			return nil, errors.Join(ErrFailedToReadPresets, err)
+		}
+
+		presets = append(presets, p)
+	}
+
+	return presets, nil
+}
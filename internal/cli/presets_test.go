@@ -0,0 +1,123 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/spf13/viper"
+)
+
+func Test_CustomFunctionPresets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		config        string
+		expected      map[string]map[int]byte
+		expectedError error
+	}{
+		{
+			name:     "no presets",
+			config:   "strict: false\n",
+			expected: map[string]map[int]byte{},
+		},
+		{
+			name: "presets",
+			config: `customfunctions:
+  presets:
+    studio: {12: 1, 15: 1}
+    sports:
+      4: 2
+      13: 1
+`,
+			expected: map[string]map[int]byte{
+				"sports": {4: 2, 13: 1},
+				"studio": {12: 1, 15: 1},
+			},
+		},
+		{
+			name: "value out of range",
+			config: `customfunctions:
+  presets:
+    sports: {4: 9}
+`,
+			expectedError: cli.ErrFailedToReadPresets,
+		},
+		{
+			name: "not a number",
+			config: `customfunctions:
+  presets:
+    sports: {4: fast}
+`,
+			expectedError: cli.ErrFailedToReadPresets,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := viper.New()
+			v.SetConfigType("yaml")
+
+			err := v.ReadConfig(bytes.NewBufferString(tt.config))
+			if err != nil {
+				t.Fatalf("failed to read config: %v", err)
+			}
+
+			presets, err := cli.CustomFunctionPresets(v)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if len(presets) != len(tt.expected) {
+				t.Fatalf("expected %d presets, got %d",
+					len(tt.expected), len(presets))
+			}
+
+			for i := 1; i < len(presets); i++ {
+				if presets[i-1].Name > presets[i].Name {
+					t.Errorf("expected presets sorted by name, got %q before %q",
+						presets[i-1].Name, presets[i].Name)
+				}
+			}
+
+			for _, p := range presets {
+				want := tt.expected[p.Name]
+				if len(p.Values) != len(want) {
+					t.Errorf("preset %q: expected %v, got %v",
+						p.Name, want, p.Values)
+				}
+
+				for n, value := range want {
+					if p.Values[n] != value {
+						t.Errorf("preset %q: expected %v, got %v",
+							p.Name, want, p.Values)
+					}
+				}
+			}
+		})
+	}
+}
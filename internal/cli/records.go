@@ -0,0 +1,55 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+)
+
+// ReadRecords reads an EFD file, failing on the first bad record unless recovery is set.
+// In recovery mode, undecodable records are skipped and each skipped region is logged as a warning.
+func ReadRecords(
+	ctx context.Context,
+	log *slog.Logger,
+	efdService efd.Service,
+	filename string,
+	recovery bool,
+) (records.Root, error) {
+	if !recovery {
+		return efdService.RecordsFromFile(ctx, filename) //nolint:wrapcheck // wrapped by caller
+	}
+
+	root, diagnostics, err := efdService.RecoverRecordsFromFile(ctx, filename)
+
+	for _, d := range diagnostics {
+		log.WarnContext(ctx, "skipped unreadable region",
+			slog.String("file", filename),
+			slog.Int64("offset", d.Offset),
+			slog.Int64("length", d.Length),
+			slog.Any("reason", d.Reason))
+	}
+
+	if err != nil {
+		return records.Root{}, err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return root, nil
+}
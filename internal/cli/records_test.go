@@ -0,0 +1,132 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func Test_ReadRecords(t *testing.T) {
+	t.Parallel()
+
+	root := records.Root{EFRMs: []records.EFRM{{FrameNumber: 1}}}
+	diagnostics := []efd.Diagnostic{
+		{Offset: 512, Length: 20, Reason: efd.ErrUnknownRecordType},
+	}
+
+	type testcase struct {
+		name          string
+		recovery      bool
+		expect        func(mockEFDService *efd_test.MockService)
+		expectedRoot  records.Root
+		expectedLog   string
+		expectedError error
+	}
+
+	tests := []testcase{
+		{
+			name: "reads strictly without recovery",
+			expect: func(mockEFDService *efd_test.MockService) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, nil)
+			},
+			expectedRoot: root,
+		},
+		{
+			name: "propagates strict read error",
+			expect: func(mockEFDService *efd_test.MockService) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: errExample,
+		},
+		{
+			name:     "logs diagnostics in recovery mode",
+			recovery: true,
+			expect: func(mockEFDService *efd_test.MockService) {
+				mockEFDService.EXPECT().
+					RecoverRecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, diagnostics, nil)
+			},
+			expectedRoot: root,
+			expectedLog:  "skipped unreadable region",
+		},
+		{
+			name:     "logs diagnostics before recovery error",
+			recovery: true,
+			expect: func(mockEFDService *efd_test.MockService) {
+				mockEFDService.EXPECT().
+					RecoverRecordsFromFile(gomock.Any(), "file.efd").
+					Return(records.Root{}, diagnostics, errExample)
+			},
+			expectedLog:   "skipped unreadable region",
+			expectedError: errExample,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			buf := &bytes.Buffer{}
+			logger := slog.New(slog.NewTextHandler(buf, nil))
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			tt.expect(mockEFDService)
+
+			got, err := cli.ReadRecords(
+				t.Context(),
+				logger,
+				mockEFDService,
+				"file.efd",
+				tt.recovery,
+			)
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.expectedRoot) {
+				t.Errorf("expected root %v, got %v", tt.expectedRoot, got)
+			}
+
+			if !strings.Contains(buf.String(), tt.expectedLog) {
+				t.Errorf("expected log to contain %q, got %q",
+					tt.expectedLog, buf.String())
+			}
+		})
+	}
+}
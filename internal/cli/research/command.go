@@ -0,0 +1,45 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package research provides commands for analysing the undocumented parts of the EFD format.
+package research
+
+import (
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/cli/research/unknowns"
+	"github.com/ma-tf/meta1v/internal/container"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(log *slog.Logger, ctr *container.Container) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "research <command>",
+		Short: "Analyse undocumented parts of the EFD format",
+		Long: `Tools for reverse engineering the EFD format. They compare many EFD files to help
+work out the meaning of fields that are not yet understood.`,
+	}
+
+	unknownsUseCase := NewUnknownsUseCase(
+		log,
+		ctr.EFDService,
+		ctr.ResearchService,
+	)
+
+	cmd.AddCommand(unknowns.NewCommand(log, unknownsUseCase))
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package research_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli/research"
+	"github.com/ma-tf/meta1v/internal/container"
+	osexec_test "github.com/ma-tf/meta1v/internal/service/osexec/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct // only partial is needed
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	mockLookPath := osexec_test.NewMockLookPath(ctrl)
+
+	ctr := container.New(logger, mockLookPath)
+	cmd := research.NewCommand(logger, ctr)
+
+	const expectedSubcommands = 1
+	if len(cmd.Commands()) != expectedSubcommands {
+		t.Fatalf("expected %d subcommand to be registered, got %d",
+			expectedSubcommands, len(cmd.Commands()))
+	}
+}
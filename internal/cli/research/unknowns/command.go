@@ -0,0 +1,148 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=unknowns_test github.com/ma-tf/meta1v/internal/cli/research/unknowns UseCase
+
+// Package unknowns provides the CLI command for analysing unknown bytes across EFD files.
+package unknowns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+
+	defaultMinCorrelation = 0.9
+)
+
+var (
+	ErrUnsupportedFormat     = errors.New("unsupported output format")
+	ErrInvalidMinCorrelation = errors.New(
+		"min correlation must be between 0 and 1",
+	)
+	ErrFailedToGetResearchFlags = errors.New("failed to get research flags")
+)
+
+// Options controls the analysis and its output.
+type Options struct {
+	Format         string
+	Against        []string
+	MinCorrelation float64
+}
+
+// UseCase defines the business logic for analysing unknown bytes across EFD files.
+type UseCase interface {
+	// Unknowns scans every EFD file and writes a report on the bytes of their unknown
+	// fields to stdout.
+	Unknowns(ctx context.Context, efdFiles []string, opts Options) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unknowns <efd_file>...",
+		Short: "Report how unknown bytes vary across EFD files",
+		Long: `Collect the unknown fields of the EFDF and EFRM records of many EFD files and report,
+byte by byte, which are constant and which vary. Varying bytes are correlated with known
+fields of the same record, by default ShootingMode, AFMode and FocalLength.
+
+A correlation is reported if its Pearson coefficient reaches --min-correlation, or if the
+byte is determined by the field: every value of the field seen more than once always comes
+with the same value of the byte.
+
+Files are scanned without being decoded, so damaged files contribute the records that can
+still be framed.`,
+		Example: `  # Analyse a collection of files as JSON
+  meta1v research unknowns rolls/*.efd
+
+  # Correlate with other fields and write CSV
+  meta1v research unknowns rolls/*.efd --format csv --against MeteringMode,FlashMode
+
+  # Report weaker correlations too
+  meta1v research unknowns rolls/*.efd --min-correlation 0.7`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			opts, err := getOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.Any("efd_files", args),
+				slog.String("format", opts.Format),
+				slog.Any("against", opts.Against),
+				slog.Float64("min_correlation", opts.MinCorrelation),
+			)
+
+			return uc.Unknowns(ctx, args, opts)
+		},
+	}
+
+	cmd.Flags().String("format", FormatJSON, "output format (json, csv)")
+	cmd.Flags().StringSlice(
+		"against",
+		[]string{"ShootingMode", "AFMode", "FocalLength"},
+		"known fields to correlate unknown bytes with",
+	)
+	cmd.Flags().Float64(
+		"min-correlation",
+		defaultMinCorrelation,
+		"smallest absolute Pearson coefficient to report",
+	)
+
+	return cmd
+}
+
+func getOptions(cmd *cobra.Command) (Options, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetResearchFlags, err)
+	}
+
+	if !slices.Contains([]string{FormatJSON, FormatCSV}, format) {
+		return Options{}, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	against, err := cmd.Flags().GetStringSlice("against")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetResearchFlags, err)
+	}
+
+	minCorrelation, err := cmd.Flags().GetFloat64("min-correlation")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetResearchFlags, err)
+	}
+
+	if minCorrelation < 0 || minCorrelation > 1 {
+		return Options{}, fmt.Errorf("%w, got %v",
+			ErrInvalidMinCorrelation, minCorrelation)
+	}
+
+	return Options{
+		Format:         format,
+		Against:        against,
+		MinCorrelation: minCorrelation,
+	}, nil
+}
@@ -0,0 +1,147 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package unknowns_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli/research/unknowns"
+	unknowns_test "github.com/ma-tf/meta1v/internal/cli/research/unknowns/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	defaults := unknowns.Options{
+		Format:         unknowns.FormatJSON,
+		Against:        []string{"ShootingMode", "AFMode", "FocalLength"},
+		MinCorrelation: 0.9,
+	}
+
+	type testcase struct {
+		name          string
+		args          []string
+		expect        func(mockUseCase *unknowns_test.MockUseCase)
+		expectedError error
+	}
+
+	tests := []testcase{
+		{
+			name:          "unsupported format",
+			args:          []string{"a.efd", "--format", "xml"},
+			expectedError: unknowns.ErrUnsupportedFormat,
+		},
+		{
+			name:          "min correlation out of range",
+			args:          []string{"a.efd", "--min-correlation", "1.5"},
+			expectedError: unknowns.ErrInvalidMinCorrelation,
+		},
+		{
+			name: "use case fails",
+			args: []string{"a.efd"},
+			expect: func(mockUseCase *unknowns_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Unknowns(gomock.Any(), []string{"a.efd"}, defaults).
+					Return(errExample)
+			},
+			expectedError: errExample,
+		},
+		{
+			name: "defaults",
+			args: []string{"a.efd", "b.efd"},
+			expect: func(mockUseCase *unknowns_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Unknowns(
+						gomock.Any(),
+						[]string{"a.efd", "b.efd"},
+						defaults,
+					).
+					Return(nil)
+			},
+		},
+		{
+			name: "csv against other fields",
+			args: []string{
+				"a.efd",
+				"--format", "csv",
+				"--against", "MeteringMode,FlashMode",
+				"--min-correlation", "0.5",
+			},
+			expect: func(mockUseCase *unknowns_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					Unknowns(gomock.Any(), []string{"a.efd"}, unknowns.Options{
+						Format:         unknowns.FormatCSV,
+						Against:        []string{"MeteringMode", "FlashMode"},
+						MinCorrelation: 0.5,
+					}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := unknowns_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase)
+			}
+
+			cmd := unknowns.NewCommand(logger, mockUseCase)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package research
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/ma-tf/meta1v/internal/cli/research/unknowns"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	researchsvc "github.com/ma-tf/meta1v/internal/service/research"
+)
+
+var (
+	ErrFailedToScanFile = errors.New("failed to scan EFD file")
+	ErrFailedToAnalyse  = errors.New("failed to analyse unknown bytes")
+	ErrFailedToReport   = errors.New("failed to write unknown bytes report")
+)
+
+type unknownsUseCase struct {
+	log             *slog.Logger
+	efdService      efd.Service
+	researchService researchsvc.Service
+}
+
+func NewUnknownsUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	researchService researchsvc.Service,
+) unknowns.UseCase {
+	return unknownsUseCase{
+		log:             log,
+		efdService:      efdService,
+		researchService: researchService,
+	}
+}
+
+func (uc unknownsUseCase) Unknowns(
+	ctx context.Context,
+	efdFiles []string,
+	opts unknowns.Options,
+) error {
+	uc.log.InfoContext(ctx, "starting unknown bytes research",
+		slog.Int("files", len(efdFiles)),
+		slog.String("format", opts.Format))
+
+	files := make([][]efd.Region, 0, len(efdFiles))
+
+	for _, efdFile := range efdFiles {
+		regions, err := uc.efdService.ScanFile(ctx, efdFile)
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", ErrFailedToScanFile, efdFile, err)
+		}
+
+		files = append(files, regions)
+	}
+
+	report, err := uc.researchService.Analyse(ctx, files, researchsvc.Options{
+		Against:        opts.Against,
+		MinCorrelation: opts.MinCorrelation,
+	})
+	if err != nil {
+		return errors.Join(ErrFailedToAnalyse, err)
+	}
+
+	if opts.Format == unknowns.FormatCSV {
+		err = uc.researchService.WriteCSV(ctx, os.Stdout, report)
+	} else {
+		err = uc.researchService.WriteJSON(ctx, os.Stdout, report)
+	}
+
+	if err != nil {
+		return errors.Join(ErrFailedToReport, err)
+	}
+
+	uc.log.InfoContext(ctx, "unknown bytes research completed",
+		slog.Int("bytes", len(report.Bytes)))
+
+	return nil
+}
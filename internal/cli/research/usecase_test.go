@@ -0,0 +1,216 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package research_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli/research"
+	"github.com/ma-tf/meta1v/internal/cli/research/unknowns"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	researchsvc "github.com/ma-tf/meta1v/internal/service/research"
+	researchsvc_test "github.com/ma-tf/meta1v/internal/service/research/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Unknowns(t *testing.T) {
+	t.Parallel()
+
+	regions := []efd.Region{{Offset: 0, Bytes: []byte("EFRM")}}
+	report := researchsvc.Report{Files: 2}
+	svcOpts := researchsvc.Options{
+		Against:        []string{"ShootingMode"},
+		MinCorrelation: 0.9,
+	}
+
+	type testcase struct {
+		name   string
+		files  []string
+		format string
+		expect func(
+			efdTestMock efd_test.MockService,
+			researchTestMock researchsvc_test.MockService,
+			tc testcase,
+		)
+		expectedError error
+	}
+
+	expectAnalyse := func(
+		mockEFDService efd_test.MockService,
+		mockResearchService researchsvc_test.MockService,
+		tt testcase,
+	) *gomock.Call {
+		for _, f := range tt.files {
+			mockEFDService.EXPECT().
+				ScanFile(gomock.Any(), f).
+				Return(regions, nil)
+		}
+
+		return mockResearchService.EXPECT().
+			Analyse(
+				gomock.Any(),
+				[][]efd.Region{regions, regions},
+				svcOpts,
+			)
+	}
+
+	tests := []testcase{
+		{
+			name:  "failed to scan file",
+			files: []string{"a.efd", "b.efd"},
+			expect: func(
+				mockEFDService efd_test.MockService,
+				_ researchsvc_test.MockService,
+				_ testcase,
+			) {
+				mockEFDService.EXPECT().
+					ScanFile(gomock.Any(), "a.efd").
+					Return(regions, nil)
+				mockEFDService.EXPECT().
+					ScanFile(gomock.Any(), "b.efd").
+					Return(nil, errExample)
+			},
+			expectedError: research.ErrFailedToScanFile,
+		},
+		{
+			name:  "failed to analyse",
+			files: []string{"a.efd", "b.efd"},
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockResearchService researchsvc_test.MockService,
+				tt testcase,
+			) {
+				expectAnalyse(mockEFDService, mockResearchService, tt).
+					Return(researchsvc.Report{}, errExample)
+			},
+			expectedError: research.ErrFailedToAnalyse,
+		},
+		{
+			name:   "failed to write report",
+			files:  []string{"a.efd", "b.efd"},
+			format: unknowns.FormatJSON,
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockResearchService researchsvc_test.MockService,
+				tt testcase,
+			) {
+				expectAnalyse(mockEFDService, mockResearchService, tt).
+					Return(report, nil)
+				mockResearchService.EXPECT().
+					WriteJSON(gomock.Any(), gomock.Any(), report).
+					Return(errExample)
+			},
+			expectedError: research.ErrFailedToReport,
+		},
+		{
+			name:   "json report",
+			files:  []string{"a.efd", "b.efd"},
+			format: unknowns.FormatJSON,
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockResearchService researchsvc_test.MockService,
+				tt testcase,
+			) {
+				expectAnalyse(mockEFDService, mockResearchService, tt).
+					Return(report, nil)
+				mockResearchService.EXPECT().
+					WriteJSON(gomock.Any(), gomock.Any(), report).
+					Return(nil)
+			},
+		},
+		{
+			name:   "csv report",
+			files:  []string{"a.efd", "b.efd"},
+			format: unknowns.FormatCSV,
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockResearchService researchsvc_test.MockService,
+				tt testcase,
+			) {
+				expectAnalyse(mockEFDService, mockResearchService, tt).
+					Return(report, nil)
+				mockResearchService.EXPECT().
+					WriteCSV(gomock.Any(), gomock.Any(), report).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(mockCtrl)
+			mockResearchService := researchsvc_test.NewMockService(mockCtrl)
+
+			tt.expect(*mockEFDService, *mockResearchService, tt)
+
+			uc := research.NewUnknownsUseCase(
+				newTestLogger(),
+				mockEFDService,
+				mockResearchService,
+			)
+
+			err := uc.Unknowns(t.Context(), tt.files, unknowns.Options{
+				Format:         tt.format,
+				Against:        svcOpts.Against,
+				MinCorrelation: svcOpts.MinCorrelation,
+			})
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf(
+						"expected error %v to be in chain, got %v",
+						tt.expectedError,
+						err,
+					)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+)
+
+const (
+	permission      = 0o666 // rw-rw-rw-
+	backupExtension = ".bak"
+	tempExtension   = ".tmp"
+)
+
+var (
+	ErrFrameNumberNotFound  = errors.New("frame number not found in EFD file")
+	ErrDuplicateFrameNumber = errors.New("duplicate frame number in EFD file")
+	ErrFailedToWriteFile    = errors.New("failed to write rewritten file")
+	ErrFailedToBackupFile   = errors.New("failed to back up original file")
+	ErrFailedToReplaceFile  = errors.New("failed to replace original file")
+)
+
+// FindFrame returns the position in efrms of the frame with the given recorded
+// frame number, failing if there is no such frame or more than one.
+func FindFrame(efrms []records.EFRM, frameNumber uint32) (int, error) {
+	found := -1
+
+	for i := range efrms {
+		if efrms[i].FrameNumber != frameNumber {
+			continue
+		}
+
+		if found != -1 {
+			return 0, fmt.Errorf("%w: frame number %d",
+				ErrDuplicateFrameNumber, frameNumber)
+		}
+
+		found = i
+	}
+
+	if found == -1 {
+		return 0, fmt.Errorf("%w: frame number %d",
+			ErrFrameNumberNotFound, frameNumber)
+	}
+
+	return found, nil
+}
+
+// RewriteRecords replaces efdFile with root. The records are written to a temporary
+// file first and the original is kept alongside it with a .bak extension, so a
+// failure part way through never leaves efdFile half written. An existing backup is
+// left as it is, so it always holds the file from before the first rewrite. The
+// backup file name is returned.
+func RewriteRecords(
+	ctx context.Context,
+	log *slog.Logger,
+	efdService efd.Service,
+	fs osfs.FileSystem,
+	efdFile string,
+	root records.Root,
+) (string, error) {
+	tempFile := efdFile + tempExtension
+	if err := efdService.RecordsToFile(ctx, tempFile, root); err != nil {
+		_ = fs.Remove(tempFile)
+
+		return "", fmt.Errorf("%w %q: %w", ErrFailedToWriteFile, tempFile, err)
+	}
+
+	log.DebugContext(ctx, "rewritten file written",
+		slog.String("temp_file", tempFile))
+
+	backupFile := efdFile + backupExtension
+	if err := backup(fs, efdFile, backupFile); err != nil {
+		_ = fs.Remove(tempFile)
+
+		return "", fmt.Errorf("%w to %q: %w",
+			ErrFailedToBackupFile, backupFile, err)
+	}
+
+	log.DebugContext(ctx, "original file backed up",
+		slog.String("backup_file", backupFile))
+
+	if err := fs.Rename(tempFile, efdFile); err != nil {
+		_ = fs.Remove(tempFile)
+
+		return "", fmt.Errorf("%w %q: %w", ErrFailedToReplaceFile, efdFile, err)
+	}
+
+	return backupFile, nil
+}
+
+// backup copies src to dst unless dst already exists.
+func backup(fs osfs.FileSystem, src, dst string) error {
+	_, err := fs.Stat(dst)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return copyFile(fs, src, dst)
+}
+
+func copyFile(fs osfs.FileSystem, src, dst string) error {
+	in, err := fs.Open(src)
+	if err != nil {
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+	defer in.Close()
+
+	out, err := fs.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, permission)
+	if err != nil {
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return out.Close() //nolint:wrapcheck // wrapped by caller
+}
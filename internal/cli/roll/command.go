@@ -40,6 +40,8 @@ frame count, ISO, and user-provided remarks.`,
 		ctr.EFDService,
 		ctr.DisplayableRollFactory,
 		ctr.DisplayService,
+		ctr.JSONService,
+		ctr.TemplateService,
 	)
 
 	exportUseCase := NewExportUseCase(
@@ -48,6 +50,7 @@ frame count, ISO, and user-provided remarks.`,
 		ctr.DisplayableRollFactory,
 		ctr.CSVService,
 		ctr.FileSystem,
+		ctr.TemplateService,
 	)
 
 	cmd.AddCommand(ls.NewCommand(log, listUseCase))
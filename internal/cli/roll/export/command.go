@@ -37,12 +37,15 @@ const (
 // UseCase defines the business logic for exporting film roll information from EFD files.
 type UseCase interface {
 	// Export reads an EFD file and exports roll information in CSV format to stdout or a specified file.
+	// The output is written through the output template instead if one is set.
 	Export(
 		ctx context.Context,
 		efdFile string,
 		outputFile *string,
 		strict bool,
+		recovery bool,
 		force bool,
+		output cli.Output,
 	) error
 }
 
@@ -53,7 +56,10 @@ func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
 		Short: "Export roll information to CSV format",
 		Long: `Export film roll information to CSV format, including film ID, title, load date, 
 frame count, ISO, and user-provided remarks. Output can be directed to stdout or saved 
-to a specified file.`,
+to a specified file.
+
+With --template, the roll is written through a Go text/template given inline or as a
+file instead of as CSV; see the README for the helper functions available.`,
 		Example: `  # Export roll data to stdout
   meta1v roll export data.efd
 
@@ -61,7 +67,10 @@ to a specified file.`,
   meta1v roll export data.efd output.csv
 
   # Overwrite existing file
-  meta1v r export data.efd output.csv --force`,
+  meta1v r export data.efd output.csv --force
+
+  # Through a template
+  meta1v r export data.efd --template '{{.FilmID}},{{.Title}},{{date "2006-01-02" .FilmLoadedDate}}{{"\n"}}'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
@@ -70,11 +79,21 @@ to a specified file.`,
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
 			force, err := cmd.Flags().GetBool("force")
 			if err != nil {
 				return errors.Join(cli.ErrFailedToGetForceFlag, err)
 			}
 
+			tmpl, err := cli.GetTemplate(cmd)
+			if err != nil {
+				return err
+			}
+
 			var targetFile *string
 			if len(args) == maxArgs {
 				targetFile = &args[targetFileIndex]
@@ -88,13 +107,18 @@ to a specified file.`,
 				slog.String("efd_file", args[0]),
 				slog.Any("target_file", targetFile),
 				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
 				slog.Bool("force", force),
+				slog.String("template", tmpl),
 			)
 
-			return uc.Export(ctx, args[0], targetFile, strict, force)
+			return uc.Export(ctx, args[0], targetFile, strict, recovery, force,
+				cli.Output{Format: cli.OutputCSV, Template: tmpl},
+			)
 		},
 	}
 
+	cli.AddTemplateFlag(cmd)
 	cmd.Flags().BoolP("force", "F", false, "overwrite output file if it exists")
 
 	return cmd
@@ -56,10 +56,16 @@ func Test_NewCommand(t *testing.T) {
 		return &b
 	}
 
+	setString := func(s string) *string {
+		return &s
+	}
+
 	type testcase struct {
 		name          string
 		strict        *bool
+		recovery      *bool
 		force         *bool
+		template      *string
 		args          []string
 		expect        func(uc export_test.MockUseCase, tt testcase)
 		expectedError error
@@ -73,43 +79,117 @@ func Test_NewCommand(t *testing.T) {
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrFailedToGetStrictFlag,
 		},
+		{
+			name:          "failed to get recover flag",
+			strict:        setFalse(),
+			recovery:      nil,
+			force:         setFalse(),
+			template:      setString(""),
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetRecoverFlag,
+		},
 		{
 			name:          "failed to get force flag",
 			strict:        setFalse(),
+			recovery:      setFalse(),
 			force:         nil,
 			args:          []string{"file.efd", "output.csv"},
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrFailedToGetForceFlag,
 		},
+		{
+			name:          "failed to get template flag",
+			strict:        setTrue(),
+			recovery:      setFalse(),
+			force:         setFalse(),
+			template:      nil,
+			args:          []string{"file.efd", "output.csv"},
+			expect:        func(_ export_test.MockUseCase, _ testcase) {},
+			expectedError: cli.ErrFailedToGetTemplateFlag,
+		},
 		{
 			name:          "force flag without target file",
 			strict:        setFalse(),
+			recovery:      setFalse(),
 			force:         setTrue(),
+			template:      setString(""),
 			args:          []string{"file.efd"},
 			expect:        func(_ export_test.MockUseCase, _ testcase) {},
 			expectedError: cli.ErrForceFlagRequiresTargetFile,
 		},
 		{
-			name:   "successful export to stdout (1 arg)",
-			strict: setTrue(),
-			force:  setFalse(),
-			args:   []string{"file.efd"},
+			name:     "successful export to stdout (1 arg)",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			template: setString(""),
+			args:     []string{"file.efd"},
 			expect: func(uc export_test.MockUseCase, tt testcase) {
 				uc.EXPECT().
-					Export(gomock.Any(), tt.args[0], nil, *tt.strict, *tt.force).
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+						},
+					).
 					Return(nil)
 			},
 			expectedError: nil,
 		},
 		{
-			name:   "successful export with target file",
-			strict: setTrue(),
-			force:  setTrue(),
-			args:   []string{"file.efd", "output.csv"},
+			name:     "successful export with target file",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setTrue(),
+			template: setString(""),
+			args:     []string{"file.efd", "output.csv"},
 			expect: func(uc export_test.MockUseCase, tt testcase) {
 				outputFile := "output.csv"
 				uc.EXPECT().
-					Export(gomock.Any(), tt.args[0], &outputFile, *tt.strict, *tt.force).
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						&outputFile,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+						},
+					).
+					Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "successful export through a template",
+			strict:   setTrue(),
+			recovery: setFalse(),
+			force:    setFalse(),
+			template: setString("{{.FilmID}}"),
+			args:     []string{"file.efd"},
+			expect: func(uc export_test.MockUseCase, tt testcase) {
+				uc.EXPECT().
+					Export(
+						gomock.Any(),
+						tt.args[0],
+						nil,
+						*tt.strict,
+						*tt.recovery,
+						*tt.force,
+						cli.Output{
+							Format:   cli.OutputCSV,
+							Template: *tt.template,
+						},
+					).
 					Return(nil)
 			},
 			expectedError: nil,
@@ -124,10 +204,18 @@ func Test_NewCommand(t *testing.T) {
 			cmd.Flags().Bool("strict", *tt.strict, "enable strict mode")
 		}
 
+		if tt.recovery != nil {
+			cmd.Flags().Bool("recover", *tt.recovery, "enable recovery mode")
+		}
+
 		if tt.force != nil {
 			cmd.Flags().Bool("force", *tt.force, "enable force mode")
 		}
 
+		if tt.template != nil {
+			cmd.Flags().String("template", *tt.template, "template")
+		}
+
 		cmd.SetArgs(tt.args)
 
 		return cmd
@@ -30,16 +30,28 @@ import (
 
 // UseCase defines the business logic for listing film roll information from EFD files.
 type UseCase interface {
-	// List reads an EFD file and prints roll information in a human-readable format.
-	List(ctx context.Context, filename string, strict bool) error
+	// List reads an EFD file and prints roll information in a human-readable format,
+	// as a JSON document if the output format is cli.OutputJSON, or through the output template if set.
+	List(
+		ctx context.Context,
+		filename string,
+		strict bool,
+		recovery bool,
+		output cli.Output,
+	) error
 }
 
 func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list <filename>",
 		Short: "Display roll information in human-readable format",
 		Long: `Display film roll information including film ID, title, load date, frame count, 
-ISO, and user-provided remarks.`,
+ISO, and user-provided remarks.
+
+With --output json, a JSON document following the versioned schema described in
+the README is written instead, for use with tools such as jq. With --template,
+the roll is written through a Go text/template given inline or as a file; see the
+README for the helper functions available.`,
 		Example: `  # Display roll information
   meta1v roll list data.efd
 
@@ -47,7 +59,13 @@ ISO, and user-provided remarks.`,
   meta1v roll ls data.efd
 
   # With strict mode
-  meta1v r ls data.efd --strict`,
+  meta1v r ls data.efd --strict
+
+  # As JSON
+  meta1v r ls data.efd --output json
+
+  # Through a template
+  meta1v r ls data.efd --template '{{.Title}} ({{.FilmID}}), loaded {{date "2 Jan 2006" .FilmLoadedDate}}'`,
 		Aliases: []string{"ls"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -58,12 +76,29 @@ ISO, and user-provided remarks.`,
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			output, err := cli.GetOutput(cmd, cli.OutputTable, cli.OutputJSON)
+			if err != nil {
+				return err
+			}
+
 			log.DebugContext(ctx, "arguments:",
 				slog.String("filename", args[0]),
 				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.String("output", output.Format),
+				slog.String("template", output.Template),
 			)
 
-			return uc.List(ctx, args[0], strict)
+			return uc.List(ctx, args[0], strict, recovery, output)
 		},
 	}
+
+	cli.AddOutputFlags(cmd, cli.OutputTable, cli.OutputJSON)
+
+	return cmd
 }
@@ -44,11 +44,12 @@ func Test_CommandRun(t *testing.T) {
 	}))
 
 	type testcase struct {
-		name           string
-		args           []string
-		registerStrict bool
-		expect         func(uc ls_test.MockUseCase, tt testcase)
-		expectedError  error
+		name            string
+		args            []string
+		registerStrict  bool
+		registerRecover bool
+		expect          func(uc ls_test.MockUseCase, tt testcase)
+		expectedError   error
 	}
 
 	tests := []testcase{
@@ -59,12 +60,70 @@ func Test_CommandRun(t *testing.T) {
 			expectedError:  cli.ErrFailedToGetStrictFlag,
 		},
 		{
-			name:           "successful execution",
-			args:           []string{"file.efd"},
-			registerStrict: true,
+			name:            "recover flag not registered",
+			args:            []string{"file.efd"},
+			registerStrict:  true,
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "successful execution",
+			args:            []string{"file.efd"},
+			registerStrict:  true,
+			registerRecover: true,
 			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
 				mockUseCase.EXPECT().
-					List(gomock.Any(), tt.args[0], gomock.Any()).
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputTable},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "unsupported output",
+			args:            []string{"file.efd", "--output", "csv"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   cli.ErrUnsupportedOutput,
+		},
+		{
+			name:            "json output",
+			args:            []string{"file.efd", "--output", "json"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputJSON},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "template output",
+			args:            []string{"file.efd", "--template", "{{.FilmID}}"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					List(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{
+							Format:   cli.OutputTable,
+							Template: "{{.FilmID}}",
+						},
+					).
 					Return(nil)
 			},
 		},
@@ -112,6 +171,10 @@ func Test_CommandRun(t *testing.T) {
 				cmd.Flags().Bool("strict", false, "enable strict mode")
 			}
 
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
 			cmd.SetArgs(tt.args)
 
 			err := cmd.Execute()
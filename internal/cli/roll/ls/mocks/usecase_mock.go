@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	cli "github.com/ma-tf/meta1v/internal/cli"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,15 +42,15 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 }
 
 // List mocks base method.
-func (m *MockUseCase) List(ctx context.Context, filename string, strict bool) error {
+func (m *MockUseCase) List(ctx context.Context, filename string, strict, recovery bool, output cli.Output) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, filename, strict)
+	ret := m.ctrl.Call(m, "List", ctx, filename, strict, recovery, output)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // List indicates an expected call of List.
-func (mr *MockUseCaseMockRecorder) List(ctx, filename, strict any) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) List(ctx, filename, strict, recovery, output any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx, filename, strict)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx, filename, strict, recovery, output)
 }
@@ -26,10 +26,13 @@ import (
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/roll/export"
 	"github.com/ma-tf/meta1v/internal/cli/roll/ls"
+	"github.com/ma-tf/meta1v/internal/records"
 	"github.com/ma-tf/meta1v/internal/service/csvexport"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/jsonexport"
 	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
 )
 
 const permission = 0o666 // rw-rw-rw-
@@ -41,13 +44,62 @@ var (
 		"failed to create output file for roll",
 	)
 	ErrFailedToExport = errors.New("failed to export roll to CSV")
+	ErrFailedToList   = errors.New("failed to list roll")
 )
 
+// readRoll reads the EFDF record of an EFD file and stops, as the built-in
+// roll output never shows frames or thumbnails. When full is set, as for a
+// template that may range over .Frames or a strict run that checks every
+// frame, the whole file is read. Recovery has to scan the whole file either way.
+func readRoll(
+	ctx context.Context,
+	log *slog.Logger,
+	efdService efd.Service,
+	filename string,
+	recovery bool,
+	full bool,
+) (records.Root, error) {
+	if full || recovery {
+		root, err := cli.ReadRecords(ctx, log, efdService, filename, recovery)
+		if err != nil {
+			return records.Root{}, err //nolint:wrapcheck // wrapped by caller
+		}
+
+		if !full {
+			return records.Root{
+				EFDF: root.EFDF, EFRMs: nil, EFTPs: nil, Placements: nil,
+			}, nil
+		}
+
+		return root, nil
+	}
+
+	for record, err := range efdService.Records(
+		ctx,
+		filename,
+		records.MagicEFDF,
+	) {
+		if err != nil {
+			return records.Root{}, err //nolint:wrapcheck // wrapped by caller
+		}
+
+		if efdf, ok := record.(records.EFDF); ok {
+			return records.Root{
+				EFDF: efdf, EFRMs: nil, EFTPs: nil, Placements: nil,
+			}, nil
+		}
+	}
+
+	return records.Root{}, efd.ErrMissingEFDFRecord
+}
+
 type listUseCase struct {
 	log                    *slog.Logger
 	efdService             efd.Service
 	displayableRollFactory display.DisplayableRollFactory
 	displayService         display.Service
+	jsonService            jsonexport.Service
+	templateService        usertemplate.Service
 }
 
 func NewListUseCase(
@@ -55,12 +107,16 @@ func NewListUseCase(
 	efdService efd.Service,
 	displayableRollFactory display.DisplayableRollFactory,
 	displayService display.Service,
+	jsonService jsonexport.Service,
+	templateService usertemplate.Service,
 ) ls.UseCase {
 	return listUseCase{
 		log:                    log,
 		efdService:             efdService,
 		displayableRollFactory: displayableRollFactory,
 		displayService:         displayService,
+		jsonService:            jsonService,
+		templateService:        templateService,
 	}
 }
 
@@ -68,20 +124,31 @@ func (uc listUseCase) List(
 	ctx context.Context,
 	filename string,
 	strict bool,
+	recovery bool,
+	output cli.Output,
 ) error {
 	uc.log.InfoContext(ctx, "starting roll list",
 		slog.String("file", filename),
-		slog.Bool("strict", strict))
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.String("output", output.Format),
+		slog.String("template", output.Template))
 
-	records, err := uc.efdService.RecordsFromFile(ctx, filename)
+	tmpl, err := cli.ParseTemplate(ctx, uc.templateService, output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
+
+	root, err := readRoll(ctx, uc.log, uc.efdService, filename, recovery,
+		strict || tmpl != nil)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, filename, err)
 	}
 
 	uc.log.DebugContext(ctx, "efd file read",
-		slog.Int("frame_count", len(records.EFRMs)))
+		slog.Uint64("frame_count", uint64(root.EFDF.FrameCount)))
 
-	dr, err := uc.displayableRollFactory.Create(ctx, records, strict)
+	dr, err := uc.displayableRollFactory.Create(ctx, root, strict)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
 	}
@@ -89,7 +156,18 @@ func (uc listUseCase) List(
 	uc.log.DebugContext(ctx, "displayable roll created",
 		slog.String("film_id", string(dr.FilmID)))
 
-	uc.displayService.DisplayRoll(ctx, os.Stdout, dr)
+	switch {
+	case tmpl != nil:
+		err = uc.templateService.Execute(ctx, os.Stdout, tmpl, dr)
+	case output.Format == cli.OutputJSON:
+		err = uc.jsonService.ExportRoll(ctx, os.Stdout, dr)
+	default:
+		uc.displayService.DisplayRoll(ctx, os.Stdout, dr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
 
 	uc.log.InfoContext(ctx, "roll list completed successfully")
 
@@ -102,6 +180,7 @@ type exportUseCase struct {
 	displayableRollFactory display.DisplayableRollFactory
 	csvService             csvexport.Service
 	fs                     osfs.FileSystem
+	templateService        usertemplate.Service
 }
 
 func NewExportUseCase(
@@ -110,6 +189,7 @@ func NewExportUseCase(
 	displayableRollFactory display.DisplayableRollFactory,
 	csvService csvexport.Service,
 	fs osfs.FileSystem,
+	templateService usertemplate.Service,
 ) export.UseCase {
 	return exportUseCase{
 		log:                    log,
@@ -117,6 +197,7 @@ func NewExportUseCase(
 		displayableRollFactory: displayableRollFactory,
 		csvService:             csvService,
 		fs:                     fs,
+		templateService:        templateService,
 	}
 }
 
@@ -125,23 +206,33 @@ func (uc exportUseCase) Export(
 	efdFile string,
 	outputFile *string,
 	strict bool,
+	recovery bool,
 	force bool,
+	output cli.Output,
 ) error {
 	uc.log.InfoContext(ctx, "starting roll export",
 		slog.String("efd_file", efdFile),
 		slog.Any("output_file", outputFile),
 		slog.Bool("strict", strict),
-		slog.Bool("force", force))
+		slog.Bool("recover", recovery),
+		slog.Bool("force", force),
+		slog.String("template", output.Template))
+
+	tmpl, err := cli.ParseTemplate(ctx, uc.templateService, output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToExport, err)
+	}
 
-	records, err := uc.efdService.RecordsFromFile(ctx, efdFile)
+	root, err := readRoll(ctx, uc.log, uc.efdService, efdFile, recovery,
+		strict || tmpl != nil)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
 	}
 
 	uc.log.DebugContext(ctx, "efd file read",
-		slog.Int("frame_count", len(records.EFRMs)))
+		slog.Uint64("frame_count", uint64(root.EFDF.FrameCount)))
 
-	dr, err := uc.displayableRollFactory.Create(ctx, records, strict)
+	dr, err := uc.displayableRollFactory.Create(ctx, root, strict)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
 	}
@@ -173,7 +264,13 @@ func (uc exportUseCase) Export(
 			slog.String("file", *outputFile))
 	}
 
-	if err = uc.csvService.ExportRoll(ctx, writer, dr); err != nil {
+	if tmpl != nil {
+		err = uc.templateService.Execute(ctx, writer, tmpl, dr)
+	} else {
+		err = uc.csvService.ExportRoll(ctx, writer, dr)
+	}
+
+	if err != nil {
 		return fmt.Errorf("%w: %w", ErrFailedToExport, err)
 	}
 
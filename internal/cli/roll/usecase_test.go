@@ -19,9 +19,11 @@ package roll_test
 import (
 	"bytes"
 	"errors"
+	"iter"
 	"log/slog"
 	"os"
 	"testing"
+	"text/template"
 
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/roll"
@@ -29,8 +31,12 @@ import (
 	csvexport_test "github.com/ma-tf/meta1v/internal/service/csvexport/mocks"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	display_test "github.com/ma-tf/meta1v/internal/service/display/mocks"
+	"github.com/ma-tf/meta1v/internal/service/efd"
 	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	jsonexport_test "github.com/ma-tf/meta1v/internal/service/jsonexport/mocks"
 	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
+	usertemplate_test "github.com/ma-tf/meta1v/internal/service/usertemplate/mocks"
 	"go.uber.org/mock/gomock"
 )
 
@@ -51,6 +57,19 @@ func newTestLogger() *slog.Logger {
 	}))
 }
 
+// efdfSeq streams efdf as the EFD service would, followed by err if it is not nil.
+func efdfSeq(efdf *records.EFDF, err error) iter.Seq2[records.Record, error] {
+	return func(yield func(records.Record, error) bool) {
+		if efdf != nil && !yield(*efdf, nil) {
+			return
+		}
+
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
 //nolint:exhaustruct // only partial is needed
 func Test_RollListUseCase(t *testing.T) {
 	t.Parallel()
@@ -67,6 +86,7 @@ func Test_RollListUseCase(t *testing.T) {
 		records       records.Root
 		roll          display.DisplayableRoll
 		strict        bool
+		recovery      bool
 		expectedError error
 	}
 
@@ -80,15 +100,27 @@ func Test_RollListUseCase(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.filename).
-					Return(
-						records.Root{},
-						errExample,
-					)
+					Records(gomock.Any(), tt.filename, records.MagicEFDF).
+					Return(efdfSeq(nil, errExample))
 			},
 			filename:      "file.efd",
 			expectedError: roll.ErrFailedToReadFile,
 		},
+		{
+			name: "missing EFDF record",
+			expect: func(
+				mockEFDService efd_test.MockService,
+				_ display_test.MockDisplayableRollFactory,
+				_ display_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					Records(gomock.Any(), tt.filename, records.MagicEFDF).
+					Return(efdfSeq(nil, nil))
+			},
+			filename:      "file.efd",
+			expectedError: efd.ErrMissingEFDFRecord,
+		},
 		{
 			name: "failed to parse file",
 			expect: func(
@@ -98,11 +130,8 @@ func Test_RollListUseCase(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.filename).
-					Return(
-						tt.records,
-						nil,
-					)
+					Records(gomock.Any(), tt.filename, records.MagicEFDF).
+					Return(efdfSeq(&tt.records.EFDF, nil))
 
 				mockDisplayableRollFactory.EXPECT().
 					Create(gomock.Any(), tt.records, tt.strict).
@@ -128,12 +157,47 @@ func Test_RollListUseCase(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.filename).
+					Records(gomock.Any(), tt.filename, records.MagicEFDF).
+					Return(efdfSeq(&tt.records.EFDF, nil))
+
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), tt.records, tt.strict).
 					Return(
-						tt.records,
+						tt.roll,
 						nil,
 					)
 
+				mockDisplayService.EXPECT().
+					DisplayRoll(gomock.Any(), gomock.Any(), tt.roll)
+			},
+			filename: "file.efd",
+			records: records.Root{
+				EFDF: records.EFDF{
+					Title: [64]byte{'t', 'i', 't', 'l', 'e'},
+				},
+			},
+			roll: display.DisplayableRoll{
+				Title: "title",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "successfully display recovered roll",
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockDisplayableRollFactory display_test.MockDisplayableRollFactory,
+				mockDisplayService display_test.MockService,
+				tt testcase,
+			) {
+				recovered := tt.records
+				recovered.EFRMs = []records.EFRM{{FrameNumber: 1}}
+
+				mockEFDService.EXPECT().
+					RecoverRecordsFromFile(gomock.Any(), tt.filename).
+					Return(recovered, []efd.Diagnostic{
+						{Offset: 1200, Length: 40, Reason: errExample},
+					}, nil)
+
 				mockDisplayableRollFactory.EXPECT().
 					Create(gomock.Any(), tt.records, tt.strict).
 					Return(
@@ -153,8 +217,36 @@ func Test_RollListUseCase(t *testing.T) {
 			roll: display.DisplayableRoll{
 				Title: "title",
 			},
+			recovery:      true,
 			expectedError: nil,
 		},
+		{
+			name: "strict reads and checks the frames",
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockDisplayableRollFactory display_test.MockDisplayableRollFactory,
+				_ display_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.filename).
+					Return(tt.records, nil)
+
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), tt.records, tt.strict).
+					Return(
+						display.DisplayableRoll{},
+						errExample,
+					)
+			},
+			filename: "file.efd",
+			records: records.Root{
+				EFDF:  records.EFDF{FrameCount: 1},
+				EFRMs: []records.EFRM{{FrameNumber: 1}},
+			},
+			strict:        true,
+			expectedError: roll.ErrFailedToParseFile,
+		},
 	}
 
 	for _, tt := range tests {
@@ -183,12 +275,16 @@ func Test_RollListUseCase(t *testing.T) {
 				mockEFDService,
 				mockDisplayableRollFactory,
 				mockDisplayService,
+				jsonexport_test.NewMockService(mockCtrl),
+				usertemplate_test.NewMockService(mockCtrl),
 			)
 
 			err := uc.List(
 				ctx,
 				tt.filename,
 				tt.strict,
+				tt.recovery,
+				cli.Output{Format: cli.OutputTable},
 			)
 
 			if tt.expectedError != nil {
@@ -259,8 +355,8 @@ func Test_RollExportUseCase(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.records, errExample)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFDF).
+					Return(efdfSeq(nil, errExample))
 			},
 			expectedError: roll.ErrFailedToReadFile,
 		},
@@ -278,8 +374,8 @@ func Test_RollExportUseCase(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.records, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFDF).
+					Return(efdfSeq(&tt.records.EFDF, nil))
 
 				mockDisplayableRollFactory.EXPECT().
 					Create(gomock.Any(), tt.records, tt.strict).
@@ -305,8 +401,8 @@ func Test_RollExportUseCase(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.records, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFDF).
+					Return(efdfSeq(&tt.records.EFDF, nil))
 
 				mockDisplayableRollFactory.EXPECT().
 					Create(gomock.Any(), tt.records, tt.strict).
@@ -334,8 +430,8 @@ func Test_RollExportUseCase(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.records, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFDF).
+					Return(efdfSeq(&tt.records.EFDF, nil))
 
 				mockDisplayableRollFactory.EXPECT().
 					Create(gomock.Any(), tt.records, tt.strict).
@@ -363,8 +459,8 @@ func Test_RollExportUseCase(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.records, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFDF).
+					Return(efdfSeq(&tt.records.EFDF, nil))
 
 				mockDisplayableRollFactory.EXPECT().
 					Create(gomock.Any(), tt.records, tt.strict).
@@ -443,6 +539,7 @@ func Test_RollExportUseCase(t *testing.T) {
 				mockDisplayableRollFactory,
 				mockCSVService,
 				mockFileSystem,
+				usertemplate_test.NewMockService(mockCtrl),
 			)
 
 			err := uc.Export(
@@ -450,7 +547,9 @@ func Test_RollExportUseCase(t *testing.T) {
 				tt.efdFile,
 				tt.outputFile,
 				tt.strict,
+				false,
 				tt.force,
+				cli.Output{Format: cli.OutputCSV},
 			)
 
 			assertErrors(t, err, tt.expectedError)
@@ -506,8 +605,8 @@ func Test_RollExportUseCase_Success(t *testing.T) {
 				tt testcase,
 			) {
 				mockEFDService.EXPECT().
-					RecordsFromFile(gomock.Any(), tt.efdFile).
-					Return(tt.records, nil)
+					Records(gomock.Any(), tt.efdFile, records.MagicEFDF).
+					Return(efdfSeq(&tt.records.EFDF, nil))
 
 				mockDisplayableRollFactory.EXPECT().
 					Create(gomock.Any(), tt.records, tt.strict).
@@ -586,6 +685,7 @@ func Test_RollExportUseCase_Success(t *testing.T) {
 				mockDisplayableRollFactory,
 				mockCSVService,
 				mockFileSystem,
+				usertemplate_test.NewMockService(mockCtrl),
 			)
 
 			err := uc.Export(
@@ -593,10 +693,422 @@ func Test_RollExportUseCase_Success(t *testing.T) {
 				tt.efdFile,
 				tt.outputFile,
 				tt.strict,
+				false,
 				tt.force,
+				cli.Output{Format: cli.OutputCSV},
 			)
 
 			assertErrors(t, err, tt.expectedError)
 		})
 	}
 }
+
+//nolint:exhaustruct // only partial is needed
+func Test_RollListUseCase_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedError error
+	}{
+		{
+			name:          "successfully write JSON",
+			err:           nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to write JSON",
+			err:           errExample,
+			expectedError: roll.ErrFailedToList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockJSONService := jsonexport_test.NewMockService(ctrl)
+
+			mockEFDService.EXPECT().
+				Records(gomock.Any(), "file.efd", records.MagicEFDF).
+				Return(efdfSeq(&root.EFDF, nil))
+			mockDisplayableRollFactory.EXPECT().
+				Create(gomock.Any(), root, false).
+				Return(dr, nil)
+			mockJSONService.EXPECT().
+				ExportRoll(gomock.Any(), gomock.Any(), dr).
+				Return(tt.err)
+
+			uc := roll.NewListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				mockJSONService,
+				usertemplate_test.NewMockService(ctrl),
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				cli.Output{Format: cli.OutputJSON},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_RollListUseCase_Template(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		parseErr      error
+		executeErr    error
+		expectedError error
+	}{
+		{
+			name:          "successfully execute template",
+			parseErr:      nil,
+			executeErr:    nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to parse template",
+			parseErr:      errExample,
+			executeErr:    nil,
+			expectedError: roll.ErrFailedToList,
+		},
+		{
+			name:          "failed to execute template",
+			parseErr:      nil,
+			executeErr:    errExample,
+			expectedError: roll.ErrFailedToList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockTemplateService := usertemplate_test.NewMockService(ctrl)
+			tmpl := template.Must(template.New("template").Parse("{{.FilmID}}"))
+
+			mockTemplateService.EXPECT().
+				Parse(gomock.Any(), "{{.FilmID}}").
+				Return(tmpl, tt.parseErr)
+
+			if tt.parseErr == nil {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), root, false).
+					Return(dr, nil)
+				mockTemplateService.EXPECT().
+					Execute(gomock.Any(), gomock.Any(), tmpl, dr).
+					Return(tt.executeErr)
+			}
+
+			uc := roll.NewListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				jsonexport_test.NewMockService(ctrl),
+				mockTemplateService,
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				cli.Output{
+					Format:   cli.OutputTable,
+					Template: "{{.FilmID}}",
+				},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_RollExportUseCase_Template(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		parseErr      error
+		executeErr    error
+		expectedError error
+	}{
+		{
+			name:          "successfully execute template",
+			parseErr:      nil,
+			executeErr:    nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to parse template",
+			parseErr:      errExample,
+			executeErr:    nil,
+			expectedError: roll.ErrFailedToExport,
+		},
+		{
+			name:          "failed to execute template",
+			parseErr:      nil,
+			executeErr:    errExample,
+			expectedError: roll.ErrFailedToExport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockTemplateService := usertemplate_test.NewMockService(ctrl)
+			tmpl := template.Must(template.New("template").Parse("{{.FilmID}}"))
+
+			mockTemplateService.EXPECT().
+				Parse(gomock.Any(), "{{.FilmID}}").
+				Return(tmpl, tt.parseErr)
+
+			if tt.parseErr == nil {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), root, false).
+					Return(dr, nil)
+				mockTemplateService.EXPECT().
+					Execute(gomock.Any(), gomock.Any(), tmpl, dr).
+					Return(tt.executeErr)
+			}
+
+			uc := roll.NewExportUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				csvexport_test.NewMockService(ctrl),
+				osfs_test.NewMockFileSystem(ctrl),
+				mockTemplateService,
+			)
+
+			err := uc.Export(
+				t.Context(),
+				"file.efd",
+				nil,
+				false,
+				false,
+				false,
+				cli.Output{
+					Format:   cli.OutputCSV,
+					Template: "{{.FilmID}}",
+				},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func newEFRM(frameNumber uint32) records.EFRM {
+	return records.EFRM{
+		FrameNumber:     frameNumber,
+		CodeA:           1,
+		CodeB:           1,
+		FlashMode:       1,
+		MeteringMode:    1,
+		ShootingMode:    1,
+		FilmAdvanceMode: 99,
+		AFMode:          1,
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_RollExportUseCase_TemplateFrames(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var remarks [256]byte
+
+	copy(remarks[:], "second frame")
+
+	root := records.Root{
+		EFDF:  records.EFDF{CodeA: 1, CodeB: 1, FrameCount: 2},
+		EFRMs: []records.EFRM{newEFRM(1), newEFRM(2)},
+	}
+	root.EFRMs[1].Remarks = remarks
+	outputFile := "output.txt"
+	text := "{{.FilmID}}\n{{range .Frames}}{{.FrameNumber}}: {{.Remarks}}\n{{end}}"
+
+	mockEFDService := efd_test.NewMockService(ctrl)
+	mockEFDService.EXPECT().
+		RecordsFromFile(gomock.Any(), "file.efd").
+		Return(root, nil)
+
+	mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
+	mockFileSystem.EXPECT().
+		Stat(text).
+		Return(nil, os.ErrNotExist)
+
+	var out bytes.Buffer
+
+	mockFile := osfs_test.NewMockFile(ctrl)
+	mockFile.EXPECT().
+		Write(gomock.Any()).
+		DoAndReturn(out.Write).
+		AnyTimes()
+	mockFile.EXPECT().
+		Close().
+		Return(nil)
+	mockFileSystem.EXPECT().
+		OpenFile(outputFile, gomock.Any(), gomock.Any()).
+		Return(mockFile, nil)
+
+	log := newTestLogger()
+	uc := roll.NewExportUseCase(log,
+		mockEFDService,
+		display.NewDisplayableRollFactory(display.NewFrameBuilder(log)),
+		csvexport_test.NewMockService(ctrl),
+		mockFileSystem,
+		usertemplate.NewService(log, mockFileSystem),
+	)
+
+	err := uc.Export(
+		t.Context(),
+		"file.efd",
+		&outputFile,
+		false,
+		false,
+		false,
+		cli.Output{Format: cli.OutputCSV, Template: text},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "01-001\n1: \n2: second frame\n"
+	if out.String() != want {
+		t.Errorf("unexpected output:\ngot  %q\nwant %q", out.String(), want)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_RollListUseCase_StrictFrames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		strict        bool
+		expectedError error
+	}{
+		{
+			name:          "strict fails on a bad frame",
+			strict:        true,
+			expectedError: roll.ErrFailedToParseFile,
+		},
+		{
+			name:          "frames are not read without strict",
+			strict:        false,
+			expectedError: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bad := newEFRM(1)
+			bad.Tv = 12345 // no such shutter speed
+
+			root := records.Root{
+				EFDF:  records.EFDF{CodeA: 1, CodeB: 1, FrameCount: 1},
+				EFRMs: []records.EFRM{bad},
+			}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayService := display_test.NewMockService(ctrl)
+
+			if tt.strict {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, nil)
+			} else {
+				mockEFDService.EXPECT().
+					Records(gomock.Any(), "file.efd", records.MagicEFDF).
+					Return(efdfSeq(&root.EFDF, nil))
+				mockDisplayService.EXPECT().
+					DisplayRoll(gomock.Any(), gomock.Any(), gomock.Any())
+			}
+
+			log := newTestLogger()
+			uc := roll.NewListUseCase(log,
+				mockEFDService,
+				display.NewDisplayableRollFactory(display.NewFrameBuilder(log)),
+				mockDisplayService,
+				jsonexport_test.NewMockService(ctrl),
+				usertemplate_test.NewMockService(ctrl),
+			)
+
+			err := uc.List(
+				t.Context(),
+				"file.efd",
+				tt.strict,
+				false,
+				cli.Output{Format: cli.OutputTable},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
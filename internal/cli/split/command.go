@@ -0,0 +1,134 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=split_test github.com/ma-tf/meta1v/internal/cli/split UseCase
+
+// Package split provides the CLI command for splitting EFD files by film ID or frame range.
+package split
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var ErrFailedToGetFrameFlag = errors.New("failed to get frames flag")
+
+// UseCase defines the business logic for splitting EFD files.
+type UseCase interface {
+	// SplitByFilmID writes one EFD file per film ID found in the frames of efdFile
+	// into targetDir, named after efdFile and the film ID.
+	SplitByFilmID(
+		ctx context.Context,
+		efdFile string,
+		targetDir string,
+		recovery bool,
+		force bool,
+	) error
+
+	// SplitByFrames writes the frames of efdFile within ranges to targetFile.
+	SplitByFrames(
+		ctx context.Context,
+		efdFile string,
+		targetFile string,
+		ranges []domain.FrameRange,
+		recovery bool,
+		force bool,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "split <efd_file> <target>",
+		Short: "Split an EFD file by film ID or frame range",
+		Long: `Split the frames of an EFD file into separate, valid EFD files. Thumbnails move with
+their frames.
+
+By default frames are grouped by the film ID recorded in each frame and one file per film
+ID is written to the target directory, named <efd_file>_<film_id>.efd. Frames whose film
+ID differs from the roll header get a header rebuilt from the frames.
+
+With --frames, the frames whose numbers fall within the ranges are written to the target
+file, keeping the original roll header.`,
+		Example: `  # One file per film ID in the rolls directory
+  meta1v split data.efd rolls/
+
+  # Frames 1 to 12 and 20 into a new file
+  meta1v split data.efd first.efd --frames 1-12,20
+
+  # Overwrite existing files
+  meta1v split data.efd rolls/ --force`,
+		Args: cobra.ExactArgs(2), //nolint:mnd // source and target
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetForceFlag, err)
+			}
+
+			frames, err := cmd.Flags().GetString("frames")
+			if err != nil {
+				return errors.Join(ErrFailedToGetFrameFlag, err)
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.String("target", args[1]),
+				slog.String("frames", frames),
+				slog.Bool("recover", recovery),
+				slog.Bool("force", force),
+			)
+
+			if frames == "" {
+				return uc.SplitByFilmID(ctx, args[0], args[1], recovery, force)
+			}
+
+			ranges, err := cli.ParseFrameRanges(frames)
+			if err != nil {
+				return err
+			}
+
+			return uc.SplitByFrames(
+				ctx,
+				args[0],
+				args[1],
+				ranges,
+				recovery,
+				force,
+			)
+		},
+	}
+
+	cmd.Flags().String(
+		"frames",
+		"",
+		"comma separated frame numbers or ranges to keep, e.g. 1-12,20",
+	)
+	cmd.Flags().
+		BoolP("force", "F", false, "overwrite output files if they exist")
+
+	return cmd
+}
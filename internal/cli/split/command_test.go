@@ -0,0 +1,154 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package split_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/split"
+	split_test "github.com/ma-tf/meta1v/internal/cli/split/mocks"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name            string
+		args            []string
+		registerRecover bool
+		expect          func(mockUseCase *split_test.MockUseCase)
+		expectedError   error
+	}
+
+	tests := []testcase{
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd", "out"},
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "split by film ID",
+			args:            []string{"file.efd", "out", "--force"},
+			registerRecover: true,
+			expect: func(mockUseCase *split_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					SplitByFilmID(gomock.Any(), "file.efd", "out", false, true).
+					Return(nil)
+			},
+		},
+		{
+			name: "split by frames",
+			args: []string{
+				"file.efd", "out.efd", "--frames", "1-12, 20",
+			},
+			registerRecover: true,
+			expect: func(mockUseCase *split_test.MockUseCase) {
+				mockUseCase.EXPECT().
+					SplitByFrames(
+						gomock.Any(),
+						"file.efd",
+						"out.efd",
+						[]domain.FrameRange{
+							{First: 1, Last: 12},
+							{First: 20, Last: 20},
+						},
+						false,
+						false,
+					).
+					Return(nil)
+			},
+		},
+		{
+			name: "backwards frame range",
+			args: []string{
+				"file.efd", "out.efd", "--frames", "12-1",
+			},
+			registerRecover: true,
+			expectedError:   cli.ErrInvalidFrameRange,
+		},
+		{
+			name:            "frame zero",
+			args:            []string{"file.efd", "out.efd", "--frames", "0-3"},
+			registerRecover: true,
+			expectedError:   cli.ErrInvalidFrameRange,
+		},
+		{
+			name:            "not a number",
+			args:            []string{"file.efd", "out.efd", "--frames", "1,x"},
+			registerRecover: true,
+			expectedError:   cli.ErrInvalidFrameRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := split_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase)
+			}
+
+			cmd := split.NewCommand(logger, mockUseCase)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
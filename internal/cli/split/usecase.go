@@ -0,0 +1,169 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package split
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/splitmerge"
+)
+
+var (
+	ErrFailedToReadFile   = errors.New("failed to read file for split")
+	ErrFailedToSplit      = errors.New("failed to split roll")
+	ErrTargetNotDirectory = errors.New("target is not a directory")
+	ErrFailedToWriteFile  = errors.New("failed to write split file")
+)
+
+type usecase struct {
+	log               *slog.Logger
+	efdService        efd.Service
+	splitmergeService splitmerge.Service
+	fs                osfs.FileSystem
+}
+
+func NewUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	splitmergeService splitmerge.Service,
+	fs osfs.FileSystem,
+) UseCase {
+	return usecase{
+		log:               log,
+		efdService:        efdService,
+		splitmergeService: splitmergeService,
+		fs:                fs,
+	}
+}
+
+func (uc usecase) SplitByFilmID(
+	ctx context.Context,
+	efdFile string,
+	targetDir string,
+	recovery bool,
+	force bool,
+) error {
+	uc.log.InfoContext(ctx, "starting split by film ID",
+		slog.String("efd_file", efdFile),
+		slog.String("target_dir", targetDir),
+		slog.Bool("recover", recovery))
+
+	info, err := uc.fs.Stat(targetDir)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("%w: %q", ErrTargetNotDirectory, targetDir)
+	}
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	parts := uc.splitmergeService.SplitByFilmID(ctx, root)
+
+	base := strings.TrimSuffix(filepath.Base(efdFile), filepath.Ext(efdFile))
+	targets := make([]string, len(parts))
+
+	for i, part := range parts {
+		targets[i] = filepath.Join(targetDir, base+"_"+part.FilmID+".efd")
+		if err = uc.checkTarget(targets[i], force); err != nil {
+			return err
+		}
+	}
+
+	for i, part := range parts {
+		if err = uc.write(ctx, targets[i], part.Root); err != nil {
+			return err
+		}
+	}
+
+	uc.log.InfoContext(ctx, "split by film ID completed successfully",
+		slog.Int("files", len(parts)))
+
+	return nil
+}
+
+func (uc usecase) SplitByFrames(
+	ctx context.Context,
+	efdFile string,
+	targetFile string,
+	ranges []domain.FrameRange,
+	recovery bool,
+	force bool,
+) error {
+	uc.log.InfoContext(ctx, "starting split by frames",
+		slog.String("efd_file", efdFile),
+		slog.String("target_file", targetFile),
+		slog.Int("ranges", len(ranges)),
+		slog.Bool("recover", recovery))
+
+	if err := uc.checkTarget(targetFile, force); err != nil {
+		return err
+	}
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	part, err := uc.splitmergeService.SplitByFrames(ctx, root, ranges)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToSplit, efdFile, err)
+	}
+
+	if err = uc.write(ctx, targetFile, part); err != nil {
+		return err
+	}
+
+	uc.log.InfoContext(ctx, "split by frames completed successfully")
+
+	return nil
+}
+
+// checkTarget refuses to overwrite an existing file unless forced.
+func (uc usecase) checkTarget(target string, force bool) error {
+	if _, err := uc.fs.Stat(target); err == nil && !force {
+		return fmt.Errorf("%w: %q", cli.ErrOutputFileAlreadyExists, target)
+	}
+
+	return nil
+}
+
+func (uc usecase) write(
+	ctx context.Context,
+	target string,
+	root records.Root,
+) error {
+	if err := uc.efdService.RecordsToFile(ctx, target, root); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToWriteFile, target, err)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: %d frame(s), %d thumbnail(s)\n",
+		target, len(root.EFRMs), len(root.EFTPs))
+
+	return nil
+}
@@ -0,0 +1,313 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package split_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/split"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	"github.com/ma-tf/meta1v/internal/service/splitmerge"
+	splitmerge_test "github.com/ma-tf/meta1v/internal/service/splitmerge/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+func stat(t *testing.T, name string) os.FileInfo {
+	t.Helper()
+
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatalf("failed to stat %q: %v", name, err)
+	}
+
+	return info
+}
+
+// existingFile returns the file info of a regular file.
+func existingFile(t *testing.T) os.FileInfo {
+	t.Helper()
+
+	name := filepath.Join(t.TempDir(), "existing.efd")
+	if err := os.WriteFile(name, nil, 0o600); err != nil {
+		t.Fatalf("failed to create %q: %v", name, err)
+	}
+
+	return stat(t, name)
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_SplitByFilmID(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dirInfo := stat(t, dir)
+	fileInfo := existingFile(t)
+
+	root := records.Root{EFRMs: []records.EFRM{{FrameNumber: 1}}}
+	parts := []splitmerge.Part{
+		{FilmID: "12-345", Root: records.Root{EFRMs: root.EFRMs}},
+		{FilmID: "12-346", Root: records.Root{}},
+	}
+	first := filepath.Join(dir, "roll_12-345.efd")
+	second := filepath.Join(dir, "roll_12-346.efd")
+
+	type mocks struct {
+		efd        *efd_test.MockService
+		splitmerge *splitmerge_test.MockService
+		fs         *osfs_test.MockFileSystem
+	}
+
+	tests := []struct {
+		name          string
+		force         bool
+		expect        func(m mocks)
+		expectedError error
+	}{
+		{
+			name: "target is not a directory",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(fileInfo, nil)
+			},
+			expectedError: split.ErrTargetNotDirectory,
+		},
+		{
+			name: "failed to read file",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in/roll.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: split.ErrFailedToReadFile,
+		},
+		{
+			name: "target file exists",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in/roll.efd").
+					Return(root, nil)
+				m.splitmerge.EXPECT().
+					SplitByFilmID(gomock.Any(), root).
+					Return(parts)
+				m.fs.EXPECT().Stat(first).Return(nil, os.ErrNotExist)
+				m.fs.EXPECT().Stat(second).Return(fileInfo, nil)
+			},
+			expectedError: cli.ErrOutputFileAlreadyExists,
+		},
+		{
+			name: "failed to write file",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in/roll.efd").
+					Return(root, nil)
+				m.splitmerge.EXPECT().
+					SplitByFilmID(gomock.Any(), root).
+					Return(parts)
+				m.fs.EXPECT().Stat(first).Return(nil, os.ErrNotExist)
+				m.fs.EXPECT().Stat(second).Return(nil, os.ErrNotExist)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), first, parts[0].Root).
+					Return(errExample)
+			},
+			expectedError: split.ErrFailedToWriteFile,
+		},
+		{
+			name:  "overwrite existing files",
+			force: true,
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in/roll.efd").
+					Return(root, nil)
+				m.splitmerge.EXPECT().
+					SplitByFilmID(gomock.Any(), root).
+					Return(parts)
+				m.fs.EXPECT().Stat(first).Return(fileInfo, nil)
+				m.fs.EXPECT().Stat(second).Return(fileInfo, nil)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), first, parts[0].Root).
+					Return(nil)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), second, parts[1].Root).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				efd:        efd_test.NewMockService(ctrl),
+				splitmerge: splitmerge_test.NewMockService(ctrl),
+				fs:         osfs_test.NewMockFileSystem(ctrl),
+			}
+			tt.expect(m)
+
+			uc := split.NewUseCase(newTestLogger(), m.efd, m.splitmerge, m.fs)
+
+			err := uc.SplitByFilmID(
+				t.Context(),
+				"in/roll.efd",
+				dir,
+				false,
+				tt.force,
+			)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_SplitByFrames(t *testing.T) {
+	t.Parallel()
+
+	fileInfo := existingFile(t)
+
+	root := records.Root{EFRMs: []records.EFRM{{FrameNumber: 1}}}
+	ranges := []domain.FrameRange{{First: 1, Last: 2}}
+
+	type mocks struct {
+		efd        *efd_test.MockService
+		splitmerge *splitmerge_test.MockService
+		fs         *osfs_test.MockFileSystem
+	}
+
+	tests := []struct {
+		name          string
+		expect        func(m mocks)
+		expectedError error
+	}{
+		{
+			name: "target file exists",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat("out.efd").Return(fileInfo, nil)
+			},
+			expectedError: cli.ErrOutputFileAlreadyExists,
+		},
+		{
+			name: "no frames selected",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat("out.efd").Return(nil, os.ErrNotExist)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(root, nil)
+				m.splitmerge.EXPECT().
+					SplitByFrames(gomock.Any(), root, ranges).
+					Return(records.Root{}, splitmerge.ErrNoFramesSelected)
+			},
+			expectedError: splitmerge.ErrNoFramesSelected,
+		},
+		{
+			name: "frames written",
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat("out.efd").Return(nil, os.ErrNotExist)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in.efd").
+					Return(root, nil)
+				m.splitmerge.EXPECT().
+					SplitByFrames(gomock.Any(), root, ranges).
+					Return(root, nil)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), "out.efd", root).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				efd:        efd_test.NewMockService(ctrl),
+				splitmerge: splitmerge_test.NewMockService(ctrl),
+				fs:         osfs_test.NewMockFileSystem(ctrl),
+			}
+			tt.expect(m)
+
+			uc := split.NewUseCase(newTestLogger(), m.efd, m.splitmerge, m.fs)
+
+			err := uc.SplitByFrames(
+				t.Context(),
+				"in.efd",
+				"out.efd",
+				ranges,
+				false,
+				false,
+			)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
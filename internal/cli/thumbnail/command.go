@@ -14,13 +14,15 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-// Package thumbnail provides business logic for displaying embedded thumbnails from EFD files.
+// Package thumbnail provides business logic for displaying, exporting and embedding thumbnails in EFD files.
 package thumbnail
 
 import (
 	"log/slog"
 
+	"github.com/ma-tf/meta1v/internal/cli/thumbnail/export"
 	"github.com/ma-tf/meta1v/internal/cli/thumbnail/ls"
+	"github.com/ma-tf/meta1v/internal/cli/thumbnail/set"
 	"github.com/ma-tf/meta1v/internal/container"
 	"github.com/spf13/cobra"
 )
@@ -28,9 +30,10 @@ import (
 func NewCommand(log *slog.Logger, ctr *container.Container) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "thumbnail <command>",
-		Short: "Display embedded thumbnail images from EFD files",
+		Short: "Display, export or embed thumbnail images in EFD files",
 		Long: `Display embedded thumbnail images as ASCII art, including the file path and 
-rendered ASCII representation.`,
+rendered ASCII representation, export them as PNG or JPEG files, or embed a scan as
+the thumbnail of a frame.`,
 		Aliases: []string{"t", "thumb"},
 	}
 
@@ -39,9 +42,30 @@ rendered ASCII representation.`,
 		ctr.EFDService,
 		ctr.DisplayableRollFactory,
 		ctr.DisplayService,
+		ctr.JSONService,
+		ctr.TemplateService,
 	)
 
-	cmd.AddCommand(ls.NewCommand(log, uc))
+	exportUC := NewThumbnailExportUseCase(
+		log,
+		ctr.EFDService,
+		ctr.ThumbnailService,
+		ctr.FileSystem,
+		ctr.FrameBuilder,
+	)
+
+	setUC := NewThumbnailSetUseCase(
+		log,
+		ctr.EFDService,
+		ctr.ThumbnailService,
+		ctr.FileSystem,
+	)
+
+	cmd.AddCommand(
+		ls.NewCommand(log, uc),
+		export.NewCommand(log, exportUC),
+		set.NewCommand(log, setUC),
+	)
 
 	return cmd
 }
@@ -0,0 +1,177 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=export_test github.com/ma-tf/meta1v/internal/cli/thumbnail/export UseCase
+
+// Package export provides the CLI command for exporting embedded thumbnails as image files.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/ma-tf/meta1v/internal/service/thumbnail"
+	"github.com/spf13/cobra"
+)
+
+// DefaultName is the default file name template, see the command help for its fields.
+const DefaultName = `{{.Roll}}_{{printf "%02d" .Frame}}.{{.Ext}}`
+
+var (
+	ErrUnsupportedFormat         = errors.New("unsupported image format")
+	ErrFailedToGetThumbnailFlags = errors.New("failed to get thumbnail flags")
+)
+
+// Options controls which thumbnails are exported and how the files are written.
+type Options struct {
+	Format string
+	Frames cli.FrameRanges    // nil exports every frame
+	Where  framefilter.Filter // the zero filter exports every frame
+	Name   string             // text/template for the file names
+	Force  bool
+}
+
+// UseCase defines the business logic for exporting embedded thumbnails.
+type UseCase interface {
+	// Export writes the thumbnail of every selected frame of efdFile as an image file
+	// into targetDir.
+	Export(
+		ctx context.Context,
+		efdFile string,
+		targetDir string,
+		opts Options,
+		recovery bool,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <efd_file> <target_dir>",
+		Short: "Export embedded thumbnails as PNG or JPEG files",
+		Long: `Export the embedded thumbnail of each frame as an image file in the target directory.
+
+File names are built from the --name template, which can use the fields:
+  .Roll    name of the EFD file without its extension
+  .FilmID  film ID recorded in the frame, empty if none was recorded
+  .Frame   frame number
+  .Index   position of the frame in the file, counting from 1
+  .Ext     file extension for the format (png or jpg)
+
+Thumbnails that do not belong to a frame are skipped.
+
+With --frames, only the thumbnails of the frames with the given numbers are exported,
+e.g. 1-5,12,30- where 30- runs to the end of the roll. Any missing frame of a closed
+range fails, as does a range like 30- without any frame.
+
+With --where, only the thumbnails of frames matching a filter expression are exported;
+see the README for the fields and operators available.`,
+		Example: `  # Export every thumbnail as PNG
+  meta1v thumbnail export data.efd thumbs/
+
+  # Export frames 1 to 5 and 12 as JPEG
+  meta1v thumbnail export data.efd thumbs/ --format jpeg --frames 1-5,12
+
+  # Only frames shot with flash
+  meta1v thumbnail export data.efd thumbs/ --where 'flash != "OFF"'
+
+  # Name files by film ID and frame number
+  meta1v thumbnail export data.efd thumbs/ --name '{{.FilmID}}-{{.Frame}}.{{.Ext}}'
+
+  # Overwrite existing files
+  meta1v t export data.efd thumbs/ --force`,
+		Args: cobra.ExactArgs(2), //nolint:mnd // source and target
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			opts, err := getOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.String("target_dir", args[1]),
+				slog.String("format", opts.Format),
+				slog.String("frames", opts.Frames.String()),
+				slog.String("where", opts.Where.String()),
+				slog.String("name", opts.Name),
+				slog.Bool("recover", recovery),
+				slog.Bool("force", opts.Force),
+			)
+
+			return uc.Export(ctx, args[0], args[1], opts, recovery)
+		},
+	}
+
+	cmd.Flags().String("format", thumbnail.FormatPNG, "image format (png, jpeg)")
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+	cmd.Flags().String("name", DefaultName, "file name template")
+	cmd.Flags().
+		BoolP("force", "F", false, "overwrite output files if they exist")
+
+	return cmd
+}
+
+func getOptions(cmd *cobra.Command) (Options, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetThumbnailFlags, err)
+	}
+
+	formats := []string{thumbnail.FormatPNG, thumbnail.FormatJPEG}
+	if !slices.Contains(formats, format) {
+		return Options{}, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	frames, err := cli.GetFrames(cmd)
+	if err != nil {
+		return Options{}, err
+	}
+
+	where, err := cli.GetWhere(cmd)
+	if err != nil {
+		return Options{}, err
+	}
+
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return Options{}, errors.Join(ErrFailedToGetThumbnailFlags, err)
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return Options{}, errors.Join(cli.ErrFailedToGetForceFlag, err)
+	}
+
+	return Options{
+		Format: format,
+		Frames: frames,
+		Where:  where,
+		Name:   name,
+		Force:  force,
+	}, nil
+}
@@ -25,21 +25,44 @@ import (
 	"log/slog"
 
 	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"github.com/spf13/cobra"
 )
 
 // UseCase defines the business logic for displaying embedded thumbnails from EFD files.
 type UseCase interface {
 	// DisplayThumbnails reads an EFD file and displays embedded thumbnails as ASCII art to stdout.
-	DisplayThumbnails(ctx context.Context, filename string, strict bool) error
+	// They are written as a JSON document instead if the output format is cli.OutputJSON,
+	// or through the output template if set. Only the frames matching where are displayed.
+	DisplayThumbnails(
+		ctx context.Context,
+		filename string,
+		strict bool,
+		recovery bool,
+		output cli.Output,
+		frames cli.FrameRanges,
+		where framefilter.Filter,
+	) error
 }
 
 func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list <filename>",
 		Short: "Display embedded thumbnails as ASCII art",
 		Long: `Display embedded thumbnail images as ASCII art, including the file path and 
-rendered ASCII representation.`,
+rendered ASCII representation.
+
+With --output json, a JSON document following the versioned schema described in
+the README is written instead, for use with tools such as jq. With --template,
+the roll is written through a Go text/template given inline or as a file; see the
+README for the helper functions available.
+
+With --frames, only the frames with the given numbers are listed, e.g. 1-5,12,30-
+where 30- runs to the end of the roll. Any missing frame of a closed range fails,
+as does a range like 30- without any frame.
+
+With --where, only the frames matching a filter expression are listed; see the
+README for the fields and operators available.`,
 		Example: `  # Display thumbnail information
   meta1v thumbnail list data.efd
 
@@ -47,7 +70,19 @@ rendered ASCII representation.`,
   meta1v thumbnail ls data.efd
 
   # With strict mode
-  meta1v t ls data.efd --strict`,
+  meta1v t ls data.efd --strict
+
+  # Frames 1 to 5, 12 and from 30 to the end of the roll
+  meta1v thumbnail ls data.efd --frames 1-5,12,30-
+
+  # Only frames shot after 18:00
+  meta1v t ls data.efd --where 'time >= 18:00'
+
+  # As JSON
+  meta1v t ls data.efd --output json
+
+  # Through a template
+  meta1v t ls data.efd --template '{{range .Frames}}{{with .Thumbnail}}{{.Filepath}}{{"\n"}}{{end}}{{end}}'`,
 		Aliases: []string{"ls"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -58,10 +93,48 @@ rendered ASCII representation.`,
 				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
 			}
 
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			output, err := cli.GetOutput(cmd, cli.OutputTable, cli.OutputJSON)
+			if err != nil {
+				return err
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
 			log.DebugContext(ctx, "arguments:",
-				slog.String("filename", args[0]))
+				slog.String("filename", args[0]),
+				slog.String("output", output.Format),
+				slog.String("template", output.Template),
+				slog.String("frames", frames.String()),
+				slog.String("where", where.String()))
 
-			return uc.DisplayThumbnails(ctx, args[0], strict)
+			return uc.DisplayThumbnails(
+				ctx,
+				args[0],
+				strict,
+				recovery,
+				output,
+				frames,
+				where,
+			)
 		},
 	}
+
+	cli.AddOutputFlags(cmd, cli.OutputTable, cli.OutputJSON)
+	cli.AddFramesFlag(cmd)
+	cli.AddWhereFlag(cmd)
+
+	return cmd
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/thumbnail/ls"
 	ls_test "github.com/ma-tf/meta1v/internal/cli/thumbnail/ls/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
 	"go.uber.org/mock/gomock"
 )
 
@@ -44,11 +45,12 @@ func Test_CommandRun(t *testing.T) {
 	}))
 
 	type testcase struct {
-		name           string
-		args           []string
-		registerStrict bool
-		expect         func(uc ls_test.MockUseCase, tt testcase)
-		expectedError  error
+		name            string
+		args            []string
+		registerStrict  bool
+		registerRecover bool
+		expect          func(uc ls_test.MockUseCase, tt testcase)
+		expectedError   error
 	}
 
 	tests := []testcase{
@@ -59,12 +61,95 @@ func Test_CommandRun(t *testing.T) {
 			expectedError:  cli.ErrFailedToGetStrictFlag,
 		},
 		{
-			name:           "successful execution",
-			args:           []string{"file.efd"},
-			registerStrict: true,
+			name:            "recover flag not registered",
+			args:            []string{"file.efd"},
+			registerStrict:  true,
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "successful execution",
+			args:            []string{"file.efd"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					DisplayThumbnails(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputTable},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "unsupported output",
+			args:            []string{"file.efd", "--output", "csv"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   cli.ErrUnsupportedOutput,
+		},
+		{
+			name:            "json output",
+			args:            []string{"file.efd", "--output", "json"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					DisplayThumbnails(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputJSON},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "template output",
+			args:            []string{"file.efd", "--template", "{{.FilmID}}"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					DisplayThumbnails(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{
+							Format:   cli.OutputTable,
+							Template: "{{.FilmID}}",
+						},
+						gomock.Nil(),
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name:            "filtered frames",
+			args:            []string{"file.efd", "--where", "flash == on"},
+			registerStrict:  true,
+			registerRecover: true,
 			expect: func(mockUseCase ls_test.MockUseCase, tt testcase) {
 				mockUseCase.EXPECT().
-					DisplayThumbnails(gomock.Any(), tt.args[0], gomock.Any()).
+					DisplayThumbnails(
+						gomock.Any(),
+						tt.args[0],
+						gomock.Any(),
+						gomock.Any(),
+						cli.Output{Format: cli.OutputTable},
+						gomock.Nil(),
+						mustParseFilter(t, "flash == on"),
+					).
 					Return(nil)
 			},
 		},
@@ -112,6 +197,10 @@ func Test_CommandRun(t *testing.T) {
 				cmd.Flags().Bool("strict", false, "enable strict mode")
 			}
 
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
 			cmd.SetArgs(tt.args)
 
 			err := cmd.Execute()
@@ -120,3 +209,14 @@ func Test_CommandRun(t *testing.T) {
 		})
 	}
 }
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
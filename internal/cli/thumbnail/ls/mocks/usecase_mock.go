@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	cli "github.com/ma-tf/meta1v/internal/cli"
+	framefilter "github.com/ma-tf/meta1v/internal/service/framefilter"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,15 +43,15 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 }
 
 // DisplayThumbnails mocks base method.
-func (m *MockUseCase) DisplayThumbnails(ctx context.Context, filename string, strict bool) error {
+func (m *MockUseCase) DisplayThumbnails(ctx context.Context, filename string, strict, recovery bool, output cli.Output, frames cli.FrameRanges, where framefilter.Filter) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DisplayThumbnails", ctx, filename, strict)
+	ret := m.ctrl.Call(m, "DisplayThumbnails", ctx, filename, strict, recovery, output, frames, where)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DisplayThumbnails indicates an expected call of DisplayThumbnails.
-func (mr *MockUseCaseMockRecorder) DisplayThumbnails(ctx, filename, strict any) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) DisplayThumbnails(ctx, filename, strict, recovery, output, frames, where any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisplayThumbnails", reflect.TypeOf((*MockUseCase)(nil).DisplayThumbnails), ctx, filename, strict)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisplayThumbnails", reflect.TypeOf((*MockUseCase)(nil).DisplayThumbnails), ctx, filename, strict, recovery, output, frames, where)
 }
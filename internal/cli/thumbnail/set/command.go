@@ -0,0 +1,128 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=set_test github.com/ma-tf/meta1v/internal/cli/thumbnail/set UseCase
+
+// Package set provides the CLI command for embedding an image as a frame's thumbnail.
+package set
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+const (
+	numArgs         = 3
+	frameArgIndex   = 1
+	imageArgIndex   = 2
+	frameNumberBits = 32
+)
+
+var (
+	ErrInvalidFrameNumber      = errors.New("invalid specified frame number")
+	ErrFailedToGetFilepathFlag = errors.New("failed to get filepath flag")
+)
+
+// UseCase defines the business logic for embedding thumbnails in EFD files.
+type UseCase interface {
+	// Set scales the image in imageFile down to a thumbnail and stores it in efdFile
+	// for the frame with the given frame number, together with linkedPath. An empty
+	// linkedPath stores the absolute path of imageFile.
+	Set(
+		ctx context.Context,
+		efdFile string,
+		frameNumber uint32,
+		imageFile string,
+		linkedPath string,
+		recovery bool,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <efd_file> <frame_number> <image_file>",
+		Short: "Embed an image as the thumbnail of a frame",
+		Long: `Link a scan to a frame the way Canon's software does: the image is scaled down to a
+thumbnail and stored in the EFD file together with the path of the image.
+
+PNG, JPEG and TIFF images are read. The image keeps its aspect ratio and is centred on a
+black thumbnail. A thumbnail the frame already has is replaced and keeps its size and its
+place in the file; a new thumbnail takes the size of the other thumbnails in the file. A
+file without any thumbnail is refused, as the size Canon's software uses is not known.
+All other bytes of the file are written back as they were.
+
+The file is rewritten atomically and the original is kept alongside it with a .bak
+extension; an existing .bak is never overwritten.`,
+		Example: `  # Embed the scan of frame 12
+  meta1v thumbnail set data.efd 12 scans/12.tif
+
+  # Record the path the scan has on another machine
+  meta1v thumbnail set data.efd 12 scans/12.tif --filepath 'D:\Scans\Roll 7\12.tif'`,
+		Args: cobra.ExactArgs(numArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			frameNumber, err := strconv.ParseUint(
+				args[frameArgIndex],
+				10, //nolint:mnd // decimal
+				frameNumberBits,
+			)
+			if err != nil {
+				return errors.Join(ErrInvalidFrameNumber, err)
+			}
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			linkedPath, err := cmd.Flags().GetString("filepath")
+			if err != nil {
+				return errors.Join(ErrFailedToGetFilepathFlag, err)
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.Uint64("frame_number", frameNumber),
+				slog.String("image_file", args[imageArgIndex]),
+				slog.String("filepath", linkedPath),
+				slog.Bool("recover", recovery),
+			)
+
+			return uc.Set(
+				ctx,
+				args[0],
+				uint32(frameNumber),
+				args[imageArgIndex],
+				linkedPath,
+				recovery,
+			)
+		},
+	}
+
+	cmd.Flags().String(
+		"filepath",
+		"",
+		"path to record for the image (default: absolute path of image_file)",
+	)
+
+	return cmd
+}
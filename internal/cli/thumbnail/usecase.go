@@ -20,17 +20,46 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"image"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
 
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/thumbnail/export"
 	"github.com/ma-tf/meta1v/internal/cli/thumbnail/ls"
+	"github.com/ma-tf/meta1v/internal/cli/thumbnail/set"
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/ma-tf/meta1v/internal/service/jsonexport"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/thumbnail"
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
 )
 
+const permission = 0o666 // rw-rw-rw-
+
 var (
-	ErrFailedToReadFile  = errors.New("failed to read file for thumbnails")
-	ErrFailedToParseFile = errors.New("failed to parse file for thumbnails")
+	ErrFailedToReadFile    = errors.New("failed to read file for thumbnails")
+	ErrFailedToParseFile   = errors.New("failed to parse file for thumbnails")
+	ErrTargetNotDirectory  = errors.New("target is not a directory")
+	ErrInvalidNameTemplate = errors.New("invalid file name template")
+	ErrInvalidFileName     = errors.New("invalid thumbnail file name")
+	ErrDuplicateFileName   = errors.New("file name used by more than one frame")
+	ErrFailedToCreateFile  = errors.New("failed to create thumbnail file")
+	ErrFailedToExport      = errors.New("failed to export thumbnail")
+	ErrFailedToSetFile     = errors.New("failed to set thumbnail in file")
+	ErrFailedToReadImage   = errors.New("failed to read image")
+	ErrFailedToList        = errors.New("failed to list thumbnails")
+	ErrNoThumbnailSize     = errors.New(
+		"no thumbnail in the file to take the thumbnail size from",
+	)
 )
 
 type usecase struct {
@@ -38,6 +67,8 @@ type usecase struct {
 	efdService             efd.Service
 	displayableRollFactory display.DisplayableRollFactory
 	displayService         display.Service
+	jsonService            jsonexport.Service
+	templateService        usertemplate.Service
 }
 
 func NewThumbnailListUseCase(
@@ -45,12 +76,16 @@ func NewThumbnailListUseCase(
 	efdService efd.Service,
 	displayableRollFactory display.DisplayableRollFactory,
 	displayService display.Service,
+	jsonService jsonexport.Service,
+	templateService usertemplate.Service,
 ) ls.UseCase {
 	return usecase{
 		log:                    log,
 		efdService:             efdService,
 		displayableRollFactory: displayableRollFactory,
 		displayService:         displayService,
+		jsonService:            jsonService,
+		templateService:        templateService,
 	}
 }
 
@@ -58,12 +93,32 @@ func (uc usecase) DisplayThumbnails(
 	ctx context.Context,
 	filename string,
 	strict bool,
+	recovery bool,
+	output cli.Output,
+	frames cli.FrameRanges,
+	where framefilter.Filter,
 ) error {
 	uc.log.InfoContext(ctx, "starting thumbnail display",
 		slog.String("file", filename),
-		slog.Bool("strict", strict))
+		slog.Bool("strict", strict),
+		slog.Bool("recover", recovery),
+		slog.String("output", output.Format),
+		slog.String("template", output.Template),
+		slog.String("frames", frames.String()),
+		slog.String("where", where.String()))
 
-	records, err := uc.efdService.RecordsFromFile(ctx, filename)
+	tmpl, err := cli.ParseTemplate(ctx, uc.templateService, output)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
+
+	records, err := cli.ReadRecords(
+		ctx,
+		uc.log,
+		uc.efdService,
+		filename,
+		recovery,
+	)
 	if err != nil {
 		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, filename, err)
 	}
@@ -76,12 +131,460 @@ func (uc usecase) DisplayThumbnails(
 		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
 	}
 
+	dr, err = frames.Select(dr)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, filename, err)
+	}
+
+	dr = where.Apply(dr)
+
 	uc.log.DebugContext(ctx, "displayable thumbnails created",
 		slog.Int("frame_count", len(dr.Frames)))
 
-	uc.displayService.DisplayThumbnails(ctx, os.Stdout, dr)
+	switch {
+	case tmpl != nil:
+		err = uc.templateService.Execute(ctx, os.Stdout, tmpl, dr)
+	case output.Format == cli.OutputJSON:
+		err = uc.jsonService.ExportThumbnails(ctx, os.Stdout, dr)
+	default:
+		uc.displayService.DisplayThumbnails(ctx, os.Stdout, dr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToList, err)
+	}
 
 	uc.log.InfoContext(ctx, "thumbnail display completed successfully")
 
 	return nil
 }
+
+type exportUseCase struct {
+	log              *slog.Logger
+	efdService       efd.Service
+	thumbnailService thumbnail.Service
+	fs               osfs.FileSystem
+	frameBuilder     display.Builder
+}
+
+func NewThumbnailExportUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	thumbnailService thumbnail.Service,
+	fs osfs.FileSystem,
+	frameBuilder display.Builder,
+) export.UseCase {
+	return exportUseCase{
+		log:              log,
+		efdService:       efdService,
+		thumbnailService: thumbnailService,
+		fs:               fs,
+		frameBuilder:     frameBuilder,
+	}
+}
+
+// nameData holds the fields available to the file name template.
+type nameData struct {
+	Roll   string
+	FilmID string
+	Frame  uint32
+	Index  uint16
+	Ext    string
+}
+
+// exportFile is a thumbnail to export and the file to write it to.
+type exportFile struct {
+	target string
+	frame  uint32
+	eftp   records.EFTP
+}
+
+func (uc exportUseCase) Export(
+	ctx context.Context,
+	efdFile string,
+	targetDir string,
+	opts export.Options,
+	recovery bool,
+) error {
+	uc.log.InfoContext(ctx, "starting thumbnail export",
+		slog.String("efd_file", efdFile),
+		slog.String("target_dir", targetDir),
+		slog.String("format", opts.Format),
+		slog.String("frames", opts.Frames.String()),
+		slog.String("where", opts.Where.String()),
+		slog.Bool("recover", recovery),
+		slog.Bool("force", opts.Force))
+
+	name, err := template.New("name").
+		Option("missingkey=error").
+		Parse(opts.Name)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidNameTemplate, opts.Name, err)
+	}
+
+	info, err := uc.fs.Stat(targetDir)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("%w: %q", ErrTargetNotDirectory, targetDir)
+	}
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	uc.log.DebugContext(ctx, "efd file read",
+		slog.Int("thumbnail_count", len(root.EFTPs)))
+
+	if err = opts.Frames.Check(root.EFRMs); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToParseFile, efdFile, err)
+	}
+
+	roll := strings.TrimSuffix(filepath.Base(efdFile), filepath.Ext(efdFile))
+
+	files, err := uc.plan(ctx, root.EFTPs, root.EFRMs, roll, name, opts)
+	if err != nil {
+		return err
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if opts.Force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	for _, f := range files {
+		target := filepath.Join(targetDir, f.target)
+
+		err = uc.write(ctx, target, flags, f.eftp.Thumbnail, opts)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stdout, "%s: frame %d, %dx%d\n",
+			target, f.frame, f.eftp.Width, f.eftp.Height)
+	}
+
+	uc.log.InfoContext(ctx, "thumbnail export completed successfully",
+		slog.Int("files", len(files)))
+
+	return nil
+}
+
+// plan works out the file name of every thumbnail to export before anything is
+// written, so a bad template or clashing names leave the target untouched.
+func (uc exportUseCase) plan(
+	ctx context.Context,
+	eftps []records.EFTP,
+	efrms []records.EFRM,
+	roll string,
+	name *template.Template,
+	opts export.Options,
+) ([]exportFile, error) {
+	files := make([]exportFile, 0, len(eftps))
+	seen := make(map[string]uint32, len(eftps))
+
+	for _, eftp := range eftps {
+		// Index is the 1-based position of the frame the thumbnail belongs to
+		if eftp.Index == 0 || int(eftp.Index) > len(efrms) {
+			uc.log.WarnContext(ctx, "skipping thumbnail without a frame",
+				slog.Int("index", int(eftp.Index)))
+
+			continue
+		}
+
+		efrm := efrms[eftp.Index-1]
+		if !opts.Frames.Contains(efrm.FrameNumber) {
+			continue
+		}
+
+		match, err := cli.MatchFrame(ctx, uc.frameBuilder, opts.Where, efrm)
+		if err != nil {
+			return nil, fmt.Errorf("%w: frame %d: %w",
+				ErrFailedToParseFile, efrm.FrameNumber, err)
+		}
+
+		if !match {
+			continue
+		}
+
+		fid, err := domain.NewFilmID(efrm.CodeA, efrm.CodeB)
+		if err != nil {
+			fid = ""
+		}
+
+		sb := &strings.Builder{}
+		if err = name.Execute(sb, nameData{
+			Roll:   roll,
+			FilmID: string(fid),
+			Frame:  efrm.FrameNumber,
+			Index:  eftp.Index,
+			Ext:    thumbnail.Extension(opts.Format),
+		}); err != nil {
+			return nil, fmt.Errorf("%w %q: %w",
+				ErrInvalidNameTemplate, opts.Name, err)
+		}
+
+		target := sb.String()
+		if target == "" || filepath.Base(target) != target {
+			return nil, fmt.Errorf("%w: %q for frame %d",
+				ErrInvalidFileName, target, efrm.FrameNumber)
+		}
+
+		if frame, ok := seen[target]; ok {
+			return nil, fmt.Errorf("%w: %q for frames %d and %d",
+				ErrDuplicateFileName, target, frame, efrm.FrameNumber)
+		}
+
+		seen[target] = efrm.FrameNumber
+		files = append(files, exportFile{
+			target: target,
+			frame:  efrm.FrameNumber,
+			eftp:   eftp,
+		})
+	}
+
+	return files, nil
+}
+
+func (uc exportUseCase) write(
+	ctx context.Context,
+	target string,
+	flags int,
+	img image.Image,
+	opts export.Options,
+) error {
+	file, err := uc.fs.OpenFile(target, flags, permission)
+	if err != nil {
+		if !opts.Force && errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%w: %q", cli.ErrOutputFileAlreadyExists, target)
+		}
+
+		return fmt.Errorf("%w %q: %w", ErrFailedToCreateFile, target, err)
+	}
+	defer file.Close()
+
+	err = uc.thumbnailService.Encode(ctx, file, img, opts.Format)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToExport, target, err)
+	}
+
+	return nil
+}
+
+type setUseCase struct {
+	log              *slog.Logger
+	efdService       efd.Service
+	thumbnailService thumbnail.Service
+	fs               osfs.FileSystem
+}
+
+func NewThumbnailSetUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	thumbnailService thumbnail.Service,
+	fs osfs.FileSystem,
+) set.UseCase {
+	return setUseCase{
+		log:              log,
+		efdService:       efdService,
+		thumbnailService: thumbnailService,
+		fs:               fs,
+	}
+}
+
+func (uc setUseCase) Set(
+	ctx context.Context,
+	efdFile string,
+	frameNumber uint32,
+	imageFile string,
+	linkedPath string,
+	recovery bool,
+) error {
+	uc.log.InfoContext(ctx, "starting thumbnail set",
+		slog.String("efd_file", efdFile),
+		slog.Uint64("frame_number", uint64(frameNumber)),
+		slog.String("image_file", imageFile),
+		slog.Bool("recover", recovery))
+
+	if linkedPath == "" {
+		abs, err := filepath.Abs(imageFile)
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", ErrFailedToReadImage, imageFile, err)
+		}
+
+		linkedPath = abs
+	}
+
+	fp, err := domain.EncodeFilepath(linkedPath)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToSetFile, efdFile, err)
+	}
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	i, err := cli.FindFrame(root.EFRMs, frameNumber)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToSetFile, efdFile, err)
+	}
+
+	// Index is the 1-based position of the frame the thumbnail belongs to
+	index := uint16(i + 1) //nolint:gosec // a roll has far fewer frames
+
+	eftp, err := newEFTP(root.EFTPs, index)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToSetFile, efdFile, err)
+	}
+
+	img, err := uc.readImage(ctx, imageFile)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadImage, imageFile, err)
+	}
+
+	eftp.Filepath = fp
+	eftp.Thumbnail = uc.thumbnailService.Fit(
+		ctx,
+		img,
+		int(eftp.Width),
+		int(eftp.Height),
+	)
+
+	setEFTP(&root, eftp)
+
+	backupFile, err := cli.RewriteRecords(
+		ctx,
+		uc.log,
+		uc.efdService,
+		uc.fs,
+		efdFile,
+		root,
+	)
+	if err != nil {
+		return err //nolint:wrapcheck // already descriptive
+	}
+
+	uc.log.InfoContext(ctx, "thumbnail set completed successfully",
+		slog.String("efd_file", efdFile),
+		slog.String("backup_file", backupFile))
+
+	return nil
+}
+
+func (uc setUseCase) readImage(
+	ctx context.Context,
+	imageFile string,
+) (image.Image, error) {
+	file, err := uc.fs.Open(imageFile)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by caller
+	}
+	defer file.Close()
+
+	//nolint:wrapcheck // wrapped by caller
+	return uc.thumbnailService.Decode(ctx, file)
+}
+
+// newEFTP returns the record for the thumbnail of the frame at index. An existing
+// thumbnail is reused so its size and unknown fields are kept, otherwise they are
+// taken from another thumbnail in the file. The size of a thumbnail is not known
+// for a file without any, so it fails then.
+func newEFTP(eftps []records.EFTP, index uint16) (records.EFTP, error) {
+	pos := slices.IndexFunc(eftps, func(e records.EFTP) bool {
+		return e.Index == index
+	})
+
+	switch {
+	case pos != -1:
+		return eftps[pos], nil
+	case len(eftps) > 0:
+		eftp := eftps[0]
+		eftp.Index = index
+
+		return eftp, nil
+	default:
+		return records.EFTP{}, ErrNoThumbnailSize
+	}
+}
+
+// setEFTP puts eftp into root as the thumbnail of its frame. It takes the place
+// of the frame's existing thumbnail, dropping any duplicates, so it is written
+// where that one was. A new thumbnail is inserted in frame order and written
+// right after the thumbnail of the frame before it.
+func setEFTP(root *records.Root, eftp records.EFTP) {
+	pos := slices.IndexFunc(root.EFTPs, func(e records.EFTP) bool {
+		return e.Index == eftp.Index
+	})
+	if pos != -1 {
+		root.EFTPs[pos] = eftp
+
+		for i := len(root.EFTPs) - 1; i > pos; i-- {
+			if root.EFTPs[i].Index == eftp.Index {
+				deleteEFTP(root, i)
+			}
+		}
+
+		return
+	}
+
+	pos = slices.IndexFunc(root.EFTPs, func(e records.EFTP) bool {
+		return e.Index > eftp.Index
+	})
+	if pos == -1 {
+		pos = len(root.EFTPs)
+	}
+
+	insertEFTP(root, pos, eftp)
+}
+
+// deleteEFTP removes the thumbnail at pos from root, along with its placement.
+func deleteEFTP(root *records.Root, pos int) {
+	root.EFTPs = slices.Delete(root.EFTPs, pos, pos+1)
+	root.Placements = slices.DeleteFunc(root.Placements,
+		func(p records.Placement) bool {
+			return p.Magic == records.MagicEFTP && p.Index == pos
+		})
+
+	for i, p := range root.Placements {
+		if p.Magic == records.MagicEFTP && p.Index > pos {
+			root.Placements[i].Index--
+		}
+	}
+}
+
+// insertEFTP inserts eftp into root at pos. It is placed after the thumbnail
+// before it in the file, or before the thumbnail after it if it is the first;
+// without either it is left unplaced and written at the end of the file.
+func insertEFTP(root *records.Root, pos int, eftp records.EFTP) {
+	root.EFTPs = slices.Insert(root.EFTPs, pos, eftp)
+
+	after, before := -1, -1
+
+	for i, p := range root.Placements {
+		if p.Magic != records.MagicEFTP {
+			continue
+		}
+
+		switch {
+		case p.Index == pos-1:
+			after = i
+		case p.Index >= pos:
+			if p.Index == pos {
+				before = i
+			}
+
+			root.Placements[i].Index++
+		}
+	}
+
+	at := before
+	if after != -1 {
+		at = after + 1
+	}
+
+	if at != -1 {
+		//nolint:exhaustruct // a new record has no header or trailing bytes
+		root.Placements = slices.Insert(root.Placements, at,
+			records.Placement{Magic: records.MagicEFTP, Index: pos})
+	}
+}
@@ -18,15 +18,34 @@ package thumbnail_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
+	"image"
+	"image/color"
+	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"text/template"
 
+	"github.com/ma-tf/meta1v/internal/cli"
 	"github.com/ma-tf/meta1v/internal/cli/thumbnail"
+	"github.com/ma-tf/meta1v/internal/cli/thumbnail/export"
+	"github.com/ma-tf/meta1v/internal/domain"
 	"github.com/ma-tf/meta1v/internal/records"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	display_test "github.com/ma-tf/meta1v/internal/service/display/mocks"
+	"github.com/ma-tf/meta1v/internal/service/efd"
 	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	jsonexport_test "github.com/ma-tf/meta1v/internal/service/jsonexport/mocks"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	thumbnail_service "github.com/ma-tf/meta1v/internal/service/thumbnail"
+	thumbnail_test "github.com/ma-tf/meta1v/internal/service/thumbnail/mocks"
+	usertemplate_test "github.com/ma-tf/meta1v/internal/service/usertemplate/mocks"
 	"go.uber.org/mock/gomock"
 )
 
@@ -195,12 +214,18 @@ func Test_List(t *testing.T) {
 				mockEFDService,
 				mockDisplayableRollFactory,
 				mockDisplayService,
+				jsonexport_test.NewMockService(ctrl),
+				usertemplate_test.NewMockService(ctrl),
 			)
 
 			err := uc.DisplayThumbnails(
 				ctx,
 				tt.filename,
 				tt.strict,
+				false,
+				cli.Output{Format: cli.OutputTable},
+				nil,
+				framefilter.Filter{},
 			)
 
 			if tt.expectedError != nil {
@@ -225,3 +250,885 @@ func Test_List(t *testing.T) {
 		})
 	}
 }
+
+func stat(t *testing.T, name string) os.FileInfo {
+	t.Helper()
+
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatalf("failed to stat %q: %v", name, err)
+	}
+
+	return info
+}
+
+//nolint:exhaustruct,funlen,maintidx // only partial is needed, table driven test
+func Test_Export(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	dirInfo := stat(t, dir)
+	fileInfo := stat(t, "usecase_test.go")
+
+	first := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	second := image.NewRGBA(image.Rect(0, 0, 1, 2))
+
+	// frame numbers differ from the thumbnail indexes, and one thumbnail has no frame
+	root := records.Root{
+		EFRMs: []records.EFRM{
+			{FrameNumber: 5, CodeA: 12, CodeB: 345},
+			{FrameNumber: 7, CodeA: 12, CodeB: 345},
+		},
+		EFTPs: []records.EFTP{
+			{Index: 1, Width: 2, Height: 1, Thumbnail: first},
+			{Index: 2, Width: 1, Height: 2, Thumbnail: second},
+			{Index: 9},
+		},
+	}
+
+	const (
+		create    = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		overwrite = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	)
+
+	type mocks struct {
+		efd       *efd_test.MockService
+		thumbnail *thumbnail_test.MockService
+		fs        *osfs_test.MockFileSystem
+		file      *osfs_test.MockFile
+		builder   *display_test.MockBuilder
+	}
+
+	readRoot := func(m mocks) {
+		m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+		m.efd.EXPECT().
+			RecordsFromFile(gomock.Any(), "in/roll.efd").
+			Return(root, nil)
+	}
+
+	tests := []struct {
+		name          string
+		opts          export.Options
+		expect        func(m mocks)
+		expectedError error
+	}{
+		{
+			name:          "unparsable name template",
+			opts:          export.Options{Name: "{{.Frame"},
+			expect:        func(mocks) {},
+			expectedError: thumbnail.ErrInvalidNameTemplate,
+		},
+		{
+			name: "target is not a directory",
+			opts: export.Options{Name: export.DefaultName},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(fileInfo, nil)
+			},
+			expectedError: thumbnail.ErrTargetNotDirectory,
+		},
+		{
+			name: "failed to read file",
+			opts: export.Options{Name: export.DefaultName},
+			expect: func(m mocks) {
+				m.fs.EXPECT().Stat(dir).Return(dirInfo, nil)
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "in/roll.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: thumbnail.ErrFailedToReadFile,
+		},
+		{
+			name:          "unknown template field",
+			opts:          export.Options{Name: "{{.Title}}"},
+			expect:        readRoot,
+			expectedError: thumbnail.ErrInvalidNameTemplate,
+		},
+		{
+			name:          "name outside the target directory",
+			opts:          export.Options{Name: "../{{.Frame}}.png"},
+			expect:        readRoot,
+			expectedError: thumbnail.ErrInvalidFileName,
+		},
+		{
+			name:          "same name for two frames",
+			opts:          export.Options{Name: "{{.FilmID}}.png"},
+			expect:        readRoot,
+			expectedError: thumbnail.ErrDuplicateFileName,
+		},
+		{
+			name: "file exists",
+			opts: export.Options{
+				Format: thumbnail_service.FormatPNG,
+				Name:   export.DefaultName,
+			},
+			expect: func(m mocks) {
+				readRoot(m)
+				m.fs.EXPECT().
+					OpenFile(filepath.Join(dir, "roll_05.png"), create, gomock.Any()).
+					Return(nil, os.ErrExist)
+			},
+			expectedError: cli.ErrOutputFileAlreadyExists,
+		},
+		{
+			name: "failed to encode",
+			opts: export.Options{
+				Format: thumbnail_service.FormatPNG,
+				Name:   export.DefaultName,
+			},
+			expect: func(m mocks) {
+				readRoot(m)
+				m.fs.EXPECT().
+					OpenFile(filepath.Join(dir, "roll_05.png"), create, gomock.Any()).
+					Return(m.file, nil)
+				m.thumbnail.EXPECT().
+					Encode(gomock.Any(), m.file, first, thumbnail_service.FormatPNG).
+					Return(errExample)
+				m.file.EXPECT().Close().Return(nil)
+			},
+			expectedError: thumbnail.ErrFailedToExport,
+		},
+		{
+			name: "selected frames overwriting existing files",
+			opts: export.Options{
+				Format: thumbnail_service.FormatJPEG,
+				Frames: []domain.FrameRange{{First: 7, Last: 7}},
+				Name:   "{{.FilmID}}_{{.Frame}}_{{.Index}}.{{.Ext}}",
+				Force:  true,
+			},
+			expect: func(m mocks) {
+				readRoot(m)
+				m.fs.EXPECT().
+					OpenFile(
+						filepath.Join(dir, "12-345_7_2.jpg"),
+						overwrite,
+						gomock.Any(),
+					).
+					Return(m.file, nil)
+				m.thumbnail.EXPECT().
+					Encode(gomock.Any(), m.file, second, thumbnail_service.FormatJPEG).
+					Return(nil)
+				m.file.EXPECT().Close().Return(nil)
+			},
+		},
+		{
+			name: "frames not in the roll",
+			opts: export.Options{
+				Format: thumbnail_service.FormatPNG,
+				Frames: []domain.FrameRange{{First: 30, Open: true}},
+				Name:   export.DefaultName,
+			},
+			expect:        readRoot,
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+		{
+			name: "failed to decode frame for filter",
+			opts: export.Options{
+				Format: thumbnail_service.FormatPNG,
+				Where:  mustParseFilter(t, "flash == OFF"),
+				Name:   export.DefaultName,
+			},
+			expect: func(m mocks) {
+				readRoot(m)
+				m.builder.EXPECT().
+					Build(gomock.Any(), root.EFRMs[0], gomock.Nil(), false).
+					Return(display.DisplayableFrame{}, errExample)
+			},
+			expectedError: thumbnail.ErrFailedToParseFile,
+		},
+		{
+			name: "frames matching the filter",
+			opts: export.Options{
+				Format: thumbnail_service.FormatPNG,
+				Where:  mustParseFilter(t, "flash == OFF"),
+				Name:   export.DefaultName,
+			},
+			expect: func(m mocks) {
+				readRoot(m)
+				m.builder.EXPECT().
+					Build(gomock.Any(), root.EFRMs[0], gomock.Nil(), false).
+					Return(display.DisplayableFrame{FlashMode: "E-TTL"}, nil)
+				m.builder.EXPECT().
+					Build(gomock.Any(), root.EFRMs[1], gomock.Nil(), false).
+					Return(display.DisplayableFrame{FlashMode: "OFF"}, nil)
+				m.fs.EXPECT().
+					OpenFile(filepath.Join(dir, "roll_07.png"), create, gomock.Any()).
+					Return(m.file, nil)
+				m.thumbnail.EXPECT().
+					Encode(gomock.Any(), m.file, second, thumbnail_service.FormatPNG).
+					Return(nil)
+				m.file.EXPECT().Close().Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				efd:       efd_test.NewMockService(ctrl),
+				thumbnail: thumbnail_test.NewMockService(ctrl),
+				fs:        osfs_test.NewMockFileSystem(ctrl),
+				file:      osfs_test.NewMockFile(ctrl),
+				builder:   display_test.NewMockBuilder(ctrl),
+			}
+			tt.expect(m)
+
+			uc := thumbnail.NewThumbnailExportUseCase(
+				newTestLogger(),
+				m.efd,
+				m.thumbnail,
+				m.fs,
+				m.builder,
+			)
+
+			err := uc.Export(t.Context(), "in/roll.efd", dir, tt.opts, false)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct,funlen,maintidx // only partial is needed, table driven test
+func Test_Set(t *testing.T) {
+	t.Parallel()
+
+	scan := image.NewRGBA(image.Rect(0, 0, 30, 20))
+	fitted := image.NewRGBA(image.Rect(0, 0, 4, 3))
+
+	linked := `D:\scans\7.tif`
+	fp := [256]byte{}
+	copy(fp[:], linked)
+
+	frames := []records.EFRM{
+		{FrameNumber: 5},
+		{FrameNumber: 7},
+		{FrameNumber: 9},
+	}
+
+	const (
+		backupFlags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		tempFile    = "roll.efd.tmp"
+	)
+
+	type mocks struct {
+		efd       *efd_test.MockService
+		thumbnail *thumbnail_test.MockService
+		fs        *osfs_test.MockFileSystem
+		image     *osfs_test.MockFile
+		original  *osfs_test.MockFile
+		backup    *osfs_test.MockFile
+	}
+
+	decodeScan := func(m mocks) {
+		m.fs.EXPECT().Open("scan.tif").Return(m.image, nil)
+		m.thumbnail.EXPECT().Decode(gomock.Any(), m.image).Return(scan, nil)
+		m.image.EXPECT().Close().Return(nil)
+	}
+
+	tests := []struct {
+		name          string
+		frameNumber   uint32
+		linkedPath    string
+		expect        func(m mocks)
+		expectedError error
+	}{
+		{
+			name:          "path too long",
+			frameNumber:   7,
+			linkedPath:    strings.Repeat("p", 256),
+			expect:        func(mocks) {},
+			expectedError: domain.ErrTextTooLong,
+		},
+		{
+			name:        "failed to read file",
+			frameNumber: 7,
+			linkedPath:  linked,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{}, errExample)
+			},
+			expectedError: thumbnail.ErrFailedToReadFile,
+		},
+		{
+			name:        "frame not found",
+			frameNumber: 6,
+			linkedPath:  linked,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{EFRMs: frames}, nil)
+			},
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+		{
+			name:        "failed to decode image",
+			frameNumber: 7,
+			linkedPath:  linked,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{{Index: 2, Width: 4, Height: 3}},
+					}, nil)
+				m.fs.EXPECT().Open("scan.tif").Return(m.image, nil)
+				m.thumbnail.EXPECT().
+					Decode(gomock.Any(), m.image).
+					Return(nil, errExample)
+				m.image.EXPECT().Close().Return(nil)
+			},
+			expectedError: thumbnail.ErrFailedToReadImage,
+		},
+		{
+			name:        "replaces the thumbnail of the frame",
+			frameNumber: 7,
+			linkedPath:  linked,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{
+							{Index: 1, Width: 8, Height: 6},
+							{Index: 2, Unknown1: 3, Width: 4, Height: 3},
+							{Index: 3, Width: 8, Height: 6},
+						},
+					}, nil)
+				decodeScan(m)
+				m.thumbnail.EXPECT().
+					Fit(gomock.Any(), scan, 4, 3).
+					Return(fitted)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), tempFile, records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{
+							{Index: 1, Width: 8, Height: 6},
+							{
+								Index:     2,
+								Unknown1:  3,
+								Width:     4,
+								Height:    3,
+								Filepath:  fp,
+								Thumbnail: fitted,
+							},
+							{Index: 3, Width: 8, Height: 6},
+						},
+					}).
+					Return(errExample)
+				m.fs.EXPECT().Remove(tempFile).Return(nil)
+			},
+			expectedError: cli.ErrFailedToWriteFile,
+		},
+		{
+			name:        "adds a thumbnail sized like the others",
+			frameNumber: 7,
+			linkedPath:  linked,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{
+							{Index: 3, Unknown1: 3, Width: 4, Height: 3},
+						},
+					}, nil)
+				decodeScan(m)
+				m.thumbnail.EXPECT().
+					Fit(gomock.Any(), scan, 4, 3).
+					Return(fitted)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), tempFile, records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{
+							{
+								Index:     2,
+								Unknown1:  3,
+								Width:     4,
+								Height:    3,
+								Filepath:  fp,
+								Thumbnail: fitted,
+							},
+							{Index: 3, Unknown1: 3, Width: 4, Height: 3},
+						},
+					}).
+					Return(nil)
+				m.fs.EXPECT().Stat("roll.efd.bak").Return(nil, os.ErrNotExist)
+				m.fs.EXPECT().Open("roll.efd").Return(m.original, nil)
+				m.fs.EXPECT().
+					OpenFile("roll.efd.bak", backupFlags, gomock.Any()).
+					Return(m.backup, nil)
+				m.original.EXPECT().Read(gomock.Any()).Return(0, io.EOF)
+				m.original.EXPECT().Close().Return(nil)
+				m.backup.EXPECT().Close().Return(nil)
+				m.fs.EXPECT().Rename(tempFile, "roll.efd").Return(nil)
+			},
+		},
+		{
+			name:        "keeps the place of the thumbnail in the file",
+			frameNumber: 7,
+			linkedPath:  linked,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{
+							{Index: 2, Width: 4, Height: 3},
+							{Index: 3, Width: 4, Height: 3},
+							{Index: 2, Width: 4, Height: 3},
+						},
+						Placements: []records.Placement{
+							{Magic: records.MagicEFDF},
+							{Magic: records.MagicEFTP, Index: 0},
+							{Magic: records.MagicEFTP, Index: 2},
+							{Magic: records.MagicEFTP, Index: 1},
+						},
+					}, nil)
+				decodeScan(m)
+				m.thumbnail.EXPECT().
+					Fit(gomock.Any(), scan, 4, 3).
+					Return(fitted)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), tempFile, records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{
+							{
+								Index:     2,
+								Width:     4,
+								Height:    3,
+								Filepath:  fp,
+								Thumbnail: fitted,
+							},
+							{Index: 3, Width: 4, Height: 3},
+						},
+						Placements: []records.Placement{
+							{Magic: records.MagicEFDF},
+							{Magic: records.MagicEFTP, Index: 0},
+							{Magic: records.MagicEFTP, Index: 1},
+						},
+					}).
+					Return(errExample)
+				m.fs.EXPECT().Remove(tempFile).Return(nil)
+			},
+			expectedError: cli.ErrFailedToWriteFile,
+		},
+		{
+			name:        "places a new thumbnail after the one before it",
+			frameNumber: 7,
+			linkedPath:  linked,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{
+							{Index: 1, Width: 4, Height: 3},
+							{Index: 3, Width: 4, Height: 3},
+						},
+						Placements: []records.Placement{
+							{Magic: records.MagicEFDF},
+							{Magic: records.MagicEFRM, Index: 0},
+							{Magic: records.MagicEFTP, Index: 0},
+							{Magic: records.MagicEFRM, Index: 1},
+							{Magic: records.MagicEFRM, Index: 2},
+							{Magic: records.MagicEFTP, Index: 1},
+						},
+					}, nil)
+				decodeScan(m)
+				m.thumbnail.EXPECT().
+					Fit(gomock.Any(), scan, 4, 3).
+					Return(fitted)
+				m.efd.EXPECT().
+					RecordsToFile(gomock.Any(), tempFile, records.Root{
+						EFRMs: frames,
+						EFTPs: []records.EFTP{
+							{Index: 1, Width: 4, Height: 3},
+							{
+								Index:     2,
+								Width:     4,
+								Height:    3,
+								Filepath:  fp,
+								Thumbnail: fitted,
+							},
+							{Index: 3, Width: 4, Height: 3},
+						},
+						Placements: []records.Placement{
+							{Magic: records.MagicEFDF},
+							{Magic: records.MagicEFRM, Index: 0},
+							{Magic: records.MagicEFTP, Index: 0},
+							{Magic: records.MagicEFTP, Index: 1},
+							{Magic: records.MagicEFRM, Index: 1},
+							{Magic: records.MagicEFRM, Index: 2},
+							{Magic: records.MagicEFTP, Index: 2},
+						},
+					}).
+					Return(errExample)
+				m.fs.EXPECT().Remove(tempFile).Return(nil)
+			},
+			expectedError: cli.ErrFailedToWriteFile,
+		},
+		{
+			name:        "no thumbnail to take the size from",
+			frameNumber: 9,
+			linkedPath:  linked,
+			expect: func(m mocks) {
+				m.efd.EXPECT().
+					RecordsFromFile(gomock.Any(), "roll.efd").
+					Return(records.Root{EFRMs: frames}, nil)
+			},
+			expectedError: thumbnail.ErrNoThumbnailSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				efd:       efd_test.NewMockService(ctrl),
+				thumbnail: thumbnail_test.NewMockService(ctrl),
+				fs:        osfs_test.NewMockFileSystem(ctrl),
+				image:     osfs_test.NewMockFile(ctrl),
+				original:  osfs_test.NewMockFile(ctrl),
+				backup:    osfs_test.NewMockFile(ctrl),
+			}
+			tt.expect(m)
+
+			uc := thumbnail.NewThumbnailSetUseCase(
+				newTestLogger(),
+				m.efd,
+				m.thumbnail,
+				m.fs,
+			)
+
+			err := uc.Set(
+				t.Context(),
+				"roll.efd",
+				tt.frameNumber,
+				"scan.tif",
+				tt.linkedPath,
+				false,
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_ThumbnailListUseCase_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           error
+		expectedError error
+	}{
+		{
+			name:          "successfully write JSON",
+			err:           nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to write JSON",
+			err:           errExample,
+			expectedError: thumbnail.ErrFailedToList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockJSONService := jsonexport_test.NewMockService(ctrl)
+
+			mockEFDService.EXPECT().
+				RecordsFromFile(gomock.Any(), "file.efd").
+				Return(root, nil)
+			mockDisplayableRollFactory.EXPECT().
+				Create(gomock.Any(), root, false).
+				Return(dr, nil)
+			mockJSONService.EXPECT().
+				ExportThumbnails(gomock.Any(), gomock.Any(), dr).
+				Return(tt.err)
+
+			uc := thumbnail.NewThumbnailListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				mockJSONService,
+				usertemplate_test.NewMockService(ctrl),
+			)
+
+			err := uc.DisplayThumbnails(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				cli.Output{Format: cli.OutputJSON},
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_ThumbnailListUseCase_Template(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		parseErr      error
+		executeErr    error
+		expectedError error
+	}{
+		{
+			name:          "successfully execute template",
+			parseErr:      nil,
+			executeErr:    nil,
+			expectedError: nil,
+		},
+		{
+			name:          "failed to parse template",
+			parseErr:      errExample,
+			executeErr:    nil,
+			expectedError: thumbnail.ErrFailedToList,
+		},
+		{
+			name:          "failed to execute template",
+			parseErr:      nil,
+			executeErr:    errExample,
+			expectedError: thumbnail.ErrFailedToList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := records.Root{EFDF: records.EFDF{FrameCount: 1}}
+			dr := display.DisplayableRoll{FrameCount: "1"}
+
+			mockEFDService := efd_test.NewMockService(ctrl)
+			mockDisplayableRollFactory := display_test.NewMockDisplayableRollFactory(
+				ctrl,
+			)
+			mockTemplateService := usertemplate_test.NewMockService(ctrl)
+			tmpl := template.Must(template.New("template").Parse("{{.FilmID}}"))
+
+			mockTemplateService.EXPECT().
+				Parse(gomock.Any(), "{{.FilmID}}").
+				Return(tmpl, tt.parseErr)
+
+			if tt.parseErr == nil {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), "file.efd").
+					Return(root, nil)
+				mockDisplayableRollFactory.EXPECT().
+					Create(gomock.Any(), root, false).
+					Return(dr, nil)
+				mockTemplateService.EXPECT().
+					Execute(gomock.Any(), gomock.Any(), tmpl, dr).
+					Return(tt.executeErr)
+			}
+
+			uc := thumbnail.NewThumbnailListUseCase(newTestLogger(),
+				mockEFDService,
+				mockDisplayableRollFactory,
+				display_test.NewMockService(ctrl),
+				jsonexport_test.NewMockService(ctrl),
+				mockTemplateService,
+			)
+
+			err := uc.DisplayThumbnails(
+				t.Context(),
+				"file.efd",
+				false,
+				false,
+				cli.Output{
+					Format:   cli.OutputTable,
+					Template: "{{.FilmID}}",
+				},
+				nil,
+				framefilter.Filter{},
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("unexpected error: got %v, want %v",
+					err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
+
+// Test_Set_OnlyThumbnailBytes sets the thumbnail of a frame in a file whose
+// records carry header and trailing bytes, and checks that only the path and
+// pixels of the thumbnail changed.
+//
+//nolint:exhaustruct // for records
+func Test_Set_OnlyThumbnailBytes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := newTestLogger()
+	fs := osfs.NewFileSystem()
+	efdService := efd.NewService(
+		log,
+		efd.NewRootBuilder(log),
+		efd.NewReader(log, records.NewDefaultThumbnailFactory()),
+		efd.NewWriter(log),
+		fs,
+	)
+
+	efdf := encodeRecord(t, records.MagicEFDF, [4]byte{1, 0, 2, 0},
+		records.EFDF{FrameCount: 2}, nil)
+	efrm1 := encodeRecord(t, records.MagicEFRM, [4]byte{3, 4, 5, 6},
+		records.EFRM{FrameNumber: 1}, []byte{0x99})
+	eftp := encodeRecord(t, records.MagicEFTP, [4]byte{7, 8, 9, 10},
+		struct {
+			Index, Unknown, Width, Height uint16
+			Unknown3                      [8]byte
+			Filepath                      [256]byte
+			Pixels                        [3]byte
+		}{Index: 1, Width: 1, Height: 1, Filepath: [256]byte{'o', 'l', 'd'}},
+		[]byte{0x77, 0x88})
+	efrm2 := encodeRecord(t, records.MagicEFRM, [4]byte{},
+		records.EFRM{FrameNumber: 2}, nil)
+
+	original := bytes.Join([][]byte{efdf, efrm1, eftp, efrm2}, nil)
+
+	dir := t.TempDir()
+	efdFile := filepath.Join(dir, "roll.efd")
+
+	if err := os.WriteFile(efdFile, original, 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	scanFile := filepath.Join(dir, "scan.png")
+	if err := os.WriteFile(scanFile, nil, 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	scan := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	fitted := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	fitted.SetRGBA(0, 0, color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xFF})
+
+	mockThumbnail := thumbnail_test.NewMockService(ctrl)
+	mockThumbnail.EXPECT().Decode(gomock.Any(), gomock.Any()).Return(scan, nil)
+	mockThumbnail.EXPECT().Fit(gomock.Any(), scan, 1, 1).Return(fitted)
+
+	uc := thumbnail.NewThumbnailSetUseCase(log, efdService, mockThumbnail, fs)
+
+	err := uc.Set(t.Context(), efdFile, 1, scanFile, `D:\new.tif`, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	eftpOffset := len(efdf) + len(efrm1)
+
+	expected := bytes.Clone(original)
+	filepathOffset := eftpOffset + fieldOffset(t, records.MagicEFTP, "Filepath")
+	copy(expected[filepathOffset:filepathOffset+256], make([]byte, 256))
+	copy(expected[filepathOffset:], `D:\new.tif`)
+	copy(expected[eftpOffset+fieldOffset(t, records.MagicEFTP, "Pixels"):],
+		[]byte{0x30, 0x20, 0x10})
+
+	got, err := os.ReadFile(efdFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(got, expected) {
+		for i := range min(len(got), len(expected)) {
+			if got[i] != expected[i] {
+				t.Fatalf("unexpected change at byte %d: expected %#x, got %#x",
+					i, expected[i], got[i])
+			}
+		}
+
+		t.Fatalf("expected %d bytes, got %d", len(expected), len(got))
+	}
+}
+
+// encodeRecord encodes data as a record with the given header bytes, followed
+// by trailing.
+func encodeRecord(
+	t *testing.T,
+	magic string,
+	unknown [4]byte,
+	data any,
+	trailing []byte,
+) []byte {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	if err := binary.Write(body, binary.LittleEndian, data); err != nil {
+		t.Fatalf("failed to encode %s record: %v", magic, err)
+	}
+
+	_, _ = body.Write(trailing)
+
+	buf := &bytes.Buffer{}
+	_, _ = buf.WriteString(magic)
+	_, _ = buf.Write(unknown[:])
+	_ = binary.Write(buf, binary.LittleEndian,
+		uint64(records.HeaderSize+body.Len()))
+	_, _ = buf.Write(body.Bytes())
+
+	return buf.Bytes()
+}
+
+// fieldOffset returns the offset of the named field from the start of a record.
+func fieldOffset(t *testing.T, magic, name string) int {
+	t.Helper()
+
+	for _, f := range records.Layout(magic) {
+		if f.Name == name {
+			return f.Offset
+		}
+	}
+
+	t.Fatalf("no field %q in %s records", name, magic)
+
+	return 0
+}
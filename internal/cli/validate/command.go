@@ -0,0 +1,119 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=validate_test github.com/ma-tf/meta1v/internal/cli/validate UseCase
+
+// Package validate provides the CLI command for checking the consistency of a roll in an EFD file.
+package validate
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes reported when the roll has problems. Failing to read the file
+// exits with status 1 like every other command.
+const (
+	ExitCodeWarnings = 2
+	ExitCodeErrors   = 3
+)
+
+// UseCase defines the business logic for validating EFD files.
+type UseCase interface {
+	// Validate checks an EFD file and prints its findings. It returns ErrRollHasErrors
+	// or ErrRollHasWarnings according to the most serious finding. The whole roll
+	// is checked, but only the findings about the roll and the frames within frames
+	// are reported.
+	Validate(
+		ctx context.Context,
+		efdFile string,
+		recovery bool,
+		frames cli.FrameRanges,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate <efd_file>",
+		Short: "Check an EFD file for inconsistencies across the roll",
+		Long: `Check the records of an EFD file against each other and list any problems found:
+duplicate or missing frame numbers, frames whose film ID or film load date differ from
+the roll, frames taken before the frame preceding them, more frames than the roll counts,
+thumbnails pointing at frames that do not exist, and frames modified after recording.
+
+Each finding has a severity of error, warning or info. The exit status is 0 when there
+are no errors or warnings, 1 when the file cannot be read, 2 when the most serious
+finding is a warning, and 3 when there is at least one error.
+
+With --frames, the whole roll is still checked but only the findings about the frames
+with the given numbers are reported, along with those about the roll itself, e.g.
+1-5,12,30- where 30- runs to the end of the roll. Any missing frame of a closed range
+fails, as does a range like 30- without any frame.`,
+		Example: `  # Check a roll
+  meta1v validate data.efd
+
+  # Check a damaged roll, skipping unreadable records
+  meta1v validate data.efd --recover
+
+  # Only report problems with frames 1 to 5 and the roll itself
+  meta1v validate data.efd --frames 1-5
+
+  # Use the exit status in a script
+  meta1v validate data.efd > /dev/null || echo "needs attention"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			frames, err := cli.GetFrames(cmd)
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "arguments:",
+				slog.String("efd_file", args[0]),
+				slog.Bool("recover", recovery),
+				slog.String("frames", frames.String()),
+			)
+
+			// findings are already printed, usage would only bury them
+			cmd.SilenceUsage = true
+
+			err = uc.Validate(ctx, args[0], recovery, frames)
+
+			switch {
+			case errors.Is(err, ErrRollHasErrors):
+				return &cli.ExitError{Code: ExitCodeErrors, Err: err}
+			case errors.Is(err, ErrRollHasWarnings):
+				return &cli.ExitError{Code: ExitCodeWarnings, Err: err}
+			default:
+				return err
+			}
+		},
+	}
+
+	cli.AddFramesFlag(cmd)
+
+	return cmd
+}
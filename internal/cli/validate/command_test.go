@@ -0,0 +1,177 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/validate"
+	validate_test "github.com/ma-tf/meta1v/internal/cli/validate/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct // only partial is needed
+func Test_NewCommand(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name             string
+		args             []string
+		registerRecover  bool
+		expect           func(mockUseCase *validate_test.MockUseCase, tc testcase)
+		expectedError    error
+		expectedExitCode int
+	}
+
+	tests := []testcase{
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd"},
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "failed to read file",
+			args:            []string{"file.efd"},
+			registerRecover: true,
+			expect: func(mockUseCase *validate_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Validate(gomock.Any(), tc.args[0], false, cli.FrameRanges(nil)).
+					Return(validate.ErrFailedToReadFile)
+			},
+			expectedError: validate.ErrFailedToReadFile,
+		},
+		{
+			name:            "roll has errors",
+			args:            []string{"file.efd"},
+			registerRecover: true,
+			expect: func(mockUseCase *validate_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Validate(gomock.Any(), tc.args[0], false, cli.FrameRanges(nil)).
+					Return(validate.ErrRollHasErrors)
+			},
+			expectedError:    validate.ErrRollHasErrors,
+			expectedExitCode: validate.ExitCodeErrors,
+		},
+		{
+			name:            "roll has warnings",
+			args:            []string{"file.efd"},
+			registerRecover: true,
+			expect: func(mockUseCase *validate_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Validate(gomock.Any(), tc.args[0], false, cli.FrameRanges(nil)).
+					Return(validate.ErrRollHasWarnings)
+			},
+			expectedError:    validate.ErrRollHasWarnings,
+			expectedExitCode: validate.ExitCodeWarnings,
+		},
+		{
+			name:            "invalid frames",
+			args:            []string{"file.efd", "--frames", "0"},
+			registerRecover: true,
+			expectedError:   cli.ErrInvalidFrameRange,
+		},
+		{
+			name:            "selected frames",
+			args:            []string{"file.efd", "--frames", "1-5,12"},
+			registerRecover: true,
+			expect: func(mockUseCase *validate_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Validate(gomock.Any(), tc.args[0], false, cli.FrameRanges{
+						{First: 1, Last: 5},
+						{First: 12, Last: 12},
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name:            "valid roll",
+			args:            []string{"file.efd"},
+			registerRecover: true,
+			expect: func(mockUseCase *validate_test.MockUseCase, tc testcase) {
+				mockUseCase.EXPECT().
+					Validate(gomock.Any(), tc.args[0], false, cli.FrameRanges(nil)).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := validate_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase, tt)
+			}
+
+			cmd := validate.NewCommand(logger, mockUseCase)
+			cmd.SilenceErrors = true
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			var exitErr *cli.ExitError
+
+			exitCode := 0
+			if errors.As(err, &exitErr) {
+				exitCode = exitErr.Code
+			}
+
+			if exitCode != tt.expectedExitCode {
+				t.Fatalf("expected exit code %d, got %d",
+					tt.expectedExitCode, exitCode)
+			}
+		})
+	}
+}
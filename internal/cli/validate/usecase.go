@@ -0,0 +1,103 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/validate"
+)
+
+var (
+	ErrFailedToReadFile = errors.New("failed to read EFD file")
+	ErrRollHasErrors    = errors.New("roll has errors")
+	ErrRollHasWarnings  = errors.New("roll has warnings")
+)
+
+type validateUseCase struct {
+	log             *slog.Logger
+	efdService      efd.Service
+	validateService validate.Service
+}
+
+func NewUseCase(
+	log *slog.Logger,
+	efdService efd.Service,
+	validateService validate.Service,
+) UseCase {
+	return validateUseCase{
+		log:             log,
+		efdService:      efdService,
+		validateService: validateService,
+	}
+}
+
+func (uc validateUseCase) Validate(
+	ctx context.Context,
+	efdFile string,
+	recovery bool,
+	frames cli.FrameRanges,
+) error {
+	uc.log.InfoContext(ctx, "starting validation",
+		slog.String("efd_file", efdFile),
+		slog.Bool("recover", recovery),
+		slog.String("frames", frames.String()))
+
+	root, err := cli.ReadRecords(ctx, uc.log, uc.efdService, efdFile, recovery)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	if err = frames.Check(root.EFRMs); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToReadFile, efdFile, err)
+	}
+
+	findings := uc.validateService.Validate(ctx, root)
+	if frames != nil {
+		findings = slices.DeleteFunc(findings, func(f validate.Finding) bool {
+			return f.Frame != 0 && !frames.Contains(f.Frame)
+		})
+	}
+
+	uc.validateService.Report(ctx, os.Stdout, findings)
+
+	uc.log.InfoContext(ctx, "validation completed",
+		slog.String("efd_file", efdFile),
+		slog.Int("finding_count", len(findings)))
+
+	highest, ok := validate.Highest(findings)
+	if !ok {
+		return nil
+	}
+
+	switch highest {
+	case validate.SeverityError:
+		return fmt.Errorf("%w: %q", ErrRollHasErrors, efdFile)
+	case validate.SeverityWarning:
+		return fmt.Errorf("%w: %q", ErrRollHasWarnings, efdFile)
+	case validate.SeverityInfo:
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/validate"
+	"github.com/ma-tf/meta1v/internal/records"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	validatesvc "github.com/ma-tf/meta1v/internal/service/validate"
+	validatesvc_test "github.com/ma-tf/meta1v/internal/service/validate/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Validate(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name     string
+		efdFile  string
+		recovery bool
+		frames   cli.FrameRanges
+		root     records.Root
+		findings []validatesvc.Finding
+		reported []validatesvc.Finding // findings reported, if not all of them
+		expect   func(
+			efdTestMock efd_test.MockService,
+			validateTestMock validatesvc_test.MockService,
+			tc testcase,
+		)
+		expectedError error
+	}
+
+	expectValidate := func(
+		mockEFDService efd_test.MockService,
+		mockValidateService validatesvc_test.MockService,
+		tt testcase,
+	) {
+		mockEFDService.EXPECT().
+			RecordsFromFile(gomock.Any(), tt.efdFile).
+			Return(tt.root, nil)
+
+		mockValidateService.EXPECT().
+			Validate(gomock.Any(), tt.root).
+			Return(tt.findings)
+
+		reported := tt.findings
+		if tt.reported != nil {
+			reported = tt.reported
+		}
+
+		mockValidateService.EXPECT().
+			Report(gomock.Any(), gomock.Any(), reported)
+	}
+
+	tests := []testcase{
+		{
+			name:    "failed to read file",
+			efdFile: "file.efd",
+			expect: func(
+				mockEFDService efd_test.MockService,
+				_ validatesvc_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(records.Root{}, errExample)
+			},
+			expectedError: validate.ErrFailedToReadFile,
+		},
+		{
+			name:    "no findings",
+			efdFile: "file.efd",
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}},
+			},
+			expect:        expectValidate,
+			expectedError: nil,
+		},
+		{
+			name:    "only info findings",
+			efdFile: "file.efd",
+			findings: []validatesvc.Finding{
+				{Severity: validatesvc.SeverityInfo},
+			},
+			expect:        expectValidate,
+			expectedError: nil,
+		},
+		{
+			name:    "warning findings",
+			efdFile: "file.efd",
+			findings: []validatesvc.Finding{
+				{Severity: validatesvc.SeverityInfo},
+				{Severity: validatesvc.SeverityWarning},
+			},
+			expect:        expectValidate,
+			expectedError: validate.ErrRollHasWarnings,
+		},
+		{
+			name:    "error findings",
+			efdFile: "file.efd",
+			findings: []validatesvc.Finding{
+				{Severity: validatesvc.SeverityError},
+				{Severity: validatesvc.SeverityWarning},
+			},
+			expect:        expectValidate,
+			expectedError: validate.ErrRollHasErrors,
+		},
+		{
+			name:    "only findings about selected frames",
+			efdFile: "file.efd",
+			frames:  cli.FrameRanges{{First: 2, Last: 3}},
+			root: records.Root{
+				EFRMs: []records.EFRM{
+					{FrameNumber: 1},
+					{FrameNumber: 2},
+					{FrameNumber: 3},
+				},
+			},
+			findings: []validatesvc.Finding{
+				{Severity: validatesvc.SeverityWarning, Record: "roll"},
+				{Severity: validatesvc.SeverityError, Frame: 1},
+				{Severity: validatesvc.SeverityInfo, Frame: 3},
+			},
+			reported: []validatesvc.Finding{
+				{Severity: validatesvc.SeverityWarning, Record: "roll"},
+				{Severity: validatesvc.SeverityInfo, Frame: 3},
+			},
+			expect:        expectValidate,
+			expectedError: validate.ErrRollHasWarnings,
+		},
+		{
+			name:    "selected frame missing",
+			efdFile: "file.efd",
+			frames:  cli.FrameRanges{{First: 2, Last: 2}},
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}},
+			},
+			expect: func(
+				mockEFDService efd_test.MockService,
+				_ validatesvc_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(tt.root, nil)
+			},
+			expectedError: cli.ErrFrameNumberNotFound,
+		},
+		{
+			name:     "recovered file",
+			efdFile:  "file.efd",
+			recovery: true,
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 2}},
+			},
+			expect: func(
+				mockEFDService efd_test.MockService,
+				mockValidateService validatesvc_test.MockService,
+				tt testcase,
+			) {
+				mockEFDService.EXPECT().
+					RecoverRecordsFromFile(gomock.Any(), tt.efdFile).
+					Return(tt.root, nil, nil)
+
+				mockValidateService.EXPECT().
+					Validate(gomock.Any(), tt.root).
+					Return(nil)
+
+				mockValidateService.EXPECT().
+					Report(gomock.Any(), gomock.Any(), gomock.Nil())
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockEFDService := efd_test.NewMockService(mockCtrl)
+			mockValidateService := validatesvc_test.NewMockService(mockCtrl)
+
+			tt.expect(*mockEFDService, *mockValidateService, tt)
+
+			uc := validate.NewUseCase(
+				newTestLogger(),
+				mockEFDService,
+				mockValidateService,
+			)
+
+			err := uc.Validate(t.Context(), tt.efdFile, tt.recovery, tt.frames)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf(
+						"expected error %v to be in chain, got %v",
+						tt.expectedError,
+						err,
+					)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
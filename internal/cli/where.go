@@ -0,0 +1,70 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/display"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/spf13/cobra"
+)
+
+var ErrFailedToGetWhereFlag = errors.New("failed to get where flag")
+
+// AddWhereFlag adds the --where flag, selecting frames with a filter
+// expression, to cmd.
+func AddWhereFlag(cmd *cobra.Command) {
+	cmd.Flags().String("where", "",
+		`only frames matching a filter expression, e.g. 'av <= 2.8 && flash != "OFF"'`)
+}
+
+// GetWhere returns the filter given to the --where flag of cmd, or the zero
+// filter matching every frame if there is none.
+func GetWhere(cmd *cobra.Command) (framefilter.Filter, error) {
+	expr, err := cmd.Flags().GetString("where")
+	if err != nil {
+		return framefilter.Filter{}, errors.Join(ErrFailedToGetWhereFlag, err)
+	}
+
+	//nolint:wrapcheck // the filter errors describe the expression
+	return framefilter.Parse(expr)
+}
+
+// MatchFrame reports whether the frame of efrm matches where, for commands
+// that work on the records rather than the decoded roll. The frame is only
+// decoded if where needs to look at it, and then leniently, as the filter
+// compares whatever values can be read.
+func MatchFrame(
+	ctx context.Context,
+	frameBuilder display.Builder,
+	where framefilter.Filter,
+	efrm records.EFRM,
+) (bool, error) {
+	if where.IsZero() {
+		return true, nil
+	}
+
+	fr, err := frameBuilder.Build(ctx, efrm, nil, false)
+	if err != nil {
+		return false, err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return where.Match(fr), nil
+}
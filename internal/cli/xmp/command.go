@@ -0,0 +1,135 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=xmp_test github.com/ma-tf/meta1v/internal/cli/xmp UseCase
+
+// Package xmp provides the CLI command for writing the metadata of a frame to
+// an XMP sidecar file.
+package xmp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"github.com/spf13/cobra"
+)
+
+const requiredArgsCount = 3
+
+var ErrInvalidFrameNumber = errors.New("invalid specified frame number")
+
+// UseCase defines the business logic for exporting XMP sidecars from EFD
+// files.
+type UseCase interface {
+	// ExportXMP writes the metadata of the frames within frames to XMP sidecar
+	// files, creating them or updating the ones that are there. The sidecar file
+	// names the sidecar of a single frame, or with {frame} or {index}
+	// placeholders the sidecar of every frame. Frames that do not match where
+	// leave their sidecar file unchanged and are reported to w.
+	ExportXMP(
+		ctx context.Context,
+		w io.Writer,
+		efdFile string,
+		frames cli.FrameRanges,
+		sidecarFile string,
+		strict bool,
+		recovery bool,
+		where framefilter.Filter,
+	) error
+}
+
+func NewCommand(log *slog.Logger, uc UseCase) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "xmp <efd_file> <frames> <sidecar_file>",
+		Short: "Write metadata from EFD file to an XMP sidecar file",
+		Long: `Write the metadata exif writes to an image to an XMP sidecar file instead, for
+asset managers such as digiKam and Lightroom, so the scan itself is never modified.
+No external tool is needed.
+
+The EXIF tags are written as their exif namespace properties, and the film, flash
+and camera settings in the AnalogueData namespace. If the sidecar file exists, the
+properties are set in it and the rest of it is kept, so ratings and keywords added
+by an asset manager survive.
+
+The frame is given by its number, or as frame numbers and ranges as --frames takes
+them elsewhere, e.g. 1-5,12,30- where 30- runs to the end of the roll. Any missing
+frame of a closed range fails, as does a range like 30- without any frame. When
+more than one frame is selected, the sidecar file is a pattern as exif batch takes
+it, such as '{frame:02}.xmp'.
+
+With --where, the sidecar is only written if the frame matches a filter expression;
+see the README for the fields and operators available.`,
+		Example: `  # Write the metadata of frame 1 to a sidecar of scan.tif
+  meta1v xmp data.efd 1 scan.xmp
+
+  # Name the sidecar as darktable expects
+  meta1v xmp data.efd 1 scan.tif.xmp
+
+  # Write the sidecars of the whole roll, 01.xmp, 02.xmp, ...
+  meta1v xmp data.efd 1- '{frame:02}.xmp'`,
+		Args: cobra.ExactArgs(requiredArgsCount),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			strict, err := cmd.Flags().GetBool("strict")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetStrictFlag, err)
+			}
+
+			recovery, err := cmd.Flags().GetBool("recover")
+			if err != nil {
+				return errors.Join(cli.ErrFailedToGetRecoverFlag, err)
+			}
+
+			where, err := cli.GetWhere(cmd)
+			if err != nil {
+				return err
+			}
+
+			log.DebugContext(ctx, "xmp arguments:",
+				slog.String("efd_file", args[0]),
+				slog.String("frames", args[1]),
+				slog.String("sidecar_file", args[2]),
+				slog.Bool("strict", strict),
+				slog.Bool("recover", recovery),
+				slog.String("where", where.String()))
+
+			frames, err := cli.ParseFrameRanges(args[1])
+			if err != nil {
+				return errors.Join(ErrInvalidFrameNumber, err)
+			}
+
+			return uc.ExportXMP(
+				ctx,
+				cmd.OutOrStdout(),
+				args[0],
+				frames,
+				args[2],
+				strict,
+				recovery,
+				where,
+			)
+		},
+	}
+
+	cli.AddWhereFlag(cmd)
+
+	return cmd
+}
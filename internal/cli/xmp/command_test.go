@@ -0,0 +1,204 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package xmp_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/cli"
+	"github.com/ma-tf/meta1v/internal/cli/xmp"
+	xmp_test "github.com/ma-tf/meta1v/internal/cli/xmp/mocks"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+	"go.uber.org/mock/gomock"
+)
+
+//nolint:exhaustruct // only partial is needed
+func Test_CommandRun(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	type testcase struct {
+		name            string
+		args            []string
+		registerStrict  bool
+		registerRecover bool
+		expect          func(uc *xmp_test.MockUseCase, tt testcase)
+		expectedError   error
+	}
+
+	tests := []testcase{
+		{
+			name:           "strict flag not registered",
+			args:           []string{"file.efd", "1", "scan.xmp"},
+			registerStrict: false,
+			expectedError:  cli.ErrFailedToGetStrictFlag,
+		},
+		{
+			name:            "recover flag not registered",
+			args:            []string{"file.efd", "1", "scan.xmp"},
+			registerStrict:  true,
+			registerRecover: false,
+			expectedError:   cli.ErrFailedToGetRecoverFlag,
+		},
+		{
+			name:            "invalid frame number",
+			args:            []string{"file.efd", "one", "scan.xmp"},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   xmp.ErrInvalidFrameNumber,
+		},
+		{
+			name:            "successful execution",
+			args:            []string{"file.efd", "1", "scan.xmp"},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase *xmp_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					ExportXMP(
+						gomock.Any(),
+						gomock.Any(),
+						tt.args[0],
+						cli.FrameRanges{{First: 1, Last: 1}},
+						tt.args[2],
+						false,
+						false,
+						framefilter.Filter{},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name: "invalid where expression",
+			args: []string{
+				"file.efd",
+				"1",
+				"scan.xmp",
+				"--where",
+				"av <=",
+			},
+			registerStrict:  true,
+			registerRecover: true,
+			expectedError:   framefilter.ErrInvalidExpression,
+		},
+		{
+			name: "filtered frame",
+			args: []string{
+				"file.efd",
+				"1",
+				"scan.xmp",
+				"--where",
+				"av <= 2.8",
+			},
+			registerStrict:  true,
+			registerRecover: true,
+			expect: func(mockUseCase *xmp_test.MockUseCase, tt testcase) {
+				mockUseCase.EXPECT().
+					ExportXMP(
+						gomock.Any(),
+						gomock.Any(),
+						tt.args[0],
+						cli.FrameRanges{{First: 1, Last: 1}},
+						tt.args[2],
+						false,
+						false,
+						mustParseFilter(t, "av <= 2.8"),
+					).
+					Return(nil)
+			},
+		},
+	}
+
+	assertError := func(t *testing.T, tt testcase, got error) {
+		t.Helper()
+
+		if tt.expectedError != nil {
+			if got == nil {
+				t.Fatalf("expected error %v, got nil", tt.expectedError)
+			}
+
+			if !errors.Is(got, tt.expectedError) {
+				t.Fatalf(
+					"expected error %v to be in chain, got %v",
+					tt.expectedError,
+					got,
+				)
+			}
+
+			return
+		}
+
+		if got != nil {
+			t.Fatalf("unexpected error: %v", got)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := xmp_test.NewMockUseCase(ctrl)
+
+			if tt.expect != nil {
+				tt.expect(mockUseCase, tt)
+			}
+
+			cmd := xmp.NewCommand(logger, mockUseCase)
+			cmd.SilenceUsage = true
+
+			if tt.registerStrict {
+				cmd.Flags().Bool("strict", false, "enable strict mode")
+			}
+
+			if tt.registerRecover {
+				cmd.Flags().Bool("recover", false, "enable recovery mode")
+			}
+
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+
+			assertError(t, tt, err)
+		})
+	}
+}
+
+func mustParseFilter(t *testing.T, expr string) framefilter.Filter {
+	t.Helper()
+
+	f, err := framefilter.Parse(expr)
+	if err != nil {
+		t.Fatalf("failed to parse filter %q: %v", expr, err)
+	}
+
+	return f
+}
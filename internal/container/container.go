@@ -24,12 +24,22 @@ import (
 	"log/slog"
 
 	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/cfdiff"
+	"github.com/ma-tf/meta1v/internal/service/contactsheet"
 	"github.com/ma-tf/meta1v/internal/service/csvexport"
 	"github.com/ma-tf/meta1v/internal/service/display"
 	"github.com/ma-tf/meta1v/internal/service/efd"
 	"github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/ma-tf/meta1v/internal/service/focuspoints"
+	"github.com/ma-tf/meta1v/internal/service/inspect"
+	"github.com/ma-tf/meta1v/internal/service/jsonexport"
 	"github.com/ma-tf/meta1v/internal/service/osexec"
 	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/research"
+	"github.com/ma-tf/meta1v/internal/service/splitmerge"
+	"github.com/ma-tf/meta1v/internal/service/thumbnail"
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
+	"github.com/ma-tf/meta1v/internal/service/validate"
 )
 
 // Container holds all application dependencies and services.
@@ -41,8 +51,19 @@ type Container struct {
 	EFDService             efd.Service
 	DisplayService         display.Service
 	DisplayableRollFactory display.DisplayableRollFactory
+	FrameBuilder           display.Builder
 	CSVService             csvexport.Service
+	JSONService            jsonexport.Service
+	TemplateService        usertemplate.Service
 	ExifService            exif.Service
+	ValidateService        validate.Service
+	InspectService         inspect.Service
+	ResearchService        research.Service
+	SplitMergeService      splitmerge.Service
+	ThumbnailService       thumbnail.Service
+	ContactSheetService    contactsheet.Service
+	FocusPointsService     focuspoints.Service
+	CFDiffService          cfdiff.Service
 }
 
 // New creates and initializes a Container with all required services and dependencies.
@@ -59,18 +80,32 @@ func New(logger *slog.Logger, lookPath osexec.LookPath) *Container {
 			logger,
 			efd.NewRootBuilder(logger),
 			efd.NewReader(logger, thumbnailFactory),
+			efd.NewWriter(logger),
 			fs,
 		),
 		DisplayService:         display.NewService(logger),
 		DisplayableRollFactory: display.NewDisplayableRollFactory(frameBuilder),
+		FrameBuilder:           frameBuilder,
 		CSVService:             csvexport.NewService(logger),
+		JSONService:            jsonexport.NewService(logger),
+		TemplateService:        usertemplate.NewService(logger, fs),
 		ExifService: exif.NewService(
 			logger,
 			exif.NewExifToolRunner(
 				fs,
 				exif.NewExiftoolCommandFactory(lookPath),
 			),
+			exif.NewNativeRunner(fs),
+			exif.NewSidecarRunner(fs),
 			exif.NewExifBuilder(logger),
 		),
+		ValidateService:     validate.NewService(logger),
+		InspectService:      inspect.NewService(logger),
+		ResearchService:     research.NewService(logger),
+		SplitMergeService:   splitmerge.NewService(logger),
+		ThumbnailService:    thumbnail.NewService(logger),
+		ContactSheetService: contactsheet.NewService(logger),
+		FocusPointsService:  focuspoints.NewService(logger),
+		CFDiffService:       cfdiff.NewService(logger),
 	}
 }
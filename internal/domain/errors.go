@@ -41,4 +41,9 @@ var (
 	ErrInvalidBulbTime         = errors.New("invalid bulb exposure time")
 	ErrUnknownMultipleExposure = errors.New("unknown multiple exposure value")
 	ErrInvalidCustomFunction   = errors.New("invalid custom function")
+	ErrInvalidTitle            = errors.New("invalid title")
+	ErrInvalidRemarks          = errors.New("invalid remarks")
+	ErrInvalidFilepath         = errors.New("invalid thumbnail file path")
+	ErrTextTooLong             = errors.New("text too long")
+	ErrContainsNullByte        = errors.New("text contains a null byte")
 )
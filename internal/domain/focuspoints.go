@@ -0,0 +1,221 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FocusPointCount is the number of points of the autofocus grid.
+const FocusPointCount = 45
+
+const (
+	automaticSelection = 0
+	leftmostBitMask    = byte(0b10000000)
+	outerRowBits       = 7
+	leftSegmentBits    = 8
+	centreRow          = 2
+	centreColumn       = 5
+)
+
+// focusPointSegment is a byte of focus point data and the number of its bits
+// in use, counting from the most significant.
+type focusPointSegment struct {
+	index int
+	bits  int
+}
+
+// focusPointRows lists the segments making up each row of the grid, top to
+// bottom and left to right. The eight bytes map to:
+//
+//	[0]: row 0 - 7 bits
+//	[2]: row 1 left - 8 bits,  [1]: row 1 right - 2 bits
+//	[4]: row 2 left - 8 bits,  [3]: row 2 right - 3 bits
+//	[6]: row 3 left - 8 bits,  [5]: row 3 right - 2 bits
+//	[7]: row 4 - 7 bits
+//
+//nolint:gochecknoglobals // fixed grid layout
+var focusPointRows = [][]focusPointSegment{
+	{{0, 7}},
+	{{2, 8}, {1, 2}},
+	{{4, 8}, {3, 3}},
+	{{6, 8}, {5, 2}},
+	{{7, 7}},
+}
+
+// FocusPoint is a point of the 45-point autofocus grid. The grid has rows of
+// 7, 10, 11, 10 and 7 points, each centred on the one above.
+type FocusPoint struct {
+	Number int  `json:"number"` // 1 to 45, row by row from the top left
+	Row    int  `json:"row"`    // 0 to 4, top to bottom
+	Column int  `json:"column"` // from 0, left to right within the row
+	Edge   bool `json:"edge"`   // on the edge of the grid
+	Active bool `json:"active"` // used to focus
+}
+
+// Centre reports whether p is the point in the middle of the grid.
+func (p FocusPoint) Centre() bool {
+	return p.Row == centreRow && p.Column == centreColumn
+}
+
+// String describes the position of p, such as "row 1 col 3" or
+// "centre, row 2 col 5".
+func (p FocusPoint) String() string {
+	position := fmt.Sprintf("row %d col %d", p.Row, p.Column)
+	if p.Centre() {
+		return "centre, " + position
+	}
+
+	return position
+}
+
+// FocusPoints is the autofocus grid of a frame, decoded from its focus point
+// bytes and focusing point selection.
+type FocusPoints struct {
+	// Recorded is false if the frame recorded no focusing point, in which case
+	// no point is active.
+	Recorded bool `json:"recorded"`
+
+	// Automatic is set if the camera selected the focusing point.
+	Automatic bool `json:"automatic"`
+
+	// Selected is the Number of the point selected by hand, 0 if there is none.
+	Selected int `json:"selected"`
+
+	Points [FocusPointCount]FocusPoint `json:"points"`
+}
+
+// NewFocusPoints decodes the FocusingPoint selection and the eight
+// FocusPoints bytes of a frame. A selection of math.MaxUint32 means no
+// focusing point was recorded, 0 means automatic selection and 1 to 45 is the
+// Number of the point selected by hand. Any other selection is left unknown,
+// as the points themselves can still be decoded.
+func NewFocusPoints(selection uint32, points [8]byte) FocusPoints {
+	fp := FocusPoints{
+		Recorded:  selection != math.MaxUint32,
+		Automatic: selection == automaticSelection,
+		Selected:  0,
+		Points:    [FocusPointCount]FocusPoint{},
+	}
+
+	switch {
+	case !fp.Recorded, fp.Automatic:
+	case selection <= FocusPointCount:
+		fp.Selected = int(selection)
+	}
+
+	n := 0
+
+	for r, segments := range focusPointRows {
+		column := 0
+
+		for _, seg := range segments {
+			isOuterRow := seg.bits == outerRowBits
+			isLeftSegment := seg.bits == leftSegmentBits
+
+			for bit := range seg.bits {
+				isFirstOrLastBit := (isLeftSegment && bit == 0) ||
+					(!isLeftSegment && bit == seg.bits-1)
+
+				fp.Points[n] = FocusPoint{
+					Number: n + 1,
+					Row:    r,
+					Column: column,
+					Edge:   isOuterRow || isFirstOrLastBit,
+					Active: fp.Recorded &&
+						points[seg.index]&(leftmostBitMask>>bit) != 0,
+				}
+
+				n++
+				column++
+			}
+		}
+	}
+
+	return fp
+}
+
+// Rows returns the points row by row, top to bottom.
+func (fp FocusPoints) Rows() [][]FocusPoint {
+	rows := make([][]FocusPoint, len(focusPointRows))
+	start := 0
+
+	for r, segments := range focusPointRows {
+		end := start
+		for _, seg := range segments {
+			end += seg.bits
+		}
+
+		rows[r] = fp.Points[start:end]
+		start = end
+	}
+
+	return rows
+}
+
+// Active returns the points used to focus.
+func (fp FocusPoints) Active() []FocusPoint {
+	var active []FocusPoint
+
+	for _, p := range fp.Points {
+		if p.Active {
+			active = append(active, p)
+		}
+	}
+
+	return active
+}
+
+// SelectedPoint returns the point selected by hand, if there is one.
+func (fp FocusPoints) SelectedPoint() (FocusPoint, bool) {
+	if fp.Selected < 1 || fp.Selected > FocusPointCount {
+		return FocusPoint{}, false
+	}
+
+	return fp.Points[fp.Selected-1], true
+}
+
+// Describe describes the grid in words, for screen readers and plain text
+// exports, such as "active: centre, row 2 col 5 (automatic selection)".
+func (fp FocusPoints) Describe() string {
+	if !fp.Recorded {
+		return "no focusing point recorded"
+	}
+
+	active := fp.Active()
+
+	positions := make([]string, len(active))
+	for i, p := range active {
+		positions[i] = p.String()
+	}
+
+	description := "active: none"
+	if len(positions) > 0 {
+		description = "active: " + strings.Join(positions, "; ")
+	}
+
+	switch selected, ok := fp.SelectedPoint(); {
+	case fp.Automatic:
+		return description + " (automatic selection)"
+	case ok:
+		return description + " (selected: " + selected.String() + ")"
+	default:
+		return description + " (unknown selection)"
+	}
+}
@@ -0,0 +1,163 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package domain_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+)
+
+// centre is the byte of the left segment of row 2 with the centre point active.
+const centre = 0b00000100
+
+func Test_NewFocusPoints(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name                string
+		selection           uint32
+		points              [8]byte
+		expectedRecorded    bool
+		expectedAutomatic   bool
+		expectedSelected    int
+		expectedDescription string
+	}
+
+	tests := []testcase{
+		{
+			name:                "automatic selection",
+			selection:           0,
+			points:              [8]byte{0, 0, 0, 0, centre},
+			expectedRecorded:    true,
+			expectedAutomatic:   true,
+			expectedDescription: "active: centre, row 2 col 5 (automatic selection)",
+		},
+		{
+			name:                "selected by hand",
+			selection:           1,
+			points:              [8]byte{0b10000000, 0, 0, 0, centre},
+			expectedRecorded:    true,
+			expectedSelected:    1,
+			expectedDescription: "active: row 0 col 0; centre, row 2 col 5 (selected: row 0 col 0)",
+		},
+		{
+			name:                "right segment",
+			selection:           0,
+			points:              [8]byte{0, 0b01000000},
+			expectedRecorded:    true,
+			expectedAutomatic:   true,
+			expectedDescription: "active: row 1 col 9 (automatic selection)",
+		},
+		{
+			name:                "no active points",
+			selection:           45,
+			expectedRecorded:    true,
+			expectedSelected:    45,
+			expectedDescription: "active: none (selected: row 4 col 6)",
+		},
+		{
+			name:      "not recorded",
+			selection: math.MaxUint32,
+			points: [8]byte{
+				0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+			},
+			expectedDescription: "no focusing point recorded",
+		},
+		{
+			name:                "unknown selection",
+			selection:           46,
+			points:              [8]byte{0, 0, 0, 0, centre},
+			expectedRecorded:    true,
+			expectedDescription: "active: centre, row 2 col 5 (unknown selection)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fp := domain.NewFocusPoints(tc.selection, tc.points)
+
+			if fp.Recorded != tc.expectedRecorded ||
+				fp.Automatic != tc.expectedAutomatic ||
+				fp.Selected != tc.expectedSelected {
+				t.Errorf(
+					"expected recorded %t, automatic %t, selected %d, got %+v",
+					tc.expectedRecorded,
+					tc.expectedAutomatic,
+					tc.expectedSelected,
+					fp,
+				)
+			}
+
+			if got := fp.Describe(); got != tc.expectedDescription {
+				t.Errorf("expected description %q, got %q",
+					tc.expectedDescription, got)
+			}
+		})
+	}
+}
+
+func Test_FocusPoints_Rows(t *testing.T) {
+	t.Parallel()
+
+	fp := domain.NewFocusPoints(0, [8]byte{})
+
+	rows := fp.Rows()
+
+	lengths := []int{7, 10, 11, 10, 7}
+	if len(rows) != len(lengths) {
+		t.Fatalf("expected %d rows, got %d", len(lengths), len(rows))
+	}
+
+	number, edges := 1, 0
+
+	for r, row := range rows {
+		if len(row) != lengths[r] {
+			t.Errorf("expected %d points in row %d, got %d",
+				lengths[r], r, len(row))
+		}
+
+		for c, p := range row {
+			if p.Number != number || p.Row != r || p.Column != c {
+				t.Errorf("expected point %d at row %d col %d, got %+v",
+					number, r, c, p)
+			}
+
+			if p.Edge {
+				edges++
+			}
+
+			number++
+		}
+	}
+
+	// the outer rows and both ends of the rows between them
+	if edges != 7+2+2+2+7 {
+		t.Errorf("expected 20 edge points, got %d", edges)
+	}
+
+	if p := fp.Points[22]; !p.Centre() {
+		t.Errorf("expected point 23 to be the centre, got %+v", p)
+	}
+
+	if len(domain.FocusPoints{}.Rows()[2]) != 11 {
+		t.Error("expected the zero value to keep the layout of the grid")
+	}
+}
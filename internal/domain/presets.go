@@ -0,0 +1,101 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// CustomFunctionPreset is a named setup of custom functions, such as one kept
+// for sports or studio work. Values are keyed by custom function number,
+// counted from 0 as on the camera; functions left out may have any value.
+type CustomFunctionPreset struct {
+	Name   string
+	Values map[int]byte
+}
+
+// NewCustomFunctionPreset creates a preset, checking that every custom function
+// exists and every value is within the range allowed for it.
+func NewCustomFunctionPreset(
+	name string,
+	values map[int]byte,
+) (CustomFunctionPreset, error) {
+	for n, v := range values {
+		if n < 0 || n >= len(CustomFunctions{}) {
+			return CustomFunctionPreset{}, fmt.Errorf(
+				"%w %d in preset %q: no such custom function",
+				ErrInvalidCustomFunction, n, name)
+		}
+
+		if limit, ok := defaultMaps.cfl[n]; ok && v > limit {
+			return CustomFunctionPreset{}, fmt.Errorf(
+				"%w %d in preset %q: out of range (0-%d): %d",
+				ErrInvalidCustomFunction, n, name, limit, v)
+		}
+	}
+
+	return CustomFunctionPreset{
+		Name:   name,
+		Values: values,
+	}, nil
+}
+
+// Deviations returns the numbers of the custom functions whose values in cfs
+// differ from the preset, in ascending order.
+func (p CustomFunctionPreset) Deviations(cfs CustomFunctions) []int {
+	var deviations []int
+
+	for _, n := range slices.Sorted(maps.Keys(p.Values)) {
+		if cfs[n] != strconv.Itoa(int(p.Values[n])) {
+			deviations = append(deviations, n)
+		}
+	}
+
+	return deviations
+}
+
+// ClosestPreset returns the preset cfs deviates least from, along with the
+// number of deviations. Ties go to the preset that comes first. Returns false
+// if there are no presets or cfs has no custom function recorded.
+func ClosestPreset(
+	cfs CustomFunctions,
+	presets []CustomFunctionPreset,
+) (CustomFunctionPreset, int, bool) {
+	if !slices.ContainsFunc(cfs[:], func(v string) bool { return v != " " }) {
+		return CustomFunctionPreset{}, 0, false
+	}
+
+	var (
+		closest CustomFunctionPreset
+		fewest  = -1
+	)
+
+	for _, p := range presets {
+		if n := len(p.Deviations(cfs)); fewest == -1 || n < fewest {
+			closest, fewest = p, n
+		}
+	}
+
+	if fewest == -1 {
+		return CustomFunctionPreset{}, 0, false
+	}
+
+	return closest, fewest, true
+}
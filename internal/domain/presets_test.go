@@ -0,0 +1,209 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package domain_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+)
+
+func newCustomFunctions(overrides map[int]string) domain.CustomFunctions {
+	var cfs domain.CustomFunctions
+	for n := range cfs {
+		cfs[n] = "0"
+	}
+
+	for n, v := range overrides {
+		cfs[n] = v
+	}
+
+	return cfs
+}
+
+func newPreset(
+	t *testing.T,
+	name string,
+	values map[int]byte,
+) domain.CustomFunctionPreset {
+	t.Helper()
+
+	p, err := domain.NewCustomFunctionPreset(name, values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return p
+}
+
+func Test_NewCustomFunctionPreset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		values        map[int]byte
+		expectedError error
+	}{
+		{
+			name:          "valid preset",
+			values:        map[int]byte{0: 1, 4: 3, 19: 5},
+			expectedError: nil,
+		},
+		{
+			name:          "no such custom function",
+			values:        map[int]byte{20: 0},
+			expectedError: domain.ErrInvalidCustomFunction,
+		},
+		{
+			name:          "value out of range",
+			values:        map[int]byte{4: 4},
+			expectedError: domain.ErrInvalidCustomFunction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := domain.NewCustomFunctionPreset("sports", tt.values)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if err == nil && p.Name != "sports" {
+				t.Errorf("expected name %q, got %q", "sports", p.Name)
+			}
+		})
+	}
+}
+
+func Test_CustomFunctionPreset_Deviations(t *testing.T) {
+	t.Parallel()
+
+	p := newPreset(t, "sports", map[int]byte{13: 1, 4: 2, 12: 0, 1: 0})
+
+	tests := []struct {
+		name     string
+		cfs      domain.CustomFunctions
+		expected []int
+	}{
+		{
+			name: "matches",
+			cfs: newCustomFunctions(
+				map[int]string{13: "1", 4: "2", 6: "1"},
+			),
+			expected: nil,
+		},
+		{
+			name:     "deviates",
+			cfs:      newCustomFunctions(map[int]string{1: " ", 13: "1"}),
+			expected: []int{1, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := p.Deviations(tt.cfs); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected deviations %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_ClosestPreset(t *testing.T) {
+	t.Parallel()
+
+	presets := []domain.CustomFunctionPreset{
+		newPreset(t, "landscape", map[int]byte{12: 1, 13: 0}),
+		newPreset(t, "sports", map[int]byte{4: 2, 13: 1}),
+		newPreset(t, "studio", map[int]byte{12: 1, 15: 1}),
+	}
+
+	var unrecorded domain.CustomFunctions
+	for n := range unrecorded {
+		unrecorded[n] = " "
+	}
+
+	tests := []struct {
+		name               string
+		cfs                domain.CustomFunctions
+		presets            []domain.CustomFunctionPreset
+		expectedName       string
+		expectedDeviations int
+		expectedOK         bool
+	}{
+		{
+			name: "exact match",
+			cfs: newCustomFunctions(
+				map[int]string{4: "2", 13: "1"},
+			),
+			presets:            presets,
+			expectedName:       "sports",
+			expectedDeviations: 0,
+			expectedOK:         true,
+		},
+		{
+			name:               "tie goes to the first preset",
+			cfs:                newCustomFunctions(map[int]string{12: "1"}),
+			presets:            presets,
+			expectedName:       "landscape",
+			expectedDeviations: 0,
+			expectedOK:         true,
+		},
+		{
+			name:               "closest with deviations",
+			cfs:                newCustomFunctions(map[int]string{15: "1"}),
+			presets:            presets,
+			expectedName:       "landscape",
+			expectedDeviations: 1,
+			expectedOK:         true,
+		},
+		{
+			name:       "no presets",
+			cfs:        newCustomFunctions(nil),
+			presets:    nil,
+			expectedOK: false,
+		},
+		{
+			name:       "no custom functions recorded",
+			cfs:        unrecorded,
+			presets:    presets,
+			expectedOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, deviations, ok := domain.ClosestPreset(tt.cfs, tt.presets)
+			if ok != tt.expectedOK {
+				t.Fatalf("expected ok %t, got %t", tt.expectedOK, ok)
+			}
+
+			if p.Name != tt.expectedName ||
+				deviations != tt.expectedDeviations {
+				t.Errorf("expected %q with %d deviations, got %q with %d",
+					tt.expectedName, tt.expectedDeviations, p.Name, deviations)
+			}
+		})
+	}
+}
@@ -39,19 +39,31 @@ type MapProvider struct {
 	afms map[uint32]AutoFocusMode
 	mes  map[uint32]MultipleExposure
 	cfl  map[int]byte
+	cfs  map[int]customFunction
+}
+
+type customFunction struct {
+	name     string
+	settings []string
+}
+
+type customFunctionJSON struct {
+	Name     string   `json:"name"`
+	Settings []string `json:"settings"`
 }
 
 type domainJSON struct {
-	ShutterSpeeds         map[string]string `json:"shutterSpeeds"`
-	ApertureValues        map[string]string `json:"apertureValues"`
-	ExposureCompensations map[string]string `json:"exposureCompensations"`
-	FlashModes            map[string]string `json:"flashModes"`
-	MeteringModes         map[string]string `json:"meteringModes"`
-	ShootingModes         map[string]string `json:"shootingModes"`
-	FilmAdvanceModes      map[string]string `json:"filmAdvanceModes"`
-	AutoFocusModes        map[string]string `json:"autoFocusModes"`
-	MultipleExposures     map[string]string `json:"multipleExposures"`
-	CustomFunctionsLimits map[string]byte   `json:"customFunctionsLimits"`
+	ShutterSpeeds         map[string]string             `json:"shutterSpeeds"`
+	ApertureValues        map[string]string             `json:"apertureValues"`
+	ExposureCompensations map[string]string             `json:"exposureCompensations"`
+	FlashModes            map[string]string             `json:"flashModes"`
+	MeteringModes         map[string]string             `json:"meteringModes"`
+	ShootingModes         map[string]string             `json:"shootingModes"`
+	FilmAdvanceModes      map[string]string             `json:"filmAdvanceModes"`
+	AutoFocusModes        map[string]string             `json:"autoFocusModes"`
+	MultipleExposures     map[string]string             `json:"multipleExposures"`
+	CustomFunctionsLimits map[string]byte               `json:"customFunctionsLimits"`
+	CustomFunctions       map[string]customFunctionJSON `json:"customFunctions"`
 }
 
 func NewMapProvider() *MapProvider {
@@ -70,6 +82,7 @@ func NewMapProvider() *MapProvider {
 		afms: convertMapUint32[AutoFocusMode](data.AutoFocusModes),
 		mes:  convertMapUint32[MultipleExposure](data.MultipleExposures),
 		cfl:  convertCustomFunctionsLimits(data.CustomFunctionsLimits),
+		cfs:  convertCustomFunctions(data.CustomFunctions),
 	}
 }
 
@@ -107,6 +120,19 @@ func convertCustomFunctionsLimits(src map[string]byte) map[int]byte {
 	return result
 }
 
+func convertCustomFunctions(
+	src map[string]customFunctionJSON,
+) map[int]customFunction {
+	result := make(map[int]customFunction, len(src))
+	for k, v := range src {
+		if i, err := strconv.Atoi(k); err == nil {
+			result[i] = customFunction{name: v.Name, settings: v.Settings}
+		}
+	}
+
+	return result
+}
+
 // GetTv retrieves the human-readable shutter speed string for a raw Tv value.
 // Returns false if the value is not found in the lookup map.
 func (m *MapProvider) GetTv(tv int32) (Tv, bool) {
@@ -180,3 +206,22 @@ func (m *MapProvider) GetMultipleExposure(me uint32) (MultipleExposure, bool) {
 
 	return r, ok
 }
+
+// GetCustomFunctionName retrieves the name of custom function n, numbered from 0
+// as on the camera. Returns false if there is no such custom function.
+func (m *MapProvider) GetCustomFunctionName(n int) (string, bool) {
+	cf, ok := m.cfs[n]
+
+	return cf.name, ok
+}
+
+// GetCustomFunctionSetting retrieves the meaning of value v of custom function n.
+// Returns false if there is no such custom function or setting.
+func (m *MapProvider) GetCustomFunctionSetting(n int, v byte) (string, bool) {
+	cf, ok := m.cfs[n]
+	if !ok || int(v) >= len(cf.settings) {
+		return "", false
+	}
+
+	return cf.settings[v], true
+}
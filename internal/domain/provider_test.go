@@ -115,6 +115,20 @@ func Test_MapProvider(t *testing.T) {
 			},
 			expectedResult: "ON",
 		},
+		{
+			name: "custom function names loaded",
+			fut: func() (string, bool) {
+				return provider.GetCustomFunctionName(4)
+			},
+			expectedResult: "AF start/AE lock button",
+		},
+		{
+			name: "custom function settings loaded",
+			fut: func() (string, bool) {
+				return provider.GetCustomFunctionSetting(4, 0)
+			},
+			expectedResult: "AF start / AE lock",
+		},
 	}
 
 	for _, tt := range tests {
@@ -132,3 +146,37 @@ func Test_MapProvider(t *testing.T) {
 		})
 	}
 }
+
+func Test_MapProvider_CustomFunctionSettings(t *testing.T) {
+	t.Parallel()
+
+	provider := domain.NewMapProvider()
+
+	for n := range len(domain.CustomFunctions{}) {
+		if _, ok := provider.GetCustomFunctionName(n); !ok {
+			t.Errorf("expected custom function %d to have a name", n)
+		}
+
+		// every value allowed in strict mode must have a meaning
+		for v := byte(0); ; v++ {
+			var cfs [20]byte
+
+			cfs[n] = v
+			if _, err := domain.NewCustomFunctions(cfs, true); err != nil {
+				if _, ok := provider.GetCustomFunctionSetting(n, v); ok {
+					t.Errorf("custom function %d: unexpected setting %d", n, v)
+				}
+
+				break
+			}
+
+			if _, ok := provider.GetCustomFunctionSetting(n, v); !ok {
+				t.Errorf("custom function %d: expected setting %d", n, v)
+			}
+		}
+	}
+
+	if _, ok := provider.GetCustomFunctionName(20); ok {
+		t.Error("expected no custom function 20")
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -98,6 +99,18 @@ func NewFrameCount(fc uint32) FrameCount {
 	return FrameCount(strconv.FormatUint(uint64(fc), 10))
 }
 
+// FrameRange is an inclusive range of recorded frame numbers.
+type FrameRange struct {
+	First uint32
+	Last  uint32 // unused if Open
+	Open  bool   // runs to the last frame of the roll
+}
+
+// Contains reports whether the frame number falls within the range.
+func (r FrameRange) Contains(frameNumber uint32) bool {
+	return frameNumber >= r.First && (r.Open || frameNumber <= r.Last)
+}
+
 // ValidatedDatetime represents a validated date-time string in the format "YYYY-MM-DD HH:MM:SS".
 // Empty string indicates that no date-time was recorded.
 type ValidatedDatetime string
@@ -146,6 +159,58 @@ func NewRemarks(r [256]byte) Remarks {
 	return Remarks(r[:bytes.IndexByte(r[:], 0)])
 }
 
+// EncodeTitle converts a title into the 64-byte null-terminated field stored in an EFDF record.
+// Returns an error if the title contains a null byte or does not fit alongside its terminator.
+func EncodeTitle(t string) ([64]byte, error) {
+	var raw [64]byte
+
+	if err := encodeNullTerminated(raw[:], t); err != nil {
+		return [64]byte{}, fmt.Errorf("%w: %w", ErrInvalidTitle, err)
+	}
+
+	return raw, nil
+}
+
+// EncodeRemarks converts remarks into the 256-byte null-terminated field stored in EFDF and EFRM records.
+// Returns an error if the remarks contain a null byte or do not fit alongside their terminator.
+func EncodeRemarks(r string) ([256]byte, error) {
+	var raw [256]byte
+
+	if err := encodeNullTerminated(raw[:], r); err != nil {
+		return [256]byte{}, fmt.Errorf("%w: %w", ErrInvalidRemarks, err)
+	}
+
+	return raw, nil
+}
+
+// EncodeFilepath converts the path of a linked image into the 256-byte null-terminated field
+// stored in an EFTP record.
+// Returns an error if the path contains a null byte or does not fit alongside its terminator.
+func EncodeFilepath(p string) ([256]byte, error) {
+	var raw [256]byte
+
+	if err := encodeNullTerminated(raw[:], p); err != nil {
+		return [256]byte{}, fmt.Errorf("%w: %w", ErrInvalidFilepath, err)
+	}
+
+	return raw, nil
+}
+
+func encodeNullTerminated(dst []byte, s string) error {
+	if strings.IndexByte(s, 0) != -1 {
+		return ErrContainsNullByte
+	}
+
+	if len(s) >= len(dst) {
+		return fmt.Errorf("%w: got %d bytes (max: %d)",
+			ErrTextTooLong, len(s), len(dst)-1)
+	}
+
+	copy(dst, s)
+
+	return nil
+}
+
 // FocalLength represents the lens focal length in millimeters.
 // Empty string indicates unknown or unavailable focal length.
 type FocalLength string
@@ -417,3 +482,55 @@ func NewCustomFunctions(cfs [20]byte, strict bool) (CustomFunctions, error) {
 
 	return values, nil
 }
+
+// CustomFunctionName returns the name of custom function n, numbered from 0 as
+// on the camera, or an empty string if there is no such custom function.
+func CustomFunctionName(n int) string {
+	name, _ := defaultMaps.GetCustomFunctionName(n)
+
+	return name
+}
+
+// Setting returns the meaning of the value set for custom function n, or an
+// empty string if the function is unset or the value has no known meaning.
+func (cfs CustomFunctions) Setting(n int) string {
+	if n < 0 || n >= len(cfs) {
+		return ""
+	}
+
+	v, err := strconv.ParseUint(cfs[n], 10, 8)
+	if err != nil {
+		return ""
+	}
+
+	setting, _ := defaultMaps.GetCustomFunctionSetting(n, byte(v))
+
+	return setting
+}
+
+// DescribeSetting returns the value set for custom function n followed by its
+// meaning, e.g. "0 = AF start / AE lock". Values without a known meaning are
+// returned on their own, and unset values as an empty string.
+func (cfs CustomFunctions) DescribeSetting(n int) string {
+	if n < 0 || n >= len(cfs) {
+		return ""
+	}
+
+	v, err := strconv.ParseUint(cfs[n], 10, 8)
+	if err != nil {
+		return strings.TrimSpace(cfs[n])
+	}
+
+	return DescribeCustomFunctionSetting(n, byte(v))
+}
+
+// DescribeCustomFunctionSetting returns value v of custom function n followed
+// by its meaning, or v on its own if it has no known meaning.
+func DescribeCustomFunctionSetting(n int, v byte) string {
+	setting, ok := defaultMaps.GetCustomFunctionSetting(n, v)
+	if !ok {
+		return strconv.Itoa(int(v))
+	}
+
+	return fmt.Sprintf("%d = %s", v, setting)
+}
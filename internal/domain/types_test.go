@@ -19,6 +19,7 @@ package domain_test
 import (
 	"errors"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/ma-tf/meta1v/internal/domain"
@@ -245,6 +246,107 @@ func Test_NewRemarks(t *testing.T) {
 	}
 }
 
+//nolint:exhaustruct // only partial needed
+func Test_EncodeTitle(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name          string
+		title         string
+		expectedError error
+	}
+
+	tests := []testcase{
+		{
+			name:  "valid title",
+			title: "Sample Title",
+		},
+		{
+			name:  "title filling the field",
+			title: strings.Repeat("a", 63),
+		},
+		{
+			name:          "title too long for terminator",
+			title:         strings.Repeat("a", 64),
+			expectedError: domain.ErrTextTooLong,
+		},
+		{
+			name:          "title containing null byte",
+			title:         "a\x00b",
+			expectedError: domain.ErrContainsNullByte,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := domain.EncodeTitle(tt.title)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) ||
+					!errors.Is(err, domain.ErrInvalidTitle) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := domain.NewTitle(result); string(got) != tt.title {
+				t.Errorf("expected Title %q, got %q", tt.title, got)
+			}
+		})
+	}
+}
+
+func Test_EncodeRemarks(t *testing.T) {
+	t.Parallel()
+
+	result, err := domain.EncodeRemarks(strings.Repeat("r", 255))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := domain.NewRemarks(result); len(got) != 255 {
+		t.Errorf("expected 255 bytes of remarks, got %d", len(got))
+	}
+
+	_, err = domain.EncodeRemarks(strings.Repeat("r", 256))
+	if !errors.Is(err, domain.ErrTextTooLong) ||
+		!errors.Is(err, domain.ErrInvalidRemarks) {
+		t.Errorf("expected error %v, got %v", domain.ErrTextTooLong, err)
+	}
+}
+
+func Test_EncodeFilepath(t *testing.T) {
+	t.Parallel()
+
+	const path = `C:\scans\roll\01.tif`
+
+	result, err := domain.EncodeFilepath(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var expected [256]byte
+	copy(expected[:], path)
+
+	if result != expected {
+		t.Errorf("expected %q to be stored null-terminated, got %q",
+			path, result[:len(path)+1])
+	}
+
+	_, err = domain.EncodeFilepath(strings.Repeat("p", 256))
+	if !errors.Is(err, domain.ErrTextTooLong) ||
+		!errors.Is(err, domain.ErrInvalidFilepath) {
+		t.Errorf("expected error %v, got %v", domain.ErrTextTooLong, err)
+	}
+}
+
 func Test_NewFocalLength(t *testing.T) {
 	t.Parallel()
 
@@ -869,3 +971,68 @@ func Test_NewCustomFunctions(t *testing.T) {
 		})
 	}
 }
+
+func Test_CustomFunctions_Setting(t *testing.T) {
+	t.Parallel()
+
+	cfs := domain.CustomFunctions{
+		"0", "1", " ", "0", "2", "0", "0", "0", "0", "0",
+		"0", "0", "0", "0", "0", "0", "0", "0", "0", "254",
+	}
+
+	tests := []struct {
+		name             string
+		n                int
+		expectedName     string
+		expectedSetting  string
+		expectedDescribe string
+	}{
+		{
+			name:             "set value",
+			n:                4,
+			expectedName:     "AF start/AE lock button",
+			expectedSetting:  "AF start / AF stop, no AE lock",
+			expectedDescribe: "2 = AF start / AF stop, no AE lock",
+		},
+		{
+			name:             "unset value",
+			n:                2,
+			expectedName:     "Film leader after rewind",
+			expectedSetting:  "",
+			expectedDescribe: "",
+		},
+		{
+			name:             "out of range value",
+			n:                19,
+			expectedName:     "Lens AF stop button",
+			expectedSetting:  "",
+			expectedDescribe: "254",
+		},
+		{
+			name:             "no such custom function",
+			n:                20,
+			expectedName:     "",
+			expectedSetting:  "",
+			expectedDescribe: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := domain.CustomFunctionName(tt.n); got != tt.expectedName {
+				t.Errorf("expected name %q, got %q", tt.expectedName, got)
+			}
+
+			if got := cfs.Setting(tt.n); got != tt.expectedSetting {
+				t.Errorf("expected setting %q, got %q", tt.expectedSetting, got)
+			}
+
+			if got := cfs.DescribeSetting(tt.n); got != tt.expectedDescribe {
+				t.Errorf("expected description %q, got %q",
+					tt.expectedDescribe, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package domain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The methods below read the numeric value back out of the display strings,
+// for calculations and comparisons. Each reports false if the value was not
+// recorded or is not a number, such as a Tv of "Bulb".
+
+// Seconds returns the shutter speed in seconds, e.g. 0.004 for "1/250" and 2.5
+// for 2.5".
+func (tv Tv) Seconds() (float64, bool) {
+	s := string(tv)
+
+	if num, den, ok := strings.Cut(s, "/"); ok {
+		n, errN := strconv.ParseFloat(num, 64)
+		d, errD := strconv.ParseFloat(den, 64)
+
+		if errN != nil || errD != nil || d == 0 {
+			return 0, false
+		}
+
+		return n / d, true
+	}
+
+	return positive(strings.TrimSuffix(s, `"`))
+}
+
+// FNumber returns the f-number of the aperture, e.g. 5.6 for "f/5.6".
+func (av Av) FNumber() (float64, bool) {
+	return positive(strings.TrimPrefix(string(av), "f/"))
+}
+
+// Stops returns the exposure compensation in stops, e.g. -0.7 for "-0.7".
+func (ec ExposureCompensation) Stops() (float64, bool) {
+	v, err := strconv.ParseFloat(string(ec), 64)
+
+	return v, err == nil
+}
+
+// Millimetres returns the focal length in millimetres, e.g. 50 for "50mm".
+func (fl FocalLength) Millimetres() (float64, bool) {
+	return positive(strings.TrimSuffix(string(fl), "mm"))
+}
+
+// Speed returns the film speed, e.g. 400 for "400".
+func (iso Iso) Speed() (float64, bool) {
+	return positive(string(iso))
+}
+
+// Time returns the date and time, or false if none was recorded.
+func (dt ValidatedDatetime) Time() (time.Time, bool) {
+	t, err := time.Parse(time.DateTime, string(dt))
+
+	return t, err == nil
+}
+
+// ExposureValue returns the exposure value of an aperture and shutter speed,
+// log2(N²/t), or false if either is unknown.
+func ExposureValue(av Av, tv Tv) (float64, bool) {
+	n, okAv := av.FNumber()
+	t, okTv := tv.Seconds()
+
+	if !okAv || !okTv {
+		return 0, false
+	}
+
+	return math.Log2(n * n / t), true
+}
+
+// positive parses s as a number greater than zero, as zero stands for an
+// unknown value in the display strings.
+func positive(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+
+	return v, true
+}
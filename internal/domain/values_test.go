@@ -0,0 +1,89 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package domain_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+)
+
+func Test_Values(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    func() (float64, bool)
+		expected float64
+		ok       bool
+	}{
+		{"tv fraction", domain.Tv("1/250").Seconds, 0.004, true},
+		{"tv seconds", domain.Tv(`2.5"`).Seconds, 2.5, true},
+		{"tv bulb", domain.Tv("Bulb").Seconds, 0, false},
+		{"tv unrecorded", domain.Tv("").Seconds, 0, false},
+		{"av", domain.Av("f/5.6").FNumber, 5.6, true},
+		{"av unknown", domain.Av("f/00").FNumber, 0, false},
+		{"ec negative", domain.ExposureCompensation("-0.7").Stops, -0.7, true},
+		{"ec zero", domain.ExposureCompensation("0.0").Stops, 0, true},
+		{"ec unrecorded", domain.ExposureCompensation("").Stops, 0, false},
+		{"focal length", domain.FocalLength("50mm").Millimetres, 50, true},
+		{"focal length zero", domain.FocalLength("0mm").Millimetres, 0, false},
+		{"iso", domain.Iso("400").Speed, 400, true},
+		{"iso zero", domain.Iso("0").Speed, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.value()
+			if ok != tt.ok || math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("expected %v (ok %t), got %v (ok %t)",
+					tt.expected, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func Test_ValidatedDatetime_Time(t *testing.T) {
+	t.Parallel()
+
+	got, ok := domain.ValidatedDatetime("2024-05-01 18:30:00").Time()
+	if want := time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC); !ok ||
+		!got.Equal(want) {
+		t.Errorf("expected %v, got %v (ok %t)", want, got, ok)
+	}
+
+	if _, ok := domain.ValidatedDatetime("").Time(); ok {
+		t.Error("expected no time for an unrecorded date")
+	}
+}
+
+func Test_ExposureValue(t *testing.T) {
+	t.Parallel()
+
+	got, ok := domain.ExposureValue("f/8.0", "1/125")
+	if !ok || math.Abs(got-12.97) > 0.01 {
+		t.Errorf("expected EV 12.97, got %v (ok %t)", got, ok)
+	}
+
+	if _, ok := domain.ExposureValue("f/8.0", "Bulb"); ok {
+		t.Error("expected no EV for a bulb exposure")
+	}
+}
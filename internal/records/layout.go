@@ -0,0 +1,104 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package records
+
+import (
+	"encoding/binary"
+	"reflect"
+	"strings"
+)
+
+// HeaderSize is the size of the header preceding every record's data:
+// magic (4) + unknown (4) + length (8).
+const HeaderSize = 16
+
+// Field locates a field within a record, counting from the start of the record header.
+type Field struct {
+	Name   string
+	Offset int
+	Size   int          // -1 if the field runs to the end of the record
+	Type   reflect.Type // type the field decodes to, nil for variable sized fields
+}
+
+// Unknown reports whether the purpose of the field has not been worked out yet.
+func (f Field) Unknown() bool {
+	return strings.HasPrefix(f.Name, "Unknown") ||
+		strings.HasPrefix(f.Name, "HeaderUnknown")
+}
+
+//nolint:gochecknoglobals // fixed binary layout
+var header = []Field{
+	{Name: "Magic", Offset: 0, Size: 4, Type: reflect.TypeFor[[4]byte]()},
+	{
+		Name:   "HeaderUnknown",
+		Offset: 4,
+		Size:   4,
+		Type:   reflect.TypeFor[[4]byte](),
+	},
+	{Name: "Length", Offset: 8, Size: 8, Type: reflect.TypeFor[uint64]()},
+}
+
+// Layout returns the fields of the record type identified by magic, in file order
+// and starting with the record header, or nil for an unknown magic number.
+// The offsets follow the packed little-endian encoding the records are decoded with.
+func Layout(magic string) []Field {
+	switch magic {
+	case MagicEFDF:
+		return structLayout(reflect.TypeFor[EFDF]())
+	case MagicEFRM:
+		return structLayout(reflect.TypeFor[EFRM]())
+	case MagicEFTP:
+		fields := structLayout(reflect.TypeFor[EFTP]())
+		last := fields[len(fields)-1]
+
+		// the decoded thumbnail is stored as BGR pixels after the filepath
+		return append(fields, Field{
+			Name:   "Pixels",
+			Offset: last.Offset + last.Size,
+			Size:   -1,
+			Type:   nil,
+		})
+	default:
+		return nil
+	}
+}
+
+// structLayout lays out the fixed size fields of t after the record header,
+// stopping at the first field without a fixed binary size.
+func structLayout(t reflect.Type) []Field {
+	fields := append([]Field{}, header...)
+	offset := HeaderSize
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+
+		size := binary.Size(reflect.Zero(sf.Type).Interface())
+		if size < 0 {
+			break
+		}
+
+		fields = append(fields, Field{
+			Name:   sf.Name,
+			Offset: offset,
+			Size:   size,
+			Type:   sf.Type,
+		})
+		offset += size
+	}
+
+	return fields
+}
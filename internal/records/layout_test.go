@@ -0,0 +1,124 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package records_test
+
+import (
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/records"
+)
+
+func Test_Layout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		magic   string
+		offsets map[string]int // expected offsets, as in the struct comments
+		end     int            // offset just past the last fixed size field
+	}{
+		{
+			name:  "EFDF",
+			magic: records.MagicEFDF,
+			offsets: map[string]int{
+				"Magic":   0x00,
+				"Length":  0x08,
+				"CodeB":   0x26,
+				"Title":   0xC0,
+				"Remarks": 0x100,
+			},
+			end: 0x200,
+		},
+		{
+			name:  "EFRM",
+			magic: records.MagicEFRM,
+			offsets: map[string]int{
+				"FrameNumber":      0x18,
+				"CustomFunction0":  0x64,
+				"FocusPoints8":     0x82,
+				"BatteryYear":      0x8B,
+				"IsModifiedRecord": 0x9F,
+				"CodeB":            0xA2,
+				"RollYear":         0xAE,
+				"Remarks":          0x100,
+			},
+			end: 0x200,
+		},
+		{
+			name:  "EFTP",
+			magic: records.MagicEFTP,
+			offsets: map[string]int{
+				"Index":    0x10,
+				"Width":    0x14,
+				"Filepath": 0x20,
+				"Pixels":   0x120,
+			},
+			end: 0x120,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			layout := records.Layout(tt.magic)
+
+			got := make(map[string]int, len(layout))
+			end := 0
+
+			for _, f := range layout {
+				if f.Offset < end {
+					t.Errorf("field %s at %#x overlaps the previous field",
+						f.Name, f.Offset)
+				}
+
+				got[f.Name] = f.Offset
+				if f.Size >= 0 {
+					end = f.Offset + f.Size
+				}
+			}
+
+			for name, offset := range tt.offsets {
+				if got[name] != offset {
+					t.Errorf("expected %s at %#x, got %#x",
+						name, offset, got[name])
+				}
+			}
+
+			if end != tt.end {
+				t.Errorf("expected fixed fields to end at %#x, got %#x",
+					tt.end, end)
+			}
+		})
+	}
+
+	if records.Layout("XXXX") != nil {
+		t.Error("expected no layout for an unknown magic number")
+	}
+}
+
+func Test_Field_Unknown(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range records.Layout(records.MagicEFRM) {
+		want := f.Name == "HeaderUnknown" ||
+			len(f.Name) > 7 && f.Name[:7] == "Unknown"
+		if f.Unknown() != want {
+			t.Errorf("expected %s unknown to be %t", f.Name, want)
+		}
+	}
+}
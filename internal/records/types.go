@@ -23,19 +23,43 @@ package records
 
 import "image"
 
+// Magic numbers identifying each record type in an EFD file.
+const (
+	MagicEFDF = "EFDF"
+	MagicEFRM = "EFRM"
+	MagicEFTP = "EFTP"
+)
+
+// Record is a decoded EFD record: one of EFDF, EFRM or EFTP.
+type Record interface {
+	// Magic returns the magic number identifying the record type.
+	Magic() string
+}
+
 // Raw represents a raw EFD record with its magic bytes, length, and binary data payload.
 type Raw struct {
-	Magic  [4]byte
-	Length uint64
-	Data   []byte
+	Magic   [4]byte
+	Unknown [4]byte // header bytes between the magic and the length
+	Length  uint64
+	Data    []byte
 }
 
 // Root represents the complete parsed structure of an EFD file,
 // containing film roll metadata (EFDF), frame metadata (EFRM), and thumbnail data (EFTP).
 type Root struct {
-	EFDF  EFDF
-	EFRMs []EFRM
-	EFTPs []EFTP
+	EFDF       EFDF
+	EFRMs      []EFRM
+	EFTPs      []EFTP
+	Placements []Placement // file order of the records, nil if not read from a file
+}
+
+// Placement locates a record of a Root in the file it was read from and keeps the
+// bytes of it that are not decoded, so that writing the Root back reproduces the file.
+type Placement struct {
+	Magic    string  // record type
+	Index    int     // position of the record in EFRMs or EFTPs, 0 for the EFDF
+	Unknown  [4]byte // header bytes between the magic and the length
+	Trailing []byte  // bytes after the decoded fields, nil if there are none
 }
 
 // EFDF contains metadata about the entire film roll,
@@ -63,6 +87,9 @@ type EFDF struct {
 	Remarks    [256]byte
 }
 
+// Magic returns the EFDF magic number.
+func (EFDF) Magic() string { return MagicEFDF }
+
 // EFRM contains detailed metadata for a single frame, including exposure settings, camera modes,
 // timestamps, custom functions, and focus point data. The structure is 512 bytes (0x200).
 type EFRM struct {
@@ -156,6 +183,9 @@ type EFRM struct {
 	Remarks [256]byte // 0x100-0x1FF
 }
 
+// Magic returns the EFRM magic number.
+func (EFRM) Magic() string { return MagicEFRM }
+
 // EFTP contains thumbnail image data for a frame, including dimensions, file path reference,
 // and the decoded RGB image.
 type EFTP struct {
@@ -169,3 +199,6 @@ type EFTP struct {
 	Filepath  [256]byte // 0x20-0x11F
 	Thumbnail *image.RGBA
 }
+
+// Magic returns the EFTP magic number.
+func (EFTP) Magic() string { return MagicEFTP }
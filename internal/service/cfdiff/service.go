@@ -0,0 +1,356 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=cfdiff_test github.com/ma-tf/meta1v/internal/service/cfdiff Service
+
+// Package cfdiff finds where custom function settings change.
+//
+// It compares the custom functions decoded for each frame, either between the
+// frames of one roll, between the settings carried over from one roll to the
+// next, or against a preset, so changes made mid-roll do not go unnoticed.
+package cfdiff
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+const (
+	filmIDWidth               = 8
+	frameNumberWidth          = 9
+	customFunctionNumberWidth = 7
+	customFunctionNameWidth   = 35
+)
+
+var ErrFailedToWriteJSON = errors.New("failed to write custom function changes")
+
+// Frame identifies the frame a setting was in effect at.
+type Frame struct {
+	FilmID      domain.FilmID `json:"filmId"`
+	FrameNumber uint          `json:"frameNumber"`
+}
+
+// Change is a custom function whose value differs between two frames.
+type Change struct {
+	From     Frame  `json:"from"`     // last frame with the old value
+	To       Frame  `json:"to"`       // first frame with the new value
+	Function int    `json:"function"` // numbered from 0 as on the camera
+	Name     string `json:"name"`
+	Old      string `json:"old"` // value followed by its meaning, if known
+	New      string `json:"new"`
+}
+
+// Deviation is a custom function of a frame whose value differs from a preset.
+type Deviation struct {
+	Frame    Frame  `json:"frame"`
+	Function int    `json:"function"` // numbered from 0 as on the camera
+	Name     string `json:"name"`
+	Expected string `json:"expected"` // value followed by its meaning, if known
+	Actual   string `json:"actual"`
+}
+
+// Service finds and reports changes of custom function settings.
+type Service interface {
+	// Frames returns the changes between consecutive frames of a roll, in frame
+	// number order. Frames without any custom function recorded are skipped.
+	Frames(ctx context.Context, r display.DisplayableRoll) []Change
+
+	// Rolls compares the settings in effect at the end of roll a with those at
+	// the start of roll b.
+	Rolls(ctx context.Context, a, b display.DisplayableRoll) []Change
+
+	// Check returns the custom functions of each frame that differ from the preset,
+	// in frame number order. Frames without any custom function recorded are skipped.
+	Check(
+		ctx context.Context,
+		r display.DisplayableRoll,
+		preset domain.CustomFunctionPreset,
+	) []Deviation
+
+	// WriteDeviations writes the deviations from the preset as a human-readable table.
+	WriteDeviations(
+		ctx context.Context,
+		w io.Writer,
+		preset domain.CustomFunctionPreset,
+		deviations []Deviation,
+	)
+
+	// WriteTable writes the changes as a human-readable table.
+	WriteTable(ctx context.Context, w io.Writer, changes []Change)
+
+	// WriteJSON writes the changes as indented JSON.
+	WriteJSON(ctx context.Context, w io.Writer, changes []Change) error
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+func (s *service) Frames(
+	ctx context.Context,
+	r display.DisplayableRoll,
+) []Change {
+	frames := recorded(r)
+
+	var changes []Change
+
+	for i := 1; i < len(frames); i++ {
+		changes = append(changes, compare(frames[i-1], frames[i])...)
+	}
+
+	s.log.DebugContext(ctx, "compared custom functions of frames",
+		slog.String("film_id", string(r.FilmID)),
+		slog.Int("frames", len(frames)),
+		slog.Int("changes", len(changes)))
+
+	return changes
+}
+
+func (s *service) Rolls(
+	ctx context.Context,
+	a, b display.DisplayableRoll,
+) []Change {
+	from, to := recorded(a), recorded(b)
+	if len(from) == 0 || len(to) == 0 {
+		s.log.WarnContext(ctx, "no custom functions recorded to compare",
+			slog.Int("frames_a", len(from)),
+			slog.Int("frames_b", len(to)))
+
+		return nil
+	}
+
+	changes := compare(from[len(from)-1], to[0])
+
+	s.log.DebugContext(ctx, "compared custom functions of rolls",
+		slog.String("film_id_a", string(a.FilmID)),
+		slog.String("film_id_b", string(b.FilmID)),
+		slog.Int("changes", len(changes)))
+
+	return changes
+}
+
+func (s *service) Check(
+	ctx context.Context,
+	r display.DisplayableRoll,
+	preset domain.CustomFunctionPreset,
+) []Deviation {
+	frames := recorded(r)
+
+	var deviations []Deviation
+
+	for _, fr := range frames {
+		for _, n := range preset.Deviations(fr.CustomFunctions) {
+			deviations = append(deviations, Deviation{
+				Frame: Frame{
+					FilmID:      fr.FilmID,
+					FrameNumber: fr.FrameNumber,
+				},
+				Function: n,
+				Name:     domain.CustomFunctionName(n),
+				Expected: domain.DescribeCustomFunctionSetting(
+					n,
+					preset.Values[n],
+				),
+				Actual: fr.CustomFunctions.DescribeSetting(n),
+			})
+		}
+	}
+
+	s.log.DebugContext(ctx, "checked custom functions against preset",
+		slog.String("film_id", string(r.FilmID)),
+		slog.String("preset", preset.Name),
+		slog.Int("frames", len(frames)),
+		slog.Int("deviations", len(deviations)))
+
+	return deviations
+}
+
+// recorded returns the frames of r with any custom function recorded, sorted
+// by frame number.
+func recorded(r display.DisplayableRoll) []display.DisplayableFrame {
+	frames := slices.DeleteFunc(
+		slices.Clone(r.Frames),
+		func(fr display.DisplayableFrame) bool {
+			return !slices.ContainsFunc(fr.CustomFunctions[:], isSet)
+		},
+	)
+
+	slices.SortStableFunc(frames, func(a, b display.DisplayableFrame) int {
+		return cmp.Compare(a.FrameNumber, b.FrameNumber)
+	})
+
+	return frames
+}
+
+func isSet(value string) bool {
+	return strings.TrimSpace(value) != ""
+}
+
+func compare(from, to display.DisplayableFrame) []Change {
+	var changes []Change
+
+	for n := range from.CustomFunctions {
+		if from.CustomFunctions[n] == to.CustomFunctions[n] {
+			continue
+		}
+
+		changes = append(changes, Change{
+			From: Frame{
+				FilmID:      from.FilmID,
+				FrameNumber: from.FrameNumber,
+			},
+			To: Frame{
+				FilmID:      to.FilmID,
+				FrameNumber: to.FrameNumber,
+			},
+			Function: n,
+			Name:     domain.CustomFunctionName(n),
+			Old:      from.CustomFunctions.DescribeSetting(n),
+			New:      to.CustomFunctions.DescribeSetting(n),
+		})
+	}
+
+	return changes
+}
+
+func (s *service) WriteTable(
+	ctx context.Context,
+	w io.Writer,
+	changes []Change,
+) {
+	s.log.DebugContext(ctx, "writing custom function changes table",
+		slog.Int("changes", len(changes)))
+
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "no custom function changes found")
+
+		return
+	}
+
+	header := fmt.Sprintf("%-*s %-*s %-*s %-*s %s",
+		filmIDWidth, "FILM ID",
+		frameNumberWidth, "FRAME NO.",
+		customFunctionNumberWidth, "C.Fn",
+		customFunctionNameWidth, "FUNCTION",
+		"CHANGE",
+	)
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, strings.Repeat("-", len(header)))
+
+	for _, c := range changes {
+		fmt.Fprintf(w, "%-*s %-*d %-*s %-*s %s -> %s\n",
+			filmIDWidth, c.To.FilmID,
+			frameNumberWidth, c.To.FrameNumber,
+			customFunctionNumberWidth, fmt.Sprintf("C.Fn-%d", c.Function),
+			customFunctionNameWidth, c.Name,
+			orUnset(c.Old),
+			orUnset(c.New),
+		)
+	}
+}
+
+func (s *service) WriteDeviations(
+	ctx context.Context,
+	w io.Writer,
+	preset domain.CustomFunctionPreset,
+	deviations []Deviation,
+) {
+	s.log.DebugContext(ctx, "writing custom function deviations table",
+		slog.String("preset", preset.Name),
+		slog.Int("deviations", len(deviations)))
+
+	if len(deviations) == 0 {
+		fmt.Fprintf(w, "every frame matches preset %q\n", preset.Name)
+
+		return
+	}
+
+	header := fmt.Sprintf("%-*s %-*s %-*s %-*s %s",
+		filmIDWidth, "FILM ID",
+		frameNumberWidth, "FRAME NO.",
+		customFunctionNumberWidth, "C.Fn",
+		customFunctionNameWidth, "FUNCTION",
+		"SETTING",
+	)
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, strings.Repeat("-", len(header)))
+
+	for _, d := range deviations {
+		fmt.Fprintf(w, "%-*s %-*d %-*s %-*s %s (preset %s: %s)\n",
+			filmIDWidth, d.Frame.FilmID,
+			frameNumberWidth, d.Frame.FrameNumber,
+			customFunctionNumberWidth, fmt.Sprintf("C.Fn-%d", d.Function),
+			customFunctionNameWidth, d.Name,
+			orUnset(d.Actual),
+			preset.Name,
+			d.Expected,
+		)
+	}
+
+	frames := make(map[Frame]bool, len(deviations))
+	for _, d := range deviations {
+		frames[d.Frame] = true
+	}
+
+	fmt.Fprintf(w, "\n%d frame(s) differ from preset %q\n",
+		len(frames), preset.Name)
+}
+
+func orUnset(setting string) string {
+	if setting == "" {
+		return "(unset)"
+	}
+
+	return setting
+}
+
+func (s *service) WriteJSON(
+	ctx context.Context,
+	w io.Writer,
+	changes []Change,
+) error {
+	s.log.DebugContext(ctx, "writing custom function changes as JSON",
+		slog.Int("changes", len(changes)))
+
+	if changes == nil {
+		changes = []Change{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(changes); err != nil {
+		return errors.Join(ErrFailedToWriteJSON, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,445 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cfdiff_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/cfdiff"
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+var errExample = errors.New("example error")
+
+type failWriter struct{}
+
+func (fw *failWriter) Write(_ []byte) (int, error) {
+	return 0, errExample
+}
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newFrame returns a frame with every custom function set to 0 apart from the
+// given overrides, keyed by custom function number.
+//
+//nolint:exhaustruct // only partial is needed
+func newFrame(
+	filmID domain.FilmID,
+	frameNumber uint,
+	overrides map[int]string,
+) display.DisplayableFrame {
+	var cfs domain.CustomFunctions
+	for n := range cfs {
+		cfs[n] = "0"
+	}
+
+	for n, v := range overrides {
+		cfs[n] = v
+	}
+
+	return display.DisplayableFrame{
+		FilmID:          filmID,
+		FrameNumber:     frameNumber,
+		CustomFunctions: cfs,
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func unrecorded(frameNumber uint) display.DisplayableFrame {
+	var cfs domain.CustomFunctions
+	for n := range cfs {
+		cfs[n] = " "
+	}
+
+	return display.DisplayableFrame{
+		FilmID:          "12-345",
+		FrameNumber:     frameNumber,
+		CustomFunctions: cfs,
+	}
+}
+
+type change struct {
+	from, to uint
+	function int
+	old, new string
+}
+
+func summarise(changes []cfdiff.Change) []change {
+	got := make([]change, 0, len(changes))
+	for _, c := range changes {
+		got = append(got, change{
+			c.From.FrameNumber,
+			c.To.FrameNumber,
+			c.Function,
+			c.Old,
+			c.New,
+		})
+	}
+
+	return got
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Frames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		frames   []display.DisplayableFrame
+		expected []change
+	}{
+		{
+			name: "no changes",
+			frames: []display.DisplayableFrame{
+				newFrame("12-345", 1, nil),
+				newFrame("12-345", 2, nil),
+			},
+			expected: []change{},
+		},
+		{
+			name: "changes mid-roll",
+			frames: []display.DisplayableFrame{
+				newFrame("12-345", 1, nil),
+				newFrame("12-345", 2, map[int]string{4: "1"}),
+				newFrame("12-345", 3, map[int]string{4: "1", 15: "1"}),
+				newFrame("12-345", 4, map[int]string{15: "1"}),
+			},
+			expected: []change{
+				{
+					1, 2, 4,
+					"0 = AF start / AE lock",
+					"1 = AE lock / AF start",
+				},
+				{2, 3, 15, "0 = First curtain", "1 = Second curtain"},
+				{
+					3, 4, 4,
+					"1 = AE lock / AF start",
+					"0 = AF start / AE lock",
+				},
+			},
+		},
+		{
+			name: "frames out of order and without custom functions",
+			frames: []display.DisplayableFrame{
+				newFrame("12-345", 5, map[int]string{12: "1"}),
+				unrecorded(3),
+				newFrame("12-345", 1, nil),
+			},
+			expected: []change{
+				{1, 5, 12, "0 = Disabled", "1 = Enabled"},
+			},
+		},
+		{
+			name: "partly unset custom functions",
+			frames: []display.DisplayableFrame{
+				newFrame("12-345", 1, map[int]string{19: " "}),
+				newFrame("12-345", 2, nil),
+			},
+			expected: []change{{1, 2, 19, "", "0 = AF stop"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changes := cfdiff.NewService(newTestLogger()).Frames(
+				t.Context(),
+				display.DisplayableRoll{Frames: tt.frames},
+			)
+
+			if got := summarise(changes); !slices.Equal(got, tt.expected) {
+				t.Errorf("unexpected changes:\ngot  %v\nwant %v",
+					got, tt.expected)
+			}
+
+			for _, c := range changes {
+				if c.Name != domain.CustomFunctionName(c.Function) {
+					t.Errorf("unexpected name %q for C.Fn-%d",
+						c.Name, c.Function)
+				}
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Rolls(t *testing.T) {
+	t.Parallel()
+
+	a := display.DisplayableRoll{
+		FilmID: "12-345",
+		Frames: []display.DisplayableFrame{
+			newFrame("12-345", 2, map[int]string{6: "2"}),
+			newFrame("12-345", 1, nil),
+			unrecorded(3),
+		},
+	}
+	b := display.DisplayableRoll{
+		FilmID: "12-346",
+		Frames: []display.DisplayableFrame{
+			newFrame("12-346", 1, map[int]string{6: "2", 13: "1"}),
+			newFrame("12-346", 2, nil),
+		},
+	}
+
+	svc := cfdiff.NewService(newTestLogger())
+
+	changes := svc.Rolls(t.Context(), a, b)
+
+	expected := []change{{
+		2, 1, 13,
+		"0 = 45 points, centre spot metering",
+		"1 = 45 points, spot metering linked to focusing point",
+	}}
+	if got := summarise(changes); !slices.Equal(got, expected) {
+		t.Errorf("unexpected changes:\ngot  %v\nwant %v", got, expected)
+	}
+
+	if changes[0].From.FilmID != "12-345" || changes[0].To.FilmID != "12-346" {
+		t.Errorf("unexpected film IDs %q and %q",
+			changes[0].From.FilmID, changes[0].To.FilmID)
+	}
+
+	empty := display.DisplayableRoll{
+		Frames: []display.DisplayableFrame{unrecorded(1)},
+	}
+	if changes := svc.Rolls(t.Context(), a, empty); changes != nil {
+		t.Errorf("expected no changes without custom functions, got %v",
+			changes)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Check(t *testing.T) {
+	t.Parallel()
+
+	preset, err := domain.NewCustomFunctionPreset(
+		"sports",
+		map[int]byte{4: 2, 13: 1},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := display.DisplayableRoll{
+		Frames: []display.DisplayableFrame{
+			newFrame("12-345", 2, map[int]string{4: "2", 13: "1"}),
+			newFrame("12-345", 3, map[int]string{4: "2", 13: " "}),
+			unrecorded(4),
+			newFrame("12-345", 1, map[int]string{13: "1"}),
+		},
+	}
+
+	deviations := cfdiff.NewService(newTestLogger()).
+		Check(t.Context(), r, preset)
+
+	expected := []cfdiff.Deviation{
+		{
+			Frame:    cfdiff.Frame{FilmID: "12-345", FrameNumber: 1},
+			Function: 4,
+			Name:     "AF start/AE lock button",
+			Expected: "2 = AF start / AF stop, no AE lock",
+			Actual:   "0 = AF start / AE lock",
+		},
+		{
+			Frame:    cfdiff.Frame{FilmID: "12-345", FrameNumber: 3},
+			Function: 13,
+			Name:     "Focusing points and spot metering",
+			Expected: "1 = 45 points, spot metering linked to focusing point",
+			Actual:   "",
+		},
+	}
+
+	if !slices.Equal(deviations, expected) {
+		t.Errorf("unexpected deviations:\ngot  %v\nwant %v",
+			deviations, expected)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_WriteDeviations(t *testing.T) {
+	t.Parallel()
+
+	svc := cfdiff.NewService(newTestLogger())
+	preset := domain.CustomFunctionPreset{
+		Name:   "sports",
+		Values: map[int]byte{4: 2},
+	}
+
+	tests := []struct {
+		name       string
+		deviations []cfdiff.Deviation
+		contains   []string
+	}{
+		{
+			name:       "no deviations",
+			deviations: nil,
+			contains:   []string{`every frame matches preset "sports"`},
+		},
+		{
+			name: "deviations",
+			deviations: []cfdiff.Deviation{
+				{
+					Frame:    cfdiff.Frame{FilmID: "12-345", FrameNumber: 1},
+					Function: 4,
+					Name:     "AF start/AE lock button",
+					Expected: "2 = AF start / AF stop, no AE lock",
+					Actual:   "0 = AF start / AE lock",
+				},
+			},
+			contains: []string{
+				"FILM ID  FRAME NO. C.Fn    FUNCTION",
+				"12-345   1         C.Fn-4  AF start/AE lock button",
+				"0 = AF start / AE lock " +
+					"(preset sports: 2 = AF start / AF stop, no AE lock)",
+				`1 frame(s) differ from preset "sports"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			svc.WriteDeviations(t.Context(), buf, preset, tt.deviations)
+
+			for _, want := range tt.contains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s",
+						want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_WriteTable(t *testing.T) {
+	t.Parallel()
+
+	svc := cfdiff.NewService(newTestLogger())
+
+	tests := []struct {
+		name     string
+		changes  []cfdiff.Change
+		contains []string
+	}{
+		{
+			name:     "no changes",
+			changes:  nil,
+			contains: []string{"no custom function changes found"},
+		},
+		{
+			name: "changes",
+			changes: []cfdiff.Change{
+				{
+					From:     cfdiff.Frame{FilmID: "12-345", FrameNumber: 1},
+					To:       cfdiff.Frame{FilmID: "12-345", FrameNumber: 2},
+					Function: 4,
+					Name:     "AF start/AE lock button",
+					Old:      "0 = AF start / AE lock",
+					New:      "",
+				},
+			},
+			contains: []string{
+				"FILM ID  FRAME NO. C.Fn    FUNCTION",
+				"12-345   2         C.Fn-4  AF start/AE lock button",
+				"0 = AF start / AE lock -> (unset)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			svc.WriteTable(t.Context(), buf, tt.changes)
+
+			for _, want := range tt.contains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s",
+						want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_WriteJSON(t *testing.T) {
+	t.Parallel()
+
+	svc := cfdiff.NewService(newTestLogger())
+
+	buf := &bytes.Buffer{}
+	if err := svc.WriteJSON(t.Context(), buf, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("expected an empty array, got %s", buf.String())
+	}
+
+	changes := []cfdiff.Change{{
+		From:     cfdiff.Frame{FilmID: "12-345", FrameNumber: 1},
+		To:       cfdiff.Frame{FilmID: "12-345", FrameNumber: 2},
+		Function: 12,
+		Name:     "Mirror lockup",
+		Old:      "0 = Disabled",
+		New:      "1 = Enabled",
+	}}
+
+	buf.Reset()
+
+	if err := svc.WriteJSON(t.Context(), buf, changes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []cfdiff.Change
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+
+	if !slices.Equal(got, changes) {
+		t.Errorf("unexpected round trip:\ngot  %v\nwant %v", got, changes)
+	}
+
+	for _, key := range []string{`"filmId"`, `"frameNumber"`, `"function"`} {
+		if !strings.Contains(buf.String(), key) {
+			t.Errorf("expected output to contain %s, got:\n%s",
+				key, buf.String())
+		}
+	}
+
+	err := svc.WriteJSON(t.Context(), &failWriter{}, changes)
+	if !errors.Is(err, cfdiff.ErrFailedToWriteJSON) {
+		t.Errorf("expected %v, got %v", cfdiff.ErrFailedToWriteJSON, err)
+	}
+}
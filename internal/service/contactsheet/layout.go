@@ -0,0 +1,148 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package contactsheet
+
+import (
+	"image"
+	"strconv"
+)
+
+// Sizes are in pixels for PNG and in points for SVG and PDF, so the three formats
+// lay out identically. Text is measured in the 7x13 monospace font used for PNG.
+const (
+	margin     = 24
+	columnGap  = 12
+	rowGap     = 16
+	captionGap = 4
+	lineHeight = 16
+	charWidth  = 7
+	ascent     = 11 // baseline below the top of a line
+
+	// size of the vector formats' monospace font, about as wide as charWidth
+	fontSize = 11.5
+
+	// cell size when no frame has a thumbnail
+	defaultThumbnailWidth  = 160
+	defaultThumbnailHeight = 120
+)
+
+// text is a line of text, positioned by the left end of its baseline.
+type text struct {
+	x, y int
+	s    string
+}
+
+// picture is a thumbnail drawn at its own size.
+type picture struct {
+	r   image.Rectangle
+	img image.Image
+}
+
+// page is a laid out sheet, ready to be drawn.
+type page struct {
+	width, height int
+	texts         []text
+	pictures      []picture
+	placeholders  []image.Rectangle // frames without a thumbnail
+}
+
+func layout(sheet Sheet) page {
+	thumbW, thumbH := thumbnailSize(sheet.Frames)
+
+	cellW := thumbW
+	for _, fr := range sheet.Frames {
+		cellW = max(cellW, textWidth(fr.Caption))
+	}
+
+	// the frame number and caption are printed below the thumbnail
+	cellH := thumbH + captionGap + 2*lineHeight //nolint:mnd // two lines
+
+	var p page
+
+	y := margin
+	for _, line := range sheet.Header {
+		p.texts = append(p.texts, text{x: margin, y: y + ascent, s: line})
+		p.width = max(p.width, 2*margin+textWidth(line))
+		y += lineHeight
+	}
+
+	if len(sheet.Header) > 0 {
+		y += rowGap
+	}
+
+	perRow := max(sheet.PerRow, 1)
+	cells := sheet.Offset + len(sheet.Frames)
+	rows := (cells + perRow - 1) / perRow
+
+	for i, fr := range sheet.Frames {
+		cell := sheet.Offset + i
+		x := margin + cell%perRow*(cellW+columnGap)
+		top := y + cell/perRow*(cellH+rowGap)
+
+		if fr.Thumbnail != nil {
+			b := fr.Thumbnail.Bounds()
+			//nolint:mnd // centred in the cell
+			at := image.Pt(x+(cellW-b.Dx())/2, top+(thumbH-b.Dy())/2)
+			p.pictures = append(p.pictures, picture{
+				r:   b.Sub(b.Min).Add(at),
+				img: fr.Thumbnail,
+			})
+		} else {
+			at := image.Pt(x+(cellW-thumbW)/2, top) //nolint:mnd // centred
+			p.placeholders = append(p.placeholders,
+				image.Rect(0, 0, thumbW, thumbH).Add(at))
+		}
+
+		baseline := top + thumbH + captionGap + ascent
+		number := strconv.FormatUint(uint64(fr.Number), 10)
+		p.texts = append(p.texts,
+			text{x: x, y: baseline, s: number},
+			text{x: x, y: baseline + lineHeight, s: fr.Caption},
+		)
+	}
+
+	p.width = max(p.width, 2*margin+perRow*cellW+(perRow-1)*columnGap)
+	p.height = y + margin
+
+	if rows > 0 {
+		p.height += rows*cellH + (rows-1)*rowGap
+	}
+
+	return p
+}
+
+// thumbnailSize returns the size of the largest thumbnail, so every cell fits.
+func thumbnailSize(frames []Frame) (int, int) {
+	w, h := 0, 0
+
+	for _, fr := range frames {
+		if fr.Thumbnail != nil {
+			w = max(w, fr.Thumbnail.Bounds().Dx())
+			h = max(h, fr.Thumbnail.Bounds().Dy())
+		}
+	}
+
+	if w == 0 || h == 0 {
+		return defaultThumbnailWidth, defaultThumbnailHeight
+	}
+
+	return w, h
+}
+
+func textWidth(s string) int {
+	return len([]rune(s)) * charWidth
+}
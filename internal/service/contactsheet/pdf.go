@@ -0,0 +1,205 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package contactsheet
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+)
+
+// fixed objects of the document, thumbnails follow as image objects
+const (
+	pdfCatalog = iota + 1
+	pdfPages
+	pdfPage
+	pdfFont
+	pdfContent
+	pdfFirstImage
+)
+
+// renderPDF writes a single page PDF 1.4 document. Courier is one of the standard
+// fonts every reader has, so nothing needs to be embedded but the thumbnails.
+func renderPDF(w io.Writer, p page) error {
+	doc := &pdfWriter{buf: bytes.Buffer{}, offsets: nil}
+	doc.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	doc.object(pdfCatalog,
+		fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPages))
+	doc.object(pdfPages,
+		fmt.Sprintf("<< /Type /Pages /Kids [%d 0 R] /Count 1 >>", pdfPage))
+
+	xobjects := make([]string, 0, len(p.pictures))
+	for i := range p.pictures {
+		xobjects = append(xobjects,
+			fmt.Sprintf("/Im%d %d 0 R", i, pdfFirstImage+i))
+	}
+
+	doc.object(pdfPage, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 %d 0 R >> /XObject << %s >> >> "+
+			"/Contents %d 0 R >>",
+		pdfPages, p.width, p.height,
+		pdfFont, strings.Join(xobjects, " "), pdfContent))
+	doc.object(pdfFont,
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>")
+
+	doc.stream(pdfContent, "", pdfContentStream(p))
+
+	for i, pic := range p.pictures {
+		data, err := pdfImageData(pic.img)
+		if err != nil {
+			return err
+		}
+
+		b := pic.img.Bounds()
+		doc.stream(pdfFirstImage+i, fmt.Sprintf(
+			"/Type /XObject /Subtype /Image /Width %d /Height %d "+
+				"/ColorSpace /DeviceRGB /BitsPerComponent 8 "+
+				"/Filter /FlateDecode ",
+			b.Dx(), b.Dy()), data)
+	}
+
+	doc.trailer()
+
+	_, err := doc.buf.WriteTo(w)
+
+	return err //nolint:wrapcheck // wrapped by caller
+}
+
+// pdfContentStream draws the page. PDF puts the origin at the bottom left, so
+// every y coordinate of the layout is flipped.
+func pdfContentStream(p page) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "%.3f g\n", float64(placeholderColor.R)/0xFF)
+
+	for _, r := range p.placeholders {
+		fmt.Fprintf(&b, "%d %d %d %d re f\n",
+			r.Min.X, p.height-r.Max.Y, r.Dx(), r.Dy())
+	}
+
+	for i, pic := range p.pictures {
+		fmt.Fprintf(&b, "q %d 0 0 %d %d %d cm /Im%d Do Q\n",
+			pic.r.Dx(), pic.r.Dy(), pic.r.Min.X, p.height-pic.r.Max.Y, i)
+	}
+
+	b.WriteString("0 g\n")
+
+	for _, t := range p.texts {
+		fmt.Fprintf(&b, "BT /F1 %g Tf %d %d Td (%s) Tj ET\n",
+			fontSize, t.x, p.height-t.y, pdfString(t.s))
+	}
+
+	return b.Bytes()
+}
+
+// pdfString escapes s for a literal string. The standard fonts only cover
+// Latin-1, so anything outside ASCII is replaced.
+func pdfString(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > '~':
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// pdfImageData returns the pixels of img as compressed 8 bit RGB rows.
+func pdfImageData(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := zlib.NewWriter(&buf)
+	b := img.Bounds()
+	row := make([]byte, 0, 3*b.Dx()) //nolint:mnd // RGB
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row = row[:0]
+
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := img.At(x, y).RGBA()
+
+			//nolint:mnd,gosec // RGBA returns 16 bit channels
+			row = append(row, byte(r>>8), byte(g>>8), byte(bl>>8))
+		}
+
+		if _, err := zw.Write(row); err != nil {
+			return nil, err //nolint:wrapcheck // wrapped by caller
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return buf.Bytes(), nil
+}
+
+// pdfWriter builds a document in memory, tracking where each object starts for
+// the cross-reference table.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (d *pdfWriter) object(n int, body string) {
+	d.begin(n)
+	fmt.Fprintf(&d.buf, "%s\nendobj\n", body)
+}
+
+// stream writes a stream object, dict holding any entries besides its length.
+func (d *pdfWriter) stream(n int, dict string, data []byte) {
+	d.begin(n)
+	fmt.Fprintf(&d.buf, "<< %s/Length %d >>\nstream\n", dict, len(data))
+	d.buf.Write(data)
+	d.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (d *pdfWriter) begin(n int) {
+	for len(d.offsets) < n {
+		d.offsets = append(d.offsets, 0)
+	}
+
+	d.offsets[n-1] = d.buf.Len()
+	fmt.Fprintf(&d.buf, "%d 0 obj\n", n)
+}
+
+func (d *pdfWriter) trailer() {
+	xref := d.buf.Len()
+
+	fmt.Fprintf(&d.buf, "xref\n0 %d\n0000000000 65535 f \n", len(d.offsets)+1)
+
+	for _, offset := range d.offsets {
+		fmt.Fprintf(&d.buf, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&d.buf, "trailer\n<< /Size %d /Root %d 0 R >>\n",
+		len(d.offsets)+1, pdfCatalog)
+	fmt.Fprintf(&d.buf, "startxref\n%d\n%%%%EOF\n", xref)
+}
@@ -0,0 +1,60 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package contactsheet
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+//nolint:gochecknoglobals // fixed palette
+var placeholderColor = color.RGBA{0xDD, 0xDD, 0xDD, 0xFF}
+
+func renderPNG(w io.Writer, p page) error {
+	img := image.NewRGBA(image.Rect(0, 0, p.width, p.height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for _, r := range p.placeholders {
+		draw.Draw(img, r, image.NewUniform(placeholderColor), image.Point{},
+			draw.Src)
+	}
+
+	for _, pic := range p.pictures {
+		draw.Draw(img, pic.r, pic.img, pic.img.Bounds().Min, draw.Src)
+	}
+
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.Black,
+		Face: basicfont.Face7x13,
+		Dot:  fixed.Point26_6{},
+	}
+
+	for _, t := range p.texts {
+		d.Dot = fixed.P(t.x, t.y)
+		d.DrawString(t.s)
+	}
+
+	return png.Encode(w, img) //nolint:wrapcheck // wrapped by caller
+}
@@ -0,0 +1,189 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=contactsheet_test github.com/ma-tf/meta1v/internal/service/contactsheet Service
+
+// Package contactsheet renders printable contact sheets of the thumbnails of a roll.
+//
+// Frames are laid out in strips the way the roll records it: PerRow frames to a
+// row, with the first row shortened by FirstRow so the strips line up with cut
+// negatives. Each frame is captioned with its number and exposure, and the sheet
+// is headed with the roll's film ID, title, load date and ISO.
+package contactsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+	FormatPDF = "pdf"
+
+	// frames per row when the roll does not record a layout, one strip of 35mm negatives
+	defaultPerRow = 6
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported contact sheet format")
+	ErrFailedToRender    = errors.New("failed to render contact sheet")
+)
+
+// Frame is a frame as it appears on the sheet.
+type Frame struct {
+	Number    uint
+	Caption   string      // exposure summary, such as "1/250 f/5.6 +0.3"
+	Thumbnail image.Image // nil if the frame has no thumbnail
+}
+
+// Sheet is the content and layout of a contact sheet.
+type Sheet struct {
+	Header []string // lines printed above the frames
+	Offset int      // empty cells before the first frame
+	PerRow int
+	Frames []Frame
+}
+
+// NewSheet builds the sheet of a roll. The captions and header come from roll, the
+// layout and thumbnails from the records it was built from.
+func NewSheet(roll display.DisplayableRoll, root records.Root) Sheet {
+	perRow := int(root.EFDF.PerRow)
+	if perRow == 0 {
+		perRow = defaultPerRow
+	}
+
+	thumbnails := make(map[uint16]image.Image, len(root.EFTPs))
+	for _, eftp := range root.EFTPs {
+		if eftp.Thumbnail != nil {
+			thumbnails[eftp.Index] = eftp.Thumbnail
+		}
+	}
+
+	frames := make([]Frame, 0, len(roll.Frames))
+	for i, fr := range roll.Frames {
+		var caption []string
+		if fr.Tv != "" {
+			caption = append(caption, string(fr.Tv))
+		}
+
+		if fr.Av != "" {
+			caption = append(caption, string(fr.Av))
+		}
+
+		if fr.ExposureCompensation != "" {
+			caption = append(caption, string(fr.ExposureCompensation))
+		}
+
+		frames = append(frames, Frame{
+			Number:  fr.FrameNumber,
+			Caption: strings.Join(caption, " "),
+			// Index is the 1-based position of the frame the thumbnail belongs to
+			//nolint:gosec // a roll has far fewer frames
+			Thumbnail: thumbnails[uint16(i+1)],
+		})
+	}
+
+	return Sheet{
+		Header: header(roll),
+		Offset: min(int(root.EFDF.FirstRow), perRow-1),
+		PerRow: perRow,
+		Frames: frames,
+	}
+}
+
+func header(roll display.DisplayableRoll) []string {
+	var lines []string
+
+	if roll.Title != "" {
+		lines = append(lines, string(roll.Title))
+	}
+
+	details := make([]string, 0, 3) //nolint:mnd // film ID, load date, ISO
+
+	if roll.FilmID != "" {
+		details = append(details, "Film ID "+string(roll.FilmID))
+	}
+
+	details = append(details, "Loaded "+string(roll.FilmLoadedDate))
+
+	if roll.IsoDX != "" {
+		details = append(details, "ISO "+string(roll.IsoDX))
+	}
+
+	return append(lines, strings.Join(details, "   "))
+}
+
+// Service renders contact sheets.
+type Service interface {
+	// Render writes sheet to w as a FormatPNG, FormatSVG or FormatPDF document.
+	Render(ctx context.Context, w io.Writer, sheet Sheet, format string) error
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+func (s *service) Render(
+	ctx context.Context,
+	w io.Writer,
+	sheet Sheet,
+	format string,
+) error {
+	s.log.InfoContext(ctx, "rendering contact sheet",
+		slog.String("format", format),
+		slog.Int("frames", len(sheet.Frames)),
+		slog.Int("per_row", sheet.PerRow))
+
+	var render func(io.Writer, page) error
+
+	switch format {
+	case FormatPNG:
+		render = renderPNG
+	case FormatSVG:
+		render = renderSVG
+	case FormatPDF:
+		render = renderPDF
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	p := layout(sheet)
+
+	s.log.DebugContext(ctx, "contact sheet laid out",
+		slog.Int("width", p.width),
+		slog.Int("height", p.height))
+
+	if err := render(w, p); err != nil {
+		return errors.Join(ErrFailedToRender, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,305 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package contactsheet_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/contactsheet"
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+var errExample = errors.New("example error")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errExample }
+
+func newThumbnail(c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 12))
+
+	for y := range 12 {
+		for x := range 16 {
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_NewSheet(t *testing.T) {
+	t.Parallel()
+
+	red := newThumbnail(color.RGBA{0xFF, 0, 0, 0xFF})
+
+	tests := []struct {
+		name     string
+		roll     display.DisplayableRoll
+		root     records.Root
+		expected contactsheet.Sheet
+	}{
+		{
+			name: "roll layout and captions",
+			roll: display.DisplayableRoll{
+				FilmID:         "12-345",
+				Title:          "Holiday",
+				FilmLoadedDate: "2024-05-01 09:00:00",
+				IsoDX:          "400",
+				Frames: []display.DisplayableFrame{
+					{
+						FrameNumber:          1,
+						Tv:                   "1/250",
+						Av:                   "f/5.6",
+						ExposureCompensation: "+0.3",
+					},
+					{FrameNumber: 2, Tv: "30\""},
+				},
+			},
+			root: records.Root{
+				EFDF:  records.EFDF{FirstRow: 2, PerRow: 5},
+				EFTPs: []records.EFTP{{Index: 2, Thumbnail: red}},
+			},
+			expected: contactsheet.Sheet{
+				Header: []string{
+					"Holiday",
+					"Film ID 12-345   Loaded 2024-05-01 09:00:00   ISO 400",
+				},
+				Offset: 2,
+				PerRow: 5,
+				Frames: []contactsheet.Frame{
+					{Number: 1, Caption: "1/250 f/5.6 +0.3"},
+					{Number: 2, Caption: "30\"", Thumbnail: red},
+				},
+			},
+		},
+		{
+			name: "no recorded layout",
+			roll: display.DisplayableRoll{
+				FilmLoadedDate: "2024-05-01 09:00:00",
+				Frames:         []display.DisplayableFrame{{FrameNumber: 1}},
+			},
+			root: records.Root{},
+			expected: contactsheet.Sheet{
+				Header: []string{"Loaded 2024-05-01 09:00:00"},
+				Offset: 0,
+				PerRow: 6,
+				Frames: []contactsheet.Frame{{Number: 1}},
+			},
+		},
+		{
+			name: "first row no shorter than a row",
+			roll: display.DisplayableRoll{},
+			root: records.Root{
+				EFDF: records.EFDF{FirstRow: 4, PerRow: 4},
+			},
+			expected: contactsheet.Sheet{
+				Header: []string{"Loaded "},
+				Offset: 3,
+				PerRow: 4,
+				Frames: []contactsheet.Frame{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := contactsheet.NewSheet(tt.roll, tt.root)
+
+			if !slices.Equal(got.Header, tt.expected.Header) {
+				t.Errorf("expected header %q, got %q",
+					tt.expected.Header, got.Header)
+			}
+
+			if got.Offset != tt.expected.Offset ||
+				got.PerRow != tt.expected.PerRow {
+				t.Errorf("expected offset %d and %d per row, got %d and %d",
+					tt.expected.Offset, tt.expected.PerRow,
+					got.Offset, got.PerRow)
+			}
+
+			if !slices.EqualFunc(got.Frames, tt.expected.Frames,
+				func(a, b contactsheet.Frame) bool {
+					return a.Number == b.Number && a.Caption == b.Caption &&
+						a.Thumbnail == b.Thumbnail
+				}) {
+				t.Errorf("expected frames %+v, got %+v",
+					tt.expected.Frames, got.Frames)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Render(t *testing.T) {
+	t.Parallel()
+
+	sheet := contactsheet.Sheet{
+		Header: []string{"Film ID 12-345 (test)"},
+		Offset: 1,
+		PerRow: 3,
+		Frames: []contactsheet.Frame{
+			{
+				Number:    1,
+				Caption:   "1/250 f/5.6",
+				Thumbnail: newThumbnail(color.RGBA{0xFF, 0, 0, 0xFF}),
+			},
+			{Number: 2, Caption: "1/125 f/8"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		w             io.Writer
+		format        string
+		contains      []string
+		expectedError error
+	}{
+		{
+			name:     "png",
+			w:        &bytes.Buffer{},
+			format:   contactsheet.FormatPNG,
+			contains: []string{"\x89PNG"},
+		},
+		{
+			name:   "svg",
+			w:      &bytes.Buffer{},
+			format: contactsheet.FormatSVG,
+			contains: []string{
+				"<svg",
+				"data:image/png;base64,",
+				">Film ID 12-345 (test)</text>",
+				">1/125 f/8</text>",
+				"</svg>",
+			},
+		},
+		{
+			name:   "pdf",
+			w:      &bytes.Buffer{},
+			format: contactsheet.FormatPDF,
+			contains: []string{
+				"%PDF-1.4",
+				"/Subtype /Image /Width 16 /Height 12",
+				"(Film ID 12-345 \\(test\\)) Tj",
+				"(1/125 f/8) Tj",
+				"%%EOF",
+			},
+		},
+		{
+			name:          "unsupported format",
+			w:             &bytes.Buffer{},
+			format:        "gif",
+			expectedError: contactsheet.ErrUnsupportedFormat,
+		},
+		{
+			name:          "write fails",
+			w:             failingWriter{},
+			format:        contactsheet.FormatSVG,
+			expectedError: contactsheet.ErrFailedToRender,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := contactsheet.NewService(slog.New(slog.DiscardHandler)).
+				Render(t.Context(), tt.w, sheet, tt.format)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if tt.expectedError != nil {
+				return
+			}
+
+			//nolint:forcetypeassert // test writer
+			out := tt.w.(*bytes.Buffer).String()
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+// Test_Render_Layout checks cells are placed in strips, the first row starting
+// after the sheet's offset.
+//
+//nolint:exhaustruct // only partial is needed
+func Test_Render_Layout(t *testing.T) {
+	t.Parallel()
+
+	red := color.RGBA{0xFF, 0, 0, 0xFF}
+	white := color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	sheet := contactsheet.Sheet{
+		Offset: 2,
+		PerRow: 3,
+		Frames: []contactsheet.Frame{
+			{Number: 1, Thumbnail: newThumbnail(red)},
+			{Number: 2, Thumbnail: newThumbnail(red)},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := contactsheet.NewService(slog.New(slog.DiscardHandler)).
+		Render(t.Context(), buf, sheet, contactsheet.FormatPNG); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(buf)
+	if err != nil {
+		t.Fatalf("failed to decode sheet: %v", err)
+	}
+
+	// cells are as wide as the widest of the 16x12 thumbnails and the frame
+	// numbers, with a 24 margin, 12 between columns and 16 between rows
+	const cellW, cellH = 16, 12 + 4 + 2*16
+
+	if got := img.Bounds().Dx(); got != 2*24+3*cellW+2*12 {
+		t.Errorf("unexpected sheet width %d", got)
+	}
+
+	for _, tt := range []struct {
+		at       image.Point
+		expected color.RGBA
+	}{
+		{image.Pt(24, 24), white},
+		{image.Pt(24+2*(cellW+12), 24), red},
+		{image.Pt(24, 24+cellH+16), red},
+		{image.Pt(24+cellW+12, 24+cellH+16), white},
+	} {
+		got := color.RGBAModel.Convert(img.At(tt.at.X, tt.at.Y))
+
+		if got != tt.expected {
+			t.Errorf("expected %v at %v, got %v", tt.expected, tt.at, got)
+		}
+	}
+}
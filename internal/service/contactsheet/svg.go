@@ -0,0 +1,71 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package contactsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image/png"
+	"io"
+)
+
+func renderSVG(w io.Writer, p page) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">
+<rect width="100%%" height="100%%" fill="#ffffff"/>
+`, p.width, p.height, p.width, p.height)
+
+	for _, r := range p.placeholders {
+		fmt.Fprintf(bw,
+			`<rect x="%d" y="%d" width="%d" height="%d" fill="#%02x%02x%02x"/>`+"\n",
+			r.Min.X, r.Min.Y, r.Dx(), r.Dy(),
+			placeholderColor.R, placeholderColor.G, placeholderColor.B)
+	}
+
+	for _, pic := range p.pictures {
+		buf := &bytes.Buffer{}
+		if err := png.Encode(buf, pic.img); err != nil {
+			return err //nolint:wrapcheck // wrapped by caller
+		}
+
+		fmt.Fprintf(bw,
+			`<image x="%d" y="%d" width="%d" height="%d" href="data:image/png;base64,%s"/>`+"\n",
+			pic.r.Min.X, pic.r.Min.Y, pic.r.Dx(), pic.r.Dy(),
+			base64.StdEncoding.EncodeToString(buf.Bytes()))
+	}
+
+	for _, t := range p.texts {
+		fmt.Fprintf(bw,
+			`<text x="%d" y="%d" font-family="monospace" font-size="%g">`,
+			t.x, t.y, fontSize)
+
+		if err := xml.EscapeText(bw, []byte(t.s)); err != nil {
+			return err //nolint:wrapcheck // wrapped by caller
+		}
+
+		fmt.Fprint(bw, "</text>\n")
+	}
+
+	fmt.Fprint(bw, "</svg>\n")
+
+	return bw.Flush() //nolint:wrapcheck // wrapped by caller
+}
@@ -43,31 +43,31 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // ExportCustomFunctions mocks base method.
-func (m *MockService) ExportCustomFunctions(ctx context.Context, w io.Writer, cf display.DisplayableRoll) error {
+func (m *MockService) ExportCustomFunctions(ctx context.Context, w io.Writer, cf display.DisplayableRoll, verbose bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExportCustomFunctions", ctx, w, cf)
+	ret := m.ctrl.Call(m, "ExportCustomFunctions", ctx, w, cf, verbose)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ExportCustomFunctions indicates an expected call of ExportCustomFunctions.
-func (mr *MockServiceMockRecorder) ExportCustomFunctions(ctx, w, cf any) *gomock.Call {
+func (mr *MockServiceMockRecorder) ExportCustomFunctions(ctx, w, cf, verbose any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCustomFunctions", reflect.TypeOf((*MockService)(nil).ExportCustomFunctions), ctx, w, cf)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCustomFunctions", reflect.TypeOf((*MockService)(nil).ExportCustomFunctions), ctx, w, cf, verbose)
 }
 
 // ExportFrames mocks base method.
-func (m *MockService) ExportFrames(ctx context.Context, w io.Writer, f display.DisplayableRoll) error {
+func (m *MockService) ExportFrames(ctx context.Context, w io.Writer, f display.DisplayableRoll, columns []display.FrameColumn) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExportFrames", ctx, w, f)
+	ret := m.ctrl.Call(m, "ExportFrames", ctx, w, f, columns)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ExportFrames indicates an expected call of ExportFrames.
-func (mr *MockServiceMockRecorder) ExportFrames(ctx, w, f any) *gomock.Call {
+func (mr *MockServiceMockRecorder) ExportFrames(ctx, w, f, columns any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportFrames", reflect.TypeOf((*MockService)(nil).ExportFrames), ctx, w, f)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportFrames", reflect.TypeOf((*MockService)(nil).ExportFrames), ctx, w, f, columns)
 }
 
 // ExportRoll mocks base method.
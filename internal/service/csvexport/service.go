@@ -30,9 +30,21 @@ import (
 	"log/slog"
 	"strings"
 
+	"github.com/ma-tf/meta1v/internal/domain"
 	"github.com/ma-tf/meta1v/internal/service/display"
 )
 
+// defaultFrameColumns are the columns of the frame export if none are selected.
+//
+//nolint:gochecknoglobals // fixed column order
+var defaultFrameColumns = []string{
+	"filmId", "filmLoadedAt", "frame", "isoDx", "focalLength", "maxAperture",
+	"tv", "av", "isoM", "ec", "flashEc", "flashMode", "meteringMode",
+	"shootingMode", "filmAdvanceMode", "afMode", "bulbExposureTime",
+	"takenAt", "multipleExposure", "batteryLoadedAt", "remarks", "modified",
+	"focusPoints",
+}
+
 var (
 	ErrFailedToBufferRollHeader = errors.New("failed to buffer roll header")
 	ErrFailedToWriteRollHeader  = errors.New(
@@ -53,18 +65,23 @@ type Service interface {
 		r display.DisplayableRoll,
 	) error
 
-	// ExportFrames writes detailed frame-by-frame metadata as CSV.
+	// ExportFrames writes detailed frame-by-frame metadata as CSV with the given
+	// columns, or the default columns if there are none.
 	ExportFrames(
 		ctx context.Context,
 		w io.Writer,
 		f display.DisplayableRoll,
+		columns []display.FrameColumn,
 	) error
 
 	// ExportCustomFunctions writes custom function settings for each frame as CSV.
+	// If verbose is set, each setting is written as its own row with the name of
+	// the custom function and the meaning of its value.
 	ExportCustomFunctions(
 		ctx context.Context,
 		w io.Writer,
 		cf display.DisplayableRoll,
+		verbose bool,
 	) error
 }
 
@@ -118,46 +135,40 @@ func (s *service) ExportFrames(
 	ctx context.Context,
 	w io.Writer,
 	f display.DisplayableRoll,
+	columns []display.FrameColumn,
 ) error {
+	if len(columns) == 0 {
+		var err error
+		if columns, err = display.LookupFrameColumns(
+			defaultFrameColumns,
+		); err != nil {
+			return errors.Join(ErrFailedToWriteFrames, err)
+		}
+	}
+
 	s.log.InfoContext(ctx, "exporting frames to csv",
 		slog.String("film_id", string(f.FilmID)),
-		slog.Int("frame_count", len(f.Frames)))
+		slog.Int("frame_count", len(f.Frames)),
+		slog.Int("columns", len(columns)))
 
 	var b strings.Builder
 
-	_, _ = b.WriteString(
-		"FILM ID,FILM LOADED AT,FRAME NUMBER,ISO (DX),FOCAL LENGTH,MAX APERTURE,Tv,Av,ISO (M),EXPOSURE COMPENSATION,FLASH EXPOSURE COMPENSATION,FLASH MODE,METERING MODE,SHOOTING MODE,FILM ADVANCE  MODE,AUTOFOCUS MODE,BULB EXPSOSURE TIME,TAKEN AT,MULTIPLE EXPOSURE,BATTERY LOADED AT,REMARKS,USER MODIFIED RECORD\n",
-	)
+	fields := make([]string, len(columns))
+
+	for i, c := range columns {
+		fields[i] = quote(c.CSVHeader)
+	}
+
+	_, _ = b.WriteString(strings.Join(fields, ",") + "\n")
 
 	s.log.DebugContext(ctx, "csv headers written")
 
 	for _, frame := range f.Frames {
-		_, _ = fmt.Fprintf(
-			&b,
-			"%s,%s,%d,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%v\n",
-			frame.FilmID,
-			frame.FilmLoadedAt,
-			frame.FrameNumber,
-			frame.IsoDX,
-			frame.FocalLength,
-			frame.MaxAperture,
-			frame.Tv,
-			frame.Av,
-			frame.IsoM,
-			frame.ExposureCompensation,
-			frame.FlashExposureCompensation,
-			frame.FlashMode,
-			frame.MeteringMode,
-			frame.ShootingMode,
-			frame.FilmAdvanceMode,
-			frame.AFMode,
-			frame.BulbExposureTime,
-			frame.TakenAt,
-			frame.MultipleExposure,
-			frame.BatteryLoadedAt,
-			frame.Remarks,
-			frame.UserModifiedRecord,
-		)
+		for i, c := range columns {
+			fields[i] = quote(c.Value(frame))
+		}
+
+		_, _ = b.WriteString(strings.Join(fields, ",") + "\n")
 	}
 
 	s.log.DebugContext(ctx, "frame data written",
@@ -178,24 +189,19 @@ func (s *service) ExportCustomFunctions(
 	ctx context.Context,
 	w io.Writer,
 	cf display.DisplayableRoll,
+	verbose bool,
 ) error {
 	s.log.InfoContext(ctx, "exporting custom functions to csv",
 		slog.String("film_id", string(cf.FilmID)),
-		slog.Int("frame_count", len(cf.Frames)))
+		slog.Int("frame_count", len(cf.Frames)),
+		slog.Bool("verbose", verbose))
 
 	var b strings.Builder
 
-	_, _ = b.WriteString(
-		"FILM ID,FRAME NO.,C.Fn-1,C.Fn-2,C.Fn-3,C.Fn-4,C.Fn-5,C.Fn-6,C.Fn-7,C.Fn-8,C.Fn-9,C.Fn-10,C.Fn-11,C.Fn-12,C.Fn-13,C.Fn-14,C.Fn-15,C.Fn-16,C.Fn-17,C.Fn-18,C.Fn-19,C.Fn-20\n",
-	)
-
-	s.log.DebugContext(ctx, "csv headers written")
-
-	for _, frame := range cf.Frames {
-		_, _ = fmt.Fprintf(&b, "%s,%d,%s\n",
-			frame.FilmID,
-			frame.FrameNumber,
-			strings.Join(frame.CustomFunctions[:], ","))
+	if verbose {
+		writeCustomFunctionsVerbose(&b, cf)
+	} else {
+		writeCustomFunctions(&b, cf)
 	}
 
 	s.log.DebugContext(ctx, "custom functions data written",
@@ -211,3 +217,46 @@ func (s *service) ExportCustomFunctions(
 
 	return nil
 }
+
+func writeCustomFunctions(b *strings.Builder, cf display.DisplayableRoll) {
+	_, _ = b.WriteString(
+		"FILM ID,FRAME NO.,C.Fn-0,C.Fn-1,C.Fn-2,C.Fn-3,C.Fn-4,C.Fn-5,C.Fn-6,C.Fn-7,C.Fn-8,C.Fn-9,C.Fn-10,C.Fn-11,C.Fn-12,C.Fn-13,C.Fn-14,C.Fn-15,C.Fn-16,C.Fn-17,C.Fn-18,C.Fn-19\n",
+	)
+
+	for _, frame := range cf.Frames {
+		_, _ = fmt.Fprintf(b, "%s,%d,%s\n",
+			frame.FilmID,
+			frame.FrameNumber,
+			strings.Join(frame.CustomFunctions[:], ","))
+	}
+}
+
+// writeCustomFunctionsVerbose writes a row per custom function of each frame,
+// numbered from 0 as on the camera. Unset values are left empty.
+func writeCustomFunctionsVerbose(
+	b *strings.Builder,
+	cf display.DisplayableRoll,
+) {
+	_, _ = b.WriteString("FILM ID,FRAME NO.,C.Fn,FUNCTION,VALUE,SETTING\n")
+
+	for _, frame := range cf.Frames {
+		for n, value := range frame.CustomFunctions {
+			_, _ = fmt.Fprintf(b, "%s,%d,%d,%s,%s,%s\n",
+				frame.FilmID,
+				frame.FrameNumber,
+				n,
+				quote(domain.CustomFunctionName(n)),
+				strings.TrimSpace(value),
+				quote(frame.CustomFunctions.Setting(n)))
+		}
+	}
+}
+
+// quote makes s a single CSV field, quoting it if it holds a separator.
+func quote(s string) string {
+	if !strings.ContainsAny(s, ",\"\n") {
+		return s
+	}
+
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
@@ -20,6 +20,8 @@ import (
 	"bytes"
 	"errors"
 	"log/slog"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/ma-tf/meta1v/internal/domain"
@@ -124,7 +126,8 @@ func Test_ExportFrames_Error(t *testing.T) {
 
 	ctx := t.Context()
 
-	err := csvexport.NewService(newTestLogger()).ExportFrames(ctx, writer, dr)
+	err := csvexport.NewService(newTestLogger()).
+		ExportFrames(ctx, writer, dr, nil)
 
 	if !errors.Is(err, expectedError) {
 		t.Errorf(
@@ -139,6 +142,8 @@ func Test_ExportFrames_Error(t *testing.T) {
 func Test_ExportFrames_Success(t *testing.T) {
 	t.Parallel()
 
+	centre := domain.NewFocusPoints(0, [8]byte{0, 0, 0, 0, 0b100})
+
 	dr := display.DisplayableRoll{
 		Frames: []display.DisplayableFrame{
 			{
@@ -164,13 +169,14 @@ func Test_ExportFrames_Success(t *testing.T) {
 				BatteryLoadedAt:           "2024-01-01T11:00:00Z",
 				Remarks:                   "This is a test frame.",
 				UserModifiedRecord:        true,
+				FocusPoints:               centre,
 			},
 		},
 	}
 	writer := &bytes.Buffer{}
 	expectedOutput := []byte(
-		`FILM ID,FILM LOADED AT,FRAME NUMBER,ISO (DX),FOCAL LENGTH,MAX APERTURE,Tv,Av,ISO (M),EXPOSURE COMPENSATION,FLASH EXPOSURE COMPENSATION,FLASH MODE,METERING MODE,SHOOTING MODE,FILM ADVANCE  MODE,AUTOFOCUS MODE,BULB EXPSOSURE TIME,TAKEN AT,MULTIPLE EXPOSURE,BATTERY LOADED AT,REMARKS,USER MODIFIED RECORD
-AAA-BB,2024-01-01T12:00:00Z,1,200,50mm,f/1.8,1/125,f/1.8,200,+0.3,+0.7,On,Evaluative,Manual,Single Frame,One-Shot AF,,2024-01-01T12:00:00Z,No,2024-01-01T11:00:00Z,This is a test frame.,true
+		`FILM ID,FILM LOADED AT,FRAME NUMBER,ISO (DX),FOCAL LENGTH,MAX APERTURE,Tv,Av,ISO (M),EXPOSURE COMPENSATION,FLASH EXPOSURE COMPENSATION,FLASH MODE,METERING MODE,SHOOTING MODE,FILM ADVANCE  MODE,AUTOFOCUS MODE,BULB EXPSOSURE TIME,TAKEN AT,MULTIPLE EXPOSURE,BATTERY LOADED AT,REMARKS,USER MODIFIED RECORD,FOCUSING POINTS
+AAA-BB,2024-01-01T12:00:00Z,1,200,50mm,f/1.8,1/125,f/1.8,200,+0.3,+0.7,On,Evaluative,Manual,Single Frame,One-Shot AF,,2024-01-01T12:00:00Z,No,2024-01-01T11:00:00Z,This is a test frame.,true,"active: centre, row 2 col 5 (automatic selection)"
 `,
 	)
 
@@ -178,7 +184,7 @@ AAA-BB,2024-01-01T12:00:00Z,1,200,50mm,f/1.8,1/125,f/1.8,200,+0.3,+0.7,On,Evalua
 
 	svc := csvexport.NewService(newTestLogger())
 
-	err := svc.ExportFrames(ctx, writer, dr)
+	err := svc.ExportFrames(ctx, writer, dr, nil)
 	if err != nil {
 		t.Errorf("unexpected error: got %v, want %v", err, nil)
 	}
@@ -192,6 +198,41 @@ AAA-BB,2024-01-01T12:00:00Z,1,200,50mm,f/1.8,1/125,f/1.8,200,+0.3,+0.7,On,Evalua
 	}
 }
 
+//nolint:exhaustruct // only partial is needed
+func Test_ExportFrames_Columns(t *testing.T) {
+	t.Parallel()
+
+	columns, err := display.LookupFrameColumns(
+		[]string{"frame", "tv", "remarks"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dr := display.DisplayableRoll{
+		Frames: []display.DisplayableFrame{
+			{FrameNumber: 1, Tv: "1/125", Remarks: "beach, low tide"},
+			{FrameNumber: 2, Tv: "1/250", Remarks: ""},
+		},
+	}
+	writer := &bytes.Buffer{}
+
+	err = csvexport.NewService(newTestLogger()).
+		ExportFrames(t.Context(), writer, dr, columns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `FRAME NUMBER,Tv,REMARKS
+1,1/125,"beach, low tide"
+2,1/250,
+`
+	if writer.String() != expected {
+		t.Errorf("unexpected output: got %s, want %s",
+			writer.String(), expected)
+	}
+}
+
 //nolint:exhaustruct // only partial is needed
 func Test_ExportCustomFunctions_Error(t *testing.T) {
 	t.Parallel()
@@ -208,7 +249,7 @@ func Test_ExportCustomFunctions_Error(t *testing.T) {
 
 	svc := csvexport.NewService(newTestLogger())
 
-	err := svc.ExportCustomFunctions(ctx, writer, dr)
+	err := svc.ExportCustomFunctions(ctx, writer, dr, false)
 
 	if !errors.Is(err, expectedError) {
 		t.Errorf(
@@ -254,21 +295,34 @@ func Test_ExportCustomFunctions_Success(t *testing.T) {
 		},
 	}
 	writer := &bytes.Buffer{}
-	expectedOutput := []byte(
-		`FILM ID,FRAME NO.,C.Fn-1,C.Fn-2,C.Fn-3,C.Fn-4,C.Fn-5,C.Fn-6,C.Fn-7,C.Fn-8,C.Fn-9,C.Fn-10,C.Fn-11,C.Fn-12,C.Fn-13,C.Fn-14,C.Fn-15,C.Fn-16,C.Fn-17,C.Fn-18,C.Fn-19,C.Fn-20
+
+	// The header is read by scripts, so renumbering it is a breaking change:
+	// C.Fn-0 to C.Fn-19 as on the camera, not C.Fn-1 to C.Fn-20.
+	expectedHeader := []string{
+		"FILM ID", "FRAME NO.",
+		"C.Fn-0", "C.Fn-1", "C.Fn-2", "C.Fn-3", "C.Fn-4",
+		"C.Fn-5", "C.Fn-6", "C.Fn-7", "C.Fn-8", "C.Fn-9",
+		"C.Fn-10", "C.Fn-11", "C.Fn-12", "C.Fn-13", "C.Fn-14",
+		"C.Fn-15", "C.Fn-16", "C.Fn-17", "C.Fn-18", "C.Fn-19",
+	}
+	expectedOutput := []byte(strings.Join(expectedHeader, ",") + `
 AAA-BB,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1
-`,
-	)
+`)
 
 	ctx := t.Context()
 
 	svc := csvexport.NewService(newTestLogger())
 
-	err := svc.ExportCustomFunctions(ctx, writer, dr)
+	err := svc.ExportCustomFunctions(ctx, writer, dr, false)
 	if err != nil {
 		t.Errorf("unexpected error: got %v, want %v", err, nil)
 	}
 
+	header, _, _ := strings.Cut(writer.String(), "\n")
+	if got := strings.Split(header, ","); !slices.Equal(got, expectedHeader) {
+		t.Errorf("unexpected header: got %q, want %q", got, expectedHeader)
+	}
+
 	if !bytes.Equal(writer.Bytes(), expectedOutput) {
 		t.Errorf(
 			"unexpected output: got %s, want %s",
@@ -277,3 +331,51 @@ AAA-BB,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1,1
 		)
 	}
 }
+
+//nolint:exhaustruct // only partial is needed
+func Test_ExportCustomFunctions_Verbose(t *testing.T) {
+	t.Parallel()
+
+	dr := display.DisplayableRoll{
+		Frames: []display.DisplayableFrame{
+			{
+				FilmID:      "AAA-BB",
+				FrameNumber: 1,
+				CustomFunctions: domain.CustomFunctions{
+					"0", "1", " ", " ", " ", " ", " ", " ", " ", " ",
+					" ", " ", " ", " ", " ", " ", " ", " ", " ", "9",
+				},
+			},
+		},
+	}
+	writer := &bytes.Buffer{}
+
+	ctx := t.Context()
+
+	svc := csvexport.NewService(newTestLogger())
+
+	err := svc.ExportCustomFunctions(ctx, writer, dr, true)
+	if err != nil {
+		t.Errorf("unexpected error: got %v, want %v", err, nil)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(writer.String(), "\n"), "\n")
+
+	expectedLines := map[int]string{
+		0:  "FILM ID,FRAME NO.,C.Fn,FUNCTION,VALUE,SETTING",
+		1:  `AAA-BB,1,0,Focusing screen,0,"Ec-N, R"`,
+		2:  `AAA-BB,1,1,Film rewind,1,"Automatic, silent"`,
+		3:  "AAA-BB,1,2,Film leader after rewind,,",
+		20: "AAA-BB,1,19,Lens AF stop button,9,",
+	}
+
+	if len(lines) != len(dr.Frames[0].CustomFunctions)+1 {
+		t.Fatalf("expected a header and 20 rows, got %d lines", len(lines))
+	}
+
+	for i, want := range expectedLines {
+		if lines[i] != want {
+			t.Errorf("line %d: got %q, want %q", i, lines[i], want)
+		}
+	}
+}
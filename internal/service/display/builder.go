@@ -22,7 +22,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"math"
 	"strings"
 
 	"github.com/ma-tf/meta1v/internal/domain"
@@ -83,37 +82,8 @@ const (
 		"\u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF\n" +
 		" \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF\n" +
 		"    \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF \u25AF" + ansiReset + "\n"
-
-	// focusPointBitCounts defines the number of bits used in each byte of focus point data.
-	// Canon EOS cameras use a 45-point AF grid arranged in 5 rows. The 8 bytes map to:
-	//
-	//		[0]: Row 0 top - 7 bits
-	//		[1]: Row 1 right - 2 bits,  [2]: Row 1 left - 8 bits
-	//		[3]: Row 2 right - 3 bits,  [4]: Row 2 left - 8 bits
-	//		[5]: Row 3 right - 2 bits,  [6]: Row 3 left - 8 bits
-	//		[7]: Row 4 bottom - 7 bits
-
-	topOrBottomRowBits = 7
-	leftSegmentBits    = 8
-
-	topBits                         = topOrBottomRowBits
-	topRightBits, topLeftBits       = 2, leftSegmentBits
-	middleRightBits, middleLeftBits = 3, leftSegmentBits
-	bottomRightBits, bottomLeftBits = 2, leftSegmentBits
-	bottomBits                      = topOrBottomRowBits
-
-	leftmostBitMask = byte(0b10000000)
 )
 
-//nolint:gochecknoglobals // package-level constant for focus point rendering
-var focusPointBitCounts = [8]int{
-	topBits,
-	topRightBits, topLeftBits,
-	middleRightBits, middleLeftBits,
-	bottomRightBits, bottomLeftBits,
-	bottomBits,
-}
-
 type builder struct {
 	log *slog.Logger
 }
@@ -391,13 +361,14 @@ func (b *builder) withCustomFunctionsAndFocusPoints(
 		efrm.FocusPoints8,
 	}
 
-	focusingPoints := b.formatFocusPoints(
+	focusPoints := domain.NewFocusPoints(
 		efrm.FocusingPoint,
 		rawFocusPointsBytes,
 	)
 
 	frame.CustomFunctions = customFunctions
-	frame.FocusingPoints = focusingPoints
+	frame.FocusPoints = focusPoints
+	frame.FocusingPoints = formatFocusPoints(focusPoints)
 
 	return nil
 }
@@ -415,58 +386,40 @@ func formatAperture(av uint32, strict bool) (domain.Av, error) {
 	return result, nil
 }
 
-func renderFocusPointByte(pointsByte byte, bitCount int) string {
-	var grid strings.Builder
-
-	isTopOrBottomRow := bitCount == topOrBottomRowBits
-	isLeftSegment := bitCount == leftSegmentBits
-
-	for bitIndex, mask := 0, leftmostBitMask; bitIndex < bitCount; bitIndex, mask = bitIndex+1, mask>>1 {
-		isFirstOrLastBit := (isLeftSegment && bitIndex == 0) ||
-			(!isLeftSegment && bitIndex == bitCount-1)
-		isEdge := isTopOrBottomRow || isFirstOrLastBit
-
-		isFocusPointActive := pointsByte&mask != 0
-
-		switch {
-		case isFocusPointActive:
-			grid.WriteString(redFilledBox) // Active focus point
-		case isEdge:
-			grid.WriteString(redEmptyBox) // Edge position, not active
-		default:
-			grid.WriteString(greyBox) // Interior position, not active
-		}
+// focusPointIndents lines the rows of the grid up on their centres.
+//
+//nolint:gochecknoglobals // fixed grid layout
+var focusPointIndents = []string{"    ", " ", "", " ", "    "}
+
+func renderFocusPoint(p domain.FocusPoint) string {
+	switch {
+	case p.Active:
+		return redFilledBox // Active focus point
+	case p.Edge:
+		return redEmptyBox // Edge position, not active
+	default:
+		return greyBox // Interior position, not active
 	}
-
-	return grid.String()
 }
 
-func (b *builder) formatFocusPoints(
-	selection uint32,
-	points [8]byte,
-) DisplayableFocusPoints {
-	if selection == math.MaxUint32 {
+func formatFocusPoints(fp domain.FocusPoints) DisplayableFocusPoints {
+	if !fp.Recorded {
 		return DisplayableFocusPoints(emptyFocusPointsGrid)
 	}
 
-	renderedSegments := make([]string, len(focusPointBitCounts))
-	for i, bitCount := range focusPointBitCounts {
-		renderedSegments[i] = renderFocusPointByte(points[i], bitCount)
+	var grid strings.Builder
+
+	for r, row := range fp.Rows() {
+		grid.WriteString(focusPointIndents[r])
+
+		for _, p := range row {
+			grid.WriteString(renderFocusPoint(p))
+		}
+
+		grid.WriteString("\n")
 	}
 
-	// Assemble the 5-row grid layout:
-	// Row 0:     segment[0]              (7 points, indented)
-	// Row 1:  segment[2] + segment[1]    (10 points: 8 left + 2 right)
-	// Row 2:  segment[4] + segment[3]    (11 points: 8 left + 3 right)
-	// Row 3:  segment[6] + segment[5]    (10 points: 8 left + 2 right)
-	// Row 4:     segment[7]              (7 points, indented)
-	focusPointGrid := "    " + renderedSegments[0] + "\n" +
-		" " + renderedSegments[2] + renderedSegments[1] + "\n" +
-		renderedSegments[4] + renderedSegments[3] + "\n" +
-		" " + renderedSegments[6] + renderedSegments[5] + "\n" +
-		"    " + renderedSegments[7] + "\n"
-
-	return DisplayableFocusPoints(focusPointGrid)
+	return DisplayableFocusPoints(grid.String())
 }
 
 func wrapFrameError(baseErr, frameErr error, frameNumber uint32) error {
@@ -478,6 +478,16 @@ func Test_FrameBuilder_CustomFunctionsAndFocus(t *testing.T) {
 		}
 	}
 
+	focusPoints := func(selection uint32, points byte) domain.FocusPoints {
+		return domain.NewFocusPoints(
+			selection,
+			[8]byte{
+				points, points, points, points,
+				points, points, points, points,
+			},
+		)
+	}
+
 	assertError := func(t *testing.T, got, want error) {
 		t.Helper()
 
@@ -554,6 +564,53 @@ func Test_FrameBuilder_CustomFunctionsAndFocus(t *testing.T) {
 					"0", "0", "0", "0", "0",
 					"0", "0", "0", "0", "0",
 				},
+				FocusPoints: focusPoints(0, 0),
+				//nolint:staticcheck // would make it even less readable
+				FocusingPoints: ansi(
+					`    [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m 
+ [31m▯[0m ▯ ▯ ▯ ▯ ▯ ▯ ▯ ▯ [31m▯[0m 
+[31m▯[0m ▯ ▯ ▯ ▯ ▯ ▯ ▯ ▯ ▯ [31m▯[0m 
+ [31m▯[0m ▯ ▯ ▯ ▯ ▯ ▯ ▯ ▯ [31m▯[0m 
+    [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m 
+`,
+				),
+			},
+		},
+		{
+			name: "unknown focusing point in strict mode",
+			frame: func() records.EFRM {
+				f := validBaseFrame()
+				f.FocusingPoint = 46
+
+				return f
+			}(),
+			strict: true,
+			expectedResult: display.DisplayableFrame{
+				FrameNumber:               0,
+				FilmID:                    "12-034",
+				FilmLoadedAt:              "2023-05-15 10:30:45",
+				BatteryLoadedAt:           "2023-05-15 09:15:00",
+				TakenAt:                   "2023-05-15 10:45:30",
+				MaxAperture:               "f/2.8",
+				Tv:                        "1\"",
+				Av:                        "f/2.8",
+				FocalLength:               "0mm",
+				IsoDX:                     "0",
+				IsoM:                      "0",
+				ExposureCompensation:      "+1.0",
+				MultipleExposure:          "ON",
+				FlashExposureCompensation: "+1.0",
+				FlashMode:                 "ON",
+				MeteringMode:              "Center averaging",
+				ShootingMode:              "Program AE",
+				AFMode:                    "One-Shot AF",
+				CustomFunctions: [20]string{
+					"0", "0", "0", "0", "0",
+					"0", "0", "0", "0", "0",
+					"0", "0", "0", "0", "0",
+					"0", "0", "0", "0", "0",
+				},
+				FocusPoints: focusPoints(46, 0),
 				//nolint:staticcheck // would make it even less readable
 				FocusingPoints: ansi(
 					`    [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m [31m▯[0m 
@@ -598,6 +655,7 @@ func Test_FrameBuilder_CustomFunctionsAndFocus(t *testing.T) {
 					"0", "0", "0", "0", "0",
 					"0", "0", "0", "0", "0",
 				},
+				FocusPoints: focusPoints(math.MaxUint32, 0),
 				//nolint:staticcheck // would make it even less readable
 				FocusingPoints: ansi(`    [30m▯ ▯ ▯ ▯ ▯ ▯ ▯
  ▯ ▯ ▯ ▯ ▯ ▯ ▯ ▯ ▯ ▯
@@ -648,6 +706,7 @@ func Test_FrameBuilder_CustomFunctionsAndFocus(t *testing.T) {
 					"0", "0", "0", "0", "0",
 					"0", "0", "0", "0", "0",
 				},
+				FocusPoints: focusPoints(1, 0b11111111),
 				//nolint:staticcheck // would make it even less readable
 				FocusingPoints: ansi(
 					`    [31m▮[0m [31m▮[0m [31m▮[0m [31m▮[0m [31m▮[0m [31m▮[0m [31m▮[0m 
@@ -0,0 +1,364 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package display
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+)
+
+var ErrUnknownColumn = errors.New("unknown column")
+
+// ColumnKind is how the values of a frame column compare with each other.
+type ColumnKind int
+
+const (
+	ColumnText   ColumnKind = iota // as text, regardless of case
+	ColumnNumber                   // by number, see FrameColumn.Number
+	ColumnBool                     // as true or false
+)
+
+// FrameColumn is a field of a frame that can be shown as a column of the frame
+// table or the frame CSV export, or compared in a frame filter. All of them
+// select their fields from the same registry, so every field is available to each.
+type FrameColumn struct {
+	Name      string     // name the column is selected by, e.g. "takenAt"
+	Header    string     // header in the frame table
+	CSVHeader string     // header in the frame CSV export
+	Width     int        // width in the frame table
+	Kind      ColumnKind // how values of the column compare
+
+	value   func(fr DisplayableFrame) string
+	cell    func(fr DisplayableFrame) string          // table text, if not value
+	number  func(fr DisplayableFrame) (float64, bool) // value of a number column
+	literal func(s string) (float64, bool)            // parses a value to compare with number
+	cut     bool                                      // cut the table text to Width
+	hidden  bool                                      // left out of the table by default
+}
+
+// Value returns the field of fr as it is exported.
+func (c FrameColumn) Value(fr DisplayableFrame) string {
+	return c.value(fr)
+}
+
+// Number returns the field of fr as a number, reporting false if the column is
+// not a number column or the camera did not record a number.
+func (c FrameColumn) Number(fr DisplayableFrame) (float64, bool) {
+	if c.number == nil {
+		return 0, false
+	}
+
+	return c.number(fr)
+}
+
+// ParseNumber parses a value of a number column, written as it is displayed or
+// as a bare number, e.g. 1/250, f/2.8 or 50mm.
+func (c FrameColumn) ParseNumber(s string) (float64, bool) {
+	if c.literal == nil {
+		return 0, false
+	}
+
+	return c.literal(s)
+}
+
+// Cell returns the field of fr as it is shown in the frame table, unpadded.
+func (c FrameColumn) Cell(fr DisplayableFrame) string {
+	s := c.value(fr)
+	if c.cell != nil {
+		s = c.cell(fr)
+	}
+
+	if c.cut {
+		return truncate(s, c.Width)
+	}
+
+	return s
+}
+
+//nolint:gochecknoglobals // fixed registry of frame columns
+var frameColumns = []FrameColumn{
+	column("filmId", "FILM ID", "FILM ID", filmIDWidth,
+		func(fr DisplayableFrame) string { return string(fr.FilmID) }),
+	{
+		Name:      "frame",
+		Header:    "FRAME NO.",
+		CSVHeader: "FRAME NUMBER",
+		Width:     frameNumberWidth,
+		Kind:      ColumnNumber,
+		value: func(fr DisplayableFrame) string {
+			return strconv.FormatUint(uint64(fr.FrameNumber), 10)
+		},
+		cell: renderFrameNumber,
+		number: func(fr DisplayableFrame) (float64, bool) {
+			return float64(fr.FrameNumber), true
+		},
+		literal: func(s string) (float64, bool) {
+			n, err := strconv.ParseUint(s, 10, 32)
+
+			return float64(n), err == nil
+		},
+		cut:    false,
+		hidden: false,
+	},
+	column("filmLoadedAt", "FILM LOADED AT", "FILM LOADED AT",
+		filmLoadedAtWidth,
+		func(fr DisplayableFrame) string { return string(fr.FilmLoadedAt) }),
+	numeric(column("isoDx", "ISO (DX)", "ISO (DX)", isoDxWidth,
+		func(fr DisplayableFrame) string { return string(fr.IsoDX) }),
+		func(fr DisplayableFrame) (float64, bool) { return fr.IsoDX.Speed() },
+		func(s string) (float64, bool) { return domain.Iso(s).Speed() }),
+	numeric(column("focalLength", "FOCAL LENGTH", "FOCAL LENGTH",
+		focalLengthWidth,
+		func(fr DisplayableFrame) string { return string(fr.FocalLength) }),
+		func(fr DisplayableFrame) (float64, bool) {
+			return fr.FocalLength.Millimetres()
+		},
+		func(s string) (float64, bool) {
+			return domain.FocalLength(s).Millimetres()
+		}),
+	numeric(column("maxAperture", "MAX APERTURE", "MAX APERTURE",
+		maxApertureWidth,
+		func(fr DisplayableFrame) string { return string(fr.MaxAperture) }),
+		func(fr DisplayableFrame) (float64, bool) {
+			return fr.MaxAperture.FNumber()
+		},
+		func(s string) (float64, bool) { return domain.Av(s).FNumber() }),
+	numeric(column("tv", "TV", "Tv", tvWidth,
+		func(fr DisplayableFrame) string { return string(fr.Tv) }),
+		func(fr DisplayableFrame) (float64, bool) { return fr.Tv.Seconds() },
+		func(s string) (float64, bool) { return domain.Tv(s).Seconds() }),
+	numeric(column("av", "AV", "Av", avWidth,
+		func(fr DisplayableFrame) string { return string(fr.Av) }),
+		func(fr DisplayableFrame) (float64, bool) { return fr.Av.FNumber() },
+		func(s string) (float64, bool) { return domain.Av(s).FNumber() }),
+	hidden(numeric(column("ev", "EV", "EV", evWidth,
+		func(fr DisplayableFrame) string {
+			v, ok := domain.ExposureValue(fr.Av, fr.Tv)
+			if !ok {
+				return ""
+			}
+
+			return strconv.FormatFloat(v, 'f', 1, 64)
+		}),
+		func(fr DisplayableFrame) (float64, bool) {
+			return domain.ExposureValue(fr.Av, fr.Tv)
+		},
+		parseFloat)),
+	numeric(column("isoM", "ISO (M)", "ISO (M)", isoMWidth,
+		func(fr DisplayableFrame) string { return string(fr.IsoM) }),
+		func(fr DisplayableFrame) (float64, bool) { return fr.IsoM.Speed() },
+		func(s string) (float64, bool) { return domain.Iso(s).Speed() }),
+	numeric(column("ec", "EXPOSURE COMP.", "EXPOSURE COMPENSATION",
+		exposureCompWidth,
+		func(fr DisplayableFrame) string {
+			return string(fr.ExposureCompensation)
+		}),
+		func(fr DisplayableFrame) (float64, bool) {
+			return fr.ExposureCompensation.Stops()
+		},
+		parseFloat),
+	numeric(column("flashEc", "FLASH EXPOSURE COMP.",
+		"FLASH EXPOSURE COMPENSATION", flashExposureCompensationWidth,
+		func(fr DisplayableFrame) string {
+			return string(fr.FlashExposureCompensation)
+		}),
+		func(fr DisplayableFrame) (float64, bool) {
+			return fr.FlashExposureCompensation.Stops()
+		},
+		parseFloat),
+	column("flashMode", "FLASH MODE", "FLASH MODE", flashModeWidth,
+		func(fr DisplayableFrame) string { return string(fr.FlashMode) }),
+	column("meteringMode", "METERING MODE", "METERING MODE",
+		meteringModeWidth,
+		func(fr DisplayableFrame) string { return string(fr.MeteringMode) }),
+	column("shootingMode", "SHOOTING MODE", "SHOOTING MODE",
+		shootingModeWidth,
+		func(fr DisplayableFrame) string { return string(fr.ShootingMode) }),
+	column("filmAdvanceMode", "FILM ADVANCE MODE", "FILM ADVANCE  MODE",
+		filmAdvanceModeWidth,
+		func(fr DisplayableFrame) string {
+			return string(fr.FilmAdvanceMode)
+		}),
+	column("afMode", "AF MODE", "AUTOFOCUS MODE", afModeWidth,
+		func(fr DisplayableFrame) string { return string(fr.AFMode) }),
+	column("bulbExposureTime", "BULB EXPOSURE TIME", "BULB EXPSOSURE TIME",
+		bulbExposureTimeWidth,
+		func(fr DisplayableFrame) string {
+			return string(fr.BulbExposureTime)
+		}),
+	column("takenAt", "TAKEN AT", "TAKEN AT", takenAtWidth,
+		func(fr DisplayableFrame) string { return string(fr.TakenAt) }),
+	hidden(column("date", "DATE", "DATE", dateWidth,
+		func(fr DisplayableFrame) string {
+			date, _, _ := strings.Cut(string(fr.TakenAt), " ")
+
+			return date
+		})),
+	hidden(column("time", "TIME", "TIME", timeWidth,
+		func(fr DisplayableFrame) string {
+			_, clock, _ := strings.Cut(string(fr.TakenAt), " ")
+
+			return clock
+		})),
+	column("multipleExposure", "MULTIPLE EXPOSURE", "MULTIPLE EXPOSURE",
+		multipleExposureWidth,
+		func(fr DisplayableFrame) string {
+			return string(fr.MultipleExposure)
+		}),
+	column("batteryLoadedAt", "BATTERY LOADED AT", "BATTERY LOADED AT",
+		batteryLoadedAtWidth,
+		func(fr DisplayableFrame) string {
+			return string(fr.BatteryLoadedAt)
+		}),
+	cutColumn("remarks", "REMARKS", "REMARKS", remarksWidth,
+		func(fr DisplayableFrame) string { return string(fr.Remarks) }),
+	hidden(boolean(column("modified", "MODIFIED", "USER MODIFIED RECORD",
+		modifiedWidth,
+		func(fr DisplayableFrame) string {
+			return strconv.FormatBool(fr.UserModifiedRecord)
+		}))),
+	hidden(cutColumn("focusPoints", "FOCUS POINTS", "FOCUSING POINTS",
+		focusingPointsWidth,
+		func(fr DisplayableFrame) string { return fr.FocusPoints.Describe() })),
+}
+
+func column(
+	name, header, csvHeader string,
+	width int,
+	value func(fr DisplayableFrame) string,
+) FrameColumn {
+	return FrameColumn{
+		Name:      name,
+		Header:    header,
+		CSVHeader: csvHeader,
+		Width:     width,
+		Kind:      ColumnText,
+		value:     value,
+		cell:      nil,
+		number:    nil,
+		literal:   nil,
+		cut:       false,
+		hidden:    false,
+	}
+}
+
+func cutColumn(
+	name, header, csvHeader string,
+	width int,
+	value func(fr DisplayableFrame) string,
+) FrameColumn {
+	c := column(name, header, csvHeader, width, value)
+	c.cut = true
+
+	return c
+}
+
+func hidden(c FrameColumn) FrameColumn {
+	c.hidden = true
+
+	return c
+}
+
+// numeric makes c a number column, read from a frame with number and from a
+// filter value with literal.
+func numeric(
+	c FrameColumn,
+	number func(fr DisplayableFrame) (float64, bool),
+	literal func(s string) (float64, bool),
+) FrameColumn {
+	c.Kind = ColumnNumber
+	c.number = number
+	c.literal = literal
+
+	return c
+}
+
+func boolean(c FrameColumn) FrameColumn {
+	c.Kind = ColumnBool
+
+	return c
+}
+
+func parseFloat(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(s, 64)
+
+	return v, err == nil
+}
+
+// tableFrameColumns returns the columns of the frame table if none are
+// selected.
+func tableFrameColumns() []FrameColumn {
+	columns := make([]FrameColumn, 0, len(frameColumns))
+
+	for _, c := range frameColumns {
+		if !c.hidden {
+			columns = append(columns, c)
+		}
+	}
+
+	return columns
+}
+
+// FrameColumnNames returns the names of all frame columns.
+func FrameColumnNames() []string {
+	names := make([]string, 0, len(frameColumns))
+	for _, c := range frameColumns {
+		names = append(names, c.Name)
+	}
+
+	return names
+}
+
+// LookupFrameColumns returns the frame columns with the given names, in the
+// order given. Names are matched regardless of case. It returns nil for no
+// names, which the frame table and CSV export take as their default columns.
+func LookupFrameColumns(names []string) ([]FrameColumn, error) {
+	if len(names) == 0 {
+		return nil, nil //nolint:nilnil // no names selects the defaults
+	}
+
+	columns := make([]FrameColumn, 0, len(names))
+
+	for _, name := range names {
+		c, ok := LookupFrameColumn(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("%w %q (columns: %s)",
+				ErrUnknownColumn, name,
+				strings.Join(FrameColumnNames(), ", "))
+		}
+
+		columns = append(columns, c)
+	}
+
+	return columns, nil
+}
+
+// LookupFrameColumn returns the frame column with the given name, matched
+// regardless of case.
+func LookupFrameColumn(name string) (FrameColumn, bool) {
+	for _, c := range frameColumns {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+
+	return FrameColumn{}, false //nolint:exhaustruct // not found
+}
@@ -0,0 +1,200 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package display_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+func Test_LookupFrameColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		names    []string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "no names selects the defaults",
+			names:    nil,
+			expected: nil,
+			err:      nil,
+		},
+		{
+			name:     "in the order given",
+			names:    []string{"takenAt", "frame", "tv"},
+			expected: []string{"takenAt", "frame", "tv"},
+			err:      nil,
+		},
+		{
+			name:     "regardless of case and spacing",
+			names:    []string{" TAKENAT", "Ec "},
+			expected: []string{"takenAt", "ec"},
+			err:      nil,
+		},
+		{
+			name:     "unknown column",
+			names:    []string{"frame", "shutter"},
+			expected: nil,
+			err:      display.ErrUnknownColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			columns, err := display.LookupFrameColumns(tt.names)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("unexpected error: got %v, want %v", err, tt.err)
+			}
+
+			var got []string
+			for _, c := range columns {
+				got = append(got, c.Name)
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("unexpected columns: got %v, want %v",
+					got, tt.expected)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FrameColumn_Cell(t *testing.T) {
+	t.Parallel()
+
+	columns, err := display.LookupFrameColumns(
+		[]string{"frame", "remarks", "modified"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fr := display.DisplayableFrame{
+		FrameNumber:        12,
+		UserModifiedRecord: true,
+		Remarks:            "a remark far too long to fit in the table column",
+	}
+
+	tests := []struct {
+		column display.FrameColumn
+		value  string
+		cell   string
+	}{
+		{columns[0], "12", "12*"},
+		{
+			columns[1],
+			"a remark far too long to fit in the table column",
+			"a remark far too long to fi...",
+		},
+		{columns[2], "true", "true"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.column.Value(fr); got != tt.value {
+			t.Errorf("%s: unexpected value: got %q, want %q",
+				tt.column.Name, got, tt.value)
+		}
+
+		if got := tt.column.Cell(fr); got != tt.cell {
+			t.Errorf("%s: unexpected cell: got %q, want %q",
+				tt.column.Name, got, tt.cell)
+		}
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_DisplayFrames_Columns(t *testing.T) {
+	t.Parallel()
+
+	columns, err := display.LookupFrameColumns(
+		[]string{"tv", "frame", "focusPoints"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var b bytes.Buffer
+	display.NewService(newTestLogger()).DisplayFrames(t.Context(), &b,
+		display.DisplayableRoll{
+			Frames: []display.DisplayableFrame{{FrameNumber: 3, Tv: "1/250"}},
+		},
+		columns,
+	)
+
+	expected := `TV      FRAME NO. FOCUS POINTS         
+---------------------------------------
+1/250   3         no focusing point ...
+`
+	if b.String() != expected {
+		t.Errorf("unexpected output:\n got:\n%s\nwant:\n%s", b.String(), expected)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_FrameColumn_Number(t *testing.T) {
+	t.Parallel()
+
+	fr := display.DisplayableFrame{Tv: "1/250", Av: "f/2.8", Remarks: "12"}
+
+	tests := []struct {
+		name    string
+		kind    display.ColumnKind
+		number  float64
+		ok      bool
+		literal string
+	}{
+		{"tv", display.ColumnNumber, 1.0 / 250, true, "1/250"},
+		{"av", display.ColumnNumber, 2.8, true, "f/2.8"},
+		{"ec", display.ColumnNumber, 0, false, "0.5"},
+		{"remarks", display.ColumnText, 0, false, ""},
+		{"modified", display.ColumnBool, 0, false, ""},
+	}
+
+	for _, tt := range tests {
+		c, ok := display.LookupFrameColumn(tt.name)
+		if !ok {
+			t.Fatalf("%s: column not found", tt.name)
+		}
+
+		if c.Kind != tt.kind {
+			t.Errorf("%s: unexpected kind: got %v, want %v", tt.name, c.Kind, tt.kind)
+		}
+
+		if got, ok := c.Number(fr); got != tt.number || ok != tt.ok {
+			t.Errorf("%s: unexpected number: got %v, %v, want %v, %v",
+				tt.name, got, ok, tt.number, tt.ok)
+		}
+
+		if tt.literal == "" {
+			continue
+		}
+
+		if _, ok := c.ParseNumber(tt.literal); !ok {
+			t.Errorf("%s: failed to parse %q", tt.name, tt.literal)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	io "io"
 	reflect "reflect"
 
+	domain "github.com/ma-tf/meta1v/internal/domain"
 	display "github.com/ma-tf/meta1v/internal/service/display"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -43,17 +44,17 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // DisplayCustomFunctions mocks base method.
-func (m *MockService) DisplayCustomFunctions(ctx context.Context, w io.Writer, r display.DisplayableRoll) error {
+func (m *MockService) DisplayCustomFunctions(ctx context.Context, w io.Writer, r display.DisplayableRoll, verbose bool, presets []domain.CustomFunctionPreset) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DisplayCustomFunctions", ctx, w, r)
+	ret := m.ctrl.Call(m, "DisplayCustomFunctions", ctx, w, r, verbose, presets)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DisplayCustomFunctions indicates an expected call of DisplayCustomFunctions.
-func (mr *MockServiceMockRecorder) DisplayCustomFunctions(ctx, w, r any) *gomock.Call {
+func (mr *MockServiceMockRecorder) DisplayCustomFunctions(ctx, w, r, verbose, presets any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisplayCustomFunctions", reflect.TypeOf((*MockService)(nil).DisplayCustomFunctions), ctx, w, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisplayCustomFunctions", reflect.TypeOf((*MockService)(nil).DisplayCustomFunctions), ctx, w, r, verbose, presets)
 }
 
 // DisplayFocusingPoints mocks base method.
@@ -69,15 +70,15 @@ func (mr *MockServiceMockRecorder) DisplayFocusingPoints(ctx, w, r any) *gomock.
 }
 
 // DisplayFrames mocks base method.
-func (m *MockService) DisplayFrames(ctx context.Context, w io.Writer, r display.DisplayableRoll) {
+func (m *MockService) DisplayFrames(ctx context.Context, w io.Writer, r display.DisplayableRoll, columns []display.FrameColumn) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DisplayFrames", ctx, w, r)
+	m.ctrl.Call(m, "DisplayFrames", ctx, w, r, columns)
 }
 
 // DisplayFrames indicates an expected call of DisplayFrames.
-func (mr *MockServiceMockRecorder) DisplayFrames(ctx, w, r any) *gomock.Call {
+func (mr *MockServiceMockRecorder) DisplayFrames(ctx, w, r, columns any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisplayFrames", reflect.TypeOf((*MockService)(nil).DisplayFrames), ctx, w, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisplayFrames", reflect.TypeOf((*MockService)(nil).DisplayFrames), ctx, w, r, columns)
 }
 
 // DisplayRoll mocks base method.
@@ -25,6 +25,8 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+
+	"github.com/ma-tf/meta1v/internal/domain"
 )
 
 const (
@@ -44,6 +46,7 @@ const (
 	maxApertureWidth               = 12
 	tvWidth                        = 7
 	avWidth                        = 7
+	evWidth                        = 5
 	isoMWidth                      = 7
 	exposureCompWidth              = 15
 	flashExposureCompensationWidth = 20
@@ -54,9 +57,14 @@ const (
 	afModeWidth                    = 12
 	bulbExposureTimeWidth          = 20
 	takenAtWidth                   = 20
+	dateWidth                      = 10
+	timeWidth                      = 8
 	multipleExposureWidth          = 20
 	batteryLoadedAtWidth           = 20
+	modifiedWidth                  = 8
 	customFunctionsWidth           = 2
+	customFunctionNumberWidth      = 7
+	customFunctionNameWidth        = 35
 
 	imageFileWidth   = 64
 	thumbnailWidth   = 64
@@ -80,24 +88,32 @@ type Service interface {
 	)
 
 	// DisplayCustomFunctions writes a table of custom function settings for all frames.
+	// If verbose is set, each setting is listed on its own line with the name of the
+	// custom function and the meaning of its value. Otherwise, if there are presets,
+	// the table shows the preset each frame matches best.
 	DisplayCustomFunctions(
 		ctx context.Context,
 		w io.Writer,
 		r DisplayableRoll,
+		verbose bool,
+		presets []domain.CustomFunctionPreset,
 	) error
 
-	// DisplayFocusingPoints writes focus point visualizations for all frames.
+	// DisplayFocusingPoints writes focus point visualizations for all frames,
+	// each followed by a description of the grid in words.
 	DisplayFocusingPoints(
 		ctx context.Context,
 		w io.Writer,
 		r DisplayableRoll,
 	)
 
-	// DisplayFrames writes a detailed table of frame metadata.
+	// DisplayFrames writes a detailed table of frame metadata with the given
+	// columns, or the default columns if there are none.
 	DisplayFrames(
 		ctx context.Context,
 		w io.Writer,
 		r DisplayableRoll,
+		columns []FrameColumn,
 	)
 
 	// DisplayThumbnails writes ASCII art thumbnails for all frames.
@@ -158,90 +174,71 @@ func (s *service) DisplayFrames(
 	ctx context.Context,
 	w io.Writer,
 	r DisplayableRoll,
+	columns []FrameColumn,
 ) {
+	if len(columns) == 0 {
+		columns = tableFrameColumns()
+	}
+
 	s.log.InfoContext(ctx, "formatting frames display",
 		slog.String("film_id", string(r.FilmID)),
-		slog.Int("frame_count", len(r.Frames)))
+		slog.Int("frame_count", len(r.Frames)),
+		slog.Int("columns", len(columns)))
 
-	//nolint:golines // more readable this way
-	header := fmt.Sprintf("%-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s",
-		filmIDWidth, "FILM ID",
-		frameNumberWidth, "FRAME NO.",
-		filmLoadedAtWidth, "FILM LOADED AT",
-		isoDxWidth, "ISO (DX)",
-		focalLengthWidth, "FOCAL LENGTH",
-		maxApertureWidth, "MAX APERTURE",
-		tvWidth, "TV",
-		avWidth, "AV",
-		isoMWidth, "ISO (M)",
-		exposureCompWidth, "EXPOSURE COMP.",
-		flashExposureCompensationWidth, "FLASH EXPOSURE COMP.",
-		flashModeWidth, "FLASH MODE",
-		meteringModeWidth, "METERING MODE",
-		shootingModeWidth, "SHOOTING MODE",
-		filmAdvanceModeWidth, "FILM ADVANCE MODE",
-		afModeWidth, "AF MODE",
-		bulbExposureTimeWidth, "BULB EXPOSURE TIME",
-		takenAtWidth, "TAKEN AT",
-		multipleExposureWidth, "MULTIPLE EXPOSURE",
-		batteryLoadedAtWidth, "BATTERY LOADED AT",
-		remarksWidth, "REMARKS",
-	)
+	headers := make([]string, 0, len(columns))
+	for _, c := range columns {
+		headers = append(headers, fmt.Sprintf("%-*s", c.Width, c.Header))
+	}
+
+	header := strings.Join(headers, " ")
 	fmt.Fprintln(w, header)
 	fmt.Fprintln(w, strings.Repeat("-", len(header)))
 
 	for _, fr := range r.Frames {
-		row := s.renderFrame(fr)
-		fmt.Fprintln(w, row)
+		fmt.Fprintln(w, renderFrame(fr, columns))
 	}
 
 	s.log.DebugContext(ctx, "frames display formatted",
 		slog.Int("frame_count", len(r.Frames)))
 }
 
-func (s *service) renderFrame(fr DisplayableFrame) string {
-	//nolint:golines // more readable this way
-	row := fmt.Sprintf("%-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s",
-		filmIDWidth, fr.FilmID,
-		frameNumberWidth, s.renderFrameNumber(fr),
-		filmLoadedAtWidth, fr.FilmLoadedAt,
-		isoDxWidth, fr.IsoDX,
-		focalLengthWidth, fr.FocalLength,
-		maxApertureWidth, fr.MaxAperture,
-		tvWidth, fr.Tv,
-		avWidth, fr.Av,
-		isoMWidth, fr.IsoM,
-		exposureCompWidth, fr.ExposureCompensation,
-		flashExposureCompensationWidth, fr.FlashExposureCompensation,
-		flashModeWidth, fr.FlashMode,
-		meteringModeWidth, fr.MeteringMode,
-		shootingModeWidth, fr.ShootingMode,
-		filmAdvanceModeWidth, fr.FilmAdvanceMode,
-		afModeWidth, fr.AFMode,
-		bulbExposureTimeWidth, fr.BulbExposureTime,
-		takenAtWidth, fr.TakenAt,
-		multipleExposureWidth, fr.MultipleExposure,
-		batteryLoadedAtWidth, fr.BatteryLoadedAt,
-		remarksWidth, truncate(fr.Remarks, remarksWidth),
-	)
+func renderFrame(fr DisplayableFrame, columns []FrameColumn) string {
+	cells := make([]string, 0, len(columns))
+	for _, c := range columns {
+		cells = append(cells, fmt.Sprintf("%-*s", c.Width, c.Cell(fr)))
+	}
 
-	return row
+	return strings.Join(cells, " ")
 }
 
 func (s *service) DisplayCustomFunctions(
 	ctx context.Context,
 	w io.Writer,
 	r DisplayableRoll,
+	verbose bool,
+	presets []domain.CustomFunctionPreset,
 ) error {
 	s.log.InfoContext(ctx, "formatting custom functions display",
 		slog.String("film_id", string(r.FilmID)),
-		slog.Int("frame_count", len(r.Frames)))
+		slog.Int("frame_count", len(r.Frames)),
+		slog.Bool("verbose", verbose),
+		slog.Int("presets", len(presets)))
+
+	if verbose {
+		s.displayCustomFunctionsVerbose(w, r)
+
+		s.log.DebugContext(ctx, "custom functions display formatted",
+			slog.Int("frame_count", len(r.Frames)))
+
+		return nil
+	}
 
 	//nolint:golines // more readable this way
 	header := fmt.Sprintf("%-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s",
 		filmIDWidth, "FILM ID",
 		frameNumberWidth, "FRAME NO.",
 		customFunctionsWidth, "#",
+		customFunctionsWidth, "0",
 		customFunctionsWidth, "1",
 		customFunctionsWidth, "2",
 		customFunctionsWidth, "3",
@@ -261,13 +258,20 @@ func (s *service) DisplayCustomFunctions(
 		customFunctionsWidth, "17",
 		customFunctionsWidth, "18",
 		customFunctionsWidth, "19",
-		customFunctionsWidth, "20",
 	)
+	if len(presets) > 0 {
+		header += " CLOSEST PRESET"
+	}
+
 	fmt.Fprintln(w, header)
 	fmt.Fprintln(w, strings.Repeat("-", len(header)))
 
 	for _, fr := range r.Frames {
 		row := s.renderCustomFunctions(fr)
+		if len(presets) > 0 {
+			row += " " + renderClosestPreset(fr.CustomFunctions, presets)
+		}
+
 		fmt.Fprintln(w, row)
 	}
 
@@ -281,7 +285,7 @@ func (s *service) renderCustomFunctions(fr DisplayableFrame) string {
 	//nolint:golines // more readable this way
 	row := fmt.Sprintf("%-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s %-*s",
 		filmIDWidth, fr.FilmID,
-		frameNumberWidth, s.renderFrameNumber(fr),
+		frameNumberWidth, renderFrameNumber(fr),
 		customFunctionsWidth, " ",
 		customFunctionsWidth, fr.CustomFunctions[0],
 		customFunctionsWidth, fr.CustomFunctions[1],
@@ -308,6 +312,54 @@ func (s *service) renderCustomFunctions(fr DisplayableFrame) string {
 	return row
 }
 
+// renderClosestPreset names the preset cfs matches best, with the number of
+// custom functions that differ from it, if any.
+func renderClosestPreset(
+	cfs domain.CustomFunctions,
+	presets []domain.CustomFunctionPreset,
+) string {
+	p, deviations, ok := domain.ClosestPreset(cfs, presets)
+
+	switch {
+	case !ok:
+		return ""
+	case deviations == 0:
+		return p.Name
+	default:
+		return fmt.Sprintf("%s (%d differ)", p.Name, deviations)
+	}
+}
+
+// displayCustomFunctionsVerbose lists each custom function of each frame with
+// its camera number, name and the meaning of its value.
+func (s *service) displayCustomFunctionsVerbose(
+	w io.Writer,
+	r DisplayableRoll,
+) {
+	header := fmt.Sprintf("%-*s %-*s %-*s %-*s %s",
+		filmIDWidth, "FILM ID",
+		frameNumberWidth, "FRAME NO.",
+		customFunctionNumberWidth, "C.Fn",
+		customFunctionNameWidth, "FUNCTION",
+		"SETTING",
+	)
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, strings.Repeat("-", len(header)))
+
+	for _, fr := range r.Frames {
+		for n := range fr.CustomFunctions {
+			row := fmt.Sprintf("%-*s %-*s %-*s %-*s %s",
+				filmIDWidth, fr.FilmID,
+				frameNumberWidth, renderFrameNumber(fr),
+				customFunctionNumberWidth, fmt.Sprintf("C.Fn-%d", n),
+				customFunctionNameWidth, domain.CustomFunctionName(n),
+				fr.CustomFunctions.DescribeSetting(n),
+			)
+			fmt.Fprintln(w, strings.TrimRight(row, " "))
+		}
+	}
+}
+
 func (s *service) DisplayFocusingPoints(
 	ctx context.Context,
 	w io.Writer,
@@ -319,10 +371,15 @@ func (s *service) DisplayFocusingPoints(
 
 	rows := make([]string, len(r.Frames))
 	for i, fr := range r.Frames {
+		// the grid is described in words below it, as it relies on colour
+		grid := strings.TrimSuffix(string(fr.FocusingPoints), "\n")
+		described := grid + "\n" + fr.FocusPoints.Describe() +
+			string(fr.FocusingPoints[len(grid):])
+
 		rows[i] = fmt.Sprintf("%-*s %-*d %-*s",
 			filmIDWidth, fr.FilmID,
 			frameNumberWidth, fr.FrameNumber,
-			focusingPointsWidth, pad(fr.FocusingPoints, focusingPointsPadding),
+			focusingPointsWidth, pad(described, focusingPointsPadding),
 		)
 	}
 
@@ -389,7 +446,7 @@ func (s *service) displayThumbnail(w io.Writer, fr DisplayableFrame) {
 	}
 }
 
-func (s *service) renderFrameNumber(fr DisplayableFrame) string {
+func renderFrameNumber(fr DisplayableFrame) string {
 	if !fr.UserModifiedRecord {
 		return strconv.FormatUint(uint64(fr.FrameNumber), 10)
 	}
@@ -153,6 +153,8 @@ func Test_DisplayCustomFunctions(t *testing.T) {
 	type testcase struct {
 		name           string
 		roll           display.DisplayableRoll
+		verbose        bool
+		presets        []domain.CustomFunctionPreset
 		expectedError  error
 		expectedOutput []byte
 	}
@@ -168,9 +170,75 @@ func Test_DisplayCustomFunctions(t *testing.T) {
 					},
 				},
 			},
-			expectedOutput: []byte(`FILM ID  FRAME NO. #  1  2  3  4  5  6  7  8  9  10 11 12 13 14 15 16 17 18 19 20
+			expectedOutput: []byte(`FILM ID  FRAME NO. #  0  1  2  3  4  5  6  7  8  9  10 11 12 13 14 15 16 17 18 19
 ---------------------------------------------------------------------------------
          0            1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1 
+`,
+			),
+		},
+		{
+			name: "verbose custom functions",
+			roll: display.DisplayableRoll{
+				Frames: []display.DisplayableFrame{
+					{
+						FilmID:             "12-345",
+						FrameNumber:        3,
+						UserModifiedRecord: true,
+						CustomFunctions: domain.CustomFunctions{
+							"9", " ", " ", " ", "0", " ", " ", " ", " ", " ",
+							" ", " ", " ", " ", " ", "1", " ", " ", " ", " ",
+						},
+					},
+				},
+			},
+			verbose: true,
+			expectedOutput: []byte(`FILM ID  FRAME NO. C.Fn    FUNCTION                            SETTING
+----------------------------------------------------------------------
+12-345   3*        C.Fn-0  Focusing screen                     9
+12-345   3*        C.Fn-1  Film rewind
+12-345   3*        C.Fn-2  Film leader after rewind
+12-345   3*        C.Fn-3  DX-coded film speed
+12-345   3*        C.Fn-4  AF start/AE lock button             0 = AF start / AE lock
+12-345   3*        C.Fn-5  Tv/Av setting in manual exposure
+12-345   3*        C.Fn-6  Exposure level increments
+12-345   3*        C.Fn-7  USM lens electronic manual focus
+12-345   3*        C.Fn-8  AF-assist beam
+12-345   3*        C.Fn-9  AEB sequence and cancellation
+12-345   3*        C.Fn-10 Superimposed display
+12-345   3*        C.Fn-11 Focusing point selection method
+12-345   3*        C.Fn-12 Mirror lockup
+12-345   3*        C.Fn-13 Focusing points and spot metering
+12-345   3*        C.Fn-14 Auto reduction of fill flash
+12-345   3*        C.Fn-15 Shutter curtain sync                1 = Second curtain
+12-345   3*        C.Fn-16 Safety shift in Tv and Av modes
+12-345   3*        C.Fn-17 AI Servo focusing point area
+12-345   3*        C.Fn-18 Switch to registered focusing point
+12-345   3*        C.Fn-19 Lens AF stop button
+`),
+		},
+		{
+			name: "closest preset",
+			roll: display.DisplayableRoll{
+				Frames: []display.DisplayableFrame{
+					{
+						CustomFunctions: newCustomFunctions(),
+					},
+					{
+						CustomFunctions: domain.CustomFunctions{
+							"0", "0", "0", "0", "0", "0", "0", "0", "0", "0",
+							"0", "0", "0", "0", "0", "0", "0", "0", "0", "0",
+						},
+					},
+				},
+			},
+			presets: []domain.CustomFunctionPreset{
+				{Name: "sports", Values: map[int]byte{4: 1, 13: 1}},
+				{Name: "studio", Values: map[int]byte{12: 0, 15: 1}},
+			},
+			expectedOutput: []byte(`FILM ID  FRAME NO. #  0  1  2  3  4  5  6  7  8  9  10 11 12 13 14 15 16 17 18 19 CLOSEST PRESET
+------------------------------------------------------------------------------------------------
+         0            1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  1  sports
+         0            0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  studio (1 differ)
 `,
 			),
 		},
@@ -186,7 +254,13 @@ func Test_DisplayCustomFunctions(t *testing.T) {
 
 			var b bytes.Buffer
 
-			err := svc.DisplayCustomFunctions(ctx, &b, tt.roll)
+			err := svc.DisplayCustomFunctions(
+				ctx,
+				&b,
+				tt.roll,
+				tt.verbose,
+				tt.presets,
+			)
 
 			if tt.expectedError != nil {
 				if err == nil {
@@ -243,6 +317,7 @@ points`,
 ----------------------------------------
          0         focusing
                    points
+                   no focusing point recorded
 
 `),
 		},
@@ -348,7 +423,7 @@ func Test_DisplayFrame(t *testing.T) {
 			var b bytes.Buffer
 			svc.DisplayFrames(ctx, &b, display.DisplayableRoll{
 				Frames: []display.DisplayableFrame{tt.frame},
-			})
+			}, nil)
 
 			if !bytes.Equal(b.Bytes(), tt.expectedOutput) {
 				t.Errorf("unexpected output:\n got:\n%s\nwant:\n%s",
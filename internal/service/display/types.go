@@ -69,12 +69,14 @@ type DisplayableFrame struct {
 	CustomFunctions domain.CustomFunctions
 	Remarks         domain.Remarks
 
+	FocusPoints    domain.FocusPoints
 	FocusingPoints DisplayableFocusPoints
 
 	Thumbnail *DisplayableThumbnail
 }
 
-// DisplayableFocusPoints represents rendered ASCII art of the 45-point AF grid.
+// DisplayableFocusPoints represents rendered ASCII art of the 45-point AF grid,
+// as drawn from a frame's FocusPoints.
 type DisplayableFocusPoints string
 
 // DisplayableThumbnail represents an ASCII art rendering of a frame's thumbnail image.
@@ -41,6 +41,9 @@ type RootBuilder interface {
 
 	// Build constructs the final Root structure. Returns an error if no EFDF was added.
 	Build() (records.Root, error)
+
+	// Reset discards the accumulated records so the builder can be reused for another file.
+	Reset()
 }
 
 type rootBuilder struct {
@@ -85,6 +88,12 @@ func (b *rootBuilder) AddEFTP(_ context.Context, eftp records.EFTP) {
 	b.eftps = append(b.eftps, eftp)
 }
 
+func (b *rootBuilder) Reset() {
+	b.efdf = nil
+	b.efrms = make([]records.EFRM, 0)
+	b.eftps = make([]records.EFTP, 0)
+}
+
 func (b *rootBuilder) Build() (records.Root, error) {
 	if b.efdf == nil {
 		return records.Root{}, ErrMissingEFDFRecord
@@ -138,3 +138,45 @@ func Test_RootBuilder(t *testing.T) {
 		})
 	}
 }
+
+//nolint:exhaustruct // only partial is needed
+func Test_RootBuilder_Reset(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	builder := efd.NewRootBuilder(newTestLogger())
+
+	if err := builder.AddEFDF(ctx, records.EFDF{FrameCount: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	builder.AddEFRM(ctx, records.EFRM{FrameNumber: 1})
+
+	first, err := builder.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	builder.Reset()
+
+	if _, err = builder.Build(); !errors.Is(err, efd.ErrMissingEFDFRecord) {
+		t.Fatalf("expected error %v after reset, got %v",
+			efd.ErrMissingEFDFRecord, err)
+	}
+
+	if err = builder.AddEFDF(ctx, records.EFDF{FrameCount: 2}); err != nil {
+		t.Fatalf("expected EFDF to be accepted after reset, got %v", err)
+	}
+
+	builder.AddEFRM(ctx, records.EFRM{FrameNumber: 2})
+
+	second, err := builder.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(second.EFRMs) != 1 || first.EFRMs[0].FrameNumber != 1 {
+		t.Errorf("expected independent builds, got %v and %v",
+			first.EFRMs, second.EFRMs)
+	}
+}
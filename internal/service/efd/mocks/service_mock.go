@@ -11,9 +11,11 @@ package efd_test
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
 	records "github.com/ma-tf/meta1v/internal/records"
+	efd "github.com/ma-tf/meta1v/internal/service/efd"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +43,25 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Records mocks base method.
+func (m *MockService) Records(ctx context.Context, filename string, magics ...string) iter.Seq2[records.Record, error] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, filename}
+	for _, a := range magics {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Records", varargs...)
+	ret0, _ := ret[0].(iter.Seq2[records.Record, error])
+	return ret0
+}
+
+// Records indicates an expected call of Records.
+func (mr *MockServiceMockRecorder) Records(ctx, filename any, magics ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, filename}, magics...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Records", reflect.TypeOf((*MockService)(nil).Records), varargs...)
+}
+
 // RecordsFromFile mocks base method.
 func (m *MockService) RecordsFromFile(ctx context.Context, filename string) (records.Root, error) {
 	m.ctrl.T.Helper()
@@ -55,3 +76,48 @@ func (mr *MockServiceMockRecorder) RecordsFromFile(ctx, filename any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordsFromFile", reflect.TypeOf((*MockService)(nil).RecordsFromFile), ctx, filename)
 }
+
+// RecordsToFile mocks base method.
+func (m *MockService) RecordsToFile(ctx context.Context, filename string, root records.Root) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordsToFile", ctx, filename, root)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordsToFile indicates an expected call of RecordsToFile.
+func (mr *MockServiceMockRecorder) RecordsToFile(ctx, filename, root any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordsToFile", reflect.TypeOf((*MockService)(nil).RecordsToFile), ctx, filename, root)
+}
+
+// RecoverRecordsFromFile mocks base method.
+func (m *MockService) RecoverRecordsFromFile(ctx context.Context, filename string) (records.Root, []efd.Diagnostic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverRecordsFromFile", ctx, filename)
+	ret0, _ := ret[0].(records.Root)
+	ret1, _ := ret[1].([]efd.Diagnostic)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RecoverRecordsFromFile indicates an expected call of RecoverRecordsFromFile.
+func (mr *MockServiceMockRecorder) RecoverRecordsFromFile(ctx, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverRecordsFromFile", reflect.TypeOf((*MockService)(nil).RecoverRecordsFromFile), ctx, filename)
+}
+
+// ScanFile mocks base method.
+func (m *MockService) ScanFile(ctx context.Context, filename string) ([]efd.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanFile", ctx, filename)
+	ret0, _ := ret[0].([]efd.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanFile indicates an expected call of ScanFile.
+func (mr *MockServiceMockRecorder) ScanFile(ctx, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanFile", reflect.TypeOf((*MockService)(nil).ScanFile), ctx, filename)
+}
@@ -22,17 +22,26 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"image"
 	"image/color"
 	"io"
 	"log/slog"
+	"math"
 
 	"github.com/ma-tf/meta1v/internal/records"
 )
 
+const (
+	recordHeaderSize = 16  // magic (4) + unknown (4) + length (8)
+	eftpHeaderSize   = 272 // index, dimensions and unknowns (16) + filepath (256)
+	bytesPerPixel    = 3
+)
+
 var (
-	ErrFailedToReadEFDF = errors.New("failed to read EFDF record")
-	ErrFailedToReadEFRM = errors.New("failed to read EFRM record")
+	ErrFailedToReadEFDF    = errors.New("failed to read EFDF record")
+	ErrFailedToReadEFRM    = errors.New("failed to read EFRM record")
+	ErrInvalidRecordLength = errors.New("invalid record length")
 )
 
 // Reader provides low-level binary reading operations for EFD file records.
@@ -70,7 +79,7 @@ func (b *reader) ReadRaw(
 	ctx context.Context,
 	r io.Reader,
 ) (records.Raw, error) {
-	var magicAndLength [16]byte
+	var magicAndLength [recordHeaderSize]byte
 	if err := binary.Read(r, binary.LittleEndian, &magicAndLength); err != nil {
 		return records.Raw{}, errors.Join(ErrInvalidRecordMagicNumber, err)
 	}
@@ -78,23 +87,41 @@ func (b *reader) ReadRaw(
 	magic := magicAndLength[:4]
 
 	l := binary.LittleEndian.Uint64(magicAndLength[8:16])
+	if l < uint64(len(magicAndLength)) {
+		return records.Raw{}, fmt.Errorf(
+			"%w: %d is shorter than the %d byte header",
+			ErrInvalidRecordLength, l, len(magicAndLength),
+		)
+	}
+
 	bufLen := l - uint64(len(magicAndLength))
-	buf := make([]byte, bufLen)
+	if bufLen > math.MaxInt64 {
+		return records.Raw{}, fmt.Errorf(
+			"%w: %d is too long", ErrInvalidRecordLength, l)
+	}
 
-	_, err := io.ReadFull(r, buf)
+	// The buffer grows with the bytes actually read, so a corrupt length
+	// cannot allocate more than is left in the file.
+	buf, err := io.ReadAll(io.LimitReader(r, int64(bufLen)))
 	if err != nil {
 		return records.Raw{}, errors.Join(ErrFailedToReadRecord, err)
 	}
 
+	if uint64(len(buf)) < bufLen {
+		return records.Raw{}, fmt.Errorf("%w: %d of %d bytes: %w",
+			ErrFailedToReadRecord, len(buf), bufLen, io.ErrUnexpectedEOF)
+	}
+
 	b.log.DebugContext(ctx, "parsed raw record",
 		slog.String("magic", string(magic)),
 		slog.Uint64("length", l),
 	)
 
 	return records.Raw{
-		Magic:  [4]byte(magic),
-		Length: l,
-		Data:   buf,
+		Magic:   [4]byte(magic),
+		Unknown: [4]byte(magicAndLength[4:8]),
+		Length:  l,
+		Data:    buf,
 	}, nil
 }
 
@@ -141,8 +168,6 @@ func (b *reader) ReadEFTP(
 	ctx context.Context,
 	data []byte,
 ) (records.EFTP, error) {
-	const bytesPerPixel = 3
-
 	var (
 		order    = binary.LittleEndian
 		header   [16]byte
@@ -163,10 +188,12 @@ func (b *reader) ReadEFTP(
 
 	thumbnail := b.thumbnailFactory.NewRGBA(image.Rect(0, 0, width, height))
 
-	for i := 0; i+2 < len(data); i += bytesPerPixel {
-		idx := i / bytesPerPixel
-		thumbnail.SetRGBA(idx%width, idx/height,
-			color.RGBA{data[idx+2], data[idx+1], data[idx], 255})
+	// pixels follow the header and filepath, stored row-major as BGR triplets
+	pixels := data[min(eftpHeaderSize, len(data)):]
+	for idx := 0; idx < width*height && (idx+1)*bytesPerPixel <= len(pixels); idx++ {
+		i := idx * bytesPerPixel
+		thumbnail.SetRGBA(idx%width, idx/width,
+			color.RGBA{pixels[i+2], pixels[i+1], pixels[i], 255})
 	}
 
 	frameNumber := order.Uint16(header[0:2])
@@ -21,6 +21,9 @@ import (
 	"encoding/binary"
 	"errors"
 	"image"
+	"image/color"
+	"io"
+	"math"
 	"testing"
 
 	"github.com/ma-tf/meta1v/internal/records"
@@ -67,6 +70,54 @@ func Test_Reader_ReadRaw(t *testing.T) {
 			}(),
 			expectedError: efd.ErrFailedToReadRecord,
 		},
+		{
+			name: "error on length past the end of the file",
+			file: func() []byte {
+				buf := &bytes.Buffer{}
+
+				_ = binary.Write(
+					buf,
+					binary.LittleEndian,
+					[8]byte{'E', 'F', 'T', 'P'},
+				)
+				_ = binary.Write(buf, binary.LittleEndian, uint64(1)<<40) // length
+
+				return buf.Bytes()
+			}(),
+			expectedError: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "error on length too long to read",
+			file: func() []byte {
+				buf := &bytes.Buffer{}
+
+				_ = binary.Write(
+					buf,
+					binary.LittleEndian,
+					[8]byte{'E', 'F', 'T', 'P'},
+				)
+				_ = binary.Write(buf, binary.LittleEndian, uint64(math.MaxUint64))
+
+				return buf.Bytes()
+			}(),
+			expectedError: efd.ErrInvalidRecordLength,
+		},
+		{
+			name: "error on length shorter than header",
+			file: func() []byte {
+				buf := &bytes.Buffer{}
+
+				_ = binary.Write(
+					buf,
+					binary.LittleEndian,
+					[8]byte{'E', 'F', 'T', 'P'},
+				)
+				_ = binary.Write(buf, binary.LittleEndian, uint64(8)) // length
+
+				return buf.Bytes()
+			}(),
+			expectedError: efd.ErrInvalidRecordLength,
+		},
 		{
 			name: "successful parse of valid raw record",
 			file: func() []byte {
@@ -385,3 +436,43 @@ func Test_Reader_ReadEFTP(t *testing.T) {
 		})
 	}
 }
+
+// Test_Reader_ReadEFTP_Pixels decodes a non-square thumbnail, so row and column
+// mix-ups and pixels read from inside the header would show up.
+func Test_Reader_ReadEFTP_Pixels(t *testing.T) {
+	t.Parallel()
+
+	const width, height = 3, 2
+
+	// BGR triplets, row-major
+	pixels := make([]byte, 0, width*height*3)
+	for i := range byte(width * height) {
+		pixels = append(pixels, 10*i+3, 10*i+2, 10*i+1)
+	}
+
+	reader := efd.NewReader(newTestLogger(), records.NewDefaultThumbnailFactory())
+
+	eftp, err := reader.ReadEFTP(
+		t.Context(),
+		newEFTPBytes(1, width, height, "thumb.tif", pixels),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := eftp.Thumbnail.Bounds(); got != image.Rect(0, 0, width, height) {
+		t.Fatalf("expected bounds %v, got %v",
+			image.Rect(0, 0, width, height), got)
+	}
+
+	for y := range height {
+		for x := range width {
+			i := byte(y*width + x)
+			want := color.RGBA{10*i + 1, 10*i + 2, 10*i + 3, 255}
+
+			if got := eftp.Thumbnail.RGBAAt(x, y); got != want {
+				t.Errorf("pixel (%d,%d): expected %v, got %v", x, y, want, got)
+			}
+		}
+	}
+}
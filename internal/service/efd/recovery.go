@@ -0,0 +1,268 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package efd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/records"
+)
+
+var ErrTruncatedRecord = errors.New("truncated record")
+
+// Diagnostic describes a region of an EFD file that was skipped while recovering records.
+type Diagnostic struct {
+	Offset int64 // byte offset of the skipped region from the start of the file
+	Length int64 // number of bytes skipped
+	Reason error // why the region could not be decoded
+}
+
+//nolint:gochecknoglobals // fixed set of record types
+var magics = [][]byte{
+	[]byte(records.MagicEFDF),
+	[]byte(records.MagicEFRM),
+	[]byte(records.MagicEFTP),
+}
+
+// Region is a contiguous span of an EFD file, either a framed record or
+// bytes that could not be framed as one.
+type Region struct {
+	Offset int64       // byte offset of the region from the start of the file
+	Bytes  []byte      // the region exactly as stored, including any record header
+	Raw    records.Raw // the framed record, zero if Reason is set
+	Reason error       // why the region could not be framed, nil for records
+}
+
+func (s *service) RecoverRecordsFromFile(
+	ctx context.Context,
+	filename string,
+) (records.Root, []Diagnostic, error) {
+	s.log.InfoContext(ctx, "recovering efd file", slog.String("file", filename))
+
+	data, err := s.readFile(filename)
+	if err != nil {
+		return records.Root{}, nil, err
+	}
+
+	defer s.builder.Reset()
+
+	var (
+		diagnostics []Diagnostic
+		placements  []records.Placement
+	)
+
+	for _, region := range s.scan(ctx, data) {
+		reason := region.Reason
+		if reason == nil {
+			var decoded records.Record
+
+			decoded, reason = s.processRecord(ctx, region.Raw)
+			if reason == nil {
+				placements = place(placements, region.Raw, decoded)
+
+				continue
+			}
+		}
+
+		s.log.DebugContext(ctx, "skipped unrecoverable region",
+			slog.Int64("offset", region.Offset),
+			slog.Int("length", len(region.Bytes)),
+			slog.Any("reason", reason))
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Offset: region.Offset,
+			Length: int64(len(region.Bytes)),
+			Reason: reason,
+		})
+	}
+
+	root, err := s.builder.Build()
+	if err != nil {
+		return records.Root{}, diagnostics, fmt.Errorf("%w %q: %w",
+			ErrFailedToBuildRoot, filename, err)
+	}
+
+	root.Placements = placements
+
+	s.log.InfoContext(ctx, "efd file recovered",
+		slog.String("file", filename),
+		slog.Int("efrms", len(root.EFRMs)),
+		slog.Int("eftps", len(root.EFTPs)),
+		slog.Int("diagnostics", len(diagnostics)))
+
+	return root, diagnostics, nil
+}
+
+func (s *service) ScanFile(
+	ctx context.Context,
+	filename string,
+) ([]Region, error) {
+	s.log.InfoContext(ctx, "scanning efd file", slog.String("file", filename))
+
+	data, err := s.readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := s.scan(ctx, data)
+
+	s.log.InfoContext(ctx, "efd file scanned",
+		slog.String("file", filename),
+		slog.Int("regions", len(regions)))
+
+	return regions, nil
+}
+
+func (s *service) readFile(filename string) ([]byte, error) {
+	file, err := s.fs.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrFailedToOpenFile, filename, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w",
+			ErrFailedToReadRecord, filename, err)
+	}
+
+	return data, nil
+}
+
+// scan splits data into regions. After any bytes that cannot be framed as a
+// record it resyncs on the next EFDF, EFRM or EFTP magic number.
+func (s *service) scan(ctx context.Context, data []byte) []Region {
+	var regions []Region
+
+	for offset := 0; offset < len(data); {
+		raw, length, reason := s.frameRecord(ctx, data[offset:])
+		if reason != nil {
+			raw = records.Raw{}
+			length = nextMagic(data, offset+1) - offset
+		}
+
+		regions = append(regions, Region{
+			Offset: int64(offset),
+			Bytes:  data[offset : offset+length],
+			Raw:    raw,
+			Reason: reason,
+		})
+
+		offset += length
+	}
+
+	return regions
+}
+
+// frameRecord reads the record at the start of data without decoding it,
+// returning the number of bytes the record spans.
+func (s *service) frameRecord(
+	ctx context.Context,
+	data []byte,
+) (records.Raw, int, error) {
+	if len(data) < recordHeaderSize {
+		return records.Raw{}, 0, fmt.Errorf(
+			"%w: %d bytes left, header needs %d",
+			ErrTruncatedRecord, len(data), recordHeaderSize)
+	}
+
+	magic := data[:4]
+	if !isMagic(magic) {
+		return records.Raw{}, 0, fmt.Errorf("%w: found %q",
+			ErrUnknownRecordType, magic)
+	}
+
+	l := binary.LittleEndian.Uint64(data[8:16])
+	if l > uint64(len(data)) {
+		return records.Raw{}, 0, fmt.Errorf(
+			"%w: record declares %d bytes, %d left",
+			ErrTruncatedRecord, l, len(data))
+	}
+
+	if expected, ok := expectedLength(data); !ok || l != expected {
+		return records.Raw{}, 0, fmt.Errorf(
+			"%w: %s record declares %d bytes, expected %d",
+			ErrInvalidRecordLength, magic, l, expected)
+	}
+
+	length := int(l) //nolint:gosec // bounded by len(data)
+
+	raw, err := s.reader.ReadRaw(ctx, bytes.NewReader(data[:length]))
+	if err != nil {
+		return records.Raw{}, 0, err //nolint:wrapcheck // descriptive already
+	}
+
+	return raw, length, nil
+}
+
+// expectedLength returns the length the record at the start of data should have,
+// given its type and, for EFTP records, the thumbnail dimensions. ReadEFDF and
+// ReadEFRM decode only a fixed size, so a longer record would silently swallow the
+// records that follow it. It reports false if data is too short to hold the
+// thumbnail dimensions.
+func expectedLength(data []byte) (uint64, bool) {
+	var (
+		efdf records.EFDF
+		efrm records.EFRM
+	)
+
+	switch string(data[:4]) {
+	case records.MagicEFDF:
+		return uint64(recordHeaderSize + binary.Size(efdf)), true
+	case records.MagicEFRM:
+		return uint64(recordHeaderSize + binary.Size(efrm)), true
+	default:
+		if len(data) < recordHeaderSize+8 {
+			return 0, false
+		}
+
+		width := uint64(binary.LittleEndian.Uint16(data[recordHeaderSize+4:]))
+		height := uint64(binary.LittleEndian.Uint16(data[recordHeaderSize+6:]))
+
+		return recordHeaderSize + eftpHeaderSize + width*height*bytesPerPixel, true
+	}
+}
+
+func isMagic(b []byte) bool {
+	for _, magic := range magics {
+		if bytes.Equal(b, magic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nextMagic returns the offset of the first record magic number at or after from,
+// or len(data) if there is none.
+func nextMagic(data []byte, from int) int {
+	next := len(data)
+
+	for _, magic := range magics {
+		if i := bytes.Index(data[from:], magic); i != -1 {
+			next = min(next, from+i)
+		}
+	}
+
+	return next
+}
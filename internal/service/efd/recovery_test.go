@@ -0,0 +1,354 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package efd_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newRecordBytes(t *testing.T, magic string, record any) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, binary.LittleEndian, record); err != nil {
+		t.Fatalf("failed to encode %s record: %v", magic, err)
+	}
+
+	return newRawBytes(magic, buf.Bytes())
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_RecoverRecordsFromFile(t *testing.T) {
+	t.Parallel()
+
+	efdf := newRecordBytes(t, "EFDF",
+		records.EFDF{Title: [64]byte{'t', 'i', 't', 'l', 'e'}})
+	efrm1 := newRecordBytes(t, "EFRM", records.EFRM{FrameNumber: 1})
+	efrm2 := newRecordBytes(t, "EFRM", records.EFRM{FrameNumber: 2})
+	unknown := newRawBytes("XXXX", []byte{1, 2, 3, 4})
+	shortEFRM := newRawBytes("EFRM", []byte{1, 2})
+	shortEFTP := newRawBytes("EFTP", newEFTPBytes(3, 2, 2, "", []byte{1, 2, 3}))
+
+	// a frame 1 record declaring the length of frames 1 and 2 together
+	longEFRM := bytes.Clone(efrm1)
+	binary.LittleEndian.PutUint64(longEFRM[8:16], uint64(len(efrm1)+len(efrm2)))
+
+	type expectedDiagnostic struct {
+		offset int
+		length int
+		reason error
+	}
+
+	type testcase struct {
+		name                string
+		file                []byte
+		expectedFrames      []uint32
+		expectedDiagnostics []expectedDiagnostic
+		expectedError       error
+	}
+
+	tests := []testcase{
+		{
+			name:           "intact file has no diagnostics",
+			file:           bytes.Join([][]byte{efdf, efrm1, efrm2}, nil),
+			expectedFrames: []uint32{1, 2},
+		},
+		{
+			name: "resyncs after unknown record",
+			file: bytes.Join(
+				[][]byte{efdf, unknown, efrm1, efrm2},
+				nil,
+			),
+			expectedFrames: []uint32{1, 2},
+			expectedDiagnostics: []expectedDiagnostic{
+				{len(efdf), len(unknown), efd.ErrUnknownRecordType},
+			},
+		},
+		{
+			name: "resyncs after garbage between records",
+			file: bytes.Join(
+				[][]byte{efdf, {0xDE, 0xAD}, efrm1},
+				nil,
+			),
+			expectedFrames: []uint32{1},
+			expectedDiagnostics: []expectedDiagnostic{
+				{len(efdf), 2, efd.ErrUnknownRecordType},
+			},
+		},
+		{
+			name: "skips record shorter than its type",
+			file: bytes.Join(
+				[][]byte{efdf, shortEFRM, efrm2},
+				nil,
+			),
+			expectedFrames: []uint32{2},
+			expectedDiagnostics: []expectedDiagnostic{
+				{len(efdf), len(shortEFRM), efd.ErrInvalidRecordLength},
+			},
+		},
+		{
+			name: "resyncs inside record longer than its type",
+			file: bytes.Join(
+				[][]byte{efdf, longEFRM, efrm2},
+				nil,
+			),
+			expectedFrames: []uint32{2},
+			expectedDiagnostics: []expectedDiagnostic{
+				{len(efdf), len(efrm1), efd.ErrInvalidRecordLength},
+			},
+		},
+		{
+			name: "skips thumbnail shorter than its dimensions",
+			file: bytes.Join(
+				[][]byte{efdf, efrm1, shortEFTP, efrm2},
+				nil,
+			),
+			expectedFrames: []uint32{1, 2},
+			expectedDiagnostics: []expectedDiagnostic{
+				{len(efdf) + len(efrm1), len(shortEFTP), efd.ErrInvalidRecordLength},
+			},
+		},
+		{
+			name: "skips truncated tail",
+			file: bytes.Join(
+				[][]byte{efdf, efrm1, efrm2[:100]},
+				nil,
+			),
+			expectedFrames: []uint32{1},
+			expectedDiagnostics: []expectedDiagnostic{
+				{len(efdf) + len(efrm1), 100, efd.ErrTruncatedRecord},
+			},
+		},
+		{
+			name: "skips header cut short",
+			file: bytes.Join(
+				[][]byte{efdf, efrm1, efrm2[:8]},
+				nil,
+			),
+			expectedFrames: []uint32{1},
+			expectedDiagnostics: []expectedDiagnostic{
+				{len(efdf) + len(efrm1), 8, efd.ErrTruncatedRecord},
+			},
+		},
+		{
+			name: "keeps first of multiple EFDF records",
+			file: bytes.Join(
+				[][]byte{efdf, efrm1, efdf},
+				nil,
+			),
+			expectedFrames: []uint32{1},
+			expectedDiagnostics: []expectedDiagnostic{
+				{len(efdf) + len(efrm1), len(efdf), efd.ErrMultipleEFDFRecords},
+			},
+		},
+		{
+			name: "fails when no EFDF record survives",
+			file: bytes.Join(
+				[][]byte{unknown, efrm1},
+				nil,
+			),
+			expectedError: efd.ErrFailedToBuildRoot,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := bytes.NewReader(tt.file)
+
+			mockFile := osfs_test.NewMockFile(ctrl)
+			mockFile.EXPECT().
+				Read(gomock.Any()).
+				DoAndReturn(r.Read).
+				AnyTimes()
+			mockFile.EXPECT().
+				Close().
+				Return(nil)
+
+			mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
+			mockFileSystem.EXPECT().
+				Open("file.efd").
+				Return(mockFile, nil)
+
+			svc := efd.NewService(
+				newTestLogger(),
+				efd.NewRootBuilder(newTestLogger()),
+				efd.NewReader(
+					newTestLogger(),
+					records.NewDefaultThumbnailFactory(),
+				),
+				efd_test.NewMockWriter(ctrl),
+				mockFileSystem,
+			)
+
+			root, diagnostics, err := svc.RecoverRecordsFromFile(
+				t.Context(),
+				"file.efd",
+			)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			frames := make([]uint32, 0, len(root.EFRMs))
+			for _, efrm := range root.EFRMs {
+				frames = append(frames, efrm.FrameNumber)
+			}
+
+			if !slices.Equal(frames, tt.expectedFrames) {
+				t.Fatalf("expected frames %v, got %v",
+					tt.expectedFrames, frames)
+			}
+
+			if len(diagnostics) != len(tt.expectedDiagnostics) {
+				t.Fatalf("expected %d diagnostics, got %v",
+					len(tt.expectedDiagnostics), diagnostics)
+			}
+
+			for i, expected := range tt.expectedDiagnostics {
+				got := diagnostics[i]
+				if got.Offset != int64(expected.offset) ||
+					got.Length != int64(expected.length) ||
+					!errors.Is(got.Reason, expected.reason) {
+					t.Errorf("expected diagnostic %d to be %+v, got %+v",
+						i, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_RecoverRecordsFromFile_OpenFailure(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
+	mockFileSystem.EXPECT().
+		Open("missing.efd").
+		Return(nil, errExample)
+
+	svc := efd.NewService(
+		newTestLogger(),
+		efd_test.NewMockRootBuilder(ctrl),
+		efd_test.NewMockReader(ctrl),
+		efd_test.NewMockWriter(ctrl),
+		mockFileSystem,
+	)
+
+	_, _, err := svc.RecoverRecordsFromFile(t.Context(), "missing.efd")
+	if !errors.Is(err, efd.ErrFailedToOpenFile) {
+		t.Fatalf("expected error %v, got %v", efd.ErrFailedToOpenFile, err)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_ScanFile(t *testing.T) {
+	t.Parallel()
+
+	efdf := newRecordBytes(t, "EFDF", records.EFDF{})
+	shortEFRM := newRawBytes("EFRM", []byte{1, 2})
+	garbage := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+	file := bytes.Join([][]byte{efdf, garbage, shortEFRM, efdf[:20]}, nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := bytes.NewReader(file)
+
+	mockFile := osfs_test.NewMockFile(ctrl)
+	mockFile.EXPECT().
+		Read(gomock.Any()).
+		DoAndReturn(r.Read).
+		AnyTimes()
+	mockFile.EXPECT().
+		Close().
+		Return(nil)
+
+	mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
+	mockFileSystem.EXPECT().
+		Open("file.efd").
+		Return(mockFile, nil)
+
+	svc := efd.NewService(
+		newTestLogger(),
+		efd_test.NewMockRootBuilder(ctrl),
+		efd.NewReader(newTestLogger(), records.NewDefaultThumbnailFactory()),
+		efd_test.NewMockWriter(ctrl),
+		mockFileSystem,
+	)
+
+	regions, err := svc.ScanFile(t.Context(), "file.efd")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		offset int
+		length int
+		magic  string
+		reason error
+	}{
+		{0, len(efdf), "EFDF", nil},
+		{len(efdf), len(garbage), "", efd.ErrUnknownRecordType},
+		{len(efdf) + len(garbage), len(shortEFRM), "", efd.ErrInvalidRecordLength},
+		{len(file) - 20, 20, "", efd.ErrTruncatedRecord},
+	}
+
+	if len(regions) != len(expected) {
+		t.Fatalf("expected %d regions, got %d", len(expected), len(regions))
+	}
+
+	for i, want := range expected {
+		got := regions[i]
+
+		magic := ""
+		if got.Reason == nil {
+			magic = string(got.Raw.Magic[:])
+		}
+
+		if got.Offset != int64(want.offset) || len(got.Bytes) != want.length ||
+			magic != want.magic || !errors.Is(got.Reason, want.reason) {
+			t.Errorf("expected region %d to be %+v, got offset %d, "+
+				"length %d, magic %q, reason %v",
+				i, want, got.Offset, len(got.Bytes), magic, got.Reason)
+		}
+	}
+}
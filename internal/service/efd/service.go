@@ -19,15 +19,22 @@
 // Package efd provides services for reading and parsing Canon EFD binary files.
 //
 // The service reads EFD files, processes the binary records (EFDF, EFRM, EFTP),
-// and constructs a structured representation of the film roll metadata.
+// and constructs a structured representation of the film roll metadata, or streams
+// the decoded records one at a time. It can also serialise that representation
+// back into an EFD file.
 package efd
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"log/slog"
+	"os"
+	"slices"
 
 	"github.com/ma-tf/meta1v/internal/records"
 	"github.com/ma-tf/meta1v/internal/service/osfs"
@@ -42,19 +49,61 @@ var (
 	ErrMultipleEFDFRecords      = errors.New("multiple EFDF records found")
 	ErrUnknownRecordType        = errors.New("unknown record type")
 	ErrFailedToParseThumbnail   = errors.New("failed to parse EFTP thumbnail")
+	ErrFailedToCreateFile       = errors.New("failed to create specified file")
+	ErrFailedToCloseFile        = errors.New("failed to close specified file")
+	ErrFailedToEncodeRecord     = errors.New("failed to encode record")
+	ErrFailedToDecodeRecord     = errors.New("failed to decode record")
 )
 
+const permission = 0o666 // rw-rw-rw-
+
 // Service provides operations for reading Canon EFD files and extracting structured metadata.
 type Service interface {
 	// RecordsFromFile reads an EFD file and returns the parsed Root structure containing
 	// film roll metadata, frame records, and thumbnails.
 	RecordsFromFile(ctx context.Context, filename string) (records.Root, error)
+
+	// RecoverRecordsFromFile reads an EFD file like RecordsFromFile, but skips records
+	// it cannot decode instead of failing. After an unknown or malformed record it resyncs
+	// on the next EFDF, EFRM or EFTP magic number. Every skipped region is reported as a
+	// Diagnostic. It only fails if the file cannot be read or no EFDF record survives.
+	RecoverRecordsFromFile(
+		ctx context.Context,
+		filename string,
+	) (records.Root, []Diagnostic, error)
+
+	// ScanFile splits an EFD file into regions without decoding any record, so that
+	// files the parser rejects can still be examined. Bytes that cannot be framed as a
+	// record form their own region, carrying the reason, up to the next magic number.
+	ScanFile(ctx context.Context, filename string) ([]Region, error)
+
+	// Records streams the records of an EFD file in file order, decoding each one
+	// only as it is reached, so callers can stop ranging once they have what they need.
+	// If magics are given, records of any other type are skipped without being decoded.
+	// Iteration ends after the first error, which is yielded with a nil record.
+	Records(
+		ctx context.Context,
+		filename string,
+		magics ...string,
+	) iter.Seq2[records.Record, error]
+
+	// RecordsToFile serialises a Root structure into an EFD file, creating or
+	// truncating it. Records are written in the order of the Root's placements,
+	// with the header and trailing bytes they were read with, so a Root read by
+	// RecordsFromFile is written back byte for byte. Records without a placement
+	// follow in the order EFDF, EFRMs, EFTPs.
+	RecordsToFile(
+		ctx context.Context,
+		filename string,
+		root records.Root,
+	) error
 }
 
 type service struct {
 	log     *slog.Logger
 	builder RootBuilder
 	reader  Reader
+	writer  Writer
 	fs      osfs.FileSystem
 }
 
@@ -62,12 +111,14 @@ func NewService(
 	log *slog.Logger,
 	builder RootBuilder,
 	reader Reader,
+	writer Writer,
 	fs osfs.FileSystem,
 ) Service {
 	return &service{
 		log:     log,
 		builder: builder,
 		reader:  reader,
+		writer:  writer,
 		fs:      fs,
 	}
 }
@@ -84,10 +135,11 @@ func (s *service) RecordsFromFile(
 			ErrFailedToOpenFile, filename, errFile)
 	}
 	defer file.Close()
+	defer s.builder.Reset()
 
 	s.log.DebugContext(ctx, "opened file:", slog.String("filename", filename))
 
-	recordCount := 0
+	var placements []records.Placement
 
 	for {
 		record, errRaw := s.reader.ReadRaw(ctx, file)
@@ -100,15 +152,16 @@ func (s *service) RecordsFromFile(
 				ErrFailedToReadRecord, filename, errRaw)
 		}
 
-		recordCount++
-
-		if errProcess := s.processRecord(ctx, record); errProcess != nil {
+		decoded, errProcess := s.processRecord(ctx, record)
+		if errProcess != nil {
 			return records.Root{}, errProcess
 		}
+
+		placements = place(placements, record, decoded)
 	}
 
 	s.log.DebugContext(ctx, "all records read",
-		slog.Int("total_records", recordCount))
+		slog.Int("total_records", len(placements)))
 
 	root, err := s.builder.Build()
 	if err != nil {
@@ -116,6 +169,8 @@ func (s *service) RecordsFromFile(
 			ErrFailedToBuildRoot, filename, err)
 	}
 
+	root.Placements = placements
+
 	s.log.InfoContext(ctx, "efd file parsed successfully",
 		slog.String("file", filename),
 		slog.Int("efrms", len(root.EFRMs)),
@@ -124,51 +179,308 @@ func (s *service) RecordsFromFile(
 	return root, nil
 }
 
-func (s *service) processRecord(ctx context.Context, record records.Raw) error {
-	magic := string(record.Magic[:])
-	switch magic {
-	case "EFDF":
-		efdf, errRead := s.reader.ReadEFDF(ctx, record.Data)
-		if errRead != nil {
-			return errors.Join(ErrFailedToAddRecord, errRead)
+// processRecord decodes record and adds it to the builder, returning the
+// decoded record.
+func (s *service) processRecord(
+	ctx context.Context,
+	record records.Raw,
+) (records.Record, error) {
+	decoded, err := s.decodeRecord(ctx, record)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToAddRecord, err)
+	}
+
+	switch r := decoded.(type) {
+	case records.EFDF:
+		if errAdd := s.builder.AddEFDF(ctx, r); errAdd != nil {
+			return nil, errors.Join(ErrFailedToAddRecord, errAdd)
 		}
+	case records.EFRM:
+		s.builder.AddEFRM(ctx, r)
+	case records.EFTP:
+		s.builder.AddEFTP(ctx, r)
+	}
+
+	return decoded, nil
+}
+
+// place appends the placement of record, decoded as decoded, to placements.
+func place(
+	placements []records.Placement,
+	record records.Raw,
+	decoded records.Record,
+) []records.Placement {
+	index := 0
 
-		if err := s.builder.AddEFDF(ctx, efdf); err != nil {
-			return errors.Join(ErrFailedToAddRecord, err)
+	for _, p := range placements {
+		if p.Magic == decoded.Magic() {
+			index++
 		}
+	}
 
-		s.log.DebugContext(ctx, "efdf record processed")
+	var trailing []byte
+	if size := decodedSize(decoded); size < len(record.Data) {
+		trailing = bytes.Clone(record.Data[size:])
+	}
+
+	return append(placements, records.Placement{
+		Magic:    decoded.Magic(),
+		Index:    index,
+		Unknown:  record.Unknown,
+		Trailing: trailing,
+	})
+}
+
+// decodedSize returns the number of bytes of a record's data the reader
+// decodes into the record.
+func decodedSize(decoded records.Record) int {
+	switch r := decoded.(type) {
+	case records.EFDF:
+		return binary.Size(r)
+	case records.EFRM:
+		return binary.Size(r)
+	case records.EFTP:
+		return eftpHeaderSize + int(r.Width)*int(r.Height)*bytesPerPixel
+	default:
+		return 0
+	}
+}
 
-		return nil
-	case "EFRM":
-		efrm, errRead := s.reader.ReadEFRM(ctx, record.Data)
-		if errRead != nil {
-			return errors.Join(ErrFailedToAddRecord, errRead)
+func (s *service) decodeRecord(
+	ctx context.Context,
+	record records.Raw,
+) (records.Record, error) {
+	magic := string(record.Magic[:])
+	switch magic {
+	case records.MagicEFDF:
+		efdf, err := s.reader.ReadEFDF(ctx, record.Data)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // wrapped by caller
 		}
 
-		s.builder.AddEFRM(ctx, efrm)
+		s.log.DebugContext(ctx, "efdf record processed")
+
+		return efdf, nil
+	case records.MagicEFRM:
+		efrm, err := s.reader.ReadEFRM(ctx, record.Data)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // wrapped by caller
+		}
 
 		s.log.DebugContext(ctx, "efrm record processed",
 			slog.Uint64("frame_number", uint64(efrm.FrameNumber)))
 
-		return nil
-	case "EFTP":
-		eftp, errRead := s.reader.ReadEFTP(ctx, record.Data)
-		if errRead != nil {
-			return errors.Join(ErrFailedToAddRecord, errRead)
+		return efrm, nil
+	case records.MagicEFTP:
+		eftp, err := s.reader.ReadEFTP(ctx, record.Data)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // wrapped by caller
 		}
 
-		s.builder.AddEFTP(ctx, eftp)
-
 		s.log.DebugContext(ctx, "eftp record processed",
 			slog.Uint64("index", uint64(eftp.Index)))
 
-		return nil
+		return eftp, nil
 	default:
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"%w: found %q, expected EFDF (file record), EFRM (frame record), or EFTP (thumbnail record)",
 			ErrUnknownRecordType,
 			magic,
 		)
 	}
 }
+
+func (s *service) Records(
+	ctx context.Context,
+	filename string,
+	magics ...string,
+) iter.Seq2[records.Record, error] {
+	return func(yield func(records.Record, error) bool) {
+		s.log.InfoContext(ctx, "streaming efd file",
+			slog.String("file", filename),
+			slog.Any("magics", magics))
+
+		file, errFile := s.fs.Open(filename)
+		if errFile != nil {
+			yield(nil, fmt.Errorf("%w %q: %w",
+				ErrFailedToOpenFile, filename, errFile))
+
+			return
+		}
+		defer file.Close()
+
+		for {
+			record, errRaw := s.reader.ReadRaw(ctx, file)
+			if errors.Is(errRaw, io.EOF) {
+				return
+			}
+
+			if errRaw != nil {
+				yield(nil, fmt.Errorf("%w %q: %w",
+					ErrFailedToReadRecord, filename, errRaw))
+
+				return
+			}
+
+			if len(magics) > 0 &&
+				!slices.Contains(magics, string(record.Magic[:])) {
+				s.log.DebugContext(ctx, "skipped record",
+					slog.String("magic", string(record.Magic[:])))
+
+				continue
+			}
+
+			decoded, errDecode := s.decodeRecord(ctx, record)
+			if errDecode != nil {
+				yield(nil, fmt.Errorf("%w %q: %w",
+					ErrFailedToDecodeRecord, filename, errDecode))
+
+				return
+			}
+
+			if !yield(decoded, nil) {
+				s.log.DebugContext(ctx, "stopped streaming efd file early",
+					slog.String("file", filename))
+
+				return
+			}
+		}
+	}
+}
+
+func (s *service) RecordsToFile(
+	ctx context.Context,
+	filename string,
+	root records.Root,
+) error {
+	s.log.InfoContext(ctx, "writing efd file", slog.String("file", filename))
+
+	raws, err := s.encodeRecords(ctx, root)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrFailedToEncodeRecord, filename, err)
+	}
+
+	file, errFile := s.fs.OpenFile(
+		filename,
+		os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
+		permission,
+	)
+	if errFile != nil {
+		return fmt.Errorf("%w %q: %w",
+			ErrFailedToCreateFile, filename, errFile)
+	}
+
+	for _, raw := range raws {
+		if errWrite := s.writer.WriteRaw(ctx, file, raw); errWrite != nil {
+			_ = file.Close()
+
+			return fmt.Errorf("%w %q: %w",
+				ErrFailedToWriteRecord, filename, errWrite)
+		}
+	}
+
+	if errClose := file.Close(); errClose != nil {
+		return fmt.Errorf("%w %q: %w",
+			ErrFailedToCloseFile, filename, errClose)
+	}
+
+	s.log.InfoContext(ctx, "efd file written successfully",
+		slog.String("file", filename),
+		slog.Int("efrms", len(root.EFRMs)),
+		slog.Int("eftps", len(root.EFTPs)))
+
+	return nil
+}
+
+func (s *service) encodeRecords(
+	ctx context.Context,
+	root records.Root,
+) ([]records.Raw, error) {
+	placements := layout(root)
+	raws := make([]records.Raw, 0, len(placements))
+
+	for _, p := range placements {
+		var (
+			data []byte
+			err  error
+		)
+
+		switch p.Magic {
+		case records.MagicEFDF:
+			data, err = s.writer.WriteEFDF(ctx, root.EFDF)
+		case records.MagicEFRM:
+			data, err = s.writer.WriteEFRM(ctx, root.EFRMs[p.Index])
+		case records.MagicEFTP:
+			data, err = s.writer.WriteEFTP(ctx, root.EFTPs[p.Index])
+		}
+
+		if err != nil {
+			return nil, err //nolint:wrapcheck // wrapped by caller
+		}
+
+		raws = append(raws, newRaw(p, data))
+	}
+
+	return raws, nil
+}
+
+// layout returns the placement of every record of root in the order they are
+// written. The placements root was read with come first, leaving out those of
+// records no longer there, followed by a placement for every record added since.
+// A root without a placement for its EFDF starts with it.
+func layout(root records.Root) []records.Placement {
+	type key struct {
+		magic string
+		index int
+	}
+
+	counts := map[string]int{
+		records.MagicEFDF: 1,
+		records.MagicEFRM: len(root.EFRMs),
+		records.MagicEFTP: len(root.EFTPs),
+	}
+
+	placed := make(map[key]bool, len(root.Placements))
+	placements := make([]records.Placement, 0, 1+len(root.EFRMs)+len(root.EFTPs))
+
+	for _, p := range root.Placements {
+		k := key{p.Magic, p.Index}
+		if p.Index < 0 || p.Index >= counts[p.Magic] || placed[k] {
+			continue
+		}
+
+		placed[k] = true
+		placements = append(placements, p)
+	}
+
+	if !placed[key{records.MagicEFDF, 0}] {
+		//nolint:exhaustruct // no header or trailing bytes to keep
+		placements = slices.Insert(placements, 0,
+			records.Placement{Magic: records.MagicEFDF})
+	}
+
+	for _, magic := range []string{records.MagicEFRM, records.MagicEFTP} {
+		for i := range counts[magic] {
+			if !placed[key{magic, i}] {
+				//nolint:exhaustruct // no header or trailing bytes to keep
+				placements = append(placements,
+					records.Placement{Magic: magic, Index: i})
+			}
+		}
+	}
+
+	return placements
+}
+
+// newRaw frames the encoded data of the record placed at p, restoring the header
+// and trailing bytes it was read with.
+func newRaw(p records.Placement, data []byte) records.Raw {
+	data = append(data, p.Trailing...)
+
+	return records.Raw{
+		Magic:   [4]byte([]byte(p.Magic)),
+		Unknown: p.Unknown,
+		Length:  uint64(recordHeaderSize + len(data)),
+		Data:    data,
+	}
+}
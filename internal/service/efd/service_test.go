@@ -21,12 +21,15 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/ma-tf/meta1v/internal/records"
 	"github.com/ma-tf/meta1v/internal/service/efd"
 	efd_test "github.com/ma-tf/meta1v/internal/service/efd/mocks"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
 	osfs_test "github.com/ma-tf/meta1v/internal/service/osfs/mocks"
 	"go.uber.org/mock/gomock"
 )
@@ -219,6 +222,11 @@ func Test_RecordsFromFile(t *testing.T) {
 				EFDF:  records.EFDF{Title: [64]byte{'t', 'i', 't', 'l', 'e'}},
 				EFRMs: []records.EFRM{{FrameNumber: 1}},
 				EFTPs: []records.EFTP{{Width: 100, Height: 100}},
+				Placements: []records.Placement{
+					{Magic: records.MagicEFDF},
+					{Magic: records.MagicEFRM},
+					{Magic: records.MagicEFTP},
+				},
 			},
 			expectedError: nil,
 		},
@@ -260,6 +268,7 @@ func Test_RecordsFromFile(t *testing.T) {
 			mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
 			mockReader := efd_test.NewMockReader(ctrl)
 			mockRootBuilder := efd_test.NewMockRootBuilder(ctrl)
+			mockRootBuilder.EXPECT().Reset().AnyTimes()
 			mockFile := osfs_test.NewMockFile(ctrl)
 
 			if tt.expect != nil {
@@ -276,6 +285,7 @@ func Test_RecordsFromFile(t *testing.T) {
 				newTestLogger(),
 				mockRootBuilder,
 				mockReader,
+				efd_test.NewMockWriter(ctrl),
 				mockFileSystem,
 			)
 
@@ -511,6 +521,7 @@ func Test_RecordsFromFile_ProcessRecord(t *testing.T) {
 			mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
 			mockReader := efd_test.NewMockReader(ctrl)
 			mockRootBuilder := efd_test.NewMockRootBuilder(ctrl)
+			mockRootBuilder.EXPECT().Reset().AnyTimes()
 			mockFile := osfs_test.NewMockFile(ctrl)
 
 			if tt.expect != nil {
@@ -527,6 +538,7 @@ func Test_RecordsFromFile_ProcessRecord(t *testing.T) {
 				newTestLogger(),
 				mockRootBuilder,
 				mockReader,
+				efd_test.NewMockWriter(ctrl),
 				mockFileSystem,
 			)
 
@@ -545,3 +557,686 @@ func Test_RecordsFromFile_ProcessRecord(t *testing.T) {
 		})
 	}
 }
+
+//nolint:exhaustruct // for records
+func Test_Records(t *testing.T) {
+	t.Parallel()
+
+	efdf := records.EFDF{Title: [64]byte{'t', 'i', 't', 'l', 'e'}}
+	efrm := records.EFRM{FrameNumber: 1}
+	eftp := records.EFTP{Width: 100, Height: 100}
+
+	efdfRaw := records.Raw{Magic: [4]byte{'E', 'F', 'D', 'F'}, Data: []byte{1}}
+	efrmRaw := records.Raw{Magic: [4]byte{'E', 'F', 'R', 'M'}, Data: []byte{2}}
+	eftpRaw := records.Raw{Magic: [4]byte{'E', 'F', 'T', 'P'}, Data: []byte{3}}
+
+	type testcase struct {
+		name     string
+		filename string
+		magics   []string
+		stopAt   int // stop ranging after this many records, 0 to range fully
+		expect   func(
+			mockFileSystem *osfs_test.MockFileSystem,
+			mockReader *efd_test.MockReader,
+			mockFile *osfs_test.MockFile,
+			tt testcase,
+		)
+		expectedRecords []records.Record
+		expectedError   error
+	}
+
+	tests := []testcase{
+		{
+			name:     "file does not exist",
+			filename: "nonexistent.efd",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				_ *efd_test.MockReader,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockFileSystem.EXPECT().
+					Open(tt.filename).
+					Return(nil, errExample)
+			},
+			expectedError: efd.ErrFailedToOpenFile,
+		},
+		{
+			name:     "failed to read record",
+			filename: "failed_to_read.efd",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockReader *efd_test.MockReader,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockFileSystem.EXPECT().
+					Open(tt.filename).
+					Return(mockFile, nil)
+
+				mockReader.EXPECT().
+					ReadRaw(gomock.Any(), mockFile).
+					Return(efdfRaw, nil)
+
+				mockReader.EXPECT().
+					ReadEFDF(gomock.Any(), efdfRaw.Data).
+					Return(efdf, nil)
+
+				mockReader.EXPECT().
+					ReadRaw(gomock.Any(), mockFile).
+					Return(records.Raw{}, errExample)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+			expectedRecords: []records.Record{efdf},
+			expectedError:   efd.ErrFailedToReadRecord,
+		},
+		{
+			name:     "unknown record magic number",
+			filename: "unknown.efd",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockReader *efd_test.MockReader,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockFileSystem.EXPECT().
+					Open(tt.filename).
+					Return(mockFile, nil)
+
+				mockReader.EXPECT().
+					ReadRaw(gomock.Any(), mockFile).
+					Return(records.Raw{Magic: [4]byte{'X', 'X', 'X', 'X'}}, nil)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+			expectedError: efd.ErrUnknownRecordType,
+		},
+		{
+			name:     "failed to decode record",
+			filename: "failed_to_decode.efd",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockReader *efd_test.MockReader,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockFileSystem.EXPECT().
+					Open(tt.filename).
+					Return(mockFile, nil)
+
+				mockReader.EXPECT().
+					ReadRaw(gomock.Any(), mockFile).
+					Return(efrmRaw, nil)
+
+				mockReader.EXPECT().
+					ReadEFRM(gomock.Any(), efrmRaw.Data).
+					Return(records.EFRM{}, errExample)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+			expectedError: efd.ErrFailedToDecodeRecord,
+		},
+		{
+			name:     "streams every record in file order",
+			filename: "file.efd",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockReader *efd_test.MockReader,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockFileSystem.EXPECT().
+					Open(tt.filename).
+					Return(mockFile, nil)
+
+				gomock.InOrder(
+					mockReader.EXPECT().
+						ReadRaw(gomock.Any(), mockFile).
+						Return(efdfRaw, nil),
+					mockReader.EXPECT().
+						ReadRaw(gomock.Any(), mockFile).
+						Return(efrmRaw, nil),
+					mockReader.EXPECT().
+						ReadRaw(gomock.Any(), mockFile).
+						Return(eftpRaw, nil),
+					mockReader.EXPECT().
+						ReadRaw(gomock.Any(), mockFile).
+						Return(records.Raw{}, io.EOF),
+				)
+
+				mockReader.EXPECT().
+					ReadEFDF(gomock.Any(), efdfRaw.Data).
+					Return(efdf, nil)
+
+				mockReader.EXPECT().
+					ReadEFRM(gomock.Any(), efrmRaw.Data).
+					Return(efrm, nil)
+
+				mockReader.EXPECT().
+					ReadEFTP(gomock.Any(), eftpRaw.Data).
+					Return(eftp, nil)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+			expectedRecords: []records.Record{efdf, efrm, eftp},
+		},
+		{
+			name:     "skips records not asked for without decoding them",
+			filename: "file.efd",
+			magics:   []string{records.MagicEFRM},
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockReader *efd_test.MockReader,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockFileSystem.EXPECT().
+					Open(tt.filename).
+					Return(mockFile, nil)
+
+				gomock.InOrder(
+					mockReader.EXPECT().
+						ReadRaw(gomock.Any(), mockFile).
+						Return(efdfRaw, nil),
+					mockReader.EXPECT().
+						ReadRaw(gomock.Any(), mockFile).
+						Return(efrmRaw, nil),
+					mockReader.EXPECT().
+						ReadRaw(gomock.Any(), mockFile).
+						Return(eftpRaw, nil),
+					mockReader.EXPECT().
+						ReadRaw(gomock.Any(), mockFile).
+						Return(records.Raw{}, io.EOF),
+				)
+
+				mockReader.EXPECT().
+					ReadEFRM(gomock.Any(), efrmRaw.Data).
+					Return(efrm, nil)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+			expectedRecords: []records.Record{efrm},
+		},
+		{
+			name:     "stops reading when caller stops ranging",
+			filename: "file.efd",
+			stopAt:   1,
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockReader *efd_test.MockReader,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockFileSystem.EXPECT().
+					Open(tt.filename).
+					Return(mockFile, nil)
+
+				mockReader.EXPECT().
+					ReadRaw(gomock.Any(), mockFile).
+					Return(efdfRaw, nil)
+
+				mockReader.EXPECT().
+					ReadEFDF(gomock.Any(), efdfRaw.Data).
+					Return(efdf, nil)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+			expectedRecords: []records.Record{efdf},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
+			mockReader := efd_test.NewMockReader(ctrl)
+			mockFile := osfs_test.NewMockFile(ctrl)
+
+			tt.expect(mockFileSystem, mockReader, mockFile, tt)
+
+			svc := efd.NewService(
+				newTestLogger(),
+				efd_test.NewMockRootBuilder(ctrl),
+				mockReader,
+				efd_test.NewMockWriter(ctrl),
+				mockFileSystem,
+			)
+
+			var (
+				got []records.Record
+				err error
+			)
+
+			for record, errRecord := range svc.Records(
+				t.Context(),
+				tt.filename,
+				tt.magics...,
+			) {
+				if errRecord != nil {
+					err = errRecord
+
+					break
+				}
+
+				got = append(got, record)
+				if tt.stopAt > 0 && len(got) == tt.stopAt {
+					break
+				}
+			}
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.expectedRecords) {
+				t.Fatalf("expected records %v, got %v", tt.expectedRecords, got)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // for records
+func Test_RecordsToFile(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name     string
+		filename string
+		root     records.Root
+		expect   func(
+			mockFileSystem *osfs_test.MockFileSystem,
+			mockWriter *efd_test.MockWriter,
+			mockFile *osfs_test.MockFile,
+			tt testcase,
+		)
+		expectedError error
+	}
+
+	tests := []testcase{
+		{
+			name:     "failed to encode EFDF record",
+			filename: "out.efd",
+			expect: func(
+				_ *osfs_test.MockFileSystem,
+				mockWriter *efd_test.MockWriter,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockWriter.EXPECT().
+					WriteEFDF(gomock.Any(), tt.root.EFDF).
+					Return(nil, errExample)
+			},
+			expectedError: efd.ErrFailedToEncodeRecord,
+		},
+		{
+			name:     "failed to encode EFRM record",
+			filename: "out.efd",
+			root:     records.Root{EFRMs: []records.EFRM{{FrameNumber: 1}}},
+			expect: func(
+				_ *osfs_test.MockFileSystem,
+				mockWriter *efd_test.MockWriter,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockWriter.EXPECT().
+					WriteEFDF(gomock.Any(), tt.root.EFDF).
+					Return([]byte{}, nil)
+
+				mockWriter.EXPECT().
+					WriteEFRM(gomock.Any(), tt.root.EFRMs[0]).
+					Return(nil, errExample)
+			},
+			expectedError: efd.ErrFailedToEncodeRecord,
+		},
+		{
+			name:     "failed to encode EFTP record",
+			filename: "out.efd",
+			root:     records.Root{EFTPs: []records.EFTP{{Index: 1}}},
+			expect: func(
+				_ *osfs_test.MockFileSystem,
+				mockWriter *efd_test.MockWriter,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockWriter.EXPECT().
+					WriteEFDF(gomock.Any(), tt.root.EFDF).
+					Return([]byte{}, nil)
+
+				mockWriter.EXPECT().
+					WriteEFTP(gomock.Any(), tt.root.EFTPs[0]).
+					Return(nil, errExample)
+			},
+			expectedError: efd.ErrFailedToEncodeRecord,
+		},
+		{
+			name:     "failed to create file",
+			filename: "out.efd",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockWriter *efd_test.MockWriter,
+				_ *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockWriter.EXPECT().
+					WriteEFDF(gomock.Any(), tt.root.EFDF).
+					Return([]byte{}, nil)
+
+				mockFileSystem.EXPECT().
+					OpenFile(tt.filename, gomock.Any(), gomock.Any()).
+					Return(nil, errExample)
+			},
+			expectedError: efd.ErrFailedToCreateFile,
+		},
+		{
+			name:     "failed to write record",
+			filename: "out.efd",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockWriter *efd_test.MockWriter,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockWriter.EXPECT().
+					WriteEFDF(gomock.Any(), tt.root.EFDF).
+					Return([]byte{}, nil)
+
+				mockFileSystem.EXPECT().
+					OpenFile(tt.filename, gomock.Any(), gomock.Any()).
+					Return(mockFile, nil)
+
+				mockWriter.EXPECT().
+					WriteRaw(gomock.Any(), mockFile, gomock.Any()).
+					Return(errExample)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+			expectedError: efd.ErrFailedToWriteRecord,
+		},
+		{
+			name:     "failed to close file",
+			filename: "out.efd",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockWriter *efd_test.MockWriter,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				mockWriter.EXPECT().
+					WriteEFDF(gomock.Any(), tt.root.EFDF).
+					Return([]byte{}, nil)
+
+				mockFileSystem.EXPECT().
+					OpenFile(tt.filename, gomock.Any(), gomock.Any()).
+					Return(mockFile, nil)
+
+				mockWriter.EXPECT().
+					WriteRaw(gomock.Any(), mockFile, gomock.Any()).
+					Return(nil)
+
+				mockFile.EXPECT().
+					Close().
+					Return(errExample)
+			},
+			expectedError: efd.ErrFailedToCloseFile,
+		},
+		{
+			name:     "successfully wrote EFD file",
+			filename: "out.efd",
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}},
+				EFTPs: []records.EFTP{{Index: 1}},
+			},
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockWriter *efd_test.MockWriter,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				efdfRaw, efrmRaw, eftpRaw := []byte{1}, []byte{2}, []byte{3}
+
+				mockWriter.EXPECT().
+					WriteEFDF(gomock.Any(), tt.root.EFDF).
+					Return(efdfRaw, nil)
+
+				mockWriter.EXPECT().
+					WriteEFRM(gomock.Any(), tt.root.EFRMs[0]).
+					Return(efrmRaw, nil)
+
+				mockWriter.EXPECT().
+					WriteEFTP(gomock.Any(), tt.root.EFTPs[0]).
+					Return(eftpRaw, nil)
+
+				mockFileSystem.EXPECT().
+					OpenFile(tt.filename, gomock.Any(), gomock.Any()).
+					Return(mockFile, nil)
+
+				gomock.InOrder(
+					mockWriter.EXPECT().
+						WriteRaw(gomock.Any(), mockFile, records.Raw{
+							Magic:  [4]byte{'E', 'F', 'D', 'F'},
+							Length: 17,
+							Data:   efdfRaw,
+						}).
+						Return(nil),
+					mockWriter.EXPECT().
+						WriteRaw(gomock.Any(), mockFile, records.Raw{
+							Magic:  [4]byte{'E', 'F', 'R', 'M'},
+							Length: 17,
+							Data:   efrmRaw,
+						}).
+						Return(nil),
+					mockWriter.EXPECT().
+						WriteRaw(gomock.Any(), mockFile, records.Raw{
+							Magic:  [4]byte{'E', 'F', 'T', 'P'},
+							Length: 17,
+							Data:   eftpRaw,
+						}).
+						Return(nil),
+				)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+		},
+		{
+			name:     "records follow their placements, new records after them",
+			filename: "out.efd",
+			root: records.Root{
+				EFRMs: []records.EFRM{{FrameNumber: 1}, {FrameNumber: 2}},
+				Placements: []records.Placement{
+					{
+						Magic:    records.MagicEFRM,
+						Index:    0,
+						Unknown:  [4]byte{1, 2, 3, 4},
+						Trailing: []byte{9},
+					},
+					{Magic: records.MagicEFDF},
+					{Magic: records.MagicEFTP, Index: 0},
+				},
+			},
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockWriter *efd_test.MockWriter,
+				mockFile *osfs_test.MockFile,
+				tt testcase,
+			) {
+				gomock.InOrder(
+					mockWriter.EXPECT().
+						WriteEFRM(gomock.Any(), tt.root.EFRMs[0]).
+						Return([]byte{1}, nil),
+					mockWriter.EXPECT().
+						WriteEFDF(gomock.Any(), tt.root.EFDF).
+						Return([]byte{2}, nil),
+					mockWriter.EXPECT().
+						WriteEFRM(gomock.Any(), tt.root.EFRMs[1]).
+						Return([]byte{3}, nil),
+				)
+
+				mockFileSystem.EXPECT().
+					OpenFile(tt.filename, gomock.Any(), gomock.Any()).
+					Return(mockFile, nil)
+
+				gomock.InOrder(
+					mockWriter.EXPECT().
+						WriteRaw(gomock.Any(), mockFile, records.Raw{
+							Magic:   [4]byte{'E', 'F', 'R', 'M'},
+							Unknown: [4]byte{1, 2, 3, 4},
+							Length:  18,
+							Data:    []byte{1, 9},
+						}).
+						Return(nil),
+					mockWriter.EXPECT().
+						WriteRaw(gomock.Any(), mockFile, records.Raw{
+							Magic:  [4]byte{'E', 'F', 'D', 'F'},
+							Length: 17,
+							Data:   []byte{2},
+						}).
+						Return(nil),
+					mockWriter.EXPECT().
+						WriteRaw(gomock.Any(), mockFile, records.Raw{
+							Magic:  [4]byte{'E', 'F', 'R', 'M'},
+							Length: 17,
+							Data:   []byte{3},
+						}).
+						Return(nil),
+				)
+
+				mockFile.EXPECT().
+					Close().
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
+			mockWriter := efd_test.NewMockWriter(ctrl)
+			mockFile := osfs_test.NewMockFile(ctrl)
+
+			tt.expect(mockFileSystem, mockWriter, mockFile, tt)
+
+			svc := efd.NewService(
+				newTestLogger(),
+				efd_test.NewMockRootBuilder(ctrl),
+				efd_test.NewMockReader(ctrl),
+				mockWriter,
+				mockFileSystem,
+			)
+
+			err := svc.RecordsToFile(t.Context(), tt.filename, tt.root)
+
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+// Test_RecordsToFile_RoundTrip writes back a file read by RecordsFromFile and
+// expects the same bytes, including the record header bytes, the order of the
+// records and any bytes after the decoded fields.
+func Test_RecordsToFile_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	efdf := newRawBytes("EFDF", newEFDF(records.EFDF{
+		Unknown1:   [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+		FrameCount: 2,
+		Title:      [64]byte{'r', 'o', 'l', 'l'},
+	}))
+	copy(efdf[4:8], []byte{0x01, 0x00, 0x02, 0x00})
+
+	efrm1 := newRawBytes("EFRM", newEFRM(records.EFRM{
+		FrameNumber: 1,
+		Unknown14:   [64]byte{0xCA, 0xFE},
+	}))
+	copy(efrm1[4:8], []byte{0xDE, 0xAD, 0xBE, 0xEF})
+
+	// a thumbnail with bytes after its pixels, between the two frames
+	eftp := newRawBytes("EFTP", append(
+		newEFTPBytes(1, 2, 1, `C:\scans\1.tif`, []byte{1, 2, 3, 4, 5, 6}),
+		0x77, 0x88,
+	))
+	copy(eftp[4:8], []byte{0x05, 0x06, 0x07, 0x08})
+
+	// a frame with bytes after its decoded fields
+	efrm2 := newRawBytes("EFRM", append(
+		newEFRM(records.EFRM{FrameNumber: 2}),
+		0x99,
+	))
+
+	original := bytes.Join([][]byte{efdf, efrm1, eftp, efrm2}, nil)
+
+	dir := t.TempDir()
+	in, out := filepath.Join(dir, "in.efd"), filepath.Join(dir, "out.efd")
+
+	if err := os.WriteFile(in, original, 0o600); err != nil {
+		t.Fatalf("failed to write %q: %v", in, err)
+	}
+
+	svc := efd.NewService(
+		newTestLogger(),
+		efd.NewRootBuilder(newTestLogger()),
+		efd.NewReader(newTestLogger(), records.NewDefaultThumbnailFactory()),
+		efd.NewWriter(newTestLogger()),
+		osfs.NewFileSystem(),
+	)
+
+	root, err := svc.RecordsFromFile(t.Context(), in)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+
+	if err = svc.RecordsToFile(t.Context(), out, root); err != nil {
+		t.Fatalf("unexpected write error: %v", err)
+	}
+
+	written, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("failed to read %q: %v", out, err)
+	}
+
+	if !bytes.Equal(written, original) {
+		t.Fatalf("round trip changed bytes:\nexpected %v\ngot      %v",
+			original,
+			written,
+		)
+	}
+}
@@ -0,0 +1,186 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/writer_mock.go -package=efd_test github.com/ma-tf/meta1v/internal/service/efd Writer
+package efd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/records"
+)
+
+var (
+	ErrFailedToWriteRecord = errors.New("failed to write record")
+	ErrFailedToWriteEFDF   = errors.New("failed to write EFDF record")
+	ErrFailedToWriteEFRM   = errors.New("failed to write EFRM record")
+	ErrFailedToWriteEFTP   = errors.New("failed to write EFTP record")
+)
+
+// Writer provides low-level binary writing operations for EFD file records.
+// It is the inverse of Reader: encoding a record produced by Reader yields the
+// bytes it was decoded from.
+type Writer interface {
+	// WriteRaw writes a raw record (magic bytes + length + data) to the output stream.
+	WriteRaw(ctx context.Context, w io.Writer, record records.Raw) error
+
+	// WriteEFDF encodes EFDF (film roll metadata) into raw bytes.
+	WriteEFDF(ctx context.Context, efdf records.EFDF) ([]byte, error)
+
+	// WriteEFRM encodes EFRM (frame metadata) into raw bytes.
+	WriteEFRM(ctx context.Context, efrm records.EFRM) ([]byte, error)
+
+	// WriteEFTP encodes EFTP (thumbnail image) into raw bytes, including the BGR image data.
+	WriteEFTP(ctx context.Context, eftp records.EFTP) ([]byte, error)
+}
+
+type writer struct {
+	log *slog.Logger
+}
+
+func NewWriter(log *slog.Logger) Writer {
+	return &writer{
+		log: log,
+	}
+}
+
+// WriteRaw writes a raw record (magic bytes + length + data) to the output stream.
+// The length is recalculated from the data, and the four bytes between the magic
+// and the length are written back as Reader.ReadRaw found them.
+func (b *writer) WriteRaw(
+	ctx context.Context,
+	w io.Writer,
+	record records.Raw,
+) error {
+	var magicAndLength [recordHeaderSize]byte
+
+	l := uint64(len(magicAndLength) + len(record.Data))
+
+	copy(magicAndLength[:4], record.Magic[:])
+	copy(magicAndLength[4:8], record.Unknown[:])
+	binary.LittleEndian.PutUint64(magicAndLength[8:16], l)
+
+	if _, err := w.Write(magicAndLength[:]); err != nil {
+		return errors.Join(ErrFailedToWriteRecord, err)
+	}
+
+	if _, err := w.Write(record.Data); err != nil {
+		return errors.Join(ErrFailedToWriteRecord, err)
+	}
+
+	b.log.DebugContext(ctx, "wrote raw record",
+		slog.String("magic", string(record.Magic[:])),
+		slog.Uint64("length", l),
+	)
+
+	return nil
+}
+
+// WriteEFDF encodes EFDF (film roll metadata) into raw bytes.
+func (b *writer) WriteEFDF(
+	ctx context.Context,
+	efdf records.EFDF,
+) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, binary.LittleEndian, efdf); err != nil {
+		return nil, errors.Join(ErrFailedToWriteEFDF, err)
+	}
+
+	b.log.DebugContext(ctx, "encoded EFDF record")
+
+	return buf.Bytes(), nil
+}
+
+// WriteEFRM encodes EFRM (frame metadata) into raw bytes.
+func (b *writer) WriteEFRM(
+	ctx context.Context,
+	efrm records.EFRM,
+) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, binary.LittleEndian, efrm); err != nil {
+		return nil, errors.Join(ErrFailedToWriteEFRM, err)
+	}
+
+	b.log.DebugContext(ctx, "encoded EFRM record",
+		slog.Uint64("frame_number", uint64(efrm.FrameNumber)))
+
+	return buf.Bytes(), nil
+}
+
+// WriteEFTP encodes EFTP (thumbnail image) into raw bytes, including the BGR image data.
+// A nil thumbnail is written as Width x Height black pixels.
+func (b *writer) WriteEFTP(
+	ctx context.Context,
+	eftp records.EFTP,
+) ([]byte, error) {
+	var (
+		order         = binary.LittleEndian
+		header        [16]byte
+		width, height = int(eftp.Width), int(eftp.Height)
+	)
+
+	order.PutUint16(header[0:2], eftp.Index)
+	header[2] = eftp.Unknown1
+	header[3] = eftp.Unknown2
+	order.PutUint16(header[4:6], eftp.Width)
+	order.PutUint16(header[6:8], eftp.Height)
+	copy(header[8:16], eftp.Unknown3[:])
+
+	if eftp.Thumbnail != nil {
+		bounds := eftp.Thumbnail.Bounds()
+		if bounds.Dx() != width || bounds.Dy() != height {
+			return nil, fmt.Errorf(
+				"%w: thumbnail is %dx%d, record declares %dx%d",
+				ErrFailedToWriteEFTP,
+				bounds.Dx(), bounds.Dy(), width, height,
+			)
+		}
+	}
+
+	buf := bytes.NewBuffer(
+		make([]byte, 0, eftpHeaderSize+width*height*bytesPerPixel),
+	)
+	_, _ = buf.Write(header[:])
+	_, _ = buf.Write(eftp.Filepath[:])
+
+	pixels := make([]byte, width*height*bytesPerPixel)
+
+	if eftp.Thumbnail != nil {
+		origin := eftp.Thumbnail.Bounds().Min
+
+		for idx := range width * height {
+			c := eftp.Thumbnail.RGBAAt(origin.X+idx%width, origin.Y+idx/width)
+			i := idx * bytesPerPixel
+			pixels[i], pixels[i+1], pixels[i+2] = c.B, c.G, c.R
+		}
+	}
+
+	_, _ = buf.Write(pixels)
+
+	b.log.DebugContext(ctx, "encoded EFTP record",
+		slog.Uint64("frame_number", uint64(eftp.Index)),
+		slog.Int("width", width),
+		slog.Int("height", height),
+	)
+
+	return buf.Bytes(), nil
+}
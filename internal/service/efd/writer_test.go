@@ -0,0 +1,351 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package efd_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errExample }
+
+func newRawBytes(magic string, data []byte) []byte {
+	buf := &bytes.Buffer{}
+	_, _ = buf.WriteString(magic)
+	_ = binary.Write(buf, binary.LittleEndian, [4]byte{})
+	_ = binary.Write(buf, binary.LittleEndian, uint64(16+len(data)))
+	_, _ = buf.Write(data)
+
+	return buf.Bytes()
+}
+
+func newEFTPBytes(
+	index, width, height uint16,
+	filepath string,
+	pixels []byte,
+) []byte {
+	buf := &bytes.Buffer{}
+	_ = binary.Write(buf, binary.LittleEndian, index)
+	_ = binary.Write(buf, binary.LittleEndian, [2]byte{0xAA, 0xBB})
+	_ = binary.Write(buf, binary.LittleEndian, width)
+	_ = binary.Write(buf, binary.LittleEndian, height)
+	_ = binary.Write(buf, binary.LittleEndian, [8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	var fp [256]byte
+	copy(fp[:], filepath)
+	_ = binary.Write(buf, binary.LittleEndian, fp)
+	_, _ = buf.Write(pixels)
+
+	return buf.Bytes()
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Writer_WriteRaw(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name           string
+		w              io.Writer
+		record         records.Raw
+		expectedError  error
+		expectedResult []byte
+	}
+
+	tests := []testcase{
+		{
+			name:          "error on failed write",
+			w:             failingWriter{},
+			record:        records.Raw{Magic: [4]byte{'E', 'F', 'R', 'M'}},
+			expectedError: efd.ErrFailedToWriteRecord,
+		},
+		{
+			name: "successful write of raw record",
+			w:    &bytes.Buffer{},
+			record: records.Raw{
+				Magic: [4]byte{'E', 'F', 'T', 'P'},
+				Data:  []byte{0x01, 0x02, 0x03, 0x04},
+			},
+			expectedResult: newRawBytes(
+				"EFTP",
+				[]byte{0x01, 0x02, 0x03, 0x04},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := efd.NewWriter(newTestLogger())
+
+			err := writer.WriteRaw(t.Context(), tt.w, tt.record)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if tt.expectedResult == nil {
+				return
+			}
+
+			buf, _ := tt.w.(*bytes.Buffer)
+			if !bytes.Equal(buf.Bytes(), tt.expectedResult) {
+				t.Fatalf("expected bytes %v, got %v",
+					tt.expectedResult,
+					buf.Bytes(),
+				)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Writer_WriteEFDF(t *testing.T) {
+	t.Parallel()
+
+	efdf := records.EFDF{
+		Unknown6: [128]byte{0xFF, 0x01},
+		Title:    [64]byte{'t', 'i', 't', 'l', 'e'},
+		CodeA:    12,
+		CodeB:    345,
+	}
+
+	writer := efd.NewWriter(newTestLogger())
+
+	result, err := writer.WriteEFDF(t.Context(), efdf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(result, newEFDF(efdf)) {
+		t.Fatalf("expected bytes %v, got %v", newEFDF(efdf), result)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Writer_WriteEFRM(t *testing.T) {
+	t.Parallel()
+
+	efrm := records.EFRM{
+		FrameNumber: 7,
+		Unknown14:   [64]byte{0xDE, 0xAD},
+		Remarks:     [256]byte{'r', 'e', 'm', 'a', 'r', 'k', 's'},
+	}
+
+	writer := efd.NewWriter(newTestLogger())
+
+	result, err := writer.WriteEFRM(t.Context(), efrm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(result, newEFRM(efrm)) {
+		t.Fatalf("expected bytes %v, got %v", newEFRM(efrm), result)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Writer_WriteEFTP(t *testing.T) {
+	t.Parallel()
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	thumbnail.SetRGBA(0, 0, color.RGBA{R: 1, G: 2, B: 3, A: 255})
+	thumbnail.SetRGBA(1, 0, color.RGBA{R: 4, G: 5, B: 6, A: 255})
+
+	type testcase struct {
+		name           string
+		eftp           records.EFTP
+		expectedError  error
+		expectedResult []byte
+	}
+
+	tests := []testcase{
+		{
+			name: "error on thumbnail size mismatch",
+			eftp: records.EFTP{
+				Width:     1,
+				Height:    1,
+				Thumbnail: thumbnail,
+			},
+			expectedError: efd.ErrFailedToWriteEFTP,
+		},
+		{
+			name: "nil thumbnail written as black pixels",
+			eftp: records.EFTP{
+				Index:    3,
+				Unknown1: 0xAA,
+				Unknown2: 0xBB,
+				Width:    2,
+				Height:   1,
+				Unknown3: [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+			expectedResult: newEFTPBytes(3, 2, 1, "", make([]byte, 6)),
+		},
+		{
+			name: "thumbnail written as BGR pixels",
+			eftp: records.EFTP{
+				Index:     3,
+				Unknown1:  0xAA,
+				Unknown2:  0xBB,
+				Width:     2,
+				Height:    1,
+				Unknown3:  [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Filepath:  [256]byte{'a', '.', 't', 'i', 'f'},
+				Thumbnail: thumbnail,
+			},
+			expectedResult: newEFTPBytes(
+				3, 2, 1, "a.tif", []byte{3, 2, 1, 6, 5, 4},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := efd.NewWriter(newTestLogger())
+
+			result, err := writer.WriteEFTP(t.Context(), tt.eftp)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if !bytes.Equal(result, tt.expectedResult) {
+				t.Fatalf("expected bytes %v, got %v",
+					tt.expectedResult,
+					result,
+				)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Writer_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	original := bytes.Join([][]byte{
+		newRawBytes("EFDF", newEFDF(records.EFDF{
+			Unknown1:   [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			FrameCount: 2,
+			Unknown6:   [128]byte{0x10, 0x20, 0x30},
+			Title:      [64]byte{'r', 'o', 'l', 'l'},
+		})),
+		newRawBytes("EFRM", newEFRM(records.EFRM{
+			FrameNumber: 1,
+			Unknown9:    [8]byte{9, 9, 9},
+			Unknown14:   [64]byte{0xCA, 0xFE},
+		})),
+		newRawBytes("EFRM", newEFRM(records.EFRM{
+			FrameNumber: 2,
+			Remarks:     [256]byte{'o', 'k'},
+		})),
+		newRawBytes("EFTP", newEFTPBytes(
+			1, 2, 2, `C:\scans\1.tif`,
+			[]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+		)),
+	}, nil)
+
+	reader := efd.NewReader(newTestLogger(), records.NewDefaultThumbnailFactory())
+	writer := efd.NewWriter(newTestLogger())
+
+	r := bytes.NewReader(original)
+	out := &bytes.Buffer{}
+
+	for {
+		raw, err := reader.ReadRaw(ctx, r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected read error: %v", err)
+		}
+
+		var data []byte
+
+		switch string(raw.Magic[:]) {
+		case "EFDF":
+			efdf, _ := reader.ReadEFDF(ctx, raw.Data)
+			data, err = writer.WriteEFDF(ctx, efdf)
+		case "EFRM":
+			efrm, _ := reader.ReadEFRM(ctx, raw.Data)
+			data, err = writer.WriteEFRM(ctx, efrm)
+		case "EFTP":
+			eftp, _ := reader.ReadEFTP(ctx, raw.Data)
+			data, err = writer.WriteEFTP(ctx, eftp)
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected encode error: %v", err)
+		}
+
+		raw.Data = data
+		if err = writer.WriteRaw(ctx, out, raw); err != nil {
+			t.Fatalf("unexpected write error: %v", err)
+		}
+	}
+
+	if !bytes.Equal(out.Bytes(), original) {
+		t.Fatalf("round trip changed bytes:\nexpected %v\ngot      %v",
+			original,
+			out.Bytes(),
+		)
+	}
+}
+
+// Test_Writer_RoundTrip_RecordHeader keeps the header bytes between the magic
+// and the length, which Reader.ReadRaw does not interpret.
+func Test_Writer_RoundTrip_RecordHeader(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	original := newRawBytes("EFRM", newEFRM(records.EFRM{FrameNumber: 1}))
+	copy(original[4:8], []byte{0xDE, 0xAD, 0xBE, 0xEF})
+
+	reader := efd.NewReader(newTestLogger(), records.NewDefaultThumbnailFactory())
+	writer := efd.NewWriter(newTestLogger())
+
+	raw, err := reader.ReadRaw(ctx, bytes.NewReader(original))
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	if err = writer.WriteRaw(ctx, out, raw); err != nil {
+		t.Fatalf("unexpected write error: %v", err)
+	}
+
+	if !bytes.Equal(out.Bytes(), original) {
+		t.Fatalf("round trip changed bytes:\nexpected %v\ngot      %v",
+			original[:16],
+			out.Bytes()[:16],
+		)
+	}
+}
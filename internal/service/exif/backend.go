@@ -0,0 +1,52 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package exif
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Backend selects how metadata is written to image files.
+type Backend string
+
+const (
+	// BackendExiftool runs exiftool, which has to be installed.
+	BackendExiftool Backend = "exiftool"
+	// BackendNative writes JPEG and TIFF files directly, without exiftool.
+	BackendNative Backend = "native"
+)
+
+var ErrUnsupportedBackend = errors.New("unsupported exif backend")
+
+// Backends returns the names of the supported backends.
+func Backends() []string {
+	return []string{string(BackendExiftool), string(BackendNative)}
+}
+
+// ParseBackend returns the backend with the given name. An empty name selects
+// exiftool.
+func ParseBackend(name string) (Backend, error) {
+	switch Backend(name) {
+	case "", BackendExiftool:
+		return BackendExiftool, nil
+	case BackendNative:
+		return BackendNative, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedBackend, name)
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/ma-tf/meta1v/internal/service/osexec"
 )
 
+const exiftoolBinary = "exiftool"
+
 var ErrExifToolBinaryNotFound = errors.New("exiftool binary not found in PATH")
 
 // ExiftoolCommandFactory creates configured exiftool command instances.
@@ -40,21 +42,30 @@ type ExiftoolCommandFactory interface {
 		metadata string,
 		rPipe *os.File,
 	) osexec.Command
+
+	// CreateBatchCommand builds an exiftool command that reads every argument,
+	// including the target files and the -execute between them, from args.
+	// Standard output and error are kept apart so that the result of each
+	// file can be told from the markers echoed to both.
+	CreateBatchCommand(
+		ctx context.Context,
+		stdout *bytes.Buffer,
+		stderr *bytes.Buffer,
+		args string,
+		rPipe *os.File,
+	) osexec.Command
 }
 
 type exiftoolCommandFactory struct {
 	lookPath osexec.LookPath
 }
 
-// NewExiftoolCommandFactory creates an ExiftoolCommandFactory.
-// Panics if the exiftool binary is not found in PATH.
+// NewExiftoolCommandFactory creates an ExiftoolCommandFactory. The exiftool
+// binary is looked up when a command is created, so that the native backend
+// can be used on systems without it.
 func NewExiftoolCommandFactory(
 	lookPath osexec.LookPath,
 ) ExiftoolCommandFactory {
-	if _, err := lookPath.LookPath("exiftool"); err != nil {
-		panic(ErrExifToolBinaryNotFound)
-	}
-
 	return &exiftoolCommandFactory{
 		lookPath: lookPath,
 	}
@@ -67,7 +78,7 @@ func (f *exiftoolCommandFactory) CreateCommand(
 	metadata string,
 	rPipe *os.File,
 ) osexec.Command {
-	cmd := exec.CommandContext(ctx, "exiftool",
+	cmd := exec.CommandContext(ctx, f.binary(),
 		"-config", "/proc/self/fd/3",
 		"-m",
 		"-@", "-",
@@ -81,3 +92,35 @@ func (f *exiftoolCommandFactory) CreateCommand(
 
 	return osexec.NewCommand(cmd)
 }
+
+func (f *exiftoolCommandFactory) CreateBatchCommand(
+	ctx context.Context,
+	stdout *bytes.Buffer,
+	stderr *bytes.Buffer,
+	args string,
+	rPipe *os.File,
+) osexec.Command {
+	// -config stays in effect across -execute, unlike every other option
+	cmd := exec.CommandContext(ctx, f.binary(),
+		"-config", "/proc/self/fd/3",
+		"-@", "-",
+	)
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Stdin = bytes.NewBufferString(args)
+	cmd.ExtraFiles = []*os.File{rPipe}
+
+	return osexec.NewCommand(cmd)
+}
+
+// binary returns the path of exiftool, or its bare name if it is not in PATH.
+// Starting a command for a binary that is not found fails with
+// exec.ErrNotFound, which the runner reports as ErrExifToolBinaryNotFound.
+func (f *exiftoolCommandFactory) binary() string {
+	if path, err := f.lookPath.LookPath(exiftoolBinary); err == nil {
+		return path
+	}
+
+	return exiftoolBinary
+}
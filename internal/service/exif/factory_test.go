@@ -17,7 +17,6 @@
 package exif_test
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/ma-tf/meta1v/internal/service/exif"
@@ -59,20 +58,14 @@ func Test_CommandFactory_LookPathFails(t *testing.T) {
 		LookPath("exiftool").
 		Return("", errExample)
 
-	defer func() {
-		r := recover()
-
-		err, ok := r.(error)
-		if !ok {
-			t.Errorf("expected panic with error, got: %v", r)
-
-			return
-		}
-
-		if !errors.Is(err, exif.ErrExifToolBinaryNotFound) {
-			t.Errorf("unexpected result or panic: %v", r)
-		}
-	}()
+	factory := exif.NewExiftoolCommandFactory(mockLookPath)
 
-	_ = exif.NewExiftoolCommandFactory(mockLookPath)
+	// the native backend works without exiftool, so this must not panic
+	_ = factory.CreateCommand(
+		t.Context(),
+		"test.jpg",
+		nil,
+		"metadata",
+		nil,
+	)
 }
@@ -0,0 +1,256 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	markerPrefix = 0xFF
+	markerSOI    = 0xD8
+	markerEOI    = 0xD9
+	markerSOS    = 0xDA
+	markerAPP0   = 0xE0
+	markerAPP1   = 0xE1
+	markerTEM    = 0x01
+	markerRST0   = 0xD0
+	markerRST7   = 0xD7
+
+	segmentLengthSize = 2
+
+	exifHeader = "Exif\x00\x00"
+	xmpHeader  = "http://ns.adobe.com/xap/1.0/\x00"
+)
+
+var (
+	ErrInvalidJPEG         = errors.New("invalid JPEG data")
+	ErrJPEGSegmentTooLarge = errors.New("metadata too large for a JPEG segment")
+)
+
+// jpegSegment is a marker segment of a JPEG file, without its length.
+type jpegSegment struct {
+	marker  byte
+	payload []byte
+}
+
+// isJPEG reports whether data starts like a JPEG file.
+func isJPEG(data []byte) bool {
+	return len(data) >= 2 && data[0] == markerPrefix && data[1] == markerSOI
+}
+
+// splitJPEG returns the marker segments of a JPEG file up to its image data,
+// and the rest of the file from the start of the image data on.
+func splitJPEG(data []byte) ([]jpegSegment, []byte, error) {
+	if !isJPEG(data) {
+		return nil, nil, fmt.Errorf("%w: no start of image", ErrInvalidJPEG)
+	}
+
+	var segments []jpegSegment
+
+	pos := 2
+
+	for {
+		if pos+2 > len(data) || data[pos] != markerPrefix {
+			return nil, nil, fmt.Errorf("%w: no marker at %d",
+				ErrInvalidJPEG, pos)
+		}
+
+		marker := data[pos+1]
+
+		switch {
+		case marker == markerPrefix: // fill byte
+			pos++
+
+			continue
+		case marker == markerSOS || marker == markerEOI:
+			return segments, data[pos:], nil
+		case marker == markerTEM ||
+			marker >= markerRST0 && marker <= markerRST7:
+			return nil, nil, fmt.Errorf("%w: unexpected marker %#02x at %d",
+				ErrInvalidJPEG, marker, pos)
+		}
+
+		start := pos + 2 + segmentLengthSize
+		if start > len(data) {
+			return nil, nil, fmt.Errorf("%w: segment at %d cut short",
+				ErrInvalidJPEG, pos)
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+
+		end := pos + 2 + length
+		if length < segmentLengthSize || end > len(data) {
+			return nil, nil, fmt.Errorf("%w: segment at %d cut short",
+				ErrInvalidJPEG, pos)
+		}
+
+		segments = append(segments, jpegSegment{
+			marker:  marker,
+			payload: data[start:end],
+		})
+		pos = end
+	}
+}
+
+// writeJPEG returns a JPEG file with m written to its EXIF and XMP segments,
+// which are created if the file has none. The EXIF and XMP segments follow
+// any APP0 segments, as readers expect, and the rest is copied unchanged.
+func writeJPEG(data []byte, m nativeMetadata) ([]byte, error) {
+	segments, rest, err := splitJPEG(data)
+	if err != nil {
+		return nil, err
+	}
+
+	exifAt, xmpAt := -1, -1
+
+	for i, s := range segments {
+		switch {
+		case s.marker != markerAPP1:
+		case exifAt == -1 && bytes.HasPrefix(s.payload, []byte(exifHeader)):
+			exifAt = i
+		case xmpAt == -1 && bytes.HasPrefix(s.payload, []byte(xmpHeader)):
+			xmpAt = i
+		}
+	}
+
+	exifSegment, err := jpegEXIF(segments, exifAt, m)
+	if err != nil {
+		return nil, err
+	}
+
+	xmpSegment, err := jpegXMP(segments, xmpAt, m)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(data)+len(exifSegment)+len(xmpSegment))
+	out = append(out, markerPrefix, markerSOI)
+
+	for _, s := range segments {
+		if s.marker == markerAPP0 {
+			out = appendSegment(out, s.marker, s.payload)
+		}
+	}
+
+	if exifSegment != nil {
+		out = appendSegment(out, markerAPP1, exifSegment)
+	}
+
+	if xmpSegment != nil {
+		out = appendSegment(out, markerAPP1, xmpSegment)
+	}
+
+	for i, s := range segments {
+		if s.marker != markerAPP0 && i != exifAt && i != xmpAt {
+			out = appendSegment(out, s.marker, s.payload)
+		}
+	}
+
+	return append(out, rest...), nil
+}
+
+// jpegEXIF returns the payload of the EXIF segment with the EXIF tags of m
+// set, the existing payload if there are none, or nil if there is neither.
+func jpegEXIF(
+	segments []jpegSegment,
+	at int,
+	m nativeMetadata,
+) ([]byte, error) {
+	if len(m.exif) == 0 {
+		if at == -1 {
+			return nil, nil
+		}
+
+		return segments[at].payload, nil
+	}
+
+	t := newTIFF()
+
+	if at != -1 {
+		var err error
+
+		t, err = parseTIFF(segments[at].payload[len(exifHeader):])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := m.exifEntries(t.order)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err = setTIFFEntries(t, entries, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return segmentPayload(exifHeader, t.data)
+}
+
+// jpegXMP returns the payload of the XMP segment with the XMP properties of m
+// set, the existing payload if there are none, or nil if there is neither.
+func jpegXMP(
+	segments []jpegSegment,
+	at int,
+	m nativeMetadata,
+) ([]byte, error) {
+	if len(m.xmp) == 0 {
+		if at == -1 {
+			return nil, nil
+		}
+
+		return segments[at].payload, nil
+	}
+
+	var packet []byte
+	if at != -1 {
+		packet = segments[at].payload[len(xmpHeader):]
+	}
+
+	packet, err := setXMP(packet, m.xmp)
+	if err != nil {
+		return nil, err
+	}
+
+	return segmentPayload(xmpHeader, packet)
+}
+
+func segmentPayload(header string, data []byte) ([]byte, error) {
+	size := len(header) + len(data) + segmentLengthSize
+	if size > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrJPEGSegmentTooLarge, size)
+	}
+
+	return append([]byte(header), data...), nil
+}
+
+// appendSegment appends a marker segment to out. The payload has to fit in a
+// segment, which segmentPayload ensures for the segments it builds.
+func appendSegment(out []byte, marker byte, payload []byte) []byte {
+	out = append(out, markerPrefix, marker)
+	size := len(payload) + segmentLengthSize
+	//nolint:gosec // segments read from the file or built by segmentPayload fit
+	out = binary.BigEndian.AppendUint16(out, uint16(size))
+
+	return append(out, payload...)
+}
@@ -43,6 +43,20 @@ func (m *MockExiftoolCommandFactory) EXPECT() *MockExiftoolCommandFactoryMockRec
 	return m.recorder
 }
 
+// CreateBatchCommand mocks base method.
+func (m *MockExiftoolCommandFactory) CreateBatchCommand(ctx context.Context, stdout, stderr *bytes.Buffer, args string, rPipe *os.File) osexec.Command {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatchCommand", ctx, stdout, stderr, args, rPipe)
+	ret0, _ := ret[0].(osexec.Command)
+	return ret0
+}
+
+// CreateBatchCommand indicates an expected call of CreateBatchCommand.
+func (mr *MockExiftoolCommandFactoryMockRecorder) CreateBatchCommand(ctx, stdout, stderr, args, rPipe any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchCommand", reflect.TypeOf((*MockExiftoolCommandFactory)(nil).CreateBatchCommand), ctx, stdout, stderr, args, rPipe)
+}
+
 // CreateCommand mocks base method.
 func (m *MockExiftoolCommandFactory) CreateCommand(ctx context.Context, targetFile string, out *bytes.Buffer, metadata string, rPipe *os.File) osexec.Command {
 	m.ctrl.T.Helper()
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ma-tf/meta1v/internal/service/exif (interfaces: ToolRunner)
+// Source: github.com/ma-tf/meta1v/internal/service/exif (interfaces: ToolRunner,BatchToolRunner)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks/runner_mock.go -package=exif_test github.com/ma-tf/meta1v/internal/service/exif ToolRunner
+//	mockgen -destination=./mocks/runner_mock.go -package=exif_test github.com/ma-tf/meta1v/internal/service/exif ToolRunner,BatchToolRunner
 //
 
 // Package exif_test is a generated GoMock package.
@@ -53,3 +53,56 @@ func (mr *MockToolRunnerMockRecorder) Run(ctx, targetFile, metadata any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockToolRunner)(nil).Run), ctx, targetFile, metadata)
 }
+
+// MockBatchToolRunner is a mock of BatchToolRunner interface.
+type MockBatchToolRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchToolRunnerMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchToolRunnerMockRecorder is the mock recorder for MockBatchToolRunner.
+type MockBatchToolRunnerMockRecorder struct {
+	mock *MockBatchToolRunner
+}
+
+// NewMockBatchToolRunner creates a new mock instance.
+func NewMockBatchToolRunner(ctrl *gomock.Controller) *MockBatchToolRunner {
+	mock := &MockBatchToolRunner{ctrl: ctrl}
+	mock.recorder = &MockBatchToolRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchToolRunner) EXPECT() *MockBatchToolRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockBatchToolRunner) Run(ctx context.Context, targetFile, metadata string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, targetFile, metadata)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockBatchToolRunnerMockRecorder) Run(ctx, targetFile, metadata any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockBatchToolRunner)(nil).Run), ctx, targetFile, metadata)
+}
+
+// RunBatch mocks base method.
+func (m *MockBatchToolRunner) RunBatch(ctx context.Context, targetFiles, metadata []string) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunBatch", ctx, targetFiles, metadata)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunBatch indicates an expected call of RunBatch.
+func (mr *MockBatchToolRunnerMockRecorder) RunBatch(ctx, targetFiles, metadata any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunBatch", reflect.TypeOf((*MockBatchToolRunner)(nil).RunBatch), ctx, targetFiles, metadata)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	records "github.com/ma-tf/meta1v/internal/records"
+	exif "github.com/ma-tf/meta1v/internal/service/exif"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,15 +43,43 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // WriteEXIF mocks base method.
-func (m *MockService) WriteEXIF(ctx context.Context, efrm records.EFRM, targetFile string, strict bool) error {
+func (m *MockService) WriteEXIF(ctx context.Context, efrm records.EFRM, targetFile string, strict bool, backend exif.Backend) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WriteEXIF", ctx, efrm, targetFile, strict)
+	ret := m.ctrl.Call(m, "WriteEXIF", ctx, efrm, targetFile, strict, backend)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WriteEXIF indicates an expected call of WriteEXIF.
-func (mr *MockServiceMockRecorder) WriteEXIF(ctx, efrm, targetFile, strict any) *gomock.Call {
+func (mr *MockServiceMockRecorder) WriteEXIF(ctx, efrm, targetFile, strict, backend any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEXIF", reflect.TypeOf((*MockService)(nil).WriteEXIF), ctx, efrm, targetFile, strict)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEXIF", reflect.TypeOf((*MockService)(nil).WriteEXIF), ctx, efrm, targetFile, strict, backend)
+}
+
+// WriteEXIFBatch mocks base method.
+func (m *MockService) WriteEXIFBatch(ctx context.Context, targets []exif.Target, strict bool, backend exif.Backend) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteEXIFBatch", ctx, targets, strict, backend)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// WriteEXIFBatch indicates an expected call of WriteEXIFBatch.
+func (mr *MockServiceMockRecorder) WriteEXIFBatch(ctx, targets, strict, backend any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteEXIFBatch", reflect.TypeOf((*MockService)(nil).WriteEXIFBatch), ctx, targets, strict, backend)
+}
+
+// WriteSidecar mocks base method.
+func (m *MockService) WriteSidecar(ctx context.Context, efrm records.EFRM, targetFile string, strict bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSidecar", ctx, efrm, targetFile, strict)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteSidecar indicates an expected call of WriteSidecar.
+func (mr *MockServiceMockRecorder) WriteSidecar(ctx, efrm, targetFile, strict any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSidecar", reflect.TypeOf((*MockService)(nil).WriteSidecar), ctx, efrm, targetFile, strict)
 }
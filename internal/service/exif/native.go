@@ -0,0 +1,521 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package exif
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+)
+
+const (
+	tagIDUserComment          = 0x9286
+	tagIDDateTimeOriginal     = 0x9003
+	tagIDExposureCompensation = 0x9204
+	tagIDFlash                = 0x9209
+	tagIDMeteringMode         = 0x9207
+
+	exifDateTime    = "2006:01:02 15:04:05"
+	meteringOther   = 255
+	charsetASCII    = "ASCII\x00\x00\x00"
+	charsetUnicode  = "UNICODE\x00"
+	tempExtension   = ".tmp"
+	backupExtension = "_original"
+)
+
+var (
+	ErrUnsupportedTag = errors.New(
+		"tag not supported by the native backend",
+	)
+	ErrInvalidTagValue       = errors.New("invalid tag value")
+	ErrUnsupportedFileFormat = errors.New(
+		"file format not supported by the native backend, " +
+			"expected JPEG or TIFF",
+	)
+	ErrReadImage    = errors.New("failed to read image file")
+	ErrWriteImage   = errors.New("failed to write image file")
+	ErrBackupImage  = errors.New("failed to back up original image file")
+	ErrReplaceImage = errors.New("failed to replace image file")
+)
+
+// exifTag is an EXIF tag written by the native backend, with the encoding
+// of its value.
+type exifTag struct {
+	id     uint16
+	encode func(value string, order byteOrder) (ifdEntry, error)
+}
+
+// xmpTag is an XMP property written by the native backend, with the
+// conversion of its value from the form exiftool takes.
+type xmpTag struct {
+	namespace string
+	prefix    string
+	name      string
+	form      xmpForm
+	convert   func(value string) (string, error)
+}
+
+// exifTags maps the EXIF tags of the Builder to where they are stored.
+//
+//nolint:gochecknoglobals // fixed tag mapping
+var exifTags = map[string]exifTag{
+	TagUserComment:          {tagIDUserComment, encodeUserComment},
+	TagDateTimeOriginal:     {tagIDDateTimeOriginal, encodeDateTime},
+	TagExposureCompensation: {tagIDExposureCompensation, encodeSRational},
+	TagFlash:                {tagIDFlash, encodeShort},
+	TagMeteringMode:         {tagIDMeteringMode, encodeMeteringMode},
+}
+
+// xmpTags maps the XMP tags of the Builder to their properties. EXIF has no
+// tag for flash exposure compensation, so it is written as the property
+// Adobe defines for it.
+//
+//nolint:gochecknoglobals // fixed tag mapping
+var xmpTags = map[string]xmpTag{
+	TagFlashExposureComp: {
+		nsAux, "aux", "FlashCompensation", xmpSimple, rational,
+	},
+	TagFNumber: {nsExif, "exif", "FNumber", xmpSimple, rational},
+	TagMaxApertureValue: {
+		nsExif, "exif", "MaxApertureValue", xmpSimple, rational,
+	},
+	TagExposureTime: {nsExif, "exif", "ExposureTime", xmpSimple, rational},
+	TagFocalLength:  {nsExif, "exif", "FocalLength", xmpSimple, rational},
+	TagISO:          {nsExif, "exif", "ISOSpeedRatings", xmpSeq, integer},
+
+	TagBatteryLoadedDate: analogueData("BatteryLoadedDate"),
+	TagFilmLoadedDate:    analogueData("FilmLoadedDate"),
+	TagFilmISO:           analogueData("FilmISO"),
+	TagManualISO:         analogueData("ManualISO"),
+	TagFlashMode:         analogueData("FlashMode"),
+	TagShootingMode:      analogueData("ShootingMode"),
+	TagAFMode:            analogueData("AFMode"),
+	TagFilmAdvanceMode:   analogueData("FilmAdvanceMode"),
+	TagMultipleExposure:  analogueData("MultipleExposure"),
+}
+
+// meteringModes maps the metering modes of the camera to the EXIF values of
+// the closest standard modes.
+//
+//nolint:gochecknoglobals // fixed value mapping
+var meteringModes = map[string]uint16{
+	"Evaluative":       5, // multi-segment
+	"Center averaging": 2, // center-weighted average
+	"Spot":             3, // spot
+	"Partial":          6, // partial
+}
+
+// nativeMetadata is the metadata of a file to be written by the native
+// backend, split by where it is stored.
+type nativeMetadata struct {
+	exif []exifValue
+	xmp  []xmpProperty
+}
+
+type exifValue struct {
+	tag   exifTag
+	value string
+}
+
+type nativeRunner struct {
+	fs osfs.FileSystem
+}
+
+// NewNativeRunner creates a ToolRunner that writes the tags of the Builder to
+// JPEG and TIFF files itself, for systems without exiftool. It takes the same
+// arguments as exiftool and stores each tag where exiftool would, with the
+// XMP-AnalogueData tags in the namespace exiftool.config defines. Like
+// exiftool, it keeps the original file with an _original suffix.
+func NewNativeRunner(fs osfs.FileSystem) ToolRunner {
+	return &nativeRunner{
+		fs: fs,
+	}
+}
+
+func (r *nativeRunner) Run(
+	ctx context.Context,
+	targetFile string,
+	metadata string,
+) error {
+	m, err := parseMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	data, err := readFile(r.fs, targetFile)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrReadImage, targetFile, err)
+	}
+
+	var out []byte
+
+	switch {
+	case isJPEG(data):
+		out, err = writeJPEG(data, m)
+	case isTIFF(data):
+		out, err = writeTIFF(data, m)
+	default:
+		return ErrUnsupportedFileFormat
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return errors.Join(ErrContextDone, err)
+	}
+
+	return r.replace(targetFile, out)
+}
+
+func readFile(fs osfs.FileSystem, name string) ([]byte, error) {
+	f, err := fs.Open(name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by caller
+	}
+	defer f.Close()
+
+	return io.ReadAll(f) //nolint:wrapcheck // wrapped by caller
+}
+
+// replace writes data to a temporary file and moves it over targetFile. The
+// original file is kept with an _original suffix unless an earlier write has
+// kept it already.
+func (r *nativeRunner) replace(targetFile string, data []byte) error {
+	info, err := r.fs.Stat(targetFile)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrWriteImage, targetFile, err)
+	}
+
+	tempFile := targetFile + tempExtension
+	err = writeFile(r.fs, tempFile, data, info.Mode().Perm())
+	if err != nil {
+		_ = r.fs.Remove(tempFile)
+
+		return fmt.Errorf("%w %q: %w", ErrWriteImage, tempFile, err)
+	}
+
+	backupFile := targetFile + backupExtension
+	if _, err = r.fs.Stat(backupFile); errors.Is(err, os.ErrNotExist) {
+		if err = r.fs.Rename(targetFile, backupFile); err != nil {
+			_ = r.fs.Remove(tempFile)
+
+			return fmt.Errorf("%w to %q: %w", ErrBackupImage, backupFile, err)
+		}
+	}
+
+	if err = r.fs.Rename(tempFile, targetFile); err != nil {
+		_ = r.fs.Remove(tempFile)
+
+		return fmt.Errorf("%w %q: %w", ErrReplaceImage, targetFile, err)
+	}
+
+	return nil
+}
+
+func writeFile(
+	fs osfs.FileSystem,
+	name string,
+	data []byte,
+	perm os.FileMode,
+) error {
+	f, err := fs.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+
+		return err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return f.Close() //nolint:wrapcheck // wrapped by caller
+}
+
+// argument is an exiftool argument of the form -TAG=VALUE.
+type argument struct {
+	tag   string
+	value string
+}
+
+// parseArguments returns the exiftool arguments of metadata, one per line.
+func parseArguments(metadata string) ([]argument, error) {
+	var args []argument
+
+	for line := range strings.Lines(metadata) {
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			continue
+		}
+
+		tag, value, ok := strings.Cut(strings.TrimPrefix(line, "-"), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTagValue, line)
+		}
+
+		args = append(args, argument{tag: tag, value: value})
+	}
+
+	return args, nil
+}
+
+// parseMetadata splits exiftool arguments by where the native backend stores
+// them.
+func parseMetadata(metadata string) (nativeMetadata, error) {
+	args, err := parseArguments(metadata)
+	if err != nil {
+		return nativeMetadata{}, err
+	}
+
+	var m nativeMetadata
+
+	for _, a := range args {
+		if tag, ok := exifTags[a.tag]; ok {
+			m.exif = append(m.exif, exifValue{tag: tag, value: a.value})
+
+			continue
+		}
+
+		tag, ok := xmpTags[a.tag]
+		if !ok {
+			return nativeMetadata{},
+				fmt.Errorf("%w: %s", ErrUnsupportedTag, a.tag)
+		}
+
+		p, err := tag.property(a.value)
+		if err != nil {
+			return nativeMetadata{}, fmt.Errorf("%w %s=%q: %w",
+				ErrInvalidTagValue, a.tag, a.value, err)
+		}
+
+		m.xmp = append(m.xmp, p)
+	}
+
+	return m, nil
+}
+
+// property returns the property of t with the given exiftool value.
+func (t xmpTag) property(value string) (xmpProperty, error) {
+	converted, err := t.convert(value)
+	if err != nil {
+		return xmpProperty{}, err
+	}
+
+	return xmpProperty{
+		namespace: t.namespace,
+		prefix:    t.prefix,
+		name:      t.name,
+		value:     converted,
+		form:      t.form,
+		fields:    nil,
+	}, nil
+}
+
+// exifEntries encodes the EXIF tags of m in the given byte order.
+func (m nativeMetadata) exifEntries(order byteOrder) ([]ifdEntry, error) {
+	entries := make([]ifdEntry, 0, len(m.exif))
+
+	for _, v := range m.exif {
+		e, err := v.tag.encode(v.value, order)
+		if err != nil {
+			return nil, fmt.Errorf("%w for tag %#04x %q: %w",
+				ErrInvalidTagValue, v.tag.id, v.value, err)
+		}
+
+		e.tag = v.tag.id
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// isTIFF reports whether data starts like a TIFF file.
+func isTIFF(data []byte) bool {
+	_, err := parseTIFF(data)
+
+	return err == nil
+}
+
+// writeTIFF returns a TIFF file with m written to its Exif directory and XMP
+// packet.
+func writeTIFF(data []byte, m nativeMetadata) ([]byte, error) {
+	t, err := parseTIFF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var xmp []byte
+
+	if len(m.xmp) > 0 {
+		ifd0, _, errIFD := t.readIFD(t.order.Uint32(t.data[4:]))
+		if errIFD != nil {
+			return nil, errIFD
+		}
+
+		var packet []byte
+		if i := findEntry(ifd0, tagXMLPacket); i != -1 {
+			if packet, err = t.bytes(ifd0[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		if xmp, err = setXMP(packet, m.xmp); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := m.exifEntries(t.order)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err = setTIFFEntries(t, entries, xmp)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.data, nil
+}
+
+func analogueData(name string) xmpTag {
+	return xmpTag{
+		namespace: nsAnalogueData,
+		prefix:    "AnalogueData",
+		name:      name,
+		form:      xmpSimple,
+		convert:   text,
+	}
+}
+
+func text(value string) (string, error) {
+	return value, nil
+}
+
+func integer(value string) (string, error) {
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return "", err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return strconv.FormatUint(n, 10), nil
+}
+
+// rational returns a decimal or fraction as an XMP rational, e.g. 2.8 as 14/5.
+func rational(value string) (string, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return "", fmt.Errorf("%w: not a number", ErrInvalidTagValue)
+	}
+
+	return r.Num().String() + "/" + r.Denom().String(), nil
+}
+
+func encodeUserComment(value string, order byteOrder) (ifdEntry, error) {
+	comment := []byte(charsetASCII)
+
+	if isASCII(value) {
+		comment = append(comment, value...)
+	} else {
+		comment = []byte(charsetUnicode)
+		for _, u := range utf16.Encode([]rune(value)) {
+			comment = order.AppendUint16(comment, u)
+		}
+	}
+
+	return newEntry(tiffUndefined, len(comment), comment)
+}
+
+func encodeDateTime(value string, _ byteOrder) (ifdEntry, error) {
+	t, err := time.Parse(time.DateTime, value)
+	if err != nil {
+		return ifdEntry{}, err //nolint:wrapcheck // wrapped by caller
+	}
+
+	s := t.Format(exifDateTime) + "\x00"
+
+	return newEntry(tiffASCII, len(s), []byte(s))
+}
+
+func encodeSRational(value string, order byteOrder) (ifdEntry, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || !r.Num().IsInt64() || !r.Denom().IsInt64() ||
+		r.Num().Int64() < math.MinInt32 || r.Num().Int64() > math.MaxInt32 ||
+		r.Denom().Int64() > math.MaxInt32 {
+		return ifdEntry{}, fmt.Errorf("%w: not a signed rational",
+			ErrInvalidTagValue)
+	}
+
+	b := order.AppendUint32(nil, uint32(int32(r.Num().Int64())))
+	b = order.AppendUint32(b, uint32(r.Denom().Int64()))
+
+	return newEntry(tiffSRational, 1, b)
+}
+
+func encodeShort(value string, order byteOrder) (ifdEntry, error) {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return ifdEntry{}, err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return newEntry(tiffShort, 1, order.AppendUint16(nil, uint16(n)))
+}
+
+func encodeMeteringMode(value string, order byteOrder) (ifdEntry, error) {
+	mode, ok := meteringModes[value]
+	if !ok {
+		mode = meteringOther
+	}
+
+	return newEntry(tiffShort, 1, order.AppendUint16(nil, mode))
+}
+
+func newEntry(typ uint16, count int, value []byte) (ifdEntry, error) {
+	if count > math.MaxUint32 {
+		return ifdEntry{}, ErrTIFFTooLarge
+	}
+
+	return ifdEntry{
+		tag:   0,
+		typ:   typ,
+		count: uint32(count),
+		field: [ifdFieldSize]byte{},
+		value: value,
+	}, nil
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] > unicode.MaxASCII {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,425 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package exif_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"golang.org/x/image/tiff"
+)
+
+const (
+	tagUserComment      = 0x9286
+	tagDateTimeOriginal = 0x9003
+	tagExposureBias     = 0x9204
+	tagFlash            = 0x9209
+	tagMeteringMode     = 0x9207
+)
+
+func newTestImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 2, color.RGBA{R: 200, G: 10, B: 10, A: 255})
+
+	return img
+}
+
+func encodeTestImage(
+	t *testing.T,
+	encode func(io.Writer, image.Image) error,
+) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	if err := encode(buf, newTestImage()); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func encodeJPEG(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, nil)
+}
+
+func encodeTIFF(w io.Writer, img image.Image) error {
+	return tiff.Encode(w, img, nil)
+}
+
+type byteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+func tiffOrder(data []byte) byteOrder {
+	if string(data[:2]) == "MM" {
+		return binary.BigEndian
+	}
+
+	return binary.LittleEndian
+}
+
+// withXMP returns a JPEG file with an XMP segment holding packet.
+func withXMP(data []byte, packet string) []byte {
+	payload := "http://ns.adobe.com/xap/1.0/\x00" + packet
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, 0xFF, 0xE1)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(payload)+2))
+	out = append(out, payload...)
+
+	return append(out, data[2:]...)
+}
+
+// ifdField returns the type and value of a tag of the IFD of TIFF data at
+// offset ifd.
+func ifdField(data []byte, ifd uint32, tag uint16) (uint16, []byte, bool) {
+	order := tiffOrder(data)
+	sizes := map[uint16]uint32{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 7: 1, 10: 8}
+
+	n := uint32(order.Uint16(data[ifd:]))
+	for i := range n {
+		e := data[ifd+2+i*12:]
+		if order.Uint16(e) != tag {
+			continue
+		}
+
+		typ := order.Uint16(e[2:])
+		size := sizes[typ] * order.Uint32(e[4:])
+
+		if size <= 4 {
+			return typ, e[8 : 8+size], true
+		}
+
+		offset := order.Uint32(e[8:])
+
+		return typ, data[offset : offset+size], true
+	}
+
+	return 0, nil, false
+}
+
+// exifField returns the type and value of an Exif IFD tag of TIFF data.
+func exifField(t *testing.T, data []byte, tag uint16) (uint16, []byte) {
+	t.Helper()
+
+	order := tiffOrder(data)
+
+	_, pointer, ok := ifdField(data, order.Uint32(data[4:]), 0x8769)
+	if !ok {
+		t.Fatal("expected an Exif IFD")
+	}
+
+	typ, value, ok := ifdField(data, order.Uint32(pointer), tag)
+	if !ok {
+		t.Fatalf("expected tag %#04x in the Exif IFD", tag)
+	}
+
+	return typ, value
+}
+
+// jpegMetadata returns the TIFF data of the EXIF segment and the XMP packet
+// of a JPEG file.
+func jpegMetadata(t *testing.T, data []byte) ([]byte, []byte) {
+	t.Helper()
+
+	segment := func(header string) []byte {
+		i := bytes.Index(data, []byte(header))
+		if i == -1 {
+			t.Fatalf("expected a %q segment", header)
+		}
+
+		end := i - 2 + int(binary.BigEndian.Uint16(data[i-2:]))
+
+		return data[i+len(header) : end]
+	}
+
+	return segment("Exif\x00\x00"), segment("http://ns.adobe.com/xap/1.0/\x00")
+}
+
+// tiffMetadata returns the TIFF data and the XMP packet of a TIFF file.
+func tiffMetadata(t *testing.T, data []byte) ([]byte, []byte) {
+	t.Helper()
+
+	_, xmp, ok := ifdField(data, tiffOrder(data).Uint32(data[4:]), 700)
+	if !ok {
+		t.Fatal("expected an XMP packet")
+	}
+
+	return data, xmp
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_NativeRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	metadata := strings.Join([]string{
+		"-" + exif.TagUserComment + "=Ilford HP5",
+		"-" + exif.TagDateTimeOriginal + "=2024-05-01 10:32:07",
+		"-" + exif.TagExposureCompensation + "=-0.3",
+		"-" + exif.TagFlash + "=16",
+		"-" + exif.TagMeteringMode + "=Spot",
+		"-" + exif.TagFlashExposureComp + "=+0.5",
+		"-" + exif.TagFNumber + "=2.8",
+		"-" + exif.TagExposureTime + "=1/250",
+		"-" + exif.TagISO + "=400",
+		"-" + exif.TagFilmISO + "=400",
+		"-" + exif.TagShootingMode + "=Aperture-priority AE",
+	}, "\n") + "\n"
+
+	type testcase struct {
+		name          string
+		file          string
+		data          func(t *testing.T) []byte
+		runs          []string
+		decode        func(io.Reader) (image.Image, error)
+		metadata      func(t *testing.T, data []byte) ([]byte, []byte)
+		contains      []string
+		expectedError error
+	}
+
+	tests := []testcase{
+		{
+			name: "jpeg without metadata",
+			file: "scan.jpg",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encodeTestImage(t, encodeJPEG)
+			},
+			runs:     []string{metadata},
+			decode:   jpeg.Decode,
+			metadata: jpegMetadata,
+			contains: []string{
+				"<exif:FNumber>14/5</exif:FNumber>",
+				"<exif:ExposureTime>1/250</exif:ExposureTime>",
+				"<rdf:Seq><rdf:li>400</rdf:li></rdf:Seq>",
+				"<aux:FlashCompensation>1/2</aux:FlashCompensation>",
+				`xmlns:AnalogueData="https://filmgra.in/AnalogueData/1.0/"`,
+				"<AnalogueData:ShootingMode>Aperture-priority AE<",
+			},
+		},
+		{
+			name: "jpeg written again",
+			file: "scan.jpg",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encodeTestImage(t, encodeJPEG)
+			},
+			runs: []string{
+				"-" + exif.TagFNumber + "=4\n-" + exif.TagMeteringMode +
+					"=Evaluative\n",
+				metadata,
+			},
+			decode:   jpeg.Decode,
+			metadata: jpegMetadata,
+			contains: []string{"<exif:FNumber>14/5</exif:FNumber>"},
+		},
+		{
+			name: "jpeg with xmp from another tool",
+			file: "scan.jpg",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return withXMP(encodeTestImage(t, encodeJPEG), `<x:xmpmeta`+
+					` xmlns:x="adobe:ns:meta/"><rdf:RDF`+
+					` xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">`+
+					`<rdf:Description rdf:about=""`+
+					` xmlns:exif="http://ns.adobe.com/exif/1.0/"`+
+					` xmlns:dc="http://purl.org/dc/elements/1.1/"`+
+					` exif:FNumber="4/1"><dc:title>Roll 12</dc:title>`+
+					`</rdf:Description></rdf:RDF></x:xmpmeta>`)
+			},
+			runs:     []string{metadata},
+			decode:   jpeg.Decode,
+			metadata: jpegMetadata,
+			contains: []string{
+				"<dc:title>Roll 12</dc:title>",
+				"<exif:FNumber>14/5</exif:FNumber>",
+			},
+		},
+		{
+			name: "tiff",
+			file: "scan.tif",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encodeTestImage(t, encodeTIFF)
+			},
+			runs:     []string{metadata, metadata},
+			decode:   tiff.Decode,
+			metadata: tiffMetadata,
+			contains: []string{
+				"<exif:FNumber>14/5</exif:FNumber>",
+				"<AnalogueData:FilmISO>400</AnalogueData:FilmISO>",
+			},
+		},
+		{
+			name: "unsupported tag",
+			file: "scan.jpg",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encodeTestImage(t, encodeJPEG)
+			},
+			runs:          []string{"-XMP-dc:Title=Roll 12\n"},
+			expectedError: exif.ErrUnsupportedTag,
+		},
+		{
+			name: "invalid tag value",
+			file: "scan.jpg",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encodeTestImage(t, encodeJPEG)
+			},
+			runs:          []string{"-" + exif.TagFNumber + "=wide\n"},
+			expectedError: exif.ErrInvalidTagValue,
+		},
+		{
+			name: "unsupported file format",
+			file: "scan.png",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encodeTestImage(t, png.Encode)
+			},
+			runs:          []string{metadata},
+			expectedError: exif.ErrUnsupportedFileFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			original := tt.data(t)
+			target := filepath.Join(t.TempDir(), tt.file)
+
+			if err := os.WriteFile(target, original, 0o600); err != nil {
+				t.Fatalf("failed to write test image: %v", err)
+			}
+
+			runner := exif.NewNativeRunner(osfs.NewFileSystem())
+
+			var err error
+			for _, run := range tt.runs {
+				if err = runner.Run(t.Context(), target, run); err != nil {
+					break
+				}
+			}
+
+			data, errRead := os.ReadFile(target)
+			if errRead != nil {
+				t.Fatalf("failed to read image: %v", errRead)
+			}
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				if !bytes.Equal(data, original) {
+					t.Error("expected the image to be left unchanged")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tiffData, xmp := tt.metadata(t, data)
+			assertWritten(t, tt.decode, tiffData, data)
+
+			for _, want := range tt.contains {
+				if !bytes.Contains(xmp, []byte(want)) {
+					t.Errorf("expected XMP to contain %q, got:\n%s", want, xmp)
+				}
+			}
+
+			if n := bytes.Count(xmp, []byte("<exif:FNumber>")); n != 1 {
+				t.Errorf("expected FNumber to be written once, got %d", n)
+			}
+
+			if bytes.Contains(xmp, []byte(`exif:FNumber="`)) {
+				t.Error("expected the FNumber attribute to be replaced")
+			}
+
+			backup, errRead := os.ReadFile(target + "_original")
+			if errRead != nil || !bytes.Equal(backup, original) {
+				t.Errorf("expected the original to be kept, got %v", errRead)
+			}
+		})
+	}
+}
+
+func assertWritten(
+	t *testing.T,
+	decode func(io.Reader) (image.Image, error),
+	tiffData []byte,
+	data []byte,
+) {
+	t.Helper()
+
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected written image to decode: %v", err)
+	}
+
+	want := newTestImage()
+	if img.Bounds() != want.Bounds() {
+		t.Errorf("expected bounds %v, got %v", want.Bounds(), img.Bounds())
+	}
+
+	order := tiffOrder(tiffData)
+
+	fields := []struct {
+		tag   uint16
+		typ   uint16
+		value []byte
+	}{
+		{tagUserComment, 7, []byte("ASCII\x00\x00\x00Ilford HP5")},
+		{tagDateTimeOriginal, 2, []byte("2024:05:01 10:32:07\x00")},
+		{tagExposureBias, 10, order.AppendUint32(
+			order.AppendUint32(nil, 0xFFFFFFFD), 10)},
+		{tagFlash, 3, order.AppendUint16(nil, 16)},
+		{tagMeteringMode, 3, order.AppendUint16(nil, 3)},
+	}
+
+	for _, f := range fields {
+		typ, value := exifField(t, tiffData, f.tag)
+		if typ != f.typ || !bytes.Equal(value, f.value) {
+			t.Errorf("expected tag %#04x to be type %d %q, got type %d %q",
+				f.tag, f.typ, f.value, typ, value)
+		}
+	}
+}
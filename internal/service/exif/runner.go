@@ -14,7 +14,7 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-//go:generate mockgen -destination=./mocks/runner_mock.go -package=exif_test github.com/ma-tf/meta1v/internal/service/exif ToolRunner
+//go:generate mockgen -destination=./mocks/runner_mock.go -package=exif_test github.com/ma-tf/meta1v/internal/service/exif ToolRunner,BatchToolRunner
 package exif
 
 import (
@@ -22,7 +22,13 @@ import (
 	"context"
 	_ "embed"
 	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
 
+	"github.com/ma-tf/meta1v/internal/service/osexec"
 	"github.com/ma-tf/meta1v/internal/service/osfs"
 )
 
@@ -35,14 +41,32 @@ var (
 	ErrExifToolFailed      = errors.New("exiftool failed")
 	ErrContextDone         = errors.New("context done before writing config")
 	ErrWriteExifToolConfig = errors.New("failed to write exiftool config")
+	ErrNoExifToolResult    = errors.New("exiftool reported no result")
+	ErrInvalidTargetFile   = errors.New("invalid target file")
 )
 
-// ToolRunner executes exiftool with metadata and configuration.
+// ToolRunner writes metadata tags to image files, with exiftool or natively.
 type ToolRunner interface {
-	// Run executes exiftool on the target file with the provided metadata tags.
+	// Run writes the provided metadata tags, given as exiftool arguments, to
+	// the target file.
 	Run(ctx context.Context, targetFile string, metadata string) error
 }
 
+// BatchToolRunner is a ToolRunner that can also write several image files in a
+// single run.
+type BatchToolRunner interface {
+	ToolRunner
+
+	// RunBatch writes the metadata of every target file, given as exiftool
+	// arguments, in a single run. It returns the error of each file, nil for
+	// those written, and fails as a whole only if the run cannot be started.
+	RunBatch(
+		ctx context.Context,
+		targetFiles []string,
+		metadata []string,
+	) ([]error, error)
+}
+
 type exifToolRunner struct {
 	fs      osfs.FileSystem
 	factory ExiftoolCommandFactory
@@ -51,7 +75,7 @@ type exifToolRunner struct {
 func NewExifToolRunner(
 	fs osfs.FileSystem,
 	factory ExiftoolCommandFactory,
-) ToolRunner {
+) BatchToolRunner {
 	return &exifToolRunner{
 		fs:      fs,
 		factory: factory,
@@ -63,6 +87,96 @@ func (r *exifToolRunner) Run(
 	ctx context.Context,
 	targetFile string,
 	metadata string,
+) error {
+	var out bytes.Buffer
+
+	return r.execute(ctx, func(rPipe *os.File) osexec.Command {
+		return r.factory.CreateCommand(ctx, targetFile, &out, metadata, rPipe)
+	})
+}
+
+// RunBatch executes exiftool once for every target file, passing the arguments
+// of each file on stdin as a separate command ended by -execute. Each command
+// echoes a {readyN} marker to stdout and stderr once it is done, so the output
+// of every file can be told apart; a file fails if exiftool reports an error
+// for it.
+func (r *exifToolRunner) RunBatch(
+	ctx context.Context,
+	targetFiles []string,
+	metadata []string,
+) ([]error, error) {
+	results := make([]error, len(targetFiles))
+
+	var (
+		args strings.Builder
+		run  []int // indices of the files passed to exiftool
+	)
+
+	for i, file := range targetFiles {
+		// a line break would end the argument and start another one
+		if strings.ContainsAny(file, "\r\n") {
+			results[i] = fmt.Errorf("%w %q: contains a line break",
+				ErrInvalidTargetFile, file)
+
+			continue
+		}
+
+		if len(run) > 0 {
+			args.WriteString("-execute\n")
+		}
+
+		marker := readyMarker(i)
+		fmt.Fprintf(&args, "-m\n%s%s\n-echo3\n%s\n-echo4\n%s\n",
+			metadata[i], file, marker, marker)
+
+		run = append(run, i)
+	}
+
+	if len(run) == 0 {
+		return results, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	err := r.execute(ctx, func(rPipe *os.File) osexec.Command {
+		return r.factory.CreateBatchCommand(
+			ctx,
+			&stdout,
+			&stderr,
+			args.String(),
+			rPipe,
+		)
+	})
+	// exiftool exits with an error if any file failed, which is told from the
+	// output of each file below
+	if err != nil && !errors.Is(err, ErrExifToolFailed) {
+		return nil, err
+	}
+
+	outputs := splitOutput(stdout.String())
+	errOutputs := splitOutput(stderr.String())
+
+	for _, i := range run {
+		_, done := outputs[i]
+		errOutput, errDone := errOutputs[i]
+
+		switch {
+		case !done || !errDone:
+			results[i] = errors.Join(ErrNoExifToolResult, err)
+		case exifToolErrors(errOutput) != "":
+			results[i] = fmt.Errorf("%w: %s",
+				ErrExifToolFailed, exifToolErrors(errOutput))
+		}
+	}
+
+	return results, nil
+}
+
+// execute starts the command create builds, streams the config to it through
+// the pipe passed as fd 3 and waits for it to exit.
+func (r *exifToolRunner) execute(
+	ctx context.Context,
+	create func(rPipe *os.File) osexec.Command,
 ) error {
 	rPipe, wPipe, err := r.fs.Pipe()
 	if err != nil {
@@ -71,11 +185,13 @@ func (r *exifToolRunner) Run(
 
 	defer rPipe.Close()
 
-	var out bytes.Buffer
-
-	cmd := r.factory.CreateCommand(ctx, targetFile, &out, metadata, rPipe)
+	cmd := create(rPipe)
 
 	if err = cmd.Start(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return errors.Join(ErrStartExifTool, ErrExifToolBinaryNotFound, err)
+		}
+
 		return errors.Join(ErrStartExifTool, err)
 	}
 
@@ -108,3 +224,48 @@ func (r *exifToolRunner) Run(
 
 	return nil
 }
+
+func readyMarker(i int) string {
+	return "{ready" + strconv.Itoa(i) + "}"
+}
+
+// splitOutput splits the output of a batch run at its {readyN} markers,
+// returning the output of each command by the N of the marker ending it.
+func splitOutput(output string) map[int]string {
+	outputs := make(map[int]string)
+
+	var block strings.Builder
+
+	for line := range strings.Lines(output) {
+		trimmed := strings.TrimSpace(line)
+
+		if n, ok := strings.CutPrefix(trimmed, "{ready"); ok {
+			if n, ok = strings.CutSuffix(n, "}"); ok {
+				if i, err := strconv.Atoi(n); err == nil {
+					outputs[i] = block.String()
+					block.Reset()
+
+					continue
+				}
+			}
+		}
+
+		block.WriteString(line)
+	}
+
+	return outputs
+}
+
+// exifToolErrors returns the error lines of exiftool output joined together,
+// leaving out warnings.
+func exifToolErrors(output string) string {
+	var errs []string
+
+	for line := range strings.Lines(output) {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "Error") {
+			errs = append(errs, line)
+		}
+	}
+
+	return strings.Join(errs, "; ")
+}
@@ -17,9 +17,11 @@
 package exif_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
+	"os/exec"
 	"testing"
 
 	"github.com/ma-tf/meta1v/internal/service/exif"
@@ -99,6 +101,40 @@ func Test_Run(t *testing.T) {
 			},
 			expectedError: exif.ErrStartExifTool,
 		},
+		{
+			name:       "exiftool binary not found",
+			targetFile: "test.jpg",
+			metadata:   "metadata",
+			expect: func(
+				mockFileSystem *osfs_test.MockFileSystem,
+				mockFactory *exif_test.MockExiftoolCommandFactory,
+				mockCmd *osexec_test.MockCommand,
+				_ testcase,
+			) {
+				rPipe, wPipe, _ := os.Pipe()
+
+				mockFileSystem.
+					EXPECT().
+					Pipe().
+					Return(rPipe, wPipe, nil)
+
+				mockFactory.
+					EXPECT().
+					CreateCommand(
+						gomock.Any(),
+						"test.jpg",
+						gomock.Any(),
+						"metadata",
+						rPipe,
+					).
+					Return(mockCmd)
+
+				mockCmd.EXPECT().
+					Start().
+					Return(exec.ErrNotFound)
+			},
+			expectedError: exif.ErrExifToolBinaryNotFound,
+		},
 		{
 			name:       "exiftool run fails",
 			targetFile: "test.jpg",
@@ -314,3 +350,161 @@ func Test_Run(t *testing.T) {
 		})
 	}
 }
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_RunBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		targetFiles     []string
+		metadata        []string
+		expectedArgs    string
+		stdout          string
+		stderr          string
+		startErr        error
+		waitErr         error
+		expectedResults []error
+		expectedError   error
+	}{
+		{
+			name:        "every file written",
+			targetFiles: []string{"01.tif", "02.tif"},
+			metadata:    []string{"-TagA=1\n", "-TagA=2\n"},
+			expectedArgs: "-m\n-TagA=1\n01.tif\n-echo3\n{ready0}\n-echo4\n{ready0}\n" +
+				"-execute\n" +
+				"-m\n-TagA=2\n02.tif\n-echo3\n{ready1}\n-echo4\n{ready1}\n",
+			stdout: "    1 image files updated\n{ready0}\n" +
+				"    1 image files updated\n{ready1}\n",
+			stderr:          "{ready0}\nWarning: minor issue - 02.tif\n{ready1}\n",
+			expectedResults: []error{nil, nil},
+		},
+		{
+			name:        "carries on past a failing file",
+			targetFiles: []string{"01.tif", "02.tif", "03.tif"},
+			metadata:    []string{"", "", ""},
+			stdout: "    1 image files updated\n{ready0}\n" +
+				"    0 image files updated\n" +
+				"    1 files weren't updated due to errors\n{ready1}\n" +
+				"    1 image files updated\n{ready2}\n",
+			stderr: "{ready0}\nError: File not found - 02.tif\n{ready1}\n" +
+				"{ready2}\n",
+			waitErr:         errExample,
+			expectedResults: []error{nil, exif.ErrExifToolFailed, nil},
+		},
+		{
+			name:        "exiftool stops before every file is done",
+			targetFiles: []string{"01.tif", "02.tif"},
+			metadata:    []string{"", ""},
+			stdout:      "    1 image files updated\n{ready0}\n",
+			stderr:      "{ready0}\n",
+			waitErr:     errExample,
+			expectedResults: []error{
+				nil,
+				exif.ErrNoExifToolResult,
+			},
+		},
+		{
+			name:        "file name with a line break",
+			targetFiles: []string{"01.tif\n-delete", "02.tif"},
+			metadata:    []string{"", ""},
+			expectedArgs: "-m\n02.tif\n-echo3\n{ready1}\n" +
+				"-echo4\n{ready1}\n",
+			stdout: "    1 image files updated\n{ready1}\n",
+			stderr: "{ready1}\n",
+			expectedResults: []error{
+				exif.ErrInvalidTargetFile,
+				nil,
+			},
+		},
+		{
+			name:          "exiftool binary not found",
+			targetFiles:   []string{"01.tif"},
+			metadata:      []string{""},
+			startErr:      exec.ErrNotFound,
+			expectedError: exif.ErrExifToolBinaryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rPipe, wPipe, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("failed to create pipe: %v", err)
+			}
+
+			mockFileSystem := osfs_test.NewMockFileSystem(ctrl)
+			mockFileSystem.EXPECT().
+				Pipe().
+				Return(rPipe, wPipe, nil)
+
+			var stdout, stderr *bytes.Buffer
+
+			mockCmd := osexec_test.NewMockCommand(ctrl)
+			mockFactory := exif_test.NewMockExiftoolCommandFactory(ctrl)
+			mockFactory.EXPECT().
+				CreateBatchCommand(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any(), rPipe).
+				DoAndReturn(func(
+					_ context.Context,
+					out *bytes.Buffer,
+					errOut *bytes.Buffer,
+					args string,
+					_ *os.File,
+				) *osexec_test.MockCommand {
+					if tt.expectedArgs != "" && args != tt.expectedArgs {
+						t.Errorf("expected arguments %q, got %q",
+							tt.expectedArgs, args)
+					}
+
+					stdout, stderr = out, errOut
+
+					return mockCmd
+				})
+
+			mockCmd.EXPECT().
+				Start().
+				Return(tt.startErr)
+
+			if tt.startErr == nil {
+				mockCmd.EXPECT().
+					Wait().
+					DoAndReturn(func() error {
+						stdout.WriteString(tt.stdout)
+						stderr.WriteString(tt.stderr)
+
+						return tt.waitErr
+					})
+			}
+
+			runner := exif.NewExifToolRunner(mockFileSystem, mockFactory)
+
+			results, err := runner.RunBatch(
+				t.Context(),
+				tt.targetFiles,
+				tt.metadata,
+			)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if len(results) != len(tt.expectedResults) {
+				t.Fatalf("expected %d results, got %v",
+					len(tt.expectedResults), results)
+			}
+
+			for i, expected := range tt.expectedResults {
+				if !errors.Is(results[i], expected) ||
+					(expected == nil) != (results[i] == nil) {
+					t.Errorf("expected result %d to be %v, got %v",
+						i, expected, results[i])
+				}
+			}
+		})
+	}
+}
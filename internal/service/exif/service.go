@@ -18,8 +18,9 @@
 
 // Package exif provides services for writing EXIF metadata to image files using Canon EFD frame data.
 //
-// This package builds EXIF tags from frame metadata and executes exiftool to embed
-// the metadata into target image files.
+// This package builds EXIF tags from frame metadata and embeds them into target
+// image files, either by executing exiftool or with a native writer for JPEG and
+// TIFF files, or writes them to XMP sidecar files.
 package exif
 
 import (
@@ -36,39 +37,75 @@ import (
 var (
 	ErrBuildExifData = errors.New("failed to build exif data")
 	ErrRunExifTool   = errors.New("failed to run exiftool")
+	ErrWriteMetadata = errors.New("failed to write metadata")
 )
 
+// Target is a frame and the image file its metadata is written to.
+type Target struct {
+	Frame records.EFRM
+	File  string
+}
+
 // Service provides operations for writing EXIF metadata to image files from Canon EFD frame records.
 type Service interface {
 	// WriteEXIF writes EXIF metadata from an EFRM record to the target image file.
-	// The strict parameter controls whether unknown metadata values cause errors.
+	// The strict parameter controls whether unknown metadata values cause errors,
+	// and the backend selects how the metadata is written.
 	WriteEXIF(
 		ctx context.Context,
 		efrm records.EFRM,
 		targetFile string,
 		strict bool,
+		backend Backend,
+	) error
+
+	// WriteEXIFBatch writes the EXIF metadata of every target as WriteEXIF
+	// does, running exiftool once for all of them with the exiftool backend.
+	// It carries on past failures and returns the error of each target, nil
+	// for those written.
+	WriteEXIFBatch(
+		ctx context.Context,
+		targets []Target,
+		strict bool,
+		backend Backend,
+	) []error
+
+	// WriteSidecar writes the metadata WriteEXIF would write to an XMP sidecar
+	// file instead, creating it or updating the one that is there.
+	WriteSidecar(
+		ctx context.Context,
+		efrm records.EFRM,
+		targetFile string,
+		strict bool,
 	) error
 }
 
 type service struct {
-	log     *slog.Logger
-	runner  ToolRunner
-	builder Builder
+	log            *slog.Logger
+	exiftoolRunner BatchToolRunner
+	nativeRunner   ToolRunner
+	sidecarRunner  ToolRunner
+	builder        Builder
 }
 
 func NewService(
 	log *slog.Logger,
-	runner ToolRunner,
+	exiftoolRunner BatchToolRunner,
+	nativeRunner ToolRunner,
+	sidecarRunner ToolRunner,
 	builder Builder,
 ) Service {
 	return &service{
-		log:     log,
-		runner:  runner,
-		builder: builder,
+		log:            log,
+		exiftoolRunner: exiftoolRunner,
+		nativeRunner:   nativeRunner,
+		sidecarRunner:  sidecarRunner,
+		builder:        builder,
 	}
 }
 
-// WriteEXIF runs exiftool with a user-defined config. It avoids shells and
+// WriteEXIF passes the built tags to the runner of the backend. The exiftool
+// backend runs exiftool with a user-defined config. It avoids shells and
 // temporary files by streaming the config over an anonymous pipe and passing
 // the read end as fd 3 to the child process (accessible as /proc/self/fd/3).
 func (s service) WriteEXIF(
@@ -76,15 +113,153 @@ func (s service) WriteEXIF(
 	efrm records.EFRM,
 	targetFile string,
 	strict bool,
+	backend Backend,
 ) error {
 	s.log.InfoContext(ctx, "writing exif data to file",
+		slog.String("target_file", targetFile),
+		slog.Uint64("frame_number", uint64(efrm.FrameNumber)),
+		slog.Bool("strict", strict),
+		slog.String("backend", string(backend)))
+
+	var runner ToolRunner = s.exiftoolRunner
+
+	runErr := ErrRunExifTool
+
+	switch backend {
+	case BackendExiftool:
+	case BackendNative:
+		runner, runErr = s.nativeRunner, ErrWriteMetadata
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedBackend, backend)
+	}
+
+	args, tagCount, err := s.arguments(ctx, efrm, strict)
+	if err != nil {
+		return err
+	}
+
+	s.log.DebugContext(ctx, "running backend",
+		slog.String("target_file", targetFile),
+		slog.String("backend", string(backend)))
+
+	err = runner.Run(ctx, targetFile, args)
+	if err != nil {
+		return fmt.Errorf("%w on %q: %w", runErr, targetFile, err)
+	}
+
+	s.log.InfoContext(ctx, "exif data written successfully",
+		slog.String("target_file", targetFile),
+		slog.Int("tags_written", tagCount))
+
+	return nil
+}
+
+func (s service) WriteEXIFBatch(
+	ctx context.Context,
+	targets []Target,
+	strict bool,
+	backend Backend,
+) []error {
+	s.log.InfoContext(ctx, "writing exif data to files",
+		slog.Int("targets", len(targets)),
+		slog.Bool("strict", strict),
+		slog.String("backend", string(backend)))
+
+	results := make([]error, len(targets))
+
+	if backend != BackendExiftool {
+		for i, t := range targets {
+			results[i] = s.WriteEXIF(ctx, t.Frame, t.File, strict, backend)
+		}
+
+		return results
+	}
+
+	var (
+		files    []string
+		metadata []string
+		run      []int // indices of the targets passed to exiftool
+	)
+
+	for i, t := range targets {
+		args, _, err := s.arguments(ctx, t.Frame, strict)
+		if err != nil {
+			results[i] = err
+
+			continue
+		}
+
+		files = append(files, t.File)
+		metadata = append(metadata, args)
+		run = append(run, i)
+	}
+
+	if len(run) == 0 {
+		return results
+	}
+
+	s.log.DebugContext(ctx, "running exiftool",
+		slog.Int("target_files", len(files)))
+
+	runResults, runErr := s.exiftoolRunner.RunBatch(ctx, files, metadata)
+
+	for j, i := range run {
+		err := runErr
+		if err == nil {
+			err = runResults[j]
+		}
+
+		if err != nil {
+			results[i] = fmt.Errorf("%w on %q: %w",
+				ErrRunExifTool, targets[i].File, err)
+		}
+	}
+
+	s.log.InfoContext(ctx, "exif data written to files",
+		slog.Int("targets", len(targets)))
+
+	return results
+}
+
+func (s service) WriteSidecar(
+	ctx context.Context,
+	efrm records.EFRM,
+	targetFile string,
+	strict bool,
+) error {
+	s.log.InfoContext(ctx, "writing xmp sidecar",
 		slog.String("target_file", targetFile),
 		slog.Uint64("frame_number", uint64(efrm.FrameNumber)),
 		slog.Bool("strict", strict))
 
+	args, tagCount, err := s.arguments(ctx, efrm, strict)
+	if err != nil {
+		return err
+	}
+
+	err = s.sidecarRunner.Run(ctx, targetFile, args)
+	if err != nil {
+		return fmt.Errorf("%w on %q: %w", ErrWriteMetadata, targetFile, err)
+	}
+
+	s.log.InfoContext(ctx, "xmp sidecar written successfully",
+		slog.String("target_file", targetFile),
+		slog.Int("tags_written", tagCount))
+
+	return nil
+}
+
+// arguments builds the tags of efrm as exiftool arguments of the form
+// -TAG=VALUE, one per line in tag order, leaving out tags without a value.
+// It also returns the number of tags built.
+func (s service) arguments(
+	ctx context.Context,
+	efrm records.EFRM,
+	strict bool,
+) (string, int, error) {
 	data, err := s.builder.Build(efrm, strict)
 	if err != nil {
-		return fmt.Errorf(
+		return "", 0, fmt.Errorf(
 			"%w for frame %d: %w",
 			ErrBuildExifData,
 			efrm.FrameNumber,
@@ -111,17 +286,5 @@ func (s service) WriteEXIF(
 		}
 	}
 
-	s.log.DebugContext(ctx, "running exiftool",
-		slog.String("target_file", targetFile))
-
-	err = s.runner.Run(ctx, targetFile, args.String())
-	if err != nil {
-		return fmt.Errorf("%w on %q: %w", ErrRunExifTool, targetFile, err)
-	}
-
-	s.log.InfoContext(ctx, "exif data written successfully",
-		slog.String("target_file", targetFile),
-		slog.Int("tags_written", len(data)))
-
-	return nil
+	return args.String(), len(data), nil
 }
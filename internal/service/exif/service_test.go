@@ -54,8 +54,10 @@ func Test_WriteEXIF(t *testing.T) {
 		frame    records.EFRM
 		filename string
 		strict   bool
+		backend  exif.Backend
 		expect   func(
-			mockToolRunner *exif_test.MockToolRunner,
+			mockToolRunner *exif_test.MockBatchToolRunner,
+			mockNativeRunner *exif_test.MockToolRunner,
 			mockBuilder *exif_test.MockBuilder,
 			tc testcase,
 		)
@@ -70,7 +72,9 @@ func Test_WriteEXIF(t *testing.T) {
 			},
 			filename: "test.jpg",
 			strict:   true,
+			backend:  exif.BackendExiftool,
 			expect: func(
+				_ *exif_test.MockBatchToolRunner,
 				_ *exif_test.MockToolRunner,
 				mockBuilder *exif_test.MockBuilder,
 				tc testcase,
@@ -87,8 +91,10 @@ func Test_WriteEXIF(t *testing.T) {
 			},
 			filename: "test2.jpg",
 			strict:   false,
+			backend:  exif.BackendExiftool,
 			expect: func(
-				mockToolRunner *exif_test.MockToolRunner,
+				mockToolRunner *exif_test.MockBatchToolRunner,
+				_ *exif_test.MockToolRunner,
 				mockBuilder *exif_test.MockBuilder,
 				tc testcase,
 			) {
@@ -114,8 +120,10 @@ func Test_WriteEXIF(t *testing.T) {
 			},
 			filename: "test3.jpg",
 			strict:   true,
+			backend:  exif.BackendExiftool,
 			expect: func(
-				mockToolRunner *exif_test.MockToolRunner,
+				mockToolRunner *exif_test.MockBatchToolRunner,
+				_ *exif_test.MockToolRunner,
 				mockBuilder *exif_test.MockBuilder,
 				tc testcase,
 			) {
@@ -134,6 +142,65 @@ func Test_WriteEXIF(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "failed to write with native backend",
+			frame: records.EFRM{
+				FrameNumber: 4,
+			},
+			filename: "test4.tif",
+			strict:   true,
+			backend:  exif.BackendNative,
+			expect: func(
+				_ *exif_test.MockBatchToolRunner,
+				mockNativeRunner *exif_test.MockToolRunner,
+				mockBuilder *exif_test.MockBuilder,
+				tc testcase,
+			) {
+				mockBuilder.EXPECT().Build(tc.frame, tc.strict).
+					Return(map[string]string{"TagA": "ValueA"}, nil)
+
+				mockNativeRunner.EXPECT().Run(
+					gomock.Any(),
+					tc.filename,
+					"-TagA=ValueA\n",
+				).Return(errExample)
+			},
+			expectedError: exif.ErrWriteMetadata,
+		},
+		{
+			name: "successful write with native backend",
+			frame: records.EFRM{
+				FrameNumber: 5,
+			},
+			filename: "test5.jpg",
+			strict:   true,
+			backend:  exif.BackendNative,
+			expect: func(
+				_ *exif_test.MockBatchToolRunner,
+				mockNativeRunner *exif_test.MockToolRunner,
+				mockBuilder *exif_test.MockBuilder,
+				tc testcase,
+			) {
+				mockBuilder.EXPECT().Build(tc.frame, tc.strict).
+					Return(map[string]string{"TagA": "ValueA"}, nil)
+
+				mockNativeRunner.EXPECT().Run(
+					gomock.Any(),
+					tc.filename,
+					"-TagA=ValueA\n",
+				).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "unsupported backend",
+			frame: records.EFRM{
+				FrameNumber: 6,
+			},
+			filename:      "test6.jpg",
+			backend:       "perl",
+			expectedError: exif.ErrUnsupportedBackend,
+		},
 	}
 
 	for _, tt := range tests {
@@ -146,16 +213,19 @@ func Test_WriteEXIF(t *testing.T) {
 			ctx := t.Context()
 			logger := newTestLogger()
 
-			mockToolRunner := exif_test.NewMockToolRunner(ctrl)
+			mockToolRunner := exif_test.NewMockBatchToolRunner(ctrl)
+			mockNativeRunner := exif_test.NewMockToolRunner(ctrl)
 			mockBuilder := exif_test.NewMockBuilder(ctrl)
 
 			if tt.expect != nil {
-				tt.expect(mockToolRunner, mockBuilder, tt)
+				tt.expect(mockToolRunner, mockNativeRunner, mockBuilder, tt)
 			}
 
 			svc := exif.NewService(
 				logger,
 				mockToolRunner,
+				mockNativeRunner,
+				exif_test.NewMockToolRunner(ctrl),
 				mockBuilder,
 			)
 
@@ -164,6 +234,7 @@ func Test_WriteEXIF(t *testing.T) {
 				tt.frame,
 				tt.filename,
 				tt.strict,
+				tt.backend,
 			)
 
 			if tt.expectedError != nil {
@@ -184,3 +255,216 @@ func Test_WriteEXIF(t *testing.T) {
 		})
 	}
 }
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_WriteEXIFBatch(t *testing.T) {
+	t.Parallel()
+
+	targets := []exif.Target{
+		{Frame: records.EFRM{FrameNumber: 1}, File: "01.tif"},
+		{Frame: records.EFRM{FrameNumber: 2}, File: "02.tif"},
+		{Frame: records.EFRM{FrameNumber: 3}, File: "03.tif"},
+	}
+
+	type mocks struct {
+		exiftool *exif_test.MockBatchToolRunner
+		native   *exif_test.MockToolRunner
+		builder  *exif_test.MockBuilder
+	}
+
+	tests := []struct {
+		name     string
+		backend  exif.Backend
+		expect   func(m mocks)
+		expected []error
+	}{
+		{
+			name:    "single exiftool run",
+			backend: exif.BackendExiftool,
+			expect: func(m mocks) {
+				for _, target := range targets {
+					m.builder.EXPECT().Build(target.Frame, true).
+						Return(map[string]string{"TagA": "ValueA"}, nil)
+				}
+
+				m.exiftool.EXPECT().
+					RunBatch(
+						gomock.Any(),
+						[]string{"01.tif", "02.tif", "03.tif"},
+						[]string{"-TagA=ValueA\n", "-TagA=ValueA\n", "-TagA=ValueA\n"},
+					).
+					Return([]error{nil, errExample, nil}, nil)
+			},
+			expected: []error{nil, exif.ErrRunExifTool, nil},
+		},
+		{
+			name:    "frame that cannot be built is left out of the run",
+			backend: exif.BackendExiftool,
+			expect: func(m mocks) {
+				m.builder.EXPECT().Build(targets[0].Frame, true).
+					Return(map[string]string{"TagA": "ValueA"}, nil)
+				m.builder.EXPECT().Build(targets[1].Frame, true).
+					Return(nil, errExample)
+				m.builder.EXPECT().Build(targets[2].Frame, true).
+					Return(map[string]string{"TagA": "ValueA"}, nil)
+
+				m.exiftool.EXPECT().
+					RunBatch(
+						gomock.Any(),
+						[]string{"01.tif", "03.tif"},
+						[]string{"-TagA=ValueA\n", "-TagA=ValueA\n"},
+					).
+					Return([]error{nil, nil}, nil)
+			},
+			expected: []error{nil, exif.ErrBuildExifData, nil},
+		},
+		{
+			name:    "exiftool cannot be started",
+			backend: exif.BackendExiftool,
+			expect: func(m mocks) {
+				for _, target := range targets {
+					m.builder.EXPECT().Build(target.Frame, true).
+						Return(map[string]string{}, nil)
+				}
+
+				m.exiftool.EXPECT().
+					RunBatch(gomock.Any(), gomock.Len(3), gomock.Len(3)).
+					Return(nil, exif.ErrExifToolBinaryNotFound)
+			},
+			expected: []error{
+				exif.ErrExifToolBinaryNotFound,
+				exif.ErrExifToolBinaryNotFound,
+				exif.ErrExifToolBinaryNotFound,
+			},
+		},
+		{
+			name:    "native backend writes file by file",
+			backend: exif.BackendNative,
+			expect: func(m mocks) {
+				for i, target := range targets {
+					m.builder.EXPECT().Build(target.Frame, true).
+						Return(map[string]string{}, nil)
+
+					var err error
+					if i == 0 {
+						err = errExample
+					}
+
+					m.native.EXPECT().
+						Run(gomock.Any(), target.File, "").
+						Return(err)
+				}
+			},
+			expected: []error{exif.ErrWriteMetadata, nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				exiftool: exif_test.NewMockBatchToolRunner(ctrl),
+				native:   exif_test.NewMockToolRunner(ctrl),
+				builder:  exif_test.NewMockBuilder(ctrl),
+			}
+			tt.expect(m)
+
+			svc := exif.NewService(
+				newTestLogger(),
+				m.exiftool,
+				m.native,
+				exif_test.NewMockToolRunner(ctrl),
+				m.builder,
+			)
+
+			results := svc.WriteEXIFBatch(t.Context(), targets, true, tt.backend)
+			if len(results) != len(tt.expected) {
+				t.Fatalf("expected %d results, got %v", len(tt.expected), results)
+			}
+
+			for i, expected := range tt.expected {
+				if !errors.Is(results[i], expected) ||
+					(expected == nil) != (results[i] == nil) {
+					t.Errorf("expected result %d to be %v, got %v",
+						i, expected, results[i])
+				}
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_WriteSidecar(t *testing.T) {
+	t.Parallel()
+
+	type testcase struct {
+		name          string
+		frame         records.EFRM
+		filename      string
+		buildErr      error
+		runErr        error
+		expectedError error
+	}
+
+	tests := []testcase{
+		{
+			name:          "failed to build exif data",
+			frame:         records.EFRM{FrameNumber: 1},
+			filename:      "test.xmp",
+			buildErr:      errExample,
+			expectedError: exif.ErrBuildExifData,
+		},
+		{
+			name:          "failed to write sidecar",
+			frame:         records.EFRM{FrameNumber: 2},
+			filename:      "test2.xmp",
+			runErr:        errExample,
+			expectedError: exif.ErrWriteMetadata,
+		},
+		{
+			name:     "successful sidecar write",
+			frame:    records.EFRM{FrameNumber: 3},
+			filename: "test3.xmp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSidecarRunner := exif_test.NewMockToolRunner(ctrl)
+			mockBuilder := exif_test.NewMockBuilder(ctrl)
+
+			if tt.buildErr != nil {
+				mockBuilder.EXPECT().Build(tt.frame, true).
+					Return(nil, tt.buildErr)
+			} else {
+				mockBuilder.EXPECT().Build(tt.frame, true).
+					Return(map[string]string{"TagA": "ValueA"}, nil)
+				mockSidecarRunner.EXPECT().
+					Run(gomock.Any(), tt.filename, "-TagA=ValueA\n").
+					Return(tt.runErr)
+			}
+
+			svc := exif.NewService(
+				newTestLogger(),
+				exif_test.NewMockBatchToolRunner(ctrl),
+				exif_test.NewMockToolRunner(ctrl),
+				mockSidecarRunner,
+				mockBuilder,
+			)
+
+			err := svc.WriteSidecar(t.Context(), tt.frame, tt.filename, true)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
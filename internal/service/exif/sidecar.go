@@ -0,0 +1,236 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package exif
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+)
+
+const (
+	// SidecarExtension is the extension of XMP sidecar files.
+	SidecarExtension = ".xmp"
+
+	sidecarPerm = 0o644
+
+	xmpDateTime = "2006-01-02T15:04:05"
+
+	flashFired    = 1 << 0
+	flashReturn   = 3 << 1
+	flashMode     = 3 << 3
+	flashFunction = 1 << 5
+	flashRedEye   = 1 << 6
+)
+
+var (
+	ErrReadSidecar  = errors.New("failed to read XMP sidecar")
+	ErrWriteSidecar = errors.New("failed to write XMP sidecar")
+)
+
+// sidecarTags maps the EXIF tags of the Builder to the XMP properties that
+// stand for them in sidecars, where there is no EXIF data.
+//
+//nolint:gochecknoglobals // fixed tag mapping
+var sidecarTags = map[string]func(value string) (xmpProperty, error){
+	TagUserComment: xmpTag{
+		nsExif, "exif", "UserComment", xmpLangAlt, text,
+	}.property,
+	TagDateTimeOriginal: xmpTag{
+		nsExif, "exif", "DateTimeOriginal", xmpSimple, xmpDate,
+	}.property,
+	TagExposureCompensation: xmpTag{
+		nsExif, "exif", "ExposureBiasValue", xmpSimple, rational,
+	}.property,
+	TagFlash: flashProperty,
+	TagMeteringMode: xmpTag{
+		nsExif, "exif", "MeteringMode", xmpSimple, meteringMode,
+	}.property,
+}
+
+type sidecarRunner struct {
+	fs osfs.FileSystem
+}
+
+// NewSidecarRunner creates a ToolRunner that writes the tags of the Builder
+// to an XMP sidecar instead of an image file, for asset managers that read
+// them, such as digiKam and Lightroom. The EXIF tags are written as their
+// exif namespace properties and the XMP-AnalogueData tags in the namespace
+// exiftool.config defines. An existing sidecar is updated, keeping the
+// properties it has that are not written.
+func NewSidecarRunner(fs osfs.FileSystem) ToolRunner {
+	return &sidecarRunner{
+		fs: fs,
+	}
+}
+
+func (r *sidecarRunner) Run(
+	ctx context.Context,
+	targetFile string,
+	metadata string,
+) error {
+	props, err := sidecarProperties(metadata)
+	if err != nil {
+		return err
+	}
+
+	perm := os.FileMode(sidecarPerm)
+
+	packet, err := readFile(r.fs, targetFile)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("%w %q: %w", ErrReadSidecar, targetFile, err)
+	default:
+		if info, errStat := r.fs.Stat(targetFile); errStat == nil {
+			perm = info.Mode().Perm()
+		}
+	}
+
+	packet, err = setXMP(packet, props)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrReadSidecar, targetFile, err)
+	}
+
+	if err = ctx.Err(); err != nil {
+		return errors.Join(ErrContextDone, err)
+	}
+
+	tempFile := targetFile + tempExtension
+	if err = writeFile(r.fs, tempFile, packet, perm); err != nil {
+		_ = r.fs.Remove(tempFile)
+
+		return fmt.Errorf("%w %q: %w", ErrWriteSidecar, tempFile, err)
+	}
+
+	if err = r.fs.Rename(tempFile, targetFile); err != nil {
+		_ = r.fs.Remove(tempFile)
+
+		return fmt.Errorf("%w %q: %w", ErrWriteSidecar, targetFile, err)
+	}
+
+	return nil
+}
+
+// SidecarPath returns the path of the XMP sidecar of an image file, named
+// after the image without its extension as Lightroom and digiKam expect.
+func SidecarPath(imageFile string) string {
+	return strings.TrimSuffix(imageFile, filepath.Ext(imageFile)) +
+		SidecarExtension
+}
+
+// sidecarProperties returns the XMP properties of exiftool arguments.
+func sidecarProperties(metadata string) ([]xmpProperty, error) {
+	args, err := parseArguments(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	props := make([]xmpProperty, 0, len(args))
+
+	for _, a := range args {
+		property, ok := sidecarTags[a.tag]
+		if !ok {
+			tag, isXMP := xmpTags[a.tag]
+			if !isXMP {
+				return nil, fmt.Errorf("%w: %s", ErrUnsupportedTag, a.tag)
+			}
+
+			property = tag.property
+		}
+
+		p, err := property(a.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s=%q: %w",
+				ErrInvalidTagValue, a.tag, a.value, err)
+		}
+
+		props = append(props, p)
+	}
+
+	return props, nil
+}
+
+// xmpDate returns a date and time as exiftool takes it in the ISO 8601 form
+// of XMP. The camera records no time zone, so none is given.
+func xmpDate(value string) (string, error) {
+	t, err := time.Parse(time.DateTime, value)
+	if err != nil {
+		return "", err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return t.Format(xmpDateTime), nil
+}
+
+func meteringMode(value string) (string, error) {
+	mode, ok := meteringModes[value]
+	if !ok {
+		mode = meteringOther
+	}
+
+	return strconv.Itoa(int(mode)), nil
+}
+
+// flashProperty returns the exif:Flash structure of an EXIF flash value.
+func flashProperty(value string) (xmpProperty, error) {
+	flash, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return xmpProperty{}, err //nolint:wrapcheck // wrapped by caller
+	}
+
+	field := func(name, value string) xmpProperty {
+		return xmpProperty{
+			namespace: nsExif,
+			prefix:    "exif",
+			name:      name,
+			value:     value,
+			form:      xmpSimple,
+			fields:    nil,
+		}
+	}
+
+	boolean := func(set bool) string {
+		if set {
+			return "True"
+		}
+
+		return "False"
+	}
+
+	return xmpProperty{
+		namespace: nsExif,
+		prefix:    "exif",
+		name:      "Flash",
+		value:     "",
+		form:      xmpStruct,
+		fields: []xmpProperty{
+			field("Fired", boolean(flash&flashFired != 0)),
+			field("Return", strconv.FormatUint((flash&flashReturn)>>1, 10)),
+			field("Mode", strconv.FormatUint((flash&flashMode)>>3, 10)),
+			field("Function", boolean(flash&flashFunction != 0)),
+			field("RedEyeMode", boolean(flash&flashRedEye != 0)),
+		},
+	}, nil
+}
@@ -0,0 +1,214 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package exif_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/service/exif"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+)
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_SidecarRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	metadata := strings.Join([]string{
+		"-" + exif.TagUserComment + "=Ilford HP5 <push +1>",
+		"-" + exif.TagDateTimeOriginal + "=2024-05-01 10:32:07",
+		"-" + exif.TagExposureCompensation + "=-0.3",
+		"-" + exif.TagFlash + "=25",
+		"-" + exif.TagMeteringMode + "=Partial",
+		"-" + exif.TagFNumber + "=2.8",
+		"-" + exif.TagISO + "=400",
+		"-" + exif.TagFilmISO + "=400",
+	}, "\n") + "\n"
+
+	tests := []struct {
+		name          string
+		existing      string
+		metadata      string
+		contains      []string
+		descriptions  int
+		expectedError error
+	}{
+		{
+			name:     "new sidecar",
+			metadata: metadata,
+			contains: []string{
+				`<?xpacket begin=`,
+				`<x:xmpmeta xmlns:x="adobe:ns:meta/">`,
+				`<rdf:li xml:lang="x-default">Ilford HP5 &lt;push +1&gt;</rdf:li>`,
+				"<exif:DateTimeOriginal>2024-05-01T10:32:07<",
+				"<exif:ExposureBiasValue>-3/10</exif:ExposureBiasValue>",
+				`<exif:Flash rdf:parseType="Resource">`,
+				"<exif:Fired>True</exif:Fired>",
+				"<exif:Return>0</exif:Return>",
+				"<exif:Mode>3</exif:Mode>",
+				"<exif:Function>False</exif:Function>",
+				"<exif:RedEyeMode>False</exif:RedEyeMode>",
+				"<exif:MeteringMode>6</exif:MeteringMode>",
+				"<exif:FNumber>14/5</exif:FNumber>",
+				"<rdf:Seq><rdf:li>400</rdf:li></rdf:Seq>",
+				"<AnalogueData:FilmISO>400</AnalogueData:FilmISO>",
+			},
+			descriptions: 1,
+		},
+		{
+			name: "existing sidecar",
+			existing: `<x:xmpmeta xmlns:x="adobe:ns:meta/">` +
+				`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+				`<rdf:Description rdf:about=""` +
+				` xmlns:exif="http://ns.adobe.com/exif/1.0/"` +
+				` xmlns:xmp="http://ns.adobe.com/xap/1.0/"` +
+				` xmp:Rating="4"><exif:FNumber>4/1</exif:FNumber>` +
+				`</rdf:Description></rdf:RDF></x:xmpmeta>`,
+			metadata: metadata,
+			contains: []string{
+				`xmp:Rating="4"`,
+				"<exif:FNumber>14/5</exif:FNumber>",
+			},
+			descriptions: 2,
+		},
+		{
+			name: "sidecar written before",
+			existing: `<x:xmpmeta xmlns:x="adobe:ns:meta/">` +
+				`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+				`<rdf:Description rdf:about=""` +
+				` xmlns:exif="http://ns.adobe.com/exif/1.0/">` +
+				`<exif:FNumber>4/1</exif:FNumber>` +
+				`</rdf:Description></rdf:RDF></x:xmpmeta>`,
+			metadata: metadata,
+			contains: []string{
+				"<exif:FNumber>14/5</exif:FNumber>",
+			},
+			descriptions: 1,
+		},
+		{
+			name:          "existing file is not XMP",
+			existing:      "not a sidecar",
+			metadata:      metadata,
+			expectedError: exif.ErrReadSidecar,
+		},
+		{
+			name:          "unsupported tag",
+			metadata:      "-XMP-dc:Title=Roll 12\n",
+			expectedError: exif.ErrUnsupportedTag,
+		},
+		{
+			name:          "invalid tag value",
+			metadata:      "-" + exif.TagFlash + "=fired\n",
+			expectedError: exif.ErrInvalidTagValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := filepath.Join(t.TempDir(), "scan.xmp")
+
+			if tt.existing != "" {
+				err := os.WriteFile(target, []byte(tt.existing), 0o600)
+				if err != nil {
+					t.Fatalf("failed to write sidecar: %v", err)
+				}
+			}
+
+			err := exif.NewSidecarRunner(osfs.NewFileSystem()).
+				Run(t.Context(), target, tt.metadata)
+
+			data, errRead := os.ReadFile(target)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				if string(data) != tt.existing {
+					t.Error("expected the sidecar to be left unchanged")
+				}
+
+				return
+			}
+
+			if err != nil || errRead != nil {
+				t.Fatalf("unexpected error: %v, %v", err, errRead)
+			}
+
+			assertWellFormed(t, data)
+
+			for _, want := range tt.contains {
+				if !bytes.Contains(data, []byte(want)) {
+					t.Errorf("expected sidecar to contain %q, got:\n%s",
+						want, data)
+				}
+			}
+
+			if n := bytes.Count(data, []byte("<exif:FNumber>")); n != 1 {
+				t.Errorf("expected FNumber to be written once, got %d", n)
+			}
+
+			n := bytes.Count(data, []byte("<rdf:Description"))
+			if n != tt.descriptions {
+				t.Errorf("expected %d descriptions, got %d:\n%s",
+					tt.descriptions, n, data)
+			}
+		})
+	}
+}
+
+func assertWellFormed(t *testing.T, data []byte) {
+	t.Helper()
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err != nil {
+			t.Fatalf("expected well-formed XML: %v\n%s", err, data)
+		}
+	}
+}
+
+func Test_SidecarPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"scan.tif":          "scan.xmp",
+		"scans/roll.12.jpg": "scans/roll.12.xmp",
+		"scan":              "scan.xmp",
+	}
+
+	for image, expected := range tests {
+		if got := exif.SidecarPath(image); got != expected {
+			t.Errorf("expected sidecar of %q to be %q, got %q",
+				image, expected, got)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package exif
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// TIFF field types written by the native backend.
+const (
+	tiffByte      = 1
+	tiffASCII     = 2
+	tiffShort     = 3
+	tiffLong      = 4
+	tiffUndefined = 7
+	tiffSRational = 10
+)
+
+const (
+	tiffHeaderSize  = 8
+	tiffMagic       = 42
+	ifdEntrySize    = 12
+	ifdFieldSize    = 4
+	tagExifIFD      = 0x8769
+	tagXMLPacket    = 0x02BC
+	wordAlignment   = 2
+	littleEndianTag = "II"
+	bigEndianTag    = "MM"
+)
+
+var (
+	ErrInvalidTIFF  = errors.New("invalid TIFF data")
+	ErrTIFFTooLarge = errors.New("TIFF data too large")
+)
+
+// byteOrder reads and appends the integers of TIFF data.
+type byteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+// ifdEntry is an entry of a TIFF image file directory. Entries read from a file
+// keep their value, or the offset of their value, as it is stored, which stays
+// valid as existing data is never moved. Entries that are set carry their
+// encoded value instead.
+type ifdEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	field [ifdFieldSize]byte
+	value []byte
+}
+
+// tiffData is TIFF structured data: a TIFF file, or the EXIF data of a JPEG.
+type tiffData struct {
+	data  []byte
+	order byteOrder
+}
+
+// newTIFF returns TIFF data without any image file directory, in which
+// setTIFFEntries creates them.
+func newTIFF() tiffData {
+	data := binary.LittleEndian.AppendUint16([]byte(littleEndianTag), tiffMagic)
+	data = binary.LittleEndian.AppendUint32(data, 0)
+
+	return tiffData{data: data, order: binary.LittleEndian}
+}
+
+func parseTIFF(data []byte) (tiffData, error) {
+	if len(data) < tiffHeaderSize {
+		return tiffData{}, fmt.Errorf("%w: header cut short", ErrInvalidTIFF)
+	}
+
+	var order byteOrder
+
+	switch string(data[:2]) {
+	case littleEndianTag:
+		order = binary.LittleEndian
+	case bigEndianTag:
+		order = binary.BigEndian
+	default:
+		return tiffData{}, fmt.Errorf("%w: unknown byte order", ErrInvalidTIFF)
+	}
+
+	if magic := order.Uint16(data[2:]); magic != tiffMagic {
+		return tiffData{}, fmt.Errorf("%w: unsupported version %d",
+			ErrInvalidTIFF, magic)
+	}
+
+	return tiffData{data: data, order: order}, nil
+}
+
+// readIFD returns the entries of the image file directory at offset, sorted by
+// tag, and the offset of the next one. An offset of 0 is an empty directory.
+func (t tiffData) readIFD(offset uint32) ([]ifdEntry, uint32, error) {
+	if offset == 0 {
+		return nil, 0, nil
+	}
+
+	start := int64(offset)
+	if start+2 > int64(len(t.data)) {
+		return nil, 0, fmt.Errorf("%w: directory at %d out of bounds",
+			ErrInvalidTIFF, offset)
+	}
+
+	n := int64(t.order.Uint16(t.data[start:]))
+
+	end := start + 2 + n*ifdEntrySize + ifdFieldSize
+	if end > int64(len(t.data)) {
+		return nil, 0, fmt.Errorf("%w: directory at %d cut short",
+			ErrInvalidTIFF, offset)
+	}
+
+	entries := make([]ifdEntry, n)
+	for i := range entries {
+		p := start + 2 + int64(i)*ifdEntrySize
+		entries[i] = ifdEntry{
+			tag:   t.order.Uint16(t.data[p:]),
+			typ:   t.order.Uint16(t.data[p+2:]),
+			count: t.order.Uint32(t.data[p+4:]),
+			field: [ifdFieldSize]byte(t.data[p+8 : p+ifdEntrySize]),
+			value: nil,
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b ifdEntry) int {
+		return cmp.Compare(a.tag, b.tag)
+	})
+
+	return entries, t.order.Uint32(t.data[end-ifdFieldSize:]), nil
+}
+
+// bytes returns the value of a BYTE or UNDEFINED entry.
+func (t tiffData) bytes(e ifdEntry) ([]byte, error) {
+	if e.value != nil {
+		return e.value, nil
+	}
+
+	if e.count <= ifdFieldSize {
+		return e.field[:e.count], nil
+	}
+
+	offset := int64(t.order.Uint32(e.field[:]))
+	if offset+int64(e.count) > int64(len(t.data)) {
+		return nil, fmt.Errorf("%w: value of tag %#04x out of bounds",
+			ErrInvalidTIFF, e.tag)
+	}
+
+	return t.data[offset : offset+int64(e.count)], nil
+}
+
+// setTIFFEntries returns t with exifEntries set in its Exif directory and, if
+// xmp is not nil, the XMP packet set in its first directory. The updated
+// directories are appended, leaving everything they point to in place, so
+// image data and maker notes stay valid without being understood.
+func setTIFFEntries(
+	t tiffData,
+	exifEntries []ifdEntry,
+	xmp []byte,
+) (tiffData, error) {
+	ifd0, next, err := t.readIFD(t.order.Uint32(t.data[4:]))
+	if err != nil {
+		return tiffData{}, err
+	}
+
+	var exifIFD []ifdEntry
+
+	if i := findEntry(ifd0, tagExifIFD); i != -1 {
+		exifIFD, _, err = t.readIFD(t.order.Uint32(ifd0[i].field[:]))
+		if err != nil {
+			return tiffData{}, err
+		}
+	}
+
+	for _, e := range exifEntries {
+		exifIFD = setEntry(exifIFD, e)
+	}
+
+	if xmp != nil {
+		ifd0 = setEntry(ifd0, ifdEntry{
+			tag:   tagXMLPacket,
+			typ:   tiffByte,
+			count: uint32(len(xmp)), //nolint:gosec // bounded by the file size
+			field: [ifdFieldSize]byte{},
+			value: xmp,
+		})
+	}
+
+	data := slices.Clip(t.data)
+
+	data, exifOffset, err := appendIFD(data, t.order, exifIFD, 0)
+	if err != nil {
+		return tiffData{}, err
+	}
+
+	ifd0 = setEntry(ifd0, ifdEntry{
+		tag:   tagExifIFD,
+		typ:   tiffLong,
+		count: 1,
+		field: [ifdFieldSize]byte{},
+		value: t.order.AppendUint32(nil, exifOffset),
+	})
+
+	data, ifd0Offset, err := appendIFD(data, t.order, ifd0, next)
+	if err != nil {
+		return tiffData{}, err
+	}
+
+	t.order.PutUint32(data[4:], ifd0Offset)
+
+	return tiffData{data: data, order: t.order}, nil
+}
+
+func findEntry(entries []ifdEntry, tag uint16) int {
+	i, found := slices.BinarySearchFunc(entries, tag, compareTag)
+	if !found {
+		return -1
+	}
+
+	return i
+}
+
+// setEntry replaces the entry with the tag of e in entries, or inserts e,
+// keeping them sorted by tag.
+func setEntry(entries []ifdEntry, e ifdEntry) []ifdEntry {
+	i, found := slices.BinarySearchFunc(entries, e.tag, compareTag)
+	if found {
+		entries[i] = e
+
+		return entries
+	}
+
+	return slices.Insert(entries, i, e)
+}
+
+func compareTag(e ifdEntry, tag uint16) int {
+	return cmp.Compare(e.tag, tag)
+}
+
+// appendIFD appends an image file directory holding entries to data, followed
+// by the values too large to be stored in their entries, and returns data with
+// the offset of the directory.
+func appendIFD(
+	data []byte,
+	order byteOrder,
+	entries []ifdEntry,
+	next uint32,
+) ([]byte, uint32, error) {
+	data = alignWord(data)
+	offset := len(data)
+	valueOffset := offset + 2 + len(entries)*ifdEntrySize + ifdFieldSize
+
+	var values []byte
+
+	//nolint:gosec // directories hold a few dozen entries
+	data = order.AppendUint16(data, uint16(len(entries)))
+
+	for _, e := range entries {
+		data = order.AppendUint16(data, e.tag)
+		data = order.AppendUint16(data, e.typ)
+		data = order.AppendUint32(data, e.count)
+
+		switch {
+		case e.value == nil:
+			data = append(data, e.field[:]...)
+		case len(e.value) <= ifdFieldSize:
+			var field [ifdFieldSize]byte
+
+			copy(field[:], e.value)
+			data = append(data, field[:]...)
+		default:
+			values = alignWord(values)
+			//nolint:gosec // the size of data is checked below
+			data = order.AppendUint32(data, uint32(valueOffset+len(values)))
+			values = append(values, e.value...)
+		}
+	}
+
+	data = order.AppendUint32(data, next)
+	data = append(data, values...)
+
+	if len(data) > math.MaxUint32 {
+		return nil, 0, ErrTIFFTooLarge
+	}
+
+	return data, uint32(offset), nil //nolint:gosec // checked above
+}
+
+// alignWord pads b to an even length, as TIFF offsets have to be.
+func alignWord(b []byte) []byte {
+	if len(b)%wordAlignment != 0 {
+		b = append(b, 0)
+	}
+
+	return b
+}
@@ -0,0 +1,388 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package exif
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// XMP namespaces written by the native backend.
+const (
+	nsRDF          = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	nsXML          = "http://www.w3.org/XML/1998/namespace"
+	nsExif         = "http://ns.adobe.com/exif/1.0/"
+	nsAux          = "http://ns.adobe.com/exif/1.0/aux/"
+	nsAnalogueData = "https://filmgra.in/AnalogueData/1.0/"
+)
+
+// emptyXMP is the packet properties are added to for files without one.
+const emptyXMP = `<?xpacket begin="` + "\ufeff" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+ </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`
+
+var ErrInvalidXMP = errors.New("invalid XMP packet")
+
+// xmpForm is the form of the value of an XMP property.
+type xmpForm int
+
+const (
+	xmpSimple  xmpForm = iota // a single value
+	xmpSeq                    // an ordered array of one value
+	xmpLangAlt                // a language alternative in the default language
+	xmpStruct                 // a structure of fields
+)
+
+// xmpProperty is a top level property of an XMP packet, or a field of a
+// structure.
+type xmpProperty struct {
+	namespace string
+	prefix    string
+	name      string
+	value     string
+	form      xmpForm
+	fields    []xmpProperty // the fields of a structure, in order
+}
+
+// xmpRewriter copies the tokens of an XMP packet, replacing the properties it
+// sets.
+type xmpRewriter struct {
+	out       bytes.Buffer
+	props     []xmpProperty
+	scopes    []map[string]string // namespace declarations of the open elements
+	depth     int
+	descDepth int // depth of the open rdf:Description, 0 if there is none
+	descStart int // offset in out the open rdf:Description starts at
+	descKept  bool
+	skipDepth int // depth of the property being dropped, 0 if there is none
+	inserted  bool
+}
+
+// setXMP returns packet with props set in it. Other properties, and any values
+// the props had before, are left as they are. An empty packet is created if
+// there is none.
+func setXMP(packet []byte, props []xmpProperty) ([]byte, error) {
+	if len(bytes.TrimSpace(packet)) == 0 {
+		packet = []byte(emptyXMP)
+	}
+
+	//nolint:exhaustruct // the buffer and scopes start empty
+	rw := &xmpRewriter{props: props}
+	dec := xml.NewDecoder(bytes.NewReader(packet))
+
+	for {
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Join(ErrInvalidXMP, err)
+		}
+
+		rw.token(tok)
+	}
+
+	if !rw.inserted {
+		return nil, fmt.Errorf("%w: no rdf:RDF element", ErrInvalidXMP)
+	}
+
+	return rw.out.Bytes(), nil
+}
+
+func (rw *xmpRewriter) token(tok xml.Token) {
+	switch tok := tok.(type) {
+	case xml.StartElement:
+		rw.start(tok)
+	case xml.EndElement:
+		rw.end(tok)
+	case xml.CharData:
+		if rw.skipDepth == 0 {
+			escapeText(&rw.out, tok)
+		}
+	case xml.Comment:
+		if rw.skipDepth == 0 {
+			fmt.Fprintf(&rw.out, "<!--%s-->", tok)
+		}
+	case xml.ProcInst:
+		fmt.Fprintf(&rw.out, "<?%s %s?>", tok.Target, tok.Inst)
+	case xml.Directive:
+		fmt.Fprintf(&rw.out, "<!%s>", tok)
+	}
+}
+
+func (rw *xmpRewriter) start(tok xml.StartElement) {
+	rw.depth++
+	rw.scopes = append(rw.scopes, declarations(tok.Attr))
+
+	if rw.skipDepth != 0 {
+		return
+	}
+
+	namespace := rw.resolve(tok.Name.Space)
+
+	if rw.descDepth != 0 && rw.depth == rw.descDepth+1 &&
+		rw.sets(namespace, tok.Name.Local) {
+		rw.skipDepth = rw.depth
+
+		// drop the line the property was on along with it
+		rw.out.Truncate(len(bytes.TrimRight(rw.out.Bytes(), " \t\r\n")))
+
+		return
+	}
+
+	if rw.descDepth != 0 && rw.depth == rw.descDepth+1 {
+		rw.descKept = true
+	}
+
+	if namespace == nsRDF && tok.Name.Local == "Description" {
+		rw.descDepth = rw.depth
+		rw.descStart = rw.out.Len()
+		rw.descKept = false
+
+		attrs := tok.Attr[:0:0]
+
+		for _, a := range tok.Attr {
+			if !rw.sets(rw.resolve(a.Name.Space), a.Name.Local) {
+				attrs = append(attrs, a)
+				rw.descKept = rw.descKept || rw.isProperty(a)
+			}
+		}
+
+		tok.Attr = attrs
+	}
+
+	writeStart(&rw.out, tok)
+}
+
+func (rw *xmpRewriter) end(tok xml.EndElement) {
+	defer func() {
+		rw.scopes = rw.scopes[:len(rw.scopes)-1]
+		rw.depth--
+	}()
+
+	if rw.skipDepth != 0 {
+		if rw.depth == rw.skipDepth {
+			rw.skipDepth = 0
+		}
+
+		return
+	}
+
+	if rw.depth == rw.descDepth {
+		rw.descDepth = 0
+
+		// a description left without properties says nothing, so it is
+		// dropped rather than piling up each time the packet is rewritten
+		if !rw.descKept {
+			rw.out.Truncate(rw.descStart)
+			rw.out.Truncate(len(bytes.TrimRight(rw.out.Bytes(), " \t\r\n")))
+
+			return
+		}
+	}
+
+	if rw.resolve(tok.Name.Space) == nsRDF && tok.Name.Local == "RDF" &&
+		!rw.inserted {
+		writeDescription(&rw.out, tok.Name.Space, rw.props)
+		rw.inserted = true
+	}
+
+	fmt.Fprintf(&rw.out, "</%s>", qualifiedName(tok.Name))
+}
+
+// resolve returns the namespace prefix is bound to in the open elements.
+func (rw *xmpRewriter) resolve(prefix string) string {
+	for i := len(rw.scopes) - 1; i >= 0; i-- {
+		if namespace, ok := rw.scopes[i][prefix]; ok {
+			return namespace
+		}
+	}
+
+	if prefix == "xml" {
+		return nsXML
+	}
+
+	return ""
+}
+
+// isProperty reports whether a is a property given in attribute form, rather
+// than a namespace declaration or an RDF attribute.
+func (rw *xmpRewriter) isProperty(a xml.Attr) bool {
+	if a.Name.Space == "xmlns" ||
+		a.Name.Space == "" && a.Name.Local == "xmlns" {
+		return false
+	}
+
+	return rw.resolve(a.Name.Space) != nsRDF
+}
+
+// sets reports whether the property with the given namespace and name is one
+// of the properties being set.
+func (rw *xmpRewriter) sets(namespace, name string) bool {
+	for _, p := range rw.props {
+		if p.namespace == namespace && p.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// declarations returns the namespace prefixes declared by attrs.
+func declarations(attrs []xml.Attr) map[string]string {
+	scope := make(map[string]string)
+
+	for _, a := range attrs {
+		switch {
+		case a.Name.Space == "xmlns":
+			scope[a.Name.Local] = a.Value
+		case a.Name.Space == "" && a.Name.Local == "xmlns":
+			scope[""] = a.Value
+		}
+	}
+
+	return scope
+}
+
+// writeDescription writes an rdf:Description holding props, using rdfPrefix
+// for the RDF namespace.
+func writeDescription(w *bytes.Buffer, rdfPrefix string, props []xmpProperty) {
+	rdf := func(local string) string {
+		return qualifiedName(xml.Name{Space: rdfPrefix, Local: local})
+	}
+
+	fmt.Fprintf(w, " <%s %s=\"\"", rdf("Description"), rdf("about"))
+
+	declared := make(map[string]bool)
+
+	var declare func(props []xmpProperty)
+
+	declare = func(props []xmpProperty) {
+		for _, p := range props {
+			if !declared[p.prefix] {
+				declared[p.prefix] = true
+
+				fmt.Fprintf(w, "\n    xmlns:%s=\"%s\"", p.prefix, p.namespace)
+			}
+
+			declare(p.fields)
+		}
+	}
+
+	declare(props)
+
+	fmt.Fprint(w, ">\n")
+
+	for _, p := range props {
+		writeProperty(w, rdfPrefix, p, "   ")
+	}
+
+	fmt.Fprintf(w, "  </%s>\n ", rdf("Description"))
+}
+
+// writeProperty writes p on its own line with the given indent, using
+// rdfPrefix for the RDF namespace.
+func writeProperty(
+	w *bytes.Buffer,
+	rdfPrefix string,
+	p xmpProperty,
+	indent string,
+) {
+	rdf := func(local string) string {
+		return qualifiedName(xml.Name{Space: rdfPrefix, Local: local})
+	}
+
+	fmt.Fprintf(w, "%s<%s:%s", indent, p.prefix, p.name)
+
+	switch p.form {
+	case xmpSimple:
+		w.WriteByte('>')
+	case xmpSeq:
+		fmt.Fprintf(w, "><%s><%s>", rdf("Seq"), rdf("li"))
+	case xmpLangAlt:
+		fmt.Fprintf(w, "><%s><%s xml:lang=\"x-default\">",
+			rdf("Alt"), rdf("li"))
+	case xmpStruct:
+		fmt.Fprintf(w, " %s=\"Resource\">\n", rdf("parseType"))
+
+		for _, f := range p.fields {
+			writeProperty(w, rdfPrefix, f, indent+" ")
+		}
+
+		fmt.Fprintf(w, "%s</%s:%s>\n", indent, p.prefix, p.name)
+
+		return
+	}
+
+	escapeText(w, []byte(p.value))
+
+	switch p.form {
+	case xmpSimple, xmpStruct:
+	case xmpSeq:
+		fmt.Fprintf(w, "</%s></%s>", rdf("li"), rdf("Seq"))
+	case xmpLangAlt:
+		fmt.Fprintf(w, "</%s></%s>", rdf("li"), rdf("Alt"))
+	}
+
+	fmt.Fprintf(w, "</%s:%s>\n", p.prefix, p.name)
+}
+
+func writeStart(w *bytes.Buffer, tok xml.StartElement) {
+	fmt.Fprintf(w, "<%s", qualifiedName(tok.Name))
+
+	for _, a := range tok.Attr {
+		fmt.Fprintf(w, " %s=\"", qualifiedName(a.Name))
+		_ = xml.EscapeText(w, []byte(a.Value))
+		w.WriteByte('"')
+	}
+
+	w.WriteByte('>')
+}
+
+// escapeText writes the character data s escaped. Unlike xml.EscapeText it
+// keeps line breaks, which lay out the packet.
+func escapeText(w *bytes.Buffer, s []byte) {
+	for _, c := range s {
+		switch c {
+		case '&':
+			w.WriteString("&amp;")
+		case '<':
+			w.WriteString("&lt;")
+		case '>':
+			w.WriteString("&gt;")
+		default:
+			w.WriteByte(c)
+		}
+	}
+}
+
+// qualifiedName returns name as it is written, with its prefix rather than
+// its namespace as tokens are read raw.
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
@@ -0,0 +1,155 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package focuspoints
+
+import (
+	"image"
+	"strconv"
+)
+
+// Sizes are in pixels for PNG and in user units for SVG.
+const (
+	padding     = 8  // around a single grid
+	pitch       = 14 // between the centres of neighbouring points in a row
+	rowPitch    = 22 // between the centres of neighbouring rows
+	pointW      = 8
+	pointH      = 16
+	stroke      = 2  // outline of inactive points
+	gridColumns = 11 // points in the widest row
+	gridRows    = 5
+
+	// montages
+	margin     = 16
+	gap        = 12
+	lineHeight = 16
+	ascent     = 11 // baseline below the top of a line
+	fontSize   = 11.5
+)
+
+type state int
+
+const (
+	stateInterior   state = iota // inactive, inside the grid
+	stateEdge                    // inactive, on the edge of the grid
+	stateActive                  // used to focus
+	stateUnrecorded              // the frame recorded no focusing point
+)
+
+// states gives the state of each point of the grid, row by row.
+func states(g Grid) [][]state {
+	rows := g.FocusPoints.Rows()
+	out := make([][]state, len(rows))
+
+	for r, row := range rows {
+		out[r] = make([]state, len(row))
+
+		for i, p := range row {
+			switch {
+			case !g.FocusPoints.Recorded:
+				out[r][i] = stateUnrecorded
+			case p.Active:
+				out[r][i] = stateActive
+			case p.Edge:
+				out[r][i] = stateEdge
+			default:
+				out[r][i] = stateInterior
+			}
+		}
+	}
+
+	return out
+}
+
+type point struct {
+	r     image.Rectangle
+	state state
+}
+
+// label is a line of text, positioned by the left end of its baseline.
+type label struct {
+	x, y int
+	s    string
+}
+
+// canvas is a laid out image, ready to be drawn.
+type canvas struct {
+	width, height int
+	points        []point
+	labels        []label
+}
+
+// gridSize is the size of a grid without padding.
+func gridSize() (int, int) {
+	return (gridColumns-1)*pitch + pointW, (gridRows-1)*rowPitch + pointH
+}
+
+// placeGrid adds the points of g to c with the grid's top left corner at.
+func (c *canvas) placeGrid(g Grid, at image.Point) {
+	for r, row := range states(g) {
+		// shorter rows are centred, so each is indented by half a pitch
+		// for every point it is short of the widest row
+		left := at.X + (gridColumns-len(row))*pitch/2 //nolint:mnd // half
+		top := at.Y + r*rowPitch
+
+		for i, s := range row {
+			at := image.Pt(left+i*pitch, top)
+			c.points = append(c.points, point{
+				r:     image.Rect(0, 0, pointW, pointH).Add(at),
+				state: s,
+			})
+		}
+	}
+}
+
+func frameCanvas(g Grid) canvas {
+	w, h := gridSize()
+	c := canvas{width: w + 2*padding, height: h + 2*padding}
+	c.placeGrid(g, image.Pt(padding, padding))
+
+	return c
+}
+
+func montageCanvas(grids []Grid, columns int) canvas {
+	columns = max(min(columns, len(grids)), 1)
+	rowCount := (len(grids) + columns - 1) / columns
+
+	w, h := gridSize()
+	cellW, cellH := w, lineHeight+h
+
+	c := canvas{
+		width:  2*margin + columns*cellW + (columns-1)*gap,
+		height: 2 * margin,
+	}
+
+	if rowCount > 0 {
+		c.height += rowCount*cellH + (rowCount-1)*gap
+	}
+
+	for i, g := range grids {
+		x := margin + i%columns*(cellW+gap)
+		y := margin + i/columns*(cellH+gap)
+
+		c.labels = append(c.labels, label{
+			x: x,
+			y: y + ascent,
+			s: strconv.FormatUint(uint64(g.FrameNumber), 10),
+		})
+		c.placeGrid(g, image.Pt(x, y+lineHeight))
+	}
+
+	return c
+}
@@ -0,0 +1,147 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package focuspoints
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// Size of a 35mm frame, in millimetres.
+const (
+	FrameWidth  = 36.0
+	FrameHeight = 24.0
+)
+
+// Position of the points on the frame, in millimetres. The grid is centred on
+// the frame; the spacing approximates the markings in the viewfinder.
+const (
+	pointPitchMM  = 1.9
+	rowPitchMM    = 2.0
+	pointWidthMM  = 0.9
+	pointHeightMM = 1.5
+
+	// outlines are a sixth as thick as a point is narrow, but always visible
+	strokeFraction = 6
+)
+
+//nolint:gochecknoglobals // fixed palette
+var (
+	overlayActiveFill    = color.NRGBA{0xD0, 0x10, 0x10, 0x99}
+	overlayActiveOutline = color.NRGBA{0xD0, 0x10, 0x10, 0xFF}
+	overlayInactive      = color.NRGBA{0xFF, 0xFF, 0xFF, 0xB0}
+)
+
+// Area is a rectangle on the frame, in millimetres from its top left corner
+// with the camera held in landscape orientation.
+type Area struct {
+	X, Y, Width, Height float64
+}
+
+// FullFrame is the area of the whole frame.
+func FullFrame() Area {
+	return Area{X: 0, Y: 0, Width: FrameWidth, Height: FrameHeight}
+}
+
+// Placement describes how a scan shows the frame.
+type Placement struct {
+	// Crop is the area of the frame the scan shows. It can reach past the frame
+	// for scans that include the film border.
+	Crop Area
+
+	// Rotate is how far the scan is turned clockwise from the camera's
+	// landscape orientation, in degrees. It must be a multiple of 90.
+	Rotate int
+}
+
+// validate checks the placement, returning it with the rotation between 0 and 270.
+func (p Placement) validate() (Placement, error) {
+	if p.Crop.Width <= 0 || p.Crop.Height <= 0 {
+		return p, fmt.Errorf("%w: crop of %gx%gmm",
+			ErrInvalidPlacement, p.Crop.Width, p.Crop.Height)
+	}
+
+	if p.Rotate%90 != 0 {
+		return p, fmt.Errorf("%w: rotation of %d degrees",
+			ErrInvalidPlacement, p.Rotate)
+	}
+
+	p.Rotate = (p.Rotate%360 + 360) % 360 //nolint:mnd // full turn
+
+	return p, nil
+}
+
+// toScan maps a point on the frame, in millimetres, to a point on the scan.
+func (p Placement) toScan(x, y float64, b image.Rectangle) image.Point {
+	u := (x - p.Crop.X) / p.Crop.Width
+	v := (y - p.Crop.Y) / p.Crop.Height
+
+	switch p.Rotate {
+	case 90: //nolint:mnd // quarter turn
+		u, v = 1-v, u
+	case 180: //nolint:mnd // half turn
+		u, v = 1-u, 1-v
+	case 270: //nolint:mnd // three quarter turn
+		u, v = v, 1-u
+	}
+
+	return image.Pt(
+		b.Min.X+int(math.Round(u*float64(b.Dx()))),
+		b.Min.Y+int(math.Round(v*float64(b.Dy()))),
+	)
+}
+
+// overlay draws the points of g on a copy of scan.
+func overlay(scan image.Image, g Grid, p Placement) *image.RGBA {
+	b := scan.Bounds()
+	img := image.NewRGBA(b)
+	draw.Draw(img, b, scan, b.Min, draw.Src)
+
+	grid := states(g)
+	centreRow := float64(len(grid)-1) / 2 //nolint:mnd // half
+
+	for r, row := range grid {
+		centre := float64(len(row)-1) / 2 //nolint:mnd // half
+
+		for i, s := range row {
+			x := FrameWidth/2 + (float64(i)-centre)*pointPitchMM
+			y := FrameHeight/2 + (float64(r)-centreRow)*rowPitchMM
+
+			pt := image.Rectangle{
+				Min: p.toScan(x-pointWidthMM/2, y-pointHeightMM/2, b),
+				Max: p.toScan(x+pointWidthMM/2, y+pointHeightMM/2, b),
+			}.Canon()
+
+			stroke := max(min(pt.Dx(), pt.Dy())/strokeFraction, 1)
+
+			if s == stateActive {
+				draw.Draw(img, pt, image.NewUniform(overlayActiveFill),
+					image.Point{}, draw.Over)
+				drawOutline(img, pt, stroke, overlayActiveOutline, draw.Over)
+
+				continue
+			}
+
+			drawOutline(img, pt, stroke, overlayInactive, draw.Over)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,105 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package focuspoints
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+//nolint:gochecknoglobals // fixed palette
+var (
+	activeColor     = color.RGBA{0xD0, 0x10, 0x10, 0xFF}
+	interiorColor   = color.RGBA{0x40, 0x40, 0x40, 0xFF}
+	unrecordedColor = color.RGBA{0xC0, 0xC0, 0xC0, 0xFF}
+)
+
+// colorOf returns the colour of a point, and whether it is filled or outlined.
+func colorOf(s state) (color.RGBA, bool) {
+	switch s {
+	case stateActive:
+		return activeColor, true
+	case stateEdge:
+		return activeColor, false
+	case stateUnrecorded:
+		return unrecordedColor, false
+	case stateInterior:
+		return interiorColor, false
+	}
+
+	return interiorColor, false
+}
+
+func renderPNG(w io.Writer, c canvas) error {
+	img := image.NewRGBA(image.Rect(0, 0, c.width, c.height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for _, p := range c.points {
+		col, filled := colorOf(p.state)
+		src := image.NewUniform(col)
+
+		if filled {
+			draw.Draw(img, p.r, src, image.Point{}, draw.Src)
+
+			continue
+		}
+
+		drawOutline(img, p.r, stroke, col, draw.Src)
+	}
+
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.Black,
+		Face: basicfont.Face7x13,
+		Dot:  fixed.Point26_6{},
+	}
+
+	for _, l := range c.labels {
+		d.Dot = fixed.P(l.x, l.y)
+		d.DrawString(l.s)
+	}
+
+	return png.Encode(w, img) //nolint:wrapcheck // wrapped by caller
+}
+
+// drawOutline draws the edge of r, stroke pixels thick, inside r.
+func drawOutline(
+	img draw.Image,
+	r image.Rectangle,
+	stroke int,
+	c color.Color,
+	op draw.Op,
+) {
+	src := image.NewUniform(c)
+	inner := r.Inset(stroke)
+
+	for _, side := range []image.Rectangle{
+		image.Rect(r.Min.X, r.Min.Y, r.Max.X, inner.Min.Y),
+		image.Rect(r.Min.X, inner.Max.Y, r.Max.X, r.Max.Y),
+		image.Rect(r.Min.X, inner.Min.Y, inner.Min.X, inner.Max.Y),
+		image.Rect(inner.Max.X, inner.Min.Y, r.Max.X, inner.Max.Y),
+	} {
+		draw.Draw(img, side, src, image.Point{}, op)
+	}
+}
@@ -0,0 +1,187 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=focuspoints_test github.com/ma-tf/meta1v/internal/service/focuspoints Service
+
+// Package focuspoints renders the 45-point autofocus grid of frames as images.
+//
+// Points are drawn the way the terminal view shows them: active points filled
+// red, inactive points on the edge of the grid outlined red and inactive
+// interior points outlined grey. A frame that recorded no focusing point is
+// drawn entirely in light grey.
+//
+// Points can also be drawn over a scan of the frame, placed where they sat in
+// the viewfinder.
+package focuspoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+)
+
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported focus point image format")
+	ErrFailedToRender    = errors.New("failed to render focus points")
+	ErrInvalidPlacement  = errors.New("invalid placement of scan on frame")
+)
+
+// Grid is the focus point grid of a frame.
+type Grid struct {
+	FrameNumber uint32
+	FocusPoints domain.FocusPoints
+}
+
+// NewGrid decodes the focus point data of a frame record.
+func NewGrid(efrm records.EFRM) Grid {
+	fp := domain.NewFocusPoints(
+		efrm.FocusingPoint,
+		[8]byte{
+			efrm.FocusPoints1,
+			efrm.FocusPoints2,
+			efrm.FocusPoints3,
+			efrm.FocusPoints4,
+			efrm.FocusPoints5,
+			efrm.FocusPoints6,
+			efrm.FocusPoints7,
+			efrm.FocusPoints8,
+		},
+	)
+
+	return Grid{
+		FrameNumber: efrm.FrameNumber,
+		FocusPoints: fp,
+	}
+}
+
+// Service renders focus point grids.
+type Service interface {
+	// RenderFrame writes the grid of a single frame to w as a FormatPNG or
+	// FormatSVG image.
+	RenderFrame(
+		ctx context.Context,
+		w io.Writer,
+		grid Grid,
+		format string,
+	) error
+
+	// RenderMontage writes the grids of many frames to w as a single image,
+	// in rows of columns grids, each labelled with its frame number.
+	RenderMontage(
+		ctx context.Context,
+		w io.Writer,
+		grids []Grid,
+		columns int,
+		format string,
+	) error
+
+	// Overlay draws the grid over a scan of the frame, returning a new image.
+	// Active points are filled red and the others outlined in white, at their
+	// position in the viewfinder as given by placement.
+	Overlay(
+		ctx context.Context,
+		scan image.Image,
+		grid Grid,
+		placement Placement,
+	) (*image.RGBA, error)
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+func (s *service) RenderFrame(
+	ctx context.Context,
+	w io.Writer,
+	grid Grid,
+	format string,
+) error {
+	s.log.DebugContext(ctx, "rendering focus points",
+		slog.String("format", format),
+		slog.Uint64("frame", uint64(grid.FrameNumber)))
+
+	return render(w, frameCanvas(grid), format)
+}
+
+func (s *service) RenderMontage(
+	ctx context.Context,
+	w io.Writer,
+	grids []Grid,
+	columns int,
+	format string,
+) error {
+	s.log.InfoContext(ctx, "rendering focus point montage",
+		slog.String("format", format),
+		slog.Int("frames", len(grids)),
+		slog.Int("columns", columns))
+
+	return render(w, montageCanvas(grids, columns), format)
+}
+
+func (s *service) Overlay(
+	ctx context.Context,
+	scan image.Image,
+	grid Grid,
+	placement Placement,
+) (*image.RGBA, error) {
+	placement, err := placement.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.DebugContext(ctx, "overlaying focus points",
+		slog.Uint64("frame", uint64(grid.FrameNumber)),
+		slog.Any("crop", placement.Crop),
+		slog.Int("rotate", placement.Rotate))
+
+	return overlay(scan, grid, placement), nil
+}
+
+func render(w io.Writer, c canvas, format string) error {
+	var err error
+
+	switch format {
+	case FormatPNG:
+		err = renderPNG(w, c)
+	case FormatSVG:
+		err = renderSVG(w, c)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	if err != nil {
+		return errors.Join(ErrFailedToRender, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,451 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package focuspoints_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/focuspoints"
+)
+
+var errExample = errors.New("example error")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errExample }
+
+//nolint:gochecknoglobals // shared test colours
+var (
+	white = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	red   = color.RGBA{0xD0, 0x10, 0x10, 0xFF}
+	grey  = color.RGBA{0x40, 0x40, 0x40, 0xFF}
+	light = color.RGBA{0xC0, 0xC0, 0xC0, 0xFF}
+)
+
+// newGrid decodes a grid with the given focus point bytes, the first of them
+// first.
+func newGrid(t *testing.T, selection uint32, points ...byte) focuspoints.Grid {
+	t.Helper()
+
+	var raw [8]byte
+	copy(raw[:], points)
+
+	return focuspoints.Grid{
+		FrameNumber: 0,
+		FocusPoints: domain.NewFocusPoints(selection, raw),
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_NewGrid(t *testing.T) {
+	t.Parallel()
+
+	got := focuspoints.NewGrid(records.EFRM{
+		FrameNumber:   3,
+		FocusingPoint: 0,
+		FocusPoints1:  0b10000000,
+		FocusPoints4:  0b00100000,
+		FocusPoints8:  0b00000010,
+	})
+
+	if got.FrameNumber != 3 {
+		t.Errorf("expected frame number 3, got %d", got.FrameNumber)
+	}
+
+	var numbers []int
+	for _, p := range got.FocusPoints.Active() {
+		numbers = append(numbers, p.Number)
+	}
+
+	// bits count from the most significant, and rows 1 to 3 start with the
+	// 8 bits of their left segment
+	if want := []int{1, 28, 45}; !slices.Equal(numbers, want) {
+		t.Errorf("expected active points %v, got %v", want, numbers)
+	}
+
+	unknown := focuspoints.NewGrid(records.EFRM{FocusingPoint: 99})
+	if !unknown.FocusPoints.Recorded || unknown.FocusPoints.Automatic {
+		t.Errorf("expected an unknown selection, got %+v", unknown.FocusPoints)
+	}
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) image.Image {
+	t.Helper()
+
+	img, err := png.Decode(buf)
+	if err != nil {
+		t.Fatalf("failed to decode image: %v", err)
+	}
+
+	return img
+}
+
+// Test_RenderFrame_Points checks points are drawn the way the terminal view
+// shows them. Points are 8x16 at a pitch of 14 and rows 22 apart, inside 8 of
+// padding, with the 7 point top row indented by two pitches.
+//
+//nolint:funlen // table driven test
+func Test_RenderFrame_Points(t *testing.T) {
+	t.Parallel()
+
+	const (
+		top    = 8      // top of the first row
+		middle = 8 + 44 // top of the middle row
+		topX   = 8 + 28 // left of the first point of the top row
+		left   = 8      // left of the first point of the middle row
+	)
+
+	tests := []struct {
+		name     string
+		grid     focuspoints.Grid
+		at       image.Point
+		expected color.RGBA
+	}{
+		{
+			name:     "active point is filled",
+			grid:     newGrid(t, 0, 0b1000000),
+			at:       image.Pt(topX+14+4, top+8),
+			expected: red,
+		},
+		{
+			name:     "inactive top row point is outlined red",
+			grid:     newGrid(t, 0),
+			at:       image.Pt(topX, top+8),
+			expected: red,
+		},
+		{
+			name:     "outlined point is hollow",
+			grid:     newGrid(t, 0),
+			at:       image.Pt(topX+4, top+8),
+			expected: white,
+		},
+		{
+			name:     "inactive left edge point is outlined red",
+			grid:     newGrid(t, 0),
+			at:       image.Pt(left, middle+8),
+			expected: red,
+		},
+		{
+			name:     "inactive interior point is outlined grey",
+			grid:     newGrid(t, 0),
+			at:       image.Pt(left+14, middle+8),
+			expected: grey,
+		},
+		{
+			name:     "inactive right edge point is outlined red",
+			grid:     newGrid(t, 0),
+			at:       image.Pt(left+10*14, middle+8),
+			expected: red,
+		},
+		{
+			name:     "active middle row right point",
+			grid:     newGrid(t, 0, 0, 0, 0, 0b01000000),
+			at:       image.Pt(left+9*14+4, middle+8),
+			expected: red,
+		},
+		{
+			name:     "no recorded focusing point",
+			grid:     newGrid(t, math.MaxUint32, 0xFF),
+			at:       image.Pt(topX, top+8),
+			expected: light,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+
+			err := focuspoints.NewService(slog.New(slog.DiscardHandler)).
+				RenderFrame(t.Context(), buf, tt.grid, focuspoints.FormatPNG)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			img := decode(t, buf)
+			if got := img.Bounds().Size(); got != image.Pt(164, 120) {
+				t.Errorf("unexpected image size %v", got)
+			}
+
+			got := color.RGBAModel.Convert(img.At(tt.at.X, tt.at.Y))
+			if got != tt.expected {
+				t.Errorf("expected %v at %v, got %v", tt.expected, tt.at, got)
+			}
+		})
+	}
+}
+
+func Test_RenderFrame(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		w             io.Writer
+		format        string
+		contains      []string
+		expectedError error
+	}{
+		{
+			name:     "png",
+			w:        &bytes.Buffer{},
+			format:   focuspoints.FormatPNG,
+			contains: []string{"\x89PNG"},
+		},
+		{
+			name:   "svg",
+			w:      &bytes.Buffer{},
+			format: focuspoints.FormatSVG,
+			contains: []string{
+				`<svg xmlns="http://www.w3.org/2000/svg" width="164" height="120"`,
+				`fill="#d01010"`,
+				`stroke="#404040"`,
+				"</svg>",
+			},
+		},
+		{
+			name:          "unsupported format",
+			w:             &bytes.Buffer{},
+			format:        "pdf",
+			expectedError: focuspoints.ErrUnsupportedFormat,
+		},
+		{
+			name:          "write fails",
+			w:             failingWriter{},
+			format:        focuspoints.FormatSVG,
+			expectedError: focuspoints.ErrFailedToRender,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			grid := newGrid(t, 0, 0, 0, 0, 0, 0b1000)
+
+			err := focuspoints.NewService(slog.New(slog.DiscardHandler)).
+				RenderFrame(t.Context(), tt.w, grid, tt.format)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if tt.expectedError != nil {
+				return
+			}
+
+			//nolint:forcetypeassert // test writer
+			out := tt.w.(*bytes.Buffer).String()
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected output to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func Test_RenderMontage(t *testing.T) {
+	t.Parallel()
+
+	grids := []focuspoints.Grid{
+		{FrameNumber: 1},
+		{FrameNumber: 2},
+		{FrameNumber: 12},
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := focuspoints.NewService(slog.New(slog.DiscardHandler)).
+		RenderMontage(t.Context(), buf, grids, 2, focuspoints.FormatSVG)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// two columns of 148x104 grids, each with a 16 line label above, 12 apart
+	// inside a 16 margin
+	for _, want := range []string{
+		`width="340" height="284"`,
+		`<text x="16" y="27" font-family="monospace" font-size="11.5">1</text>`,
+		`<text x="176" y="27" font-family="monospace" font-size="11.5">2</text>`,
+		`<text x="16" y="159" font-family="monospace" font-size="11.5">12</text>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected montage to contain %q, got:\n%s",
+				want, buf.String())
+		}
+	}
+
+	if got := strings.Count(buf.String(), "<rect "); got != 1+3*45 {
+		t.Errorf("expected 3 grids of 45 points, got %d rects", got-1)
+	}
+}
+
+// Test_Overlay checks points land where they sat on the frame. On a 360x240
+// scan of the full frame there are 10 pixels to the millimetre, and the first
+// point of the top row is centred at 12.3mm by 8mm.
+//
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Overlay(t *testing.T) {
+	t.Parallel()
+
+	active := newGrid(t, 0, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+	full := focuspoints.FullFrame()
+
+	tests := []struct {
+		name      string
+		scan      image.Rectangle
+		grid      focuspoints.Grid
+		placement focuspoints.Placement
+		at        image.Point
+		expected  string
+		err       error
+	}{
+		{
+			name:      "active point is filled",
+			scan:      image.Rect(0, 0, 360, 240),
+			grid:      active,
+			placement: focuspoints.Placement{Crop: full},
+			at:        image.Pt(123, 80),
+			expected:  "red",
+		},
+		{
+			name:      "inactive point is hollow",
+			scan:      image.Rect(0, 0, 360, 240),
+			grid:      newGrid(t, 0),
+			placement: focuspoints.Placement{Crop: full},
+			at:        image.Pt(123, 80),
+			expected:  "black",
+		},
+		{
+			name:      "inactive point is outlined",
+			scan:      image.Rect(0, 0, 360, 240),
+			grid:      newGrid(t, 0),
+			placement: focuspoints.Placement{Crop: full},
+			at:        image.Pt(123, 73),
+			expected:  "white",
+		},
+		{
+			name:      "outside the grid is untouched",
+			scan:      image.Rect(0, 0, 360, 240),
+			grid:      active,
+			placement: focuspoints.Placement{Crop: full},
+			at:        image.Pt(180, 30),
+			expected:  "black",
+		},
+		{
+			name: "cropped scan",
+			scan: image.Rect(0, 0, 360, 240),
+			grid: active,
+			placement: focuspoints.Placement{
+				Crop: focuspoints.Area{X: 9, Y: 6, Width: 18, Height: 12},
+			},
+			at:       image.Pt(66, 40),
+			expected: "red",
+		},
+		{
+			name:      "scan turned clockwise",
+			scan:      image.Rect(0, 0, 240, 360),
+			grid:      active,
+			placement: focuspoints.Placement{Crop: full, Rotate: 90},
+			at:        image.Pt(160, 123),
+			expected:  "red",
+		},
+		{
+			name:      "scan upside down",
+			scan:      image.Rect(0, 0, 360, 240),
+			grid:      active,
+			placement: focuspoints.Placement{Crop: full, Rotate: -180},
+			at:        image.Pt(237, 160),
+			expected:  "red",
+		},
+		{
+			name:      "scan turned anticlockwise",
+			scan:      image.Rect(0, 0, 240, 360),
+			grid:      active,
+			placement: focuspoints.Placement{Crop: full, Rotate: 270},
+			at:        image.Pt(80, 237),
+			expected:  "red",
+		},
+		{
+			name:      "rotation not a quarter turn",
+			scan:      image.Rect(0, 0, 360, 240),
+			placement: focuspoints.Placement{Crop: full, Rotate: 45},
+			err:       focuspoints.ErrInvalidPlacement,
+		},
+		{
+			name:      "empty crop",
+			scan:      image.Rect(0, 0, 360, 240),
+			placement: focuspoints.Placement{},
+			err:       focuspoints.ErrInvalidPlacement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scan := image.NewRGBA(tt.scan)
+			draw.Draw(scan, tt.scan, image.Black, image.Point{}, draw.Src)
+
+			img, err := focuspoints.NewService(slog.New(slog.DiscardHandler)).
+				Overlay(t.Context(), scan, tt.grid, tt.placement)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+
+			if tt.err != nil {
+				return
+			}
+
+			if img.Bounds() != tt.scan {
+				t.Errorf("expected bounds %v, got %v", tt.scan, img.Bounds())
+			}
+
+			if got := shade(img.RGBAAt(tt.at.X, tt.at.Y)); got != tt.expected {
+				t.Errorf("expected %s at %v, got %s (%v)",
+					tt.expected, tt.at, got, img.RGBAAt(tt.at.X, tt.at.Y))
+			}
+		})
+	}
+}
+
+// shade names the colour of an overlay pixel drawn on a black scan.
+func shade(c color.RGBA) string {
+	switch {
+	case c.R == 0 && c.G == 0 && c.B == 0:
+		return "black"
+	case c.R > 0x40 && c.G < 0x20 && c.B < 0x20:
+		return "red"
+	case c.R > 0x80 && c.R == c.G && c.G == c.B:
+		return "white"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,74 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package focuspoints
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+func renderSVG(w io.Writer, c canvas) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">
+<rect width="100%%" height="100%%" fill="#ffffff"/>
+`, c.width, c.height, c.width, c.height)
+
+	for _, p := range c.points {
+		col, filled := colorOf(p.state)
+
+		if filled {
+			fmt.Fprintf(bw,
+				`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+				p.r.Min.X, p.r.Min.Y, p.r.Dx(), p.r.Dy(), hex(col))
+
+			continue
+		}
+
+		// strokes are centred on the outline, so it is inset by half a stroke
+		// to stay within the point as it does in PNG
+		fmt.Fprintf(bw,
+			`<rect x="%g" y="%g" width="%d" height="%d" fill="none" `+
+				`stroke="%s" stroke-width="%d"/>`+"\n",
+			float64(p.r.Min.X)+stroke/2.0, float64(p.r.Min.Y)+stroke/2.0,
+			p.r.Dx()-stroke, p.r.Dy()-stroke, hex(col), stroke)
+	}
+
+	for _, l := range c.labels {
+		fmt.Fprintf(bw,
+			`<text x="%d" y="%d" font-family="monospace" font-size="%g">`,
+			l.x, l.y, fontSize)
+
+		if err := xml.EscapeText(bw, []byte(l.s)); err != nil {
+			return err //nolint:wrapcheck // wrapped by caller
+		}
+
+		fmt.Fprint(bw, "</text>\n")
+	}
+
+	fmt.Fprint(bw, "</svg>\n")
+
+	return bw.Flush() //nolint:wrapcheck // wrapped by caller
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,82 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package framefilter
+
+import (
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+// aliases are shorter names for some of the fields.
+//
+//nolint:gochecknoglobals // fixed aliases of filter fields
+var aliases = map[string]string{
+	"flash":   "flashMode",
+	"iso":     "isoM",
+	"advance": "filmAdvanceMode",
+}
+
+// fields are the frame columns, which filters compare as numbers, text or bools
+// according to their kind. They are held by pointer so that filters parsed from
+// the same expression compare equal.
+//
+//nolint:gochecknoglobals // built once from the frame column registry
+var fields = newFields()
+
+func newFields() []*display.FrameColumn {
+	names := display.FrameColumnNames()
+	fields := make([]*display.FrameColumn, 0, len(names))
+
+	for _, name := range names {
+		c, _ := display.LookupFrameColumn(name)
+		fields = append(fields, &c)
+	}
+
+	return fields
+}
+
+// lookupField returns the field with the given name or alias, matched
+// regardless of case.
+func lookupField(name string) (*display.FrameColumn, bool) {
+	if alias, ok := lookupAlias(name); ok {
+		name = alias
+	}
+
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+
+	return nil, false
+}
+
+func lookupAlias(name string) (string, bool) {
+	for alias, target := range aliases {
+		if strings.EqualFold(alias, name) {
+			return target, true
+		}
+	}
+
+	return "", false
+}
+
+// FieldNames returns the names of all fields, without their aliases.
+func FieldNames() []string {
+	return display.FrameColumnNames()
+}
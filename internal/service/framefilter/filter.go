@@ -0,0 +1,97 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package framefilter selects frames with small filter expressions such as
+// `av <= 2.8 && flash != "OFF"`.
+//
+// An expression compares fields of a decoded frame with values, joined with
+// && and ||, negated with ! and grouped with parentheses. Numeric fields such
+// as tv, av, ec and focalLength compare by number and take values the way they
+// are displayed, e.g. 1/250, f/2.8 or 50mm. Other fields compare as text,
+// regardless of case. A comparison with a value the camera did not record is
+// false, whatever the operator.
+package framefilter
+
+import (
+	"errors"
+
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+var (
+	ErrInvalidExpression = errors.New("invalid filter expression")
+	ErrUnknownField      = errors.New("unknown filter field")
+	ErrInvalidValue      = errors.New("invalid filter value")
+)
+
+// Filter is a parsed filter expression. The zero Filter matches every frame.
+type Filter struct {
+	expr string
+	root node
+}
+
+// Parse parses a filter expression. An empty expression gives the zero Filter.
+func Parse(expr string) (Filter, error) {
+	p := &parser{tokens: nil, pos: 0}
+	if err := p.lex(expr); err != nil {
+		return Filter{}, err
+	}
+
+	if len(p.tokens) == 0 {
+		return Filter{}, nil
+	}
+
+	root, err := p.parse()
+	if err != nil {
+		return Filter{}, err
+	}
+
+	return Filter{expr: expr, root: root}, nil
+}
+
+// String returns the expression the filter was parsed from.
+func (f Filter) String() string {
+	return f.expr
+}
+
+// IsZero reports whether the filter matches every frame without looking at it.
+func (f Filter) IsZero() bool {
+	return f.root == nil
+}
+
+// Match reports whether fr matches the filter.
+func (f Filter) Match(fr display.DisplayableFrame) bool {
+	return f.root == nil || f.root.match(fr)
+}
+
+// Apply returns r with only the frames that match the filter.
+func (f Filter) Apply(r display.DisplayableRoll) display.DisplayableRoll {
+	if f.root == nil {
+		return r
+	}
+
+	frames := make([]display.DisplayableFrame, 0, len(r.Frames))
+
+	for _, fr := range r.Frames {
+		if f.root.match(fr) {
+			frames = append(frames, fr)
+		}
+	}
+
+	r.Frames = frames
+
+	return r
+}
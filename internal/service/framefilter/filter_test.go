@@ -0,0 +1,189 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package framefilter_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/service/display"
+	"github.com/ma-tf/meta1v/internal/service/framefilter"
+)
+
+//nolint:exhaustruct // only partial is needed
+func newFrames() []display.DisplayableFrame {
+	return []display.DisplayableFrame{
+		{
+			FrameNumber:          1,
+			FocalLength:          "50",
+			Tv:                   "1/250",
+			Av:                   "f/2.8",
+			IsoM:                 "400",
+			ExposureCompensation: "+1.0",
+			FlashMode:            "OFF",
+			ShootingMode:         "Aperture-priority AE",
+			TakenAt:              "2024-05-01 17:45:00",
+			Remarks:              "Harbour",
+		},
+		{
+			FrameNumber:          2,
+			FocalLength:          "35",
+			Tv:                   `2"`,
+			Av:                   "f/8",
+			IsoM:                 "400",
+			ExposureCompensation: "-0.5",
+			FlashMode:            "E-TTL",
+			ShootingMode:         "Manual exposure",
+			TakenAt:              "2024-05-01 18:30:00",
+			UserModifiedRecord:   true,
+		},
+		{
+			FrameNumber: 3,
+			Tv:          "Bulb",
+			FlashMode:   "OFF",
+		},
+	}
+}
+
+//nolint:funlen // table driven test
+func Test_Match(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected []uint
+	}{
+		{"empty expression", "", []uint{1, 2, 3}},
+		{"wide apertures", "av <= 2.8", []uint{1}},
+		{"apertures as displayed", "av > f/2.8", []uint{2}},
+		{"flash frames", `flash != "OFF"`, []uint{2}},
+		{"mode regardless of case", "flashMode == e-ttl", []uint{2}},
+		{"fast shutter speeds", "tv <= 1/125", []uint{1}},
+		{"slow shutter speeds", `tv >= 1`, []uint{2}},
+		{"exposure compensation", "ec < 0", []uint{2}},
+		{"focal length", "focalLength >= 50mm", []uint{1}},
+		{"iso alias", "iso == 400", []uint{1, 2}},
+		{"time of day", "time >= 18:00", []uint{2}},
+		{"date", "date == 2024-05-01", []uint{1, 2}},
+		{"quoted date and time", "takenAt < '2024-05-01 18:00'", []uint{1}},
+		{"frame number", "frame > 1", []uint{2, 3}},
+		{"modified", "modified == true", []uint{2}},
+		{"and", "av <= 2.8 && flash == OFF", []uint{1}},
+		{"or", "frame == 1 || frame == 3", []uint{1, 3}},
+		{"and binds tighter than or", "frame == 3 || av > 2 && ec < 0",
+			[]uint{2, 3}},
+		{"parentheses", "(frame == 3 || av > 2) && ec < 0", []uint{2}},
+		{"not", "!(flash == OFF)", []uint{2}},
+		{"no spaces", "av<=2.8&&flash!=E-TTL", []uint{1}},
+		{"unrecorded values never match", "remarks != Harbour", []uint{}},
+		{"exposure value", "ev > 10", []uint{1}},
+	}
+
+	frames := newFrames()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := framefilter.Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := []uint{}
+
+			for _, fr := range frames {
+				if f.Match(fr) {
+					got = append(got, fr.FrameNumber)
+				}
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expected frames %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_Parse_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected error
+	}{
+		{"unknown field", "aperture < 4", framefilter.ErrUnknownField},
+		{"not a number", "av <= wide", framefilter.ErrInvalidValue},
+		{"not a bool", "modified == yes", framefilter.ErrInvalidValue},
+		{"ordered bool", "modified < true", framefilter.ErrInvalidExpression},
+		{"missing value", "av <=", framefilter.ErrInvalidExpression},
+		{"missing operator", "av 2.8", framefilter.ErrInvalidExpression},
+		{"single equals", "av = 2.8", framefilter.ErrInvalidExpression},
+		{"single ampersand", "av < 4 & ec > 0",
+			framefilter.ErrInvalidExpression},
+		{"unclosed parenthesis", "(av < 4", framefilter.ErrInvalidExpression},
+		{"trailing token", "av < 4)", framefilter.ErrInvalidExpression},
+		{"unterminated string", `flash == "OFF`,
+			framefilter.ErrInvalidExpression},
+		{"dangling and", "av < 4 &&", framefilter.ErrInvalidExpression},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := framefilter.Parse(tt.expr)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected error %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func Test_Apply(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // only partial is needed
+	roll := display.DisplayableRoll{FilmID: "12-345", Frames: newFrames()}
+
+	f, err := framefilter.Parse("flash == OFF")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := f.Apply(roll)
+	if got.FilmID != roll.FilmID || len(got.Frames) != 2 ||
+		got.Frames[0].FrameNumber != 1 || got.Frames[1].FrameNumber != 3 {
+		t.Errorf("unexpected roll %+v", got)
+	}
+
+	if len(roll.Frames) != 3 {
+		t.Error("expected the original roll to be left as it was")
+	}
+
+	if f.String() != "flash == OFF" || f.IsZero() {
+		t.Errorf("unexpected filter %q", f.String())
+	}
+
+	if zero := (framefilter.Filter{}); !zero.IsZero() ||
+		len(zero.Apply(roll).Frames) != 3 {
+		t.Error("expected the zero filter to keep every frame")
+	}
+}
@@ -0,0 +1,365 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package framefilter
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // 1-based position in the expression
+}
+
+// parser is a recursive descent parser for the grammar
+//
+//	expr       = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" expr ")" | comparison
+//	comparison = field ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) value
+//
+// where a value is a bare word such as 1/250 or 18:00, or a quoted string.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// lex splits expr into tokens.
+//
+//nolint:cyclop,funlen // one case for each kind of token
+func (p *parser) lex(expr string) error {
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		two := expr[i:min(i+2, len(expr))]
+
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(':
+			p.tokens = append(p.tokens,
+				token{kind: tokenOpen, text: string(c), pos: i + 1})
+			i++
+		case c == ')':
+			p.tokens = append(p.tokens,
+				token{kind: tokenClose, text: string(c), pos: i + 1})
+			i++
+		case two == "&&" || two == "||":
+			kind := tokenAnd
+			if two == "||" {
+				kind = tokenOr
+			}
+
+			p.tokens = append(p.tokens, token{kind: kind, text: two, pos: i + 1})
+			i += 2
+		case two == "==" || two == "!=" || two == "<=" || two == ">=":
+			p.tokens = append(p.tokens,
+				token{kind: tokenOperator, text: two, pos: i + 1})
+			i += 2
+		case c == '<' || c == '>':
+			p.tokens = append(p.tokens,
+				token{kind: tokenOperator, text: string(c), pos: i + 1})
+			i++
+		case c == '!':
+			p.tokens = append(p.tokens,
+				token{kind: tokenNot, text: string(c), pos: i + 1})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end == -1 {
+				return fmt.Errorf("%w: unterminated string at position %d",
+					ErrInvalidExpression, i+1)
+			}
+
+			p.tokens = append(p.tokens, token{
+				kind: tokenString,
+				text: expr[i+1 : i+1+end],
+				pos:  i + 1,
+			})
+			i += end + 2
+		case strings.IndexByte("=&|", c) != -1:
+			return fmt.Errorf("%w: unexpected %q at position %d",
+				ErrInvalidExpression, c, i+1)
+		default:
+			start := i
+			for i < len(expr) && !unicode.IsSpace(rune(expr[i])) &&
+				strings.IndexByte(`()!<>=&|"'`, expr[i]) == -1 {
+				i++
+			}
+
+			p.tokens = append(p.tokens, token{
+				kind: tokenWord,
+				text: expr[start:i],
+				pos:  start + 1,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) parse() (node, error) {
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, unexpected(p.tokens[p.pos])
+	}
+
+	return n, nil
+}
+
+func (p *parser) next() (token, error) {
+	if p.pos == len(p.tokens) {
+		return token{}, fmt.Errorf("%w: unexpected end of expression",
+			ErrInvalidExpression)
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+
+	return t, nil
+}
+
+func (p *parser) accept(kind tokenKind) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokenOr) {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokenAnd) {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.kind {
+	case tokenNot:
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{n: n}, nil
+	case tokenOpen:
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.accept(tokenClose) {
+			return nil, fmt.Errorf("%w: missing ) for ( at position %d",
+				ErrInvalidExpression, t.pos)
+		}
+
+		return n, nil
+	case tokenWord:
+		return p.comparison(t)
+	case tokenString, tokenOperator, tokenAnd, tokenOr, tokenClose:
+		return nil, unexpected(t)
+	}
+
+	return nil, unexpected(t)
+}
+
+func (p *parser) comparison(name token) (node, error) {
+	f, ok := lookupField(name.text)
+	if !ok {
+		return nil, fmt.Errorf("%w %q (fields: %s)",
+			ErrUnknownField, name.text, strings.Join(FieldNames(), ", "))
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if op.kind != tokenOperator {
+		return nil, unexpected(op)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, unexpected(value)
+	}
+
+	c := comparison{field: f, op: op.text, number: 0, text: value.text}
+
+	switch f.Kind {
+	case display.ColumnNumber:
+		if c.number, ok = f.ParseNumber(value.text); !ok {
+			return nil, fmt.Errorf("%w %q for %s",
+				ErrInvalidValue, value.text, f.Name)
+		}
+	case display.ColumnBool:
+		b, err := strconv.ParseBool(value.text)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q for %s",
+				ErrInvalidValue, value.text, f.Name)
+		}
+
+		if op.text != "==" && op.text != "!=" {
+			return nil, fmt.Errorf("%w: %s only compares with == and !=",
+				ErrInvalidExpression, f.Name)
+		}
+
+		c.text = strconv.FormatBool(b)
+	case display.ColumnText:
+	}
+
+	return c, nil
+}
+
+func unexpected(t token) error {
+	return fmt.Errorf("%w: unexpected %q at position %d",
+		ErrInvalidExpression, t.text, t.pos)
+}
+
+type node interface {
+	match(fr display.DisplayableFrame) bool
+}
+
+type comparison struct {
+	field  *display.FrameColumn
+	op     string
+	number float64 // value of numeric fields
+	text   string  // value of text and bool fields
+}
+
+func (c comparison) match(fr display.DisplayableFrame) bool {
+	var order int
+
+	if c.field.Kind == display.ColumnNumber {
+		v, ok := c.field.Number(fr)
+		if !ok {
+			return false
+		}
+
+		order = cmp.Compare(v, c.number)
+	} else {
+		v := c.field.Value(fr)
+		if v == "" {
+			return false
+		}
+
+		order = strings.Compare(strings.ToLower(v), strings.ToLower(c.text))
+	}
+
+	switch c.op {
+	case "==":
+		return order == 0
+	case "!=":
+		return order != 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	default:
+		return order >= 0
+	}
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) match(fr display.DisplayableFrame) bool {
+	return n.left.match(fr) && n.right.match(fr)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) match(fr display.DisplayableFrame) bool {
+	return n.left.match(fr) || n.right.match(fr)
+}
+
+type notNode struct {
+	n node
+}
+
+func (n notNode) match(fr display.DisplayableFrame) bool {
+	return !n.n.match(fr)
+}
@@ -0,0 +1,281 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=inspect_test github.com/ma-tf/meta1v/internal/service/inspect Service
+
+// Package inspect provides a structural hex dump of EFD files.
+//
+// It lays the raw bytes of every record out field by field, using the binary
+// layout of the records package, so the file can be examined at byte level
+// even when it is damaged or contains records the parser rejects.
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+)
+
+const (
+	bytesPerLine  = 16
+	sizeWidth     = 5
+	nameWidth     = 25
+	hexWidth      = bytesPerLine*3 - 1
+	unknownMarker = "*"
+
+	pixelsField   = "Pixels"
+	dataField     = "Data"
+	trailingField = "Trailing"
+
+	// unreadable regions can be arbitrarily large, so only their start is shown
+	maxUnreadableBytes = 256
+)
+
+// Field is a field of a record as it was found in a file.
+type Field struct {
+	Name    string
+	Offset  int64  // byte offset from the start of the file
+	Size    int    // size the record layout gives the field
+	Bytes   []byte // the field as stored, shorter than Size if the record is cut short
+	Value   string // decoded value, empty if there is nothing meaningful to decode
+	Unknown bool   // the purpose of the field has not been worked out yet
+}
+
+// Service writes structural hex dumps of EFD files.
+type Service interface {
+	// Dump writes every region of a file in order. Records are broken into their
+	// fields, each line showing offset, size, raw hex and decoded value, with unknown
+	// fields marked. Regions that could not be framed are dumped as raw bytes.
+	// Thumbnail pixel data is only dumped in full if pixels is set.
+	Dump(ctx context.Context, w io.Writer, regions []efd.Region, pixels bool)
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+func (s *service) Dump(
+	ctx context.Context,
+	w io.Writer,
+	regions []efd.Region,
+	pixels bool,
+) {
+	s.log.InfoContext(ctx, "formatting hex dump",
+		slog.Int("regions", len(regions)))
+
+	header := fmt.Sprintf("  %-10s %*s %-*s %-*s %s",
+		"OFFSET",
+		sizeWidth, "SIZE",
+		nameWidth, "FIELD",
+		hexWidth, "HEX",
+		"VALUE",
+	)
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, strings.Repeat("-", len(header)))
+
+	for i, region := range regions {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		s.dumpRegion(w, region, pixels)
+	}
+
+	fmt.Fprintf(w, "\n%s unknown or unreadable bytes\n", unknownMarker)
+
+	s.log.DebugContext(ctx, "hex dump formatted")
+}
+
+func (s *service) dumpRegion(w io.Writer, region efd.Region, pixels bool) {
+	if region.Reason != nil {
+		fmt.Fprintf(w, "unreadable region at 0x%08x, %d bytes: %v\n",
+			region.Offset, len(region.Bytes), region.Reason)
+
+		shown := region.Bytes[:min(len(region.Bytes), maxUnreadableBytes)]
+		writeHexLines(w, unknownMarker, region.Offset, shown)
+
+		if elided := len(region.Bytes) - len(shown); elided > 0 {
+			fmt.Fprintf(w, "%s %*s ... %d more bytes\n",
+				unknownMarker, 10, "", elided)
+		}
+
+		return
+	}
+
+	fmt.Fprintf(w, "%s record at 0x%08x, %d bytes\n",
+		region.Raw.Magic[:], region.Offset, len(region.Bytes))
+
+	for _, f := range Fields(region) {
+		writeField(w, f, pixels || f.Name != pixelsField)
+	}
+}
+
+func writeField(w io.Writer, f Field, full bool) {
+	marker := " "
+	if f.Unknown {
+		marker = unknownMarker
+	}
+
+	shown := f.Bytes
+	if !full {
+		shown = shown[:min(len(shown), bytesPerLine)]
+	}
+
+	first := shown[:min(len(shown), bytesPerLine)]
+	fmt.Fprintf(w, "%s 0x%08x %*d %-*s %-*s %s\n",
+		marker,
+		f.Offset,
+		sizeWidth, f.Size,
+		nameWidth, f.Name,
+		hexWidth, hexBytes(first),
+		f.Value,
+	)
+
+	if len(shown) > bytesPerLine {
+		writeHexLines(w, marker, f.Offset+bytesPerLine, shown[bytesPerLine:])
+	}
+}
+
+// writeHexLines writes b as continuation lines of bytesPerLine bytes each.
+func writeHexLines(w io.Writer, marker string, offset int64, b []byte) {
+	for start := 0; start < len(b); start += bytesPerLine {
+		line := b[start:min(start+bytesPerLine, len(b))]
+		fmt.Fprintf(w, "%s 0x%08x %*s %-*s %s\n",
+			marker,
+			offset+int64(start),
+			sizeWidth, "",
+			nameWidth, "",
+			hexBytes(line),
+		)
+	}
+}
+
+func hexBytes(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	encoded := hex.EncodeToString(b)
+
+	pairs := make([]string, 0, len(b))
+	for i := 0; i < len(encoded); i += 2 {
+		pairs = append(pairs, encoded[i:i+2])
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// Fields breaks a framed region into the fields of its record type. Bytes past
+// the end of the layout are returned as a trailing unknown field, and a region
+// that could not be framed is returned as a single unknown field.
+func Fields(region efd.Region) []Field {
+	n := len(region.Bytes)
+
+	layout := records.Layout(string(region.Raw.Magic[:]))
+	if region.Reason != nil || layout == nil {
+		return []Field{{
+			Name:    dataField,
+			Offset:  region.Offset,
+			Size:    n,
+			Bytes:   region.Bytes,
+			Value:   "",
+			Unknown: true,
+		}}
+	}
+
+	fields := make([]Field, 0, len(layout)+1)
+	end := 0
+
+	for _, lf := range layout {
+		size := lf.Size
+		if size < 0 {
+			size = max(n-lf.Offset, 0)
+		}
+
+		b := region.Bytes[min(lf.Offset, n):min(lf.Offset+size, n)]
+
+		fields = append(fields, Field{
+			Name:    lf.Name,
+			Offset:  region.Offset + int64(lf.Offset),
+			Size:    size,
+			Bytes:   b,
+			Value:   decodeValue(lf, b),
+			Unknown: lf.Unknown(),
+		})
+		end = lf.Offset + size
+	}
+
+	if end < n {
+		fields = append(fields, Field{
+			Name:    trailingField,
+			Offset:  region.Offset + int64(end),
+			Size:    n - end,
+			Bytes:   region.Bytes[end:],
+			Value:   "",
+			Unknown: true,
+		})
+	}
+
+	return fields
+}
+
+func decodeValue(f records.Field, b []byte) string {
+	switch {
+	case len(b) == 0:
+		return "(missing)"
+	case f.Type == nil:
+		return fmt.Sprintf("%d bytes", len(b))
+	case len(b) < f.Size:
+		return "(truncated)"
+	case f.Type.Kind() == reflect.Array:
+		if f.Unknown() {
+			return ""
+		}
+
+		if i := bytes.IndexByte(b, 0); i != -1 {
+			b = b[:i]
+		}
+
+		return strconv.Quote(string(b))
+	}
+
+	v := reflect.New(f.Type)
+	if err := binary.Read(
+		bytes.NewReader(b),
+		binary.LittleEndian,
+		v.Interface(),
+	); err != nil {
+		return ""
+	}
+
+	return fmt.Sprint(v.Elem().Interface())
+}
@@ -0,0 +1,257 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package inspect_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/inspect"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+// newRegion frames data as a record of the given magic at offset.
+//
+//nolint:exhaustruct // only partial is needed
+func newRegion(magic string, offset int64, data []byte) efd.Region {
+	b := make([]byte, records.HeaderSize, records.HeaderSize+len(data))
+	copy(b, magic)
+	binary.LittleEndian.PutUint64(b[8:], uint64(records.HeaderSize+len(data)))
+
+	raw := records.Raw{
+		Length: uint64(records.HeaderSize + len(data)),
+		Data:   data,
+	}
+	copy(raw.Magic[:], magic)
+
+	return efd.Region{Offset: offset, Bytes: append(b, data...), Raw: raw}
+}
+
+// newEFTP returns the data of a thumbnail record with n bytes of pixels.
+func newEFTP(n int) []byte {
+	return make([]byte, 0x120-records.HeaderSize+n)
+}
+
+func fieldByName(fields []inspect.Field, name string) (inspect.Field, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+
+	return inspect.Field{}, false
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Fields(t *testing.T) {
+	t.Parallel()
+
+	efrm := make([]byte, 0x200-records.HeaderSize)
+	binary.LittleEndian.PutUint32(efrm[0x18-records.HeaderSize:], 7)
+	copy(efrm[0x100-records.HeaderSize:], "remarks")
+
+	tests := []struct {
+		name     string
+		region   efd.Region
+		expected []inspect.Field
+	}{
+		{
+			name:   "EFRM record",
+			region: newRegion(records.MagicEFRM, 0x200, efrm),
+			expected: []inspect.Field{
+				{Name: "Magic", Offset: 0x200, Size: 4, Value: `"EFRM"`},
+				{Name: "HeaderUnknown", Offset: 0x204, Size: 4, Unknown: true},
+				{Name: "Unknown1", Offset: 0x210, Size: 4, Unknown: true},
+				{Name: "FrameNumber", Offset: 0x218, Size: 4, Value: "7"},
+				{Name: "Remarks", Offset: 0x300, Size: 256, Value: `"remarks"`},
+			},
+		},
+		{
+			name: "truncated EFRM record",
+			region: newRegion(
+				records.MagicEFRM,
+				0,
+				efrm[:0x1A-records.HeaderSize],
+			),
+			expected: []inspect.Field{
+				{
+					Name:   "FrameNumber",
+					Offset: 0x18,
+					Size:   4,
+					Value:  "(truncated)",
+				},
+				{
+					Name:   "FocalLength",
+					Offset: 0x1C,
+					Size:   4,
+					Value:  "(missing)",
+				},
+			},
+		},
+		{
+			name: "EFDF record with trailing bytes",
+			region: newRegion(
+				records.MagicEFDF,
+				0,
+				make([]byte, 0x200-records.HeaderSize+3),
+			),
+			expected: []inspect.Field{
+				{Name: "Trailing", Offset: 0x200, Size: 3, Unknown: true},
+			},
+		},
+		{
+			name:   "EFTP pixels",
+			region: newRegion(records.MagicEFTP, 0, newEFTP(6)),
+			expected: []inspect.Field{
+				{Name: "Pixels", Offset: 0x120, Size: 6, Value: "6 bytes"},
+			},
+		},
+		{
+			name: "unreadable region",
+			region: efd.Region{
+				Offset: 0x10,
+				Bytes:  []byte("junk"),
+				Reason: errExample,
+			},
+			expected: []inspect.Field{
+				{Name: "Data", Offset: 0x10, Size: 4, Unknown: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fields := inspect.Fields(tt.region)
+
+			for _, want := range tt.expected {
+				got, ok := fieldByName(fields, want.Name)
+				if !ok {
+					t.Fatalf("expected field %s, got %v", want.Name, fields)
+				}
+
+				if got.Offset != want.Offset || got.Size != want.Size ||
+					got.Value != want.Value || got.Unknown != want.Unknown {
+					t.Errorf("expected %s to be %+v, got %+v",
+						want.Name, want, got)
+				}
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Dump(t *testing.T) {
+	t.Parallel()
+
+	efdf := make([]byte, 0x200-records.HeaderSize)
+	binary.LittleEndian.PutUint32(efdf[0x32-records.HeaderSize:], 36)
+
+	tests := []struct {
+		name     string
+		regions  []efd.Region
+		pixels   bool
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "record and unreadable region",
+			regions: []efd.Region{
+				newRegion(records.MagicEFDF, 0, efdf),
+				{
+					Offset: 0x200,
+					Bytes:  bytes.Repeat([]byte{0xAB}, 300),
+					Reason: errExample,
+				},
+			},
+			contains: []string{
+				"OFFSET",
+				"EFDF record at 0x00000000, 512 bytes",
+				"  0x00000000     4 Magic                     45 46 44 46",
+				"* 0x00000004     4 HeaderUnknown",
+				"  0x00000032     4 FrameCount                24 00 00 00",
+				"unreadable region at 0x00000200, 300 bytes: example error",
+				"* 0x00000200",
+				"... 44 more bytes",
+				"* unknown or unreadable bytes",
+			},
+		},
+		{
+			name: "thumbnail pixels elided",
+			regions: []efd.Region{
+				newRegion(records.MagicEFTP, 0, newEFTP(32)),
+			},
+			contains: []string{"  0x00000120    32 Pixels"},
+			excludes: []string{"  0x00000130"},
+		},
+		{
+			name: "thumbnail pixels in full",
+			regions: []efd.Region{
+				newRegion(records.MagicEFTP, 0, newEFTP(32)),
+			},
+			pixels:   true,
+			contains: []string{"  0x00000120    32 Pixels", "  0x00000130"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			inspect.NewService(newTestLogger()).
+				Dump(t.Context(), buf, tt.regions, tt.pixels)
+
+			for _, want := range tt.contains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s",
+						want, buf.String())
+				}
+			}
+
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(buf.String(), unwanted) {
+					t.Errorf("expected output not to contain %q, got:\n%s",
+						unwanted, buf.String())
+				}
+			}
+		})
+	}
+}
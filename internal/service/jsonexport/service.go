@@ -0,0 +1,378 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=jsonexport_test github.com/ma-tf/meta1v/internal/service/jsonexport Service
+
+// Package jsonexport provides JSON output for Canon EFD metadata.
+//
+// Every command writes a single Document, so scripts can rely on one schema
+// whichever command produced it:
+//
+//	{
+//	  "schemaVersion": 1,
+//	  "roll": {
+//	    "filmId": "12-345", "firstRow": "...", "perRow": "...",
+//	    "title": "...", "filmLoadedAt": "...", "frameCount": "...",
+//	    "isoDx": "...", "remarks": "..."
+//	  },
+//	  "frames": [
+//	    {
+//	      "filmId": "12-345",
+//	      "frameNumber": 1,
+//	      "metadata": { "tv": "1/250", "av": "f/5.6", ... },
+//	      "customFunctions": [
+//	        { "number": 0, "name": "...", "value": 0, "setting": "..." }
+//	      ],
+//	      "closestPreset": { "name": "sports", "deviations": 0 },
+//	      "focusPoints": { "recorded": true, "automatic": false, ... },
+//	      "thumbnail": { "filepath": "...", "ascii": "..." }
+//	    }
+//	  ]
+//	}
+//
+// Values are the strings shown in the tables. The frame sections are only
+// present for the commands that show them: frame list has metadata, custom
+// functions and focus points, the other commands their own section only.
+package jsonexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/display"
+)
+
+// SchemaVersion is the version of the documents written. It is raised when a
+// field is removed, renamed or changes meaning, but not when one is added.
+const SchemaVersion = 1
+
+var ErrFailedToWriteDocument = errors.New("failed to write JSON document")
+
+// Document is the top level of every JSON document written.
+type Document struct {
+	SchemaVersion int     `json:"schemaVersion"`
+	Roll          Roll    `json:"roll"`
+	Frames        []Frame `json:"frames,omitempty"`
+}
+
+// Roll is the roll-level metadata of a Document.
+type Roll struct {
+	FilmID       domain.FilmID            `json:"filmId"`
+	FirstRow     domain.FirstRow          `json:"firstRow"`
+	PerRow       domain.PerRow            `json:"perRow"`
+	Title        domain.Title             `json:"title"`
+	FilmLoadedAt domain.ValidatedDatetime `json:"filmLoadedAt"`
+	FrameCount   domain.FrameCount        `json:"frameCount"`
+	IsoDX        domain.Iso               `json:"isoDx"`
+	Remarks      domain.Remarks           `json:"remarks"`
+}
+
+// Frame is a frame of a Document. Sections the command does not show are
+// left out.
+type Frame struct {
+	FilmID      domain.FilmID `json:"filmId"`
+	FrameNumber uint          `json:"frameNumber"`
+
+	Metadata        *Metadata           `json:"metadata,omitempty"`
+	CustomFunctions []CustomFunction    `json:"customFunctions,omitempty"`
+	ClosestPreset   *PresetMatch        `json:"closestPreset,omitempty"`
+	FocusPoints     *domain.FocusPoints `json:"focusPoints,omitempty"`
+	Thumbnail       *Thumbnail          `json:"thumbnail,omitempty"`
+}
+
+// Metadata is the exposure and camera metadata of a frame.
+type Metadata struct {
+	FilmLoadedAt       domain.ValidatedDatetime `json:"filmLoadedAt"`
+	IsoDX              domain.Iso               `json:"isoDx"`
+	UserModifiedRecord bool                     `json:"userModifiedRecord"`
+
+	FocalLength domain.FocalLength `json:"focalLength"`
+	MaxAperture domain.Av          `json:"maxAperture"`
+	Tv          domain.Tv          `json:"tv"`
+	Av          domain.Av          `json:"av"`
+	IsoM        domain.Iso         `json:"isoM"`
+
+	ExposureCompensation      domain.ExposureCompensation `json:"exposureCompensation"`
+	FlashExposureCompensation domain.ExposureCompensation `json:"flashExposureCompensation"`
+	FlashMode                 domain.FlashMode            `json:"flashMode"`
+	MeteringMode              domain.MeteringMode         `json:"meteringMode"`
+	ShootingMode              domain.ShootingMode         `json:"shootingMode"`
+
+	FilmAdvanceMode  domain.FilmAdvanceMode   `json:"filmAdvanceMode"`
+	AFMode           domain.AutoFocusMode     `json:"afMode"`
+	BulbExposureTime domain.BulbExposureTime  `json:"bulbExposureTime"`
+	TakenAt          domain.ValidatedDatetime `json:"takenAt"`
+
+	MultipleExposure domain.MultipleExposure  `json:"multipleExposure"`
+	BatteryLoadedAt  domain.ValidatedDatetime `json:"batteryLoadedAt"`
+
+	Remarks domain.Remarks `json:"remarks"`
+}
+
+// CustomFunction is the setting of a custom function of a frame.
+type CustomFunction struct {
+	Number  int    `json:"number"` // numbered from 0 as on the camera
+	Name    string `json:"name"`
+	Value   *int   `json:"value"`   // null if unset
+	Setting string `json:"setting"` // meaning of the value, empty if unknown
+}
+
+// PresetMatch is the custom function preset a frame matches best.
+type PresetMatch struct {
+	Name       string `json:"name"`
+	Deviations int    `json:"deviations"` // custom functions that differ
+}
+
+// Thumbnail is the embedded thumbnail of a frame.
+type Thumbnail struct {
+	Filepath string `json:"filepath"`
+	ASCII    string `json:"ascii"`
+}
+
+// Service provides JSON export operations for film roll metadata.
+type Service interface {
+	// ExportRoll writes roll-level metadata without any frames.
+	ExportRoll(
+		ctx context.Context,
+		w io.Writer,
+		r display.DisplayableRoll,
+	) error
+
+	// ExportFrames writes every frame with its metadata, custom functions and
+	// focus points.
+	ExportFrames(
+		ctx context.Context,
+		w io.Writer,
+		r display.DisplayableRoll,
+	) error
+
+	// ExportCustomFunctions writes the custom functions of every frame, with
+	// the preset each frame matches best if there are any presets.
+	ExportCustomFunctions(
+		ctx context.Context,
+		w io.Writer,
+		r display.DisplayableRoll,
+		presets []domain.CustomFunctionPreset,
+	) error
+
+	// ExportFocusingPoints writes the autofocus grid of every frame.
+	ExportFocusingPoints(
+		ctx context.Context,
+		w io.Writer,
+		r display.DisplayableRoll,
+	) error
+
+	// ExportThumbnails writes the thumbnail of every frame that has one.
+	ExportThumbnails(
+		ctx context.Context,
+		w io.Writer,
+		r display.DisplayableRoll,
+	) error
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+func (s *service) ExportRoll(
+	ctx context.Context,
+	w io.Writer,
+	r display.DisplayableRoll,
+) error {
+	s.log.InfoContext(ctx, "writing roll as JSON",
+		slog.String("film_id", string(r.FilmID)))
+
+	return s.write(ctx, w, r, nil)
+}
+
+func (s *service) ExportFrames(
+	ctx context.Context,
+	w io.Writer,
+	r display.DisplayableRoll,
+) error {
+	s.log.InfoContext(ctx, "writing frames as JSON",
+		slog.String("film_id", string(r.FilmID)),
+		slog.Int("frame_count", len(r.Frames)))
+
+	return s.write(ctx, w, r, func(f *Frame, fr display.DisplayableFrame) {
+		f.Metadata = newMetadata(fr)
+		f.CustomFunctions = newCustomFunctions(fr.CustomFunctions)
+		f.FocusPoints = &fr.FocusPoints
+	})
+}
+
+func (s *service) ExportCustomFunctions(
+	ctx context.Context,
+	w io.Writer,
+	r display.DisplayableRoll,
+	presets []domain.CustomFunctionPreset,
+) error {
+	s.log.InfoContext(ctx, "writing custom functions as JSON",
+		slog.String("film_id", string(r.FilmID)),
+		slog.Int("frame_count", len(r.Frames)),
+		slog.Int("presets", len(presets)))
+
+	return s.write(ctx, w, r, func(f *Frame, fr display.DisplayableFrame) {
+		f.CustomFunctions = newCustomFunctions(fr.CustomFunctions)
+
+		p, deviations, ok := domain.ClosestPreset(fr.CustomFunctions, presets)
+		if ok {
+			f.ClosestPreset = &PresetMatch{
+				Name:       p.Name,
+				Deviations: deviations,
+			}
+		}
+	})
+}
+
+func (s *service) ExportFocusingPoints(
+	ctx context.Context,
+	w io.Writer,
+	r display.DisplayableRoll,
+) error {
+	s.log.InfoContext(ctx, "writing focusing points as JSON",
+		slog.String("film_id", string(r.FilmID)),
+		slog.Int("frame_count", len(r.Frames)))
+
+	return s.write(ctx, w, r, func(f *Frame, fr display.DisplayableFrame) {
+		f.FocusPoints = &fr.FocusPoints
+	})
+}
+
+func (s *service) ExportThumbnails(
+	ctx context.Context,
+	w io.Writer,
+	r display.DisplayableRoll,
+) error {
+	s.log.InfoContext(ctx, "writing thumbnails as JSON",
+		slog.String("film_id", string(r.FilmID)),
+		slog.Int("frame_count", len(r.Frames)))
+
+	return s.write(ctx, w, r, func(f *Frame, fr display.DisplayableFrame) {
+		if fr.Thumbnail != nil {
+			f.Thumbnail = &Thumbnail{
+				Filepath: fr.Thumbnail.Filepath,
+				ASCII:    fr.Thumbnail.Thumbnail,
+			}
+		}
+	})
+}
+
+// write encodes r as an indented Document. Each frame is filled in by
+// sections, or left out altogether if sections is nil.
+func (s *service) write(
+	ctx context.Context,
+	w io.Writer,
+	r display.DisplayableRoll,
+	sections func(f *Frame, fr display.DisplayableFrame),
+) error {
+	doc := Document{
+		SchemaVersion: SchemaVersion,
+		Roll: Roll{
+			FilmID:       r.FilmID,
+			FirstRow:     r.FirstRow,
+			PerRow:       r.PerRow,
+			Title:        r.Title,
+			FilmLoadedAt: r.FilmLoadedDate,
+			FrameCount:   r.FrameCount,
+			IsoDX:        r.IsoDX,
+			Remarks:      r.Remarks,
+		},
+		Frames: nil,
+	}
+
+	if sections != nil {
+		doc.Frames = make([]Frame, 0, len(r.Frames))
+
+		for _, fr := range r.Frames {
+			//nolint:exhaustruct // sections are filled in below
+			f := Frame{
+				FilmID:      fr.FilmID,
+				FrameNumber: fr.FrameNumber,
+			}
+			sections(&f, fr)
+
+			doc.Frames = append(doc.Frames, f)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return errors.Join(ErrFailedToWriteDocument, err)
+	}
+
+	s.log.DebugContext(ctx, "JSON document written",
+		slog.Int("frame_count", len(doc.Frames)))
+
+	return nil
+}
+
+func newMetadata(fr display.DisplayableFrame) *Metadata {
+	return &Metadata{
+		FilmLoadedAt:              fr.FilmLoadedAt,
+		IsoDX:                     fr.IsoDX,
+		UserModifiedRecord:        fr.UserModifiedRecord,
+		FocalLength:               fr.FocalLength,
+		MaxAperture:               fr.MaxAperture,
+		Tv:                        fr.Tv,
+		Av:                        fr.Av,
+		IsoM:                      fr.IsoM,
+		ExposureCompensation:      fr.ExposureCompensation,
+		FlashExposureCompensation: fr.FlashExposureCompensation,
+		FlashMode:                 fr.FlashMode,
+		MeteringMode:              fr.MeteringMode,
+		ShootingMode:              fr.ShootingMode,
+		FilmAdvanceMode:           fr.FilmAdvanceMode,
+		AFMode:                    fr.AFMode,
+		BulbExposureTime:          fr.BulbExposureTime,
+		TakenAt:                   fr.TakenAt,
+		MultipleExposure:          fr.MultipleExposure,
+		BatteryLoadedAt:           fr.BatteryLoadedAt,
+		Remarks:                   fr.Remarks,
+	}
+}
+
+func newCustomFunctions(cfs domain.CustomFunctions) []CustomFunction {
+	out := make([]CustomFunction, len(cfs))
+
+	for n := range cfs {
+		out[n] = CustomFunction{
+			Number:  n,
+			Name:    domain.CustomFunctionName(n),
+			Value:   nil,
+			Setting: cfs.Setting(n),
+		}
+
+		if v, err := strconv.Atoi(cfs[n]); err == nil {
+			out[n].Value = &v
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,246 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jsonexport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/display"
+	"github.com/ma-tf/meta1v/internal/service/jsonexport"
+)
+
+var errExample = errors.New("example error")
+
+type failWriter struct{}
+
+func (fw *failWriter) Write(_ []byte) (int, error) {
+	return 0, errExample
+}
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct // only partial is needed
+func newRoll() display.DisplayableRoll {
+	cfs := domain.CustomFunctions{}
+	for i := range cfs {
+		cfs[i] = "0"
+	}
+
+	cfs[19] = " "
+
+	return display.DisplayableRoll{
+		FilmID:     "12-345",
+		Title:      "My Film Roll",
+		FrameCount: "2",
+		Frames: []display.DisplayableFrame{
+			{
+				FilmID:          "12-345",
+				FrameNumber:     1,
+				Tv:              "1/250",
+				Av:              "5.6",
+				CustomFunctions: cfs,
+				FocusPoints:     domain.FocusPoints{Recorded: true},
+				Thumbnail: &display.DisplayableThumbnail{
+					Filepath:  `C:\scans\1.jpg`,
+					Thumbnail: "##\n##\n",
+				},
+			},
+			{
+				FilmID:          "12-345",
+				FrameNumber:     2,
+				CustomFunctions: cfs,
+			},
+		},
+	}
+}
+
+type export func(
+	s jsonexport.Service,
+	ctx context.Context,
+	w io.Writer,
+	r display.DisplayableRoll,
+) error
+
+//nolint:funlen // table driven test
+func Test_Export(t *testing.T) {
+	t.Parallel()
+
+	presets := []domain.CustomFunctionPreset{
+		{Name: "standard", Values: map[int]byte{0: 0, 1: 1}},
+	}
+
+	tests := []struct {
+		name   string
+		export export
+		check  func(t *testing.T, doc jsonexport.Document)
+	}{
+		{
+			name:   "roll",
+			export: jsonexport.Service.ExportRoll,
+			check: func(t *testing.T, doc jsonexport.Document) {
+				t.Helper()
+
+				if doc.Roll.Title != "My Film Roll" || doc.Frames != nil {
+					t.Errorf("unexpected document: %+v", doc)
+				}
+			},
+		},
+		{
+			name:   "frames",
+			export: jsonexport.Service.ExportFrames,
+			check: func(t *testing.T, doc jsonexport.Document) {
+				t.Helper()
+
+				f := doc.Frames[0]
+				if f.Metadata == nil || f.Metadata.Tv != "1/250" ||
+					len(f.CustomFunctions) != 20 || f.FocusPoints == nil ||
+					f.Thumbnail != nil {
+					t.Errorf("unexpected frame: %+v", f)
+				}
+			},
+		},
+		{
+			name: "custom functions",
+			export: func(
+				s jsonexport.Service,
+				ctx context.Context,
+				w io.Writer,
+				r display.DisplayableRoll,
+			) error {
+				return s.ExportCustomFunctions(ctx, w, r, presets)
+			},
+			check: func(t *testing.T, doc jsonexport.Document) {
+				t.Helper()
+
+				f := doc.Frames[0]
+				if f.Metadata != nil || f.FocusPoints != nil {
+					t.Errorf("unexpected sections: %+v", f)
+				}
+
+				cf := f.CustomFunctions[0]
+				if cf.Number != 0 || cf.Name == "" ||
+					cf.Value == nil || *cf.Value != 0 {
+					t.Errorf("unexpected custom function: %+v", cf)
+				}
+
+				if f.CustomFunctions[19].Value != nil {
+					t.Error("expected no value for an unset custom function")
+				}
+
+				want := jsonexport.PresetMatch{Name: "standard", Deviations: 1}
+				if f.ClosestPreset == nil || *f.ClosestPreset != want {
+					t.Errorf("unexpected closest preset: got %v, want %v",
+						f.ClosestPreset, want)
+				}
+			},
+		},
+		{
+			name:   "focusing points",
+			export: jsonexport.Service.ExportFocusingPoints,
+			check: func(t *testing.T, doc jsonexport.Document) {
+				t.Helper()
+
+				f := doc.Frames[0]
+				if f.FocusPoints == nil || !f.FocusPoints.Recorded ||
+					f.CustomFunctions != nil {
+					t.Errorf("unexpected frame: %+v", f)
+				}
+			},
+		},
+		{
+			name:   "thumbnails",
+			export: jsonexport.Service.ExportThumbnails,
+			check: func(t *testing.T, doc jsonexport.Document) {
+				t.Helper()
+
+				want := jsonexport.Thumbnail{
+					Filepath: `C:\scans\1.jpg`,
+					ASCII:    "##\n##\n",
+				}
+				if doc.Frames[0].Thumbnail == nil ||
+					*doc.Frames[0].Thumbnail != want {
+					t.Errorf("unexpected thumbnail: %+v", doc.Frames[0])
+				}
+
+				if doc.Frames[1].Thumbnail != nil {
+					t.Error("expected no thumbnail for frame 2")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			svc := jsonexport.NewService(newTestLogger())
+
+			if err := tt.export(svc, t.Context(), buf, newRoll()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var doc jsonexport.Document
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+			}
+
+			if doc.SchemaVersion != jsonexport.SchemaVersion {
+				t.Errorf("unexpected schema version: got %d, want %d",
+					doc.SchemaVersion, jsonexport.SchemaVersion)
+			}
+
+			if doc.Roll.FilmID != "12-345" {
+				t.Errorf("unexpected film ID: %q", doc.Roll.FilmID)
+			}
+
+			tt.check(t, doc)
+		})
+	}
+}
+
+func Test_Export_Error(t *testing.T) {
+	t.Parallel()
+
+	err := jsonexport.NewService(newTestLogger()).
+		ExportFrames(t.Context(), &failWriter{}, newRoll())
+
+	if !errors.Is(err, jsonexport.ErrFailedToWriteDocument) {
+		t.Errorf("unexpected error: got %v, want %v",
+			err, jsonexport.ErrFailedToWriteDocument)
+	}
+}
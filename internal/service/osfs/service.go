@@ -40,6 +40,15 @@ type FileSystem interface {
 
 	// Stat returns file information.
 	Stat(name string) (os.FileInfo, error)
+
+	// Rename renames (moves) oldpath to newpath, replacing newpath if it exists.
+	Rename(oldpath, newpath string) error
+
+	// Remove removes the named file or empty directory.
+	Remove(name string) error
+
+	// ReadDir returns the entries of the named directory, sorted by file name.
+	ReadDir(name string) ([]os.DirEntry, error)
 }
 
 // File is a mockable file interface combining standard io operations.
@@ -67,6 +76,19 @@ func (osFS) Pipe() (*os.File, *os.File, error) { return os.Pipe() }
 //nolint:wrapcheck // os package errors are sufficient
 func (osFS) Stat(name string) (os.FileInfo, error) { return os.Stat(name) }
 
+//nolint:wrapcheck // os package errors are sufficient
+func (osFS) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+//nolint:wrapcheck // os package errors are sufficient
+func (osFS) Remove(name string) error { return os.Remove(name) }
+
+//nolint:wrapcheck // os package errors are sufficient
+func (osFS) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
 // NewFileSystem creates a FileSystem that delegates to the standard os package.
 func NewFileSystem() FileSystem {
 	return osFS{}
@@ -0,0 +1,417 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=research_test github.com/ma-tf/meta1v/internal/service/research Service
+
+// Package research provides analysis of the undocumented parts of the EFD format.
+//
+// It compares the bytes of the unknown fields of EFDF and EFRM records across many
+// files, reporting which bytes never change and which vary together with fields
+// whose meaning is known, as a starting point for decoding them.
+package research
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+)
+
+var (
+	ErrUnknownCorrelationField = errors.New(
+		"field to correlate against is not a known numeric field",
+	)
+	ErrFailedToWriteJSON = errors.New("failed to write JSON report")
+	ErrFailedToWriteCSV  = errors.New("failed to write CSV report")
+)
+
+// Correlation describes how a byte varies with a known field.
+type Correlation struct {
+	Field string `json:"field"`
+	// Pearson is the correlation coefficient between the byte and the field,
+	// zero if either of them never changes.
+	Pearson float64 `json:"pearson"`
+	// Determined is set if every value of the field seen more than once always
+	// comes with the same value of the byte.
+	Determined bool `json:"determined"`
+}
+
+// Byte summarises one byte of an unknown field across all records of a type.
+type Byte struct {
+	Record       string        `json:"record"`
+	Field        string        `json:"field"`
+	Offset       int           `json:"offset"` // from the start of the record
+	Samples      int           `json:"samples"`
+	Constant     bool          `json:"constant"`
+	Distinct     int           `json:"distinct"`
+	Min          uint8         `json:"min"`
+	Max          uint8         `json:"max"`
+	Correlations []Correlation `json:"correlations,omitempty"`
+}
+
+// Report is the result of analysing the unknown bytes of a set of files.
+type Report struct {
+	Files   int            `json:"files"`
+	Records map[string]int `json:"records"` // records analysed per record type
+	Bytes   []Byte         `json:"bytes"`
+}
+
+// Options controls the analysis.
+type Options struct {
+	// Against names the known fields unknown bytes are correlated with. A field
+	// is only used for the record types that have it.
+	Against []string
+	// MinCorrelation is the smallest absolute Pearson coefficient reported,
+	// determined correlations are reported regardless.
+	MinCorrelation float64
+}
+
+// Service analyses the unknown bytes of EFD files.
+type Service interface {
+	// Analyse compares the unknown bytes of the EFDF and EFRM records found in
+	// the scanned regions of each file. Regions that could not be framed are skipped.
+	Analyse(
+		ctx context.Context,
+		files [][]efd.Region,
+		opts Options,
+	) (Report, error)
+
+	// WriteJSON writes the report as indented JSON.
+	WriteJSON(ctx context.Context, w io.Writer, report Report) error
+
+	// WriteCSV writes the report as CSV, one row per byte.
+	WriteCSV(ctx context.Context, w io.Writer, report Report) error
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+//nolint:gochecknoglobals // fixed set of analysed record types
+var analysedRecords = []string{records.MagicEFDF, records.MagicEFRM}
+
+func (s *service) Analyse(
+	ctx context.Context,
+	files [][]efd.Region,
+	opts Options,
+) (Report, error) {
+	if err := validateAgainst(opts.Against); err != nil {
+		return Report{}, err
+	}
+
+	samples := make(map[string][][]byte, len(analysedRecords))
+	skipped := 0
+
+	for _, regions := range files {
+		for _, region := range regions {
+			if region.Reason != nil {
+				skipped++
+
+				continue
+			}
+
+			magic := string(region.Raw.Magic[:])
+			samples[magic] = append(samples[magic], region.Bytes)
+		}
+	}
+
+	s.log.InfoContext(ctx, "analysing unknown bytes",
+		slog.Int("files", len(files)),
+		slog.Int("efdf", len(samples[records.MagicEFDF])),
+		slog.Int("efrm", len(samples[records.MagicEFRM])),
+		slog.Int("skipped_regions", skipped))
+
+	report := Report{
+		Files:   len(files),
+		Records: make(map[string]int, len(analysedRecords)),
+		Bytes:   nil,
+	}
+
+	for _, magic := range analysedRecords {
+		report.Records[magic] = len(samples[magic])
+		report.Bytes = append(report.Bytes,
+			analyseRecord(magic, samples[magic], opts)...)
+	}
+
+	s.log.DebugContext(ctx, "unknown bytes analysed",
+		slog.Int("bytes", len(report.Bytes)))
+
+	return report, nil
+}
+
+// validateAgainst checks every field to correlate against is a known numeric
+// field of at least one analysed record type.
+func validateAgainst(against []string) error {
+	for _, name := range against {
+		found := false
+
+		for _, magic := range analysedRecords {
+			if _, ok := knownField(records.Layout(magic), name); ok {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%w: %q", ErrUnknownCorrelationField, name)
+		}
+	}
+
+	return nil
+}
+
+func knownField(layout []records.Field, name string) (records.Field, bool) {
+	for _, f := range layout {
+		if f.Name == name && !f.Unknown() && isNumeric(f) {
+			return f, true
+		}
+	}
+
+	return records.Field{}, false
+}
+
+func analyseRecord(magic string, samples [][]byte, opts Options) []Byte {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	layout := records.Layout(magic)
+
+	against := make([]records.Field, 0, len(opts.Against))
+	for _, name := range opts.Against {
+		if f, ok := knownField(layout, name); ok {
+			against = append(against, f)
+		}
+	}
+
+	var result []Byte
+
+	for _, f := range layout {
+		if !f.Unknown() {
+			continue
+		}
+
+		for offset := f.Offset; offset < f.Offset+f.Size; offset++ {
+			b, ok := analyseByte(samples, offset, against, opts.MinCorrelation)
+			if !ok {
+				continue
+			}
+
+			b.Record = magic
+			b.Field = f.Name
+			result = append(result, b)
+		}
+	}
+
+	return result
+}
+
+// analyseByte summarises the byte at offset across the samples long enough
+// to hold it, reporting false if none are.
+func analyseByte(
+	samples [][]byte,
+	offset int,
+	against []records.Field,
+	minCorrelation float64,
+) (Byte, bool) {
+	values := make([]uint8, 0, len(samples))
+	seen := make(map[uint8]struct{})
+
+	for _, sample := range samples {
+		if offset < len(sample) {
+			values = append(values, sample[offset])
+			seen[sample[offset]] = struct{}{}
+		}
+	}
+
+	if len(values) == 0 {
+		return Byte{}, false
+	}
+
+	b := Byte{
+		Record:       "",
+		Field:        "",
+		Offset:       offset,
+		Samples:      len(values),
+		Constant:     len(seen) == 1,
+		Distinct:     len(seen),
+		Min:          values[0],
+		Max:          values[0],
+		Correlations: nil,
+	}
+
+	for _, v := range values {
+		b.Min = min(b.Min, v)
+		b.Max = max(b.Max, v)
+	}
+
+	if b.Constant {
+		return b, true
+	}
+
+	for _, f := range against {
+		c, ok := correlate(samples, offset, f)
+		if ok && (c.Determined || math.Abs(c.Pearson) >= minCorrelation) {
+			b.Correlations = append(b.Correlations, c)
+		}
+	}
+
+	return b, true
+}
+
+// correlate compares the byte at offset with the known field f across the
+// samples holding both, reporting false if the field never changes.
+func correlate(
+	samples [][]byte,
+	offset int,
+	f records.Field,
+) (Correlation, bool) {
+	xs := make([]float64, 0, len(samples))
+	ys := make([]float64, 0, len(samples))
+	mapping := make(map[float64]uint8)
+	counts := make(map[float64]int)
+	consistent := true
+
+	for _, sample := range samples {
+		if offset >= len(sample) || f.Offset+f.Size > len(sample) {
+			continue
+		}
+
+		x, ok := numericValue(f, sample[f.Offset:f.Offset+f.Size])
+		if !ok {
+			continue
+		}
+
+		y := sample[offset]
+
+		if prev, seen := mapping[x]; seen && prev != y {
+			consistent = false
+		}
+
+		mapping[x] = y
+		counts[x]++
+
+		xs = append(xs, x)
+		ys = append(ys, float64(y))
+	}
+
+	if len(counts) < 2 { //nolint:mnd // a constant field explains nothing
+		return Correlation{}, false
+	}
+
+	// a field with a different value in every sample trivially determines
+	// everything, so it only counts once some value repeats
+	repeated := len(counts) < len(xs)
+
+	return Correlation{
+		Field:      f.Name,
+		Pearson:    round(pearson(xs, ys)),
+		Determined: consistent && repeated,
+	}, true
+}
+
+func (s *service) WriteJSON(
+	ctx context.Context,
+	w io.Writer,
+	report Report,
+) error {
+	s.log.DebugContext(ctx, "writing JSON report")
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return errors.Join(ErrFailedToWriteJSON, err)
+	}
+
+	return nil
+}
+
+func (s *service) WriteCSV(
+	ctx context.Context,
+	w io.Writer,
+	report Report,
+) error {
+	s.log.DebugContext(ctx, "writing CSV report")
+
+	cw := csv.NewWriter(w)
+
+	rows := [][]string{{
+		"record",
+		"field",
+		"offset",
+		"samples",
+		"constant",
+		"distinct",
+		"min",
+		"max",
+		"correlations",
+	}}
+
+	for _, b := range report.Bytes {
+		rows = append(rows, []string{
+			b.Record,
+			b.Field,
+			fmt.Sprintf("0x%02X", b.Offset),
+			strconv.Itoa(b.Samples),
+			strconv.FormatBool(b.Constant),
+			strconv.Itoa(b.Distinct),
+			strconv.Itoa(int(b.Min)),
+			strconv.Itoa(int(b.Max)),
+			formatCorrelations(b.Correlations),
+		})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return errors.Join(ErrFailedToWriteCSV, err)
+	}
+
+	return nil
+}
+
+// formatCorrelations renders correlations for a single CSV cell,
+// e.g. "ShootingMode r=0.93 determined;AFMode r=-0.85".
+func formatCorrelations(correlations []Correlation) string {
+	parts := make([]string, 0, len(correlations))
+
+	for _, c := range correlations {
+		part := fmt.Sprintf("%s r=%s",
+			c.Field, strconv.FormatFloat(c.Pearson, 'f', -1, 64))
+		if c.Determined {
+			part += " determined"
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ";")
+}
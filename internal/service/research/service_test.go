@@ -0,0 +1,266 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package research_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/efd"
+	"github.com/ma-tf/meta1v/internal/service/research"
+)
+
+var errExample = errors.New("example error")
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+// newEFRM returns a frame record region with the given shooting mode and
+// focal length, and the unknown bytes at 0x10 and 0x11 set to u1 and u2.
+//
+//nolint:exhaustruct // only partial is needed
+func newEFRM(shootingMode, focalLength uint32, u1, u2 uint8) efd.Region {
+	b := make([]byte, 0x200)
+	copy(b, records.MagicEFRM)
+	binary.LittleEndian.PutUint64(b[0x08:], 0x200)
+	b[0x10], b[0x11] = u1, u2
+	binary.LittleEndian.PutUint32(b[0x1C:], focalLength)
+	binary.LittleEndian.PutUint32(b[0x58:], shootingMode)
+
+	raw := records.Raw{Length: 0x200, Data: b[records.HeaderSize:]}
+	copy(raw.Magic[:], records.MagicEFRM)
+
+	return efd.Region{Offset: 0, Bytes: b, Raw: raw}
+}
+
+func findByte(
+	report research.Report,
+	record string,
+	offset int,
+) (research.Byte, bool) {
+	for _, b := range report.Bytes {
+		if b.Record == record && b.Offset == offset {
+			return b, true
+		}
+	}
+
+	return research.Byte{}, false
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Analyse(t *testing.T) {
+	t.Parallel()
+
+	files := [][]efd.Region{
+		{
+			newEFRM(1, 50, 7, 10),
+			newEFRM(2, 85, 7, 20),
+			{Offset: 0x200, Bytes: []byte("junk"), Reason: errExample},
+		},
+		{
+			newEFRM(1, 24, 7, 10),
+			newEFRM(3, 200, 7, 30),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		opts          research.Options
+		offset        int
+		expected      research.Byte
+		expectedError error
+	}{
+		{
+			name:   "constant byte",
+			opts:   research.Options{Against: []string{"ShootingMode"}},
+			offset: 0x10,
+			expected: research.Byte{
+				Record:   records.MagicEFRM,
+				Field:    "Unknown1",
+				Offset:   0x10,
+				Samples:  4,
+				Constant: true,
+				Distinct: 1,
+				Min:      7,
+				Max:      7,
+			},
+		},
+		{
+			name: "byte determined by shooting mode",
+			opts: research.Options{
+				Against:        []string{"ShootingMode", "FocalLength"},
+				MinCorrelation: 0.99,
+			},
+			offset: 0x11,
+			expected: research.Byte{
+				Record:   records.MagicEFRM,
+				Field:    "Unknown1",
+				Offset:   0x11,
+				Samples:  4,
+				Distinct: 3,
+				Min:      10,
+				Max:      30,
+				Correlations: []research.Correlation{
+					{Field: "ShootingMode", Pearson: 1, Determined: true},
+				},
+			},
+		},
+		{
+			name: "correlation above threshold",
+			opts: research.Options{
+				Against:        []string{"FocalLength"},
+				MinCorrelation: 0.5,
+			},
+			offset: 0x11,
+			expected: research.Byte{
+				Record:   records.MagicEFRM,
+				Field:    "Unknown1",
+				Offset:   0x11,
+				Samples:  4,
+				Distinct: 3,
+				Min:      10,
+				Max:      30,
+				Correlations: []research.Correlation{
+					{Field: "FocalLength", Pearson: 0.968},
+				},
+			},
+		},
+		{
+			name:          "unknown field to correlate against",
+			opts:          research.Options{Against: []string{"Unknown1"}},
+			expectedError: research.ErrUnknownCorrelationField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := research.NewService(newTestLogger()).
+				Analyse(t.Context(), files, tt.opts)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if report.Files != len(files) ||
+				report.Records[records.MagicEFRM] != 4 ||
+				report.Records[records.MagicEFDF] != 0 {
+				t.Errorf("unexpected totals: %d files, %v records",
+					report.Files, report.Records)
+			}
+
+			got, ok := findByte(report, records.MagicEFRM, tt.offset)
+			if !ok {
+				t.Fatalf("expected byte %#x in report", tt.offset)
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Write(t *testing.T) {
+	t.Parallel()
+
+	report := research.Report{
+		Files:   2,
+		Records: map[string]int{records.MagicEFRM: 4},
+		Bytes: []research.Byte{
+			{
+				Record:   records.MagicEFRM,
+				Field:    "Unknown1",
+				Offset:   0x11,
+				Samples:  4,
+				Distinct: 3,
+				Min:      10,
+				Max:      30,
+				Correlations: []research.Correlation{
+					{Field: "ShootingMode", Pearson: 1, Determined: true},
+					{Field: "FocalLength", Pearson: 0.968},
+				},
+			},
+		},
+	}
+
+	svc := research.NewService(newTestLogger())
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		if err := svc.WriteJSON(t.Context(), buf, report); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got research.Report
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("output is not valid JSON: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, report) {
+			t.Errorf("expected %+v, got %+v", report, got)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		if err := svc.WriteCSV(t.Context(), buf, report); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := "record,field,offset,samples,constant,distinct,min,max," +
+			"correlations\n" +
+			"EFRM,Unknown1,0x11,4,false,3,10,30," +
+			"ShootingMode r=1 determined;FocalLength r=0.968\n"
+
+		if buf.String() != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+		}
+	})
+}
@@ -0,0 +1,94 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package research
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+
+	"github.com/ma-tf/meta1v/internal/records"
+)
+
+// precision is the number of decimals correlation coefficients are rounded to.
+const precision = 1000
+
+func isNumeric(f records.Field) bool {
+	if f.Type == nil {
+		return false
+	}
+
+	switch f.Type.Kind() { //nolint:exhaustive // only scalar kinds are numeric
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// numericValue decodes the little-endian bytes of a numeric field.
+func numericValue(f records.Field, b []byte) (float64, bool) {
+	v := reflect.New(f.Type)
+	if err := binary.Read(
+		bytes.NewReader(b),
+		binary.LittleEndian,
+		v.Interface(),
+	); err != nil {
+		return 0, false
+	}
+
+	e := v.Elem()
+	if e.CanInt() {
+		return float64(e.Int()), true
+	}
+
+	return float64(e.Uint()), true
+}
+
+// pearson returns the Pearson correlation coefficient of xs and ys,
+// or zero if either does not vary.
+func pearson(xs, ys []float64) float64 {
+	n := float64(len(xs))
+
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+
+	return cov / math.Sqrt(varX*varY)
+}
+
+func round(f float64) float64 {
+	return math.Round(f*precision) / precision
+}
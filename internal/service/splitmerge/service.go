@@ -0,0 +1,314 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=splitmerge_test github.com/ma-tf/meta1v/internal/service/splitmerge Service
+
+// Package splitmerge provides splitting of EFD rolls into parts and merging of
+// parts back into a single roll.
+//
+// Thumbnails are linked to frames by the position of the frame record in the
+// file, so every operation carries the thumbnails along with their frames and
+// renumbers them to the positions the frames end up at.
+package splitmerge
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+)
+
+var (
+	ErrNoFramesSelected = errors.New("no frames in the selected ranges")
+	ErrNothingToMerge   = errors.New("at least two rolls are needed to merge")
+	ErrHeaderMismatch   = errors.New("roll headers disagree")
+	ErrDuplicateFrame   = errors.New("frame number is in more than one roll")
+	ErrFilmIDMismatch   = errors.New("film ID differs")
+	ErrLoadDateMismatch = errors.New("film load date differs")
+	ErrIsoDXMismatch    = errors.New("DX ISO differs")
+)
+
+// Part is a roll split off from an EFD file.
+type Part struct {
+	FilmID string // film ID of the frames in the part, "none" if not recorded
+	Root   records.Root
+}
+
+// Service splits and merges EFD rolls.
+type Service interface {
+	// SplitByFilmID groups the frames of a roll by their film ID, in order of
+	// first appearance. A part whose film ID differs from the roll header gets a
+	// header taken from its frames, counting up to its highest frame number.
+	SplitByFilmID(ctx context.Context, root records.Root) []Part
+
+	// SplitByFrames keeps the frames whose numbers fall within any of the ranges.
+	SplitByFrames(
+		ctx context.Context,
+		root records.Root,
+		ranges []domain.FrameRange,
+	) (records.Root, error)
+
+	// Merge combines rolls into one, ordered by frame number. The roll headers
+	// must agree on film ID, load date and DX ISO, and a frame number may only
+	// appear in one of the rolls. Title and remarks come from the first roll
+	// that has them.
+	Merge(ctx context.Context, roots []records.Root) (records.Root, error)
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+// frame is a frame record together with the thumbnails that belong to it.
+type frame struct {
+	efrm  records.EFRM
+	eftps []records.EFTP
+}
+
+// framesOf attaches each thumbnail of a roll to the frame at its index.
+// Thumbnails not matching any frame are dropped.
+func (s *service) framesOf(ctx context.Context, root records.Root) []frame {
+	frames := make([]frame, len(root.EFRMs))
+	for i, efrm := range root.EFRMs {
+		frames[i].efrm = efrm
+	}
+
+	for _, eftp := range root.EFTPs {
+		if eftp.Index == 0 || int(eftp.Index) > len(frames) {
+			s.log.WarnContext(ctx, "dropping thumbnail without a frame",
+				slog.Uint64("index", uint64(eftp.Index)))
+
+			continue
+		}
+
+		pos := eftp.Index - 1
+		frames[pos].eftps = append(frames[pos].eftps, eftp)
+	}
+
+	return frames
+}
+
+// assemble builds a roll from frames, renumbering the thumbnails to the
+// positions of their frames.
+func assemble(efdf records.EFDF, frames []frame) records.Root {
+	root := records.Root{
+		EFDF:       efdf,
+		EFRMs:      make([]records.EFRM, 0, len(frames)),
+		EFTPs:      nil,
+		Placements: nil,
+	}
+
+	for i, f := range frames {
+		root.EFRMs = append(root.EFRMs, f.efrm)
+
+		for _, eftp := range f.eftps {
+			eftp.Index = uint16(i + 1) //nolint:gosec // frame counts fit uint16
+			root.EFTPs = append(root.EFTPs, eftp)
+		}
+	}
+
+	return root
+}
+
+type filmID struct {
+	codeA uint32
+	codeB uint32
+}
+
+func (id filmID) String() string {
+	fid, err := domain.NewFilmID(id.codeA, id.codeB)
+	if err != nil {
+		return fmt.Sprintf("%d-%d", id.codeA, id.codeB)
+	}
+
+	if fid == "" {
+		return "none"
+	}
+
+	return string(fid)
+}
+
+func (s *service) SplitByFilmID(
+	ctx context.Context,
+	root records.Root,
+) []Part {
+	s.log.InfoContext(ctx, "splitting roll by film ID",
+		slog.Int("frames", len(root.EFRMs)))
+
+	var order []filmID
+
+	groups := make(map[filmID][]frame)
+
+	for _, f := range s.framesOf(ctx, root) {
+		id := filmID{codeA: f.efrm.CodeA, codeB: f.efrm.CodeB}
+		if _, ok := groups[id]; !ok {
+			order = append(order, id)
+		}
+
+		groups[id] = append(groups[id], f)
+	}
+
+	parts := make([]Part, 0, len(order))
+
+	for _, id := range order {
+		frames := groups[id]
+
+		efdf := root.EFDF
+		if id.codeA != efdf.CodeA || id.codeB != efdf.CodeB {
+			efdf = headerFromFrames(efdf, frames)
+		}
+
+		s.log.DebugContext(ctx, "split part",
+			slog.String("film_id", id.String()),
+			slog.Int("frames", len(frames)))
+
+		parts = append(parts, Part{
+			FilmID: id.String(),
+			Root:   assemble(efdf, frames),
+		})
+	}
+
+	return parts
+}
+
+// headerFromFrames rebuilds the roll header fields recorded in every frame
+// from the first of the frames, keeping the rest of base.
+func headerFromFrames(base records.EFDF, frames []frame) records.EFDF {
+	first := frames[0].efrm
+
+	base.CodeA, base.CodeB = first.CodeA, first.CodeB
+	base.IsoDX = first.IsoDX
+	base.Year, base.Month = first.RollYear, first.RollMonth
+	base.Day, base.Hour = first.RollDay, first.RollHour
+	base.Minute, base.Second = first.RollMinute, first.RollSecond
+
+	base.FrameCount = 0
+	for _, f := range frames {
+		base.FrameCount = max(base.FrameCount, f.efrm.FrameNumber)
+	}
+
+	return base
+}
+
+func (s *service) SplitByFrames(
+	ctx context.Context,
+	root records.Root,
+	ranges []domain.FrameRange,
+) (records.Root, error) {
+	s.log.InfoContext(ctx, "splitting roll by frame ranges",
+		slog.Int("frames", len(root.EFRMs)),
+		slog.Int("ranges", len(ranges)))
+
+	var selected []frame
+
+	for _, f := range s.framesOf(ctx, root) {
+		if slices.ContainsFunc(ranges, func(r domain.FrameRange) bool {
+			return r.Contains(f.efrm.FrameNumber)
+		}) {
+			selected = append(selected, f)
+		}
+	}
+
+	if len(selected) == 0 {
+		return records.Root{}, ErrNoFramesSelected
+	}
+
+	s.log.DebugContext(ctx, "frames selected",
+		slog.Int("selected", len(selected)))
+
+	return assemble(root.EFDF, selected), nil
+}
+
+func (s *service) Merge(
+	ctx context.Context,
+	roots []records.Root,
+) (records.Root, error) {
+	s.log.InfoContext(ctx, "merging rolls", slog.Int("rolls", len(roots)))
+
+	if len(roots) < 2 { //nolint:mnd // merging needs two rolls
+		return records.Root{}, ErrNothingToMerge
+	}
+
+	efdf := roots[0].EFDF
+
+	var frames []frame
+
+	seen := make(map[uint32]int)
+
+	for i, root := range roots {
+		if err := headersAgree(efdf, root.EFDF); err != nil {
+			return records.Root{}, fmt.Errorf("%w: roll %d: %w",
+				ErrHeaderMismatch, i+1, err)
+		}
+
+		for _, f := range s.framesOf(ctx, root) {
+			if prev, ok := seen[f.efrm.FrameNumber]; ok {
+				return records.Root{}, fmt.Errorf(
+					"%w: frame %d in rolls %d and %d",
+					ErrDuplicateFrame, f.efrm.FrameNumber, prev, i+1)
+			}
+
+			seen[f.efrm.FrameNumber] = i + 1
+			frames = append(frames, f)
+		}
+
+		efdf.FrameCount = max(efdf.FrameCount, root.EFDF.FrameCount)
+
+		if efdf.Title[0] == 0 {
+			efdf.Title = root.EFDF.Title
+		}
+
+		if efdf.Remarks[0] == 0 {
+			efdf.Remarks = root.EFDF.Remarks
+		}
+	}
+
+	slices.SortStableFunc(frames, func(a, b frame) int {
+		return cmp.Compare(a.efrm.FrameNumber, b.efrm.FrameNumber)
+	})
+
+	s.log.DebugContext(ctx, "rolls merged", slog.Int("frames", len(frames)))
+
+	return assemble(efdf, frames), nil
+}
+
+// headersAgree reports the first roll identifying header field that differs.
+func headersAgree(a, b records.EFDF) error {
+	switch {
+	case a.CodeA != b.CodeA || a.CodeB != b.CodeB:
+		return fmt.Errorf("%w: got %s, expected %s", ErrFilmIDMismatch,
+			filmID{b.CodeA, b.CodeB}, filmID{a.CodeA, a.CodeB})
+	case a.Year != b.Year || a.Month != b.Month || a.Day != b.Day ||
+		a.Hour != b.Hour || a.Minute != b.Minute || a.Second != b.Second:
+		return ErrLoadDateMismatch
+	case a.IsoDX != b.IsoDX:
+		return fmt.Errorf("%w: got %d, expected %d",
+			ErrIsoDXMismatch, b.IsoDX, a.IsoDX)
+	default:
+		return nil
+	}
+}
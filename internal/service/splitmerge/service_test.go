@@ -0,0 +1,362 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package splitmerge_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/splitmerge"
+)
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct // only partial is needed
+func newEFDF(codeB uint32, frameCount uint32) records.EFDF {
+	return records.EFDF{
+		CodeA:      12,
+		CodeB:      codeB,
+		Year:       2024,
+		Month:      5,
+		Day:        1,
+		IsoDX:      400,
+		FrameCount: frameCount,
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func newEFRM(codeB uint32, frameNumber uint32) records.EFRM {
+	return records.EFRM{
+		FrameNumber: frameNumber,
+		CodeA:       12,
+		CodeB:       codeB,
+		IsoDX:       400,
+		RollYear:    2024,
+		RollMonth:   5,
+		RollDay:     uint8(codeB % 28), //nolint:gosec // test data
+	}
+}
+
+// newEFTP returns a thumbnail for the frame at position index whose
+// filepath records the frame number it was taken for.
+//
+//nolint:exhaustruct // only partial is needed
+func newEFTP(index uint16, frameNumber uint32) records.EFTP {
+	eftp := records.EFTP{Index: index}
+	eftp.Filepath[0] = byte(frameNumber)
+
+	return eftp
+}
+
+// linked returns the frame number of each frame whose thumbnail points at it,
+// by thumbnail, so tests can check the links survive renumbering.
+func linked(t *testing.T, root records.Root) map[uint32]uint32 {
+	t.Helper()
+
+	links := make(map[uint32]uint32, len(root.EFTPs))
+
+	for _, eftp := range root.EFTPs {
+		if eftp.Index == 0 || int(eftp.Index) > len(root.EFRMs) {
+			t.Fatalf("thumbnail index %d has no frame", eftp.Index)
+		}
+
+		links[uint32(eftp.Filepath[0])] = root.EFRMs[eftp.Index-1].FrameNumber
+	}
+
+	return links
+}
+
+func frameNumbers(root records.Root) []uint32 {
+	numbers := make([]uint32, 0, len(root.EFRMs))
+	for _, efrm := range root.EFRMs {
+		numbers = append(numbers, efrm.FrameNumber)
+	}
+
+	return numbers
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_SplitByFilmID(t *testing.T) {
+	t.Parallel()
+
+	root := records.Root{
+		EFDF: newEFDF(345, 36),
+		EFRMs: []records.EFRM{
+			newEFRM(345, 1),
+			newEFRM(346, 1),
+			newEFRM(345, 2),
+			newEFRM(346, 3),
+		},
+		EFTPs: []records.EFTP{
+			newEFTP(2, 1),
+			newEFTP(3, 2),
+			newEFTP(4, 3),
+			newEFTP(9, 0),
+		},
+	}
+
+	parts := splitmerge.NewService(newTestLogger()).
+		SplitByFilmID(t.Context(), root)
+
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+
+	if parts[0].FilmID != "12-345" || parts[1].FilmID != "12-346" {
+		t.Errorf("unexpected film IDs %q and %q",
+			parts[0].FilmID, parts[1].FilmID)
+	}
+
+	if !reflect.DeepEqual(parts[0].Root.EFDF, root.EFDF) {
+		t.Errorf("expected the original header to be kept for 12-345")
+	}
+
+	other := parts[1].Root.EFDF
+	if other.CodeB != 346 || other.Day != 346%28 || other.FrameCount != 3 ||
+		other.Title != root.EFDF.Title {
+		t.Errorf("unexpected header for 12-346: %+v", other)
+	}
+
+	if got := frameNumbers(parts[1].Root); !reflect.DeepEqual(
+		got, []uint32{1, 3},
+	) {
+		t.Errorf("expected frames 1 and 3 in 12-346, got %v", got)
+	}
+
+	if got := linked(t, parts[0].Root); !reflect.DeepEqual(
+		got, map[uint32]uint32{2: 2},
+	) {
+		t.Errorf("unexpected thumbnails in 12-345: %v", got)
+	}
+
+	if got := linked(t, parts[1].Root); !reflect.DeepEqual(
+		got, map[uint32]uint32{1: 1, 3: 3},
+	) {
+		t.Errorf("unexpected thumbnails in 12-346: %v", got)
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_SplitByFrames(t *testing.T) {
+	t.Parallel()
+
+	root := records.Root{
+		EFDF: newEFDF(345, 36),
+		EFRMs: []records.EFRM{
+			newEFRM(345, 1),
+			newEFRM(345, 2),
+			newEFRM(345, 3),
+			newEFRM(345, 4),
+			newEFRM(345, 5),
+		},
+		EFTPs: []records.EFTP{
+			newEFTP(1, 1),
+			newEFTP(4, 4),
+			newEFTP(5, 5),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		ranges        []domain.FrameRange
+		frames        []uint32
+		thumbnails    map[uint32]uint32
+		expectedError error
+	}{
+		{
+			name: "ranges",
+			ranges: []domain.FrameRange{
+				{First: 4, Last: 9},
+				{First: 2, Last: 2},
+			},
+			frames:     []uint32{2, 4, 5},
+			thumbnails: map[uint32]uint32{4: 4, 5: 5},
+		},
+		{
+			name:          "no frames in range",
+			ranges:        []domain.FrameRange{{First: 10, Last: 12}},
+			expectedError: splitmerge.ErrNoFramesSelected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := splitmerge.NewService(newTestLogger()).
+				SplitByFrames(t.Context(), root, tt.ranges)
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got.EFDF, root.EFDF) {
+				t.Errorf("expected the header to be kept")
+			}
+
+			frames := frameNumbers(got)
+			if !reflect.DeepEqual(frames, tt.frames) {
+				t.Errorf("expected frames %v, got %v", tt.frames, frames)
+			}
+
+			links := linked(t, got)
+			if !reflect.DeepEqual(links, tt.thumbnails) {
+				t.Errorf("expected thumbnails %v, got %v", tt.thumbnails, links)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Merge(t *testing.T) {
+	t.Parallel()
+
+	titled := newEFDF(345, 12)
+	copy(titled.Title[:], "holiday")
+
+	tests := []struct {
+		name          string
+		roots         func() []records.Root
+		frames        []uint32
+		thumbnails    map[uint32]uint32
+		expectedError error
+	}{
+		{
+			name: "pieces of one roll",
+			roots: func() []records.Root {
+				return []records.Root{
+					{
+						EFDF:  newEFDF(345, 36),
+						EFRMs: []records.EFRM{newEFRM(345, 3), newEFRM(345, 4)},
+						EFTPs: []records.EFTP{newEFTP(2, 4)},
+					},
+					{
+						EFDF:  titled,
+						EFRMs: []records.EFRM{newEFRM(345, 1), newEFRM(345, 2)},
+						EFTPs: []records.EFTP{newEFTP(1, 1), newEFTP(2, 2)},
+					},
+				}
+			},
+			frames:     []uint32{1, 2, 3, 4},
+			thumbnails: map[uint32]uint32{1: 1, 2: 2, 4: 4},
+		},
+		{
+			name: "single roll",
+			roots: func() []records.Root {
+				return []records.Root{{EFDF: newEFDF(345, 36)}}
+			},
+			expectedError: splitmerge.ErrNothingToMerge,
+		},
+		{
+			name: "film ID differs",
+			roots: func() []records.Root {
+				return []records.Root{
+					{EFDF: newEFDF(345, 36)},
+					{EFDF: newEFDF(346, 36)},
+				}
+			},
+			expectedError: splitmerge.ErrFilmIDMismatch,
+		},
+		{
+			name: "DX ISO differs",
+			roots: func() []records.Root {
+				other := newEFDF(345, 36)
+				other.IsoDX = 100
+
+				return []records.Root{{EFDF: newEFDF(345, 36)}, {EFDF: other}}
+			},
+			expectedError: splitmerge.ErrIsoDXMismatch,
+		},
+		{
+			name: "frame in both rolls",
+			roots: func() []records.Root {
+				return []records.Root{
+					{
+						EFDF:  newEFDF(345, 36),
+						EFRMs: []records.EFRM{newEFRM(345, 1)},
+					},
+					{
+						EFDF:  newEFDF(345, 36),
+						EFRMs: []records.EFRM{newEFRM(345, 1)},
+					},
+				}
+			},
+			expectedError: splitmerge.ErrDuplicateFrame,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := splitmerge.NewService(newTestLogger()).
+				Merge(t.Context(), tt.roots())
+
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.EFDF.FrameCount != 36 || got.EFDF.Title != titled.Title {
+				t.Errorf("unexpected merged header: %+v", got.EFDF)
+			}
+
+			frames := frameNumbers(got)
+			if !reflect.DeepEqual(frames, tt.frames) {
+				t.Errorf("expected frames %v, got %v", tt.frames, frames)
+			}
+
+			links := linked(t, got)
+			if !reflect.DeepEqual(links, tt.thumbnails) {
+				t.Errorf("expected thumbnails %v, got %v", tt.thumbnails, links)
+			}
+		})
+	}
+}
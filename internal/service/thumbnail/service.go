@@ -0,0 +1,163 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=thumbnail_test github.com/ma-tf/meta1v/internal/service/thumbnail Service
+
+// Package thumbnail converts the thumbnails embedded in EFD files to and from
+// common image file formats.
+package thumbnail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/tiff" // registers the TIFF decoder used by Decode
+)
+
+const (
+	FormatPNG  = "png"
+	FormatJPEG = "jpeg"
+
+	jpegQuality = 95
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrFailedToEncode    = errors.New("failed to encode thumbnail")
+	ErrFailedToDecode    = errors.New("failed to decode image")
+	ErrEmptyImage        = errors.New("image has no pixels")
+)
+
+// Extension returns the file extension, without the dot, used for format.
+func Extension(format string) string {
+	if format == FormatJPEG {
+		return "jpg"
+	}
+
+	return format
+}
+
+// Service converts thumbnails to and from image files.
+type Service interface {
+	// Encode writes img to w in the given format, either FormatPNG or FormatJPEG.
+	Encode(
+		ctx context.Context,
+		w io.Writer,
+		img image.Image,
+		format string,
+	) error
+
+	// Decode reads a PNG, JPEG or TIFF image from r.
+	Decode(ctx context.Context, r io.Reader) (image.Image, error)
+
+	// Fit scales img to fit within width x height, keeping its aspect ratio, and
+	// centres it on a black thumbnail of exactly that size.
+	Fit(ctx context.Context, img image.Image, width, height int) *image.RGBA
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+func (s *service) Encode(
+	ctx context.Context,
+	w io.Writer,
+	img image.Image,
+	format string,
+) error {
+	s.log.DebugContext(ctx, "encoding thumbnail",
+		slog.String("format", format),
+		slog.String("bounds", img.Bounds().String()))
+
+	var err error
+
+	switch format {
+	case FormatPNG:
+		err = png.Encode(w, img)
+	case FormatJPEG:
+		err = jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	if err != nil {
+		return errors.Join(ErrFailedToEncode, err)
+	}
+
+	return nil
+}
+
+func (s *service) Decode(
+	ctx context.Context,
+	r io.Reader,
+) (image.Image, error) {
+	img, format, err := image.Decode(r)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToDecode, err)
+	}
+
+	if img.Bounds().Empty() {
+		return nil, ErrEmptyImage
+	}
+
+	s.log.DebugContext(ctx, "image decoded",
+		slog.String("format", format),
+		slog.String("bounds", img.Bounds().String()))
+
+	return img, nil
+}
+
+func (s *service) Fit(
+	ctx context.Context,
+	img image.Image,
+	width, height int,
+) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.Black, image.Point{}, draw.Src)
+
+	src := img.Bounds()
+
+	// scale by whichever side is the tighter fit, rounding to the nearest pixel
+	w, h := width, (src.Dy()*width*2+src.Dx())/(src.Dx()*2)
+	if h > height {
+		w, h = (src.Dx()*height*2+src.Dy())/(src.Dy()*2), height
+	}
+
+	w, h = max(w, 1), max(h, 1)
+	x, y := (width-w)/2, (height-h)/2 //nolint:mnd // centred
+	dr := image.Rect(x, y, x+w, y+h)
+
+	draw.CatmullRom.Scale(dst, dr, img, src, draw.Src, nil)
+
+	s.log.DebugContext(ctx, "image fitted",
+		slog.String("from", src.String()),
+		slog.String("to", dr.String()))
+
+	return dst
+}
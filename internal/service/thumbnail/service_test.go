@@ -0,0 +1,302 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package thumbnail_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/service/thumbnail"
+	"golang.org/x/image/tiff"
+)
+
+var errExample = errors.New("example error")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errExample }
+
+func newThumbnail() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 6))
+
+	for y := range 6 {
+		for x := range 8 {
+			c := color.RGBA{uint8(100 + x*4), uint8(80 + y*4), 128, 255}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
+
+func Test_Encode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		w             io.Writer
+		format        string
+		decode        func(io.Reader) (image.Image, error)
+		tolerance     int // allowed difference per channel, jpeg is lossy
+		expectedError error
+	}{
+		{
+			name:   "png is lossless",
+			w:      &bytes.Buffer{},
+			format: thumbnail.FormatPNG,
+			decode: png.Decode,
+		},
+		{
+			name:      "jpeg",
+			w:         &bytes.Buffer{},
+			format:    thumbnail.FormatJPEG,
+			decode:    jpeg.Decode,
+			tolerance: 16,
+		},
+		{
+			name:          "unsupported format",
+			w:             &bytes.Buffer{},
+			format:        "gif",
+			expectedError: thumbnail.ErrUnsupportedFormat,
+		},
+		{
+			name:          "write failure",
+			w:             failingWriter{},
+			format:        thumbnail.FormatPNG,
+			expectedError: thumbnail.ErrFailedToEncode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			src := newThumbnail()
+
+			err := thumbnail.NewService(slog.New(slog.DiscardHandler)).
+				Encode(t.Context(), tt.w, src, tt.format)
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if tt.decode == nil {
+				return
+			}
+
+			img, err := tt.decode(tt.w.(*bytes.Buffer))
+			if err != nil {
+				t.Fatalf("failed to decode output: %v", err)
+			}
+
+			if img.Bounds() != src.Bounds() {
+				t.Fatalf("expected bounds %v, got %v",
+					src.Bounds(), img.Bounds())
+			}
+
+			for y := range src.Bounds().Dy() {
+				for x := range src.Bounds().Dx() {
+					if !similar(src.At(x, y), img.At(x, y), tt.tolerance) {
+						t.Errorf("pixel (%d,%d): expected %v, got %v",
+							x, y, src.At(x, y), img.At(x, y))
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_Extension(t *testing.T) {
+	t.Parallel()
+
+	if got := thumbnail.Extension(thumbnail.FormatPNG); got != "png" {
+		t.Errorf("expected png, got %s", got)
+	}
+
+	if got := thumbnail.Extension(thumbnail.FormatJPEG); got != "jpg" {
+		t.Errorf("expected jpg, got %s", got)
+	}
+}
+
+func Test_Decode(t *testing.T) {
+	t.Parallel()
+
+	encoded := func(encode func(io.Writer, image.Image) error) []byte {
+		buf := &bytes.Buffer{}
+		if err := encode(buf, newThumbnail()); err != nil {
+			t.Fatalf("failed to encode test image: %v", err)
+		}
+
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name          string
+		data          []byte
+		tolerance     int
+		expectedError error
+	}{
+		{
+			name: "png",
+			data: encoded(png.Encode),
+		},
+		{
+			name: "jpeg",
+			data: encoded(func(w io.Writer, img image.Image) error {
+				return jpeg.Encode(w, img, nil)
+			}),
+			tolerance: 16,
+		},
+		{
+			name: "tiff",
+			data: encoded(func(w io.Writer, img image.Image) error {
+				return tiff.Encode(w, img, nil)
+			}),
+		},
+		{
+			name:          "not an image",
+			data:          []byte("EFDF"),
+			expectedError: thumbnail.ErrFailedToDecode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			img, err := thumbnail.NewService(slog.New(slog.DiscardHandler)).
+				Decode(t.Context(), bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.expectedError) {
+				t.Fatalf("expected error %v, got %v", tt.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			src := newThumbnail()
+			if img.Bounds() != src.Bounds() {
+				t.Fatalf("expected bounds %v, got %v",
+					src.Bounds(), img.Bounds())
+			}
+
+			if !similar(src.At(3, 2), img.At(3, 2), tt.tolerance) {
+				t.Errorf("expected %v, got %v", src.At(3, 2), img.At(3, 2))
+			}
+		})
+	}
+}
+
+func Test_Fit(t *testing.T) {
+	t.Parallel()
+
+	white := color.RGBA{255, 255, 255, 255}
+	black := color.RGBA{0, 0, 0, 255}
+
+	tests := []struct {
+		name     string
+		src      image.Rectangle
+		width    int
+		height   int
+		expected image.Rectangle // area covered by the scaled image
+	}{
+		{
+			name:     "same aspect ratio",
+			src:      image.Rect(0, 0, 300, 200),
+			width:    90,
+			height:   60,
+			expected: image.Rect(0, 0, 90, 60),
+		},
+		{
+			name:     "landscape into a square",
+			src:      image.Rect(0, 0, 300, 200),
+			width:    60,
+			height:   60,
+			expected: image.Rect(0, 10, 60, 50),
+		},
+		{
+			name:     "portrait into landscape",
+			src:      image.Rect(10, 10, 210, 310),
+			width:    90,
+			height:   60,
+			expected: image.Rect(25, 0, 65, 60),
+		},
+		{
+			name:     "upscales small images",
+			src:      image.Rect(0, 0, 3, 2),
+			width:    90,
+			height:   60,
+			expected: image.Rect(0, 0, 90, 60),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			src := image.NewRGBA(tt.src)
+			fill := image.NewUniform(white)
+			draw.Draw(src, tt.src, fill, image.Point{}, draw.Src)
+
+			got := thumbnail.NewService(slog.New(slog.DiscardHandler)).
+				Fit(t.Context(), src, tt.width, tt.height)
+
+			if got.Bounds() != image.Rect(0, 0, tt.width, tt.height) {
+				t.Fatalf("expected %dx%d thumbnail, got %v",
+					tt.width, tt.height, got.Bounds())
+			}
+
+			for y := range tt.height {
+				for x := range tt.width {
+					want := black
+					if image.Pt(x, y).In(tt.expected) {
+						want = white
+					}
+
+					if c := got.RGBAAt(x, y); !similar(c, want, 1) {
+						t.Fatalf("pixel (%d,%d): expected %v, got %v",
+							x, y, want, c)
+					}
+				}
+			}
+		})
+	}
+}
+
+func similar(a, b color.Color, tolerance int) bool {
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+
+	for _, d := range []int{
+		int(ar>>8) - int(br>>8),
+		int(ag>>8) - int(bg>>8),
+		int(ab>>8) - int(bb>>8),
+	} {
+		if d < -tolerance || d > tolerance {
+			return false
+		}
+	}
+
+	return true
+}
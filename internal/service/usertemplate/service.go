@@ -0,0 +1,235 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=usertemplate_test github.com/ma-tf/meta1v/internal/service/usertemplate Service
+
+// Package usertemplate writes film roll metadata through user-defined
+// text/template templates.
+//
+// Templates run over a display.DisplayableRoll, so {{.Title}} is the roll title
+// and {{range .Frames}}{{.FrameNumber}} {{.Tv}}{{end}} lists the frames. Besides
+// the text/template builtins, templates can use:
+//
+//	padLeft N V, padRight N V   pad V with spaces to N characters
+//	truncate N V                cut V to at most N characters
+//	upper V, lower V, trim V    change case or trim surrounding space
+//	seconds .Tv                 shutter speed in seconds
+//	fnumber .Av                 f-number of the aperture
+//	stops .ExposureCompensation exposure compensation in stops
+//	mm .FocalLength             focal length in millimetres
+//	iso .IsoM                   film speed
+//	ev .Av .Tv                  exposure value, log2(N²/t), to one decimal
+//	lv .Av .Tv .IsoDX           exposure value at ISO 100, to one decimal
+//	date LAYOUT .TakenAt        date formatted with a Go time layout
+//
+// The numeric helpers return 0 and ev, lv and date an empty string for values
+// that were not recorded.
+package usertemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/service/display"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+)
+
+const (
+	inlineName = "template"
+	isoBase    = 100
+)
+
+var (
+	ErrFailedToReadTemplate    = errors.New("failed to read template file")
+	ErrInvalidTemplate         = errors.New("invalid template")
+	ErrFailedToExecuteTemplate = errors.New("failed to execute template")
+)
+
+// Service parses and executes user-defined templates.
+type Service interface {
+	// Parse parses the template held by the file named text or, if there is no
+	// such file, text itself.
+	Parse(ctx context.Context, text string) (*template.Template, error)
+
+	// Execute runs tmpl over the roll, writing the result to w.
+	Execute(
+		ctx context.Context,
+		w io.Writer,
+		tmpl *template.Template,
+		r display.DisplayableRoll,
+	) error
+}
+
+type service struct {
+	log *slog.Logger
+	fs  osfs.FileSystem
+}
+
+func NewService(log *slog.Logger, fs osfs.FileSystem) Service {
+	return &service{
+		log: log,
+		fs:  fs,
+	}
+}
+
+func (s *service) Parse(
+	ctx context.Context,
+	text string,
+) (*template.Template, error) {
+	name := inlineName
+
+	if info, err := s.fs.Stat(text); err == nil && info.Mode().IsRegular() {
+		s.log.DebugContext(ctx, "reading template file",
+			slog.String("file", text))
+
+		b, err := s.readFile(text)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w",
+				ErrFailedToReadTemplate, text, err)
+		}
+
+		name = filepath.Base(text)
+		text = string(b)
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs()).Parse(text)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidTemplate, err)
+	}
+
+	s.log.DebugContext(ctx, "template parsed", slog.String("name", name))
+
+	return tmpl, nil
+}
+
+func (s *service) readFile(name string) ([]byte, error) {
+	f, err := s.fs.Open(name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by caller
+	}
+	defer f.Close()
+
+	return io.ReadAll(f) //nolint:wrapcheck // wrapped by caller
+}
+
+func (s *service) Execute(
+	ctx context.Context,
+	w io.Writer,
+	tmpl *template.Template,
+	r display.DisplayableRoll,
+) error {
+	s.log.InfoContext(ctx, "executing template",
+		slog.String("name", tmpl.Name()),
+		slog.String("film_id", string(r.FilmID)),
+		slog.Int("frame_count", len(r.Frames)))
+
+	if err := tmpl.Execute(w, r); err != nil {
+		return errors.Join(ErrFailedToExecuteTemplate, err)
+	}
+
+	s.log.DebugContext(ctx, "template executed")
+
+	return nil
+}
+
+func funcs() template.FuncMap {
+	return template.FuncMap{
+		"padLeft": func(n int, v any) string {
+			return pad(fmt.Sprint(v), n, true)
+		},
+		"padRight": func(n int, v any) string {
+			return pad(fmt.Sprint(v), n, false)
+		},
+		"truncate": func(n int, v any) string {
+			r := []rune(fmt.Sprint(v))
+
+			return string(r[:min(len(r), max(n, 0))])
+		},
+		"upper": func(v any) string { return strings.ToUpper(fmt.Sprint(v)) },
+		"lower": func(v any) string { return strings.ToLower(fmt.Sprint(v)) },
+		"trim":  func(v any) string { return strings.TrimSpace(fmt.Sprint(v)) },
+
+		"seconds": func(tv domain.Tv) float64 { return orZero(tv.Seconds()) },
+		"fnumber": func(av domain.Av) float64 { return orZero(av.FNumber()) },
+		"stops": func(ec domain.ExposureCompensation) float64 {
+			return orZero(ec.Stops())
+		},
+		"mm": func(fl domain.FocalLength) float64 {
+			return orZero(fl.Millimetres())
+		},
+		"iso": func(iso domain.Iso) float64 { return orZero(iso.Speed()) },
+		"ev":  ev,
+		"lv":  lv,
+
+		"date": func(layout string, dt domain.ValidatedDatetime) string {
+			t, ok := dt.Time()
+			if !ok {
+				return ""
+			}
+
+			return t.Format(layout)
+		},
+	}
+}
+
+func pad(s string, n int, left bool) string {
+	fill := strings.Repeat(" ", max(n-utf8.RuneCountInString(s), 0))
+	if left {
+		return fill + s
+	}
+
+	return s + fill
+}
+
+func orZero(v float64, ok bool) float64 {
+	if !ok {
+		return 0
+	}
+
+	return v
+}
+
+func ev(av domain.Av, tv domain.Tv) string {
+	v, ok := domain.ExposureValue(av, tv)
+	if !ok {
+		return ""
+	}
+
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+// lv is the exposure value normalised to ISO 100, a measure of the light in
+// the scene rather than of the camera settings.
+func lv(av domain.Av, tv domain.Tv, iso domain.Iso) string {
+	v, okEV := domain.ExposureValue(av, tv)
+	speed, okISO := iso.Speed()
+
+	if !okEV || !okISO {
+		return ""
+	}
+
+	return strconv.FormatFloat(v-math.Log2(speed/isoBase), 'f', 1, 64)
+}
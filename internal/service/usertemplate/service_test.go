@@ -0,0 +1,211 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package usertemplate_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/service/display"
+	"github.com/ma-tf/meta1v/internal/service/osfs"
+	"github.com/ma-tf/meta1v/internal/service/usertemplate"
+)
+
+var errExample = errors.New("example error")
+
+type failWriter struct{}
+
+func (fw *failWriter) Write(_ []byte) (int, error) {
+	return 0, errExample
+}
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct // only partial is needed
+func newRoll() display.DisplayableRoll {
+	return display.DisplayableRoll{
+		FilmID: "12-345",
+		Title:  "Holiday",
+		Frames: []display.DisplayableFrame{
+			{
+				FrameNumber:          1,
+				Tv:                   "1/125",
+				Av:                   "f/8.0",
+				IsoDX:                "400",
+				ExposureCompensation: "-0.7",
+				FocalLength:          "50mm",
+				TakenAt:              "2024-05-01 18:30:00",
+			},
+			{
+				FrameNumber: 2,
+				Tv:          "Bulb",
+				Av:          "f/2.8",
+			},
+		},
+	}
+}
+
+//nolint:funlen // table driven test
+func Test_ParseAndExecute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "fields",
+			template: "{{.Title}}: {{len .Frames}} frames",
+			expected: "Holiday: 2 frames",
+		},
+		{
+			name: "padding",
+			template: `{{range .Frames}}[{{padLeft 3 .FrameNumber}}|` +
+				`{{padRight 6 .Tv}}|{{truncate 3 .Av}}]{{end}}`,
+			expected: "[  1|1/125 |f/8][  2|Bulb  |f/2]",
+		},
+		{
+			name:     "case",
+			template: `{{upper .Title}} {{lower .FilmID}} {{trim " x "}}`,
+			expected: "HOLIDAY 12-345 x",
+		},
+		{
+			name: "numbers",
+			template: `{{with index .Frames 0}}{{seconds .Tv}} ` +
+				`{{fnumber .Av}} {{stops .ExposureCompensation}} ` +
+				`{{mm .FocalLength}} {{iso .IsoDX}}{{end}}`,
+			expected: "0.008 8 -0.7 50 400",
+		},
+		{
+			name: "exposure value",
+			template: `{{range .Frames}}[{{ev .Av .Tv}}|` +
+				`{{lv .Av .Tv .IsoDX}}]{{end}}`,
+			expected: "[13.0|11.0][|]",
+		},
+		{
+			name: "date",
+			template: `{{range .Frames}}[{{date "02 Jan 15:04" .TakenAt}}]` +
+				`{{end}}`,
+			expected: "[01 May 18:30][]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := usertemplate.NewService(newTestLogger(),
+				osfs.NewFileSystem())
+
+			tmpl, err := svc.Parse(ctx, tt.template)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			buf := &bytes.Buffer{}
+			if err = svc.Execute(ctx, buf, tmpl, newRoll()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if buf.String() != tt.expected {
+				t.Errorf("unexpected output: got %q, want %q",
+					buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func Test_Parse_File(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "log.tmpl")
+	if err := os.WriteFile(name, []byte("# {{.Title}}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := t.Context()
+	svc := usertemplate.NewService(newTestLogger(), osfs.NewFileSystem())
+
+	tmpl, err := svc.Parse(ctx, name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = svc.Execute(ctx, buf, tmpl, newRoll()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.String() != "# Holiday\n" || tmpl.Name() != "log.tmpl" {
+		t.Errorf("unexpected output from %s: %q", tmpl.Name(), buf.String())
+	}
+}
+
+func Test_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	svc := usertemplate.NewService(newTestLogger(), osfs.NewFileSystem())
+
+	if _, err := svc.Parse(ctx, "{{.Title"); !errors.Is(
+		err,
+		usertemplate.ErrInvalidTemplate,
+	) {
+		t.Errorf("expected %v, got %v", usertemplate.ErrInvalidTemplate, err)
+	}
+
+	tmpl, err := svc.Parse(ctx, "{{.NoSuchField}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = svc.Execute(ctx, &bytes.Buffer{}, tmpl, newRoll())
+	if !errors.Is(err, usertemplate.ErrFailedToExecuteTemplate) {
+		t.Errorf("expected %v, got %v",
+			usertemplate.ErrFailedToExecuteTemplate, err)
+	}
+
+	tmpl, err = svc.Parse(ctx, "{{.Title}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = svc.Execute(ctx, &failWriter{}, tmpl, newRoll())
+	if !errors.Is(err, usertemplate.ErrFailedToExecuteTemplate) {
+		t.Errorf("expected %v, got %v",
+			usertemplate.ErrFailedToExecuteTemplate, err)
+	}
+}
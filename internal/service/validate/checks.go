@@ -0,0 +1,299 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ma-tf/meta1v/internal/domain"
+	"github.com/ma-tf/meta1v/internal/records"
+)
+
+// Identifiers of the checks, stable so that scripts can filter on them.
+const (
+	CheckFrameCountExceeded   = "frame-count-exceeded"
+	CheckDuplicateFrameNumber = "duplicate-frame-number"
+	CheckMissingFrameNumber   = "missing-frame-number"
+	CheckFilmIDMismatch       = "film-id-mismatch"
+	CheckRollLoadedMismatch   = "roll-loaded-mismatch"
+	CheckInvalidTakenAt       = "invalid-taken-at"
+	CheckTakenAtBackwards     = "taken-at-backwards"
+	CheckOrphanThumbnail      = "orphan-thumbnail"
+	CheckDuplicateThumbnail   = "duplicate-thumbnail"
+	CheckModifiedRecord       = "modified-record"
+)
+
+const rollRecord = "roll"
+
+func frameRecord(frameNumber uint32) string {
+	return fmt.Sprintf("frame %d", frameNumber)
+}
+
+func thumbnailRecord(index uint16) string {
+	return fmt.Sprintf("thumbnail %d", index)
+}
+
+func checkFrameCount(r records.Root) []Finding {
+	if uint64(len(r.EFRMs)) <= uint64(r.EFDF.FrameCount) {
+		return nil
+	}
+
+	return []Finding{{
+		Severity: SeverityError,
+		Check:    CheckFrameCountExceeded,
+		Record:   rollRecord,
+		Message: fmt.Sprintf("%d frame records but the roll header counts %d",
+			len(r.EFRMs), r.EFDF.FrameCount),
+	}}
+}
+
+func checkFrameNumbers(r records.Root) []Finding {
+	var findings []Finding
+
+	seen := make(map[uint32]int, len(r.EFRMs))
+	for _, efrm := range r.EFRMs {
+		seen[efrm.FrameNumber]++
+		if seen[efrm.FrameNumber] == 2 { //nolint:mnd // report once per number
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    CheckDuplicateFrameNumber,
+				Frame:    efrm.FrameNumber,
+				Record:   frameRecord(efrm.FrameNumber),
+				Message:  "frame number is recorded more than once",
+			})
+		}
+	}
+
+	numbers := slices.Sorted(maps.Keys(seen))
+
+	// gaps between neighbouring frame numbers, then after the highest up to the
+	// number of frames the roll header counts
+	var previous uint32
+
+	for _, n := range numbers {
+		if n > previous+1 {
+			findings = append(findings, missingFrames(previous+1, n-1))
+		}
+
+		previous = n
+	}
+
+	if r.EFDF.FrameCount > previous {
+		findings = append(findings, missingFrames(previous+1, r.EFDF.FrameCount))
+	}
+
+	return findings
+}
+
+func missingFrames(first, last uint32) Finding {
+	message := fmt.Sprintf("frame %d is missing", first)
+	if first != last {
+		message = fmt.Sprintf("frames %d-%d are missing", first, last)
+	}
+
+	return Finding{
+		Severity: SeverityWarning,
+		Check:    CheckMissingFrameNumber,
+		Record:   rollRecord,
+		Message:  message,
+	}
+}
+
+func checkFilmIDs(r records.Root) []Finding {
+	var findings []Finding
+
+	for _, efrm := range r.EFRMs {
+		if efrm.CodeA == r.EFDF.CodeA && efrm.CodeB == r.EFDF.CodeB {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Check:    CheckFilmIDMismatch,
+			Frame:    efrm.FrameNumber,
+			Record:   frameRecord(efrm.FrameNumber),
+			Message: fmt.Sprintf("film ID %s differs from the roll's %s",
+				formatFilmID(efrm.CodeA, efrm.CodeB),
+				formatFilmID(r.EFDF.CodeA, r.EFDF.CodeB)),
+		})
+	}
+
+	return findings
+}
+
+func formatFilmID(prefix, suffix uint32) string {
+	fid, err := domain.NewFilmID(prefix, suffix)
+	if err != nil {
+		return fmt.Sprintf("%d/%d", prefix, suffix)
+	}
+
+	if fid == "" {
+		return "(none)"
+	}
+
+	return string(fid)
+}
+
+func checkRollLoadDates(r records.Root) []Finding {
+	rollLoaded := [6]int{
+		int(r.EFDF.Year), int(r.EFDF.Month), int(r.EFDF.Day),
+		int(r.EFDF.Hour), int(r.EFDF.Minute), int(r.EFDF.Second),
+	}
+
+	var findings []Finding
+
+	for _, efrm := range r.EFRMs {
+		frameLoaded := [6]int{
+			int(efrm.RollYear), int(efrm.RollMonth), int(efrm.RollDay),
+			int(efrm.RollHour), int(efrm.RollMinute), int(efrm.RollSecond),
+		}
+		if frameLoaded == rollLoaded {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Check:    CheckRollLoadedMismatch,
+			Frame:    efrm.FrameNumber,
+			Record:   frameRecord(efrm.FrameNumber),
+			Message: fmt.Sprintf("film loaded at %s differs from the roll's %s",
+				formatDateTime(frameLoaded), formatDateTime(rollLoaded)),
+		})
+	}
+
+	return findings
+}
+
+func formatDateTime(dt [6]int) string {
+	return fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d",
+		dt[0], dt[1], dt[2], dt[3], dt[4], dt[5])
+}
+
+// checkTakenAt walks the frames in frame number order and reports invalid
+// timestamps and any frame taken before the frame preceding it.
+func checkTakenAt(r records.Root) []Finding {
+	frames := slices.SortedStableFunc(slices.Values(r.EFRMs),
+		func(a, b records.EFRM) int {
+			return cmp.Compare(a.FrameNumber, b.FrameNumber)
+		})
+
+	var (
+		findings []Finding
+		previous time.Time
+		prevNo   uint32
+	)
+
+	for _, efrm := range frames {
+		takenAt, err := domain.NewDateTime(efrm.Year, efrm.Month, efrm.Day,
+			efrm.Hour, efrm.Minute, efrm.Second)
+		if err != nil {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    CheckInvalidTakenAt,
+				Frame:    efrm.FrameNumber,
+				Record:   frameRecord(efrm.FrameNumber),
+				Message:  err.Error(),
+			})
+
+			continue
+		}
+
+		if takenAt == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.DateTime, string(takenAt))
+		if err != nil {
+			continue
+		}
+
+		if !previous.IsZero() && t.Before(previous) {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Check:    CheckTakenAtBackwards,
+				Frame:    efrm.FrameNumber,
+				Record:   frameRecord(efrm.FrameNumber),
+				Message: fmt.Sprintf("taken at %s, before frame %d at %s",
+					takenAt, prevNo, previous.Format(time.DateTime)),
+			})
+		}
+
+		previous, prevNo = t, efrm.FrameNumber
+	}
+
+	return findings
+}
+
+// checkThumbnails reports thumbnails whose index does not match a frame record.
+// Thumbnail indices are the 1-based position of the frame record in the file.
+func checkThumbnails(r records.Root) []Finding {
+	var findings []Finding
+
+	seen := make(map[uint16]bool, len(r.EFTPs))
+	for _, eftp := range r.EFTPs {
+		if eftp.Index == 0 || int(eftp.Index) > len(r.EFRMs) {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    CheckOrphanThumbnail,
+				Record:   thumbnailRecord(eftp.Index),
+				Message: fmt.Sprintf("no frame record at index %d (%d frames)",
+					eftp.Index, len(r.EFRMs)),
+			})
+
+			continue
+		}
+
+		if seen[eftp.Index] {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Check:    CheckDuplicateThumbnail,
+				Frame:    r.EFRMs[eftp.Index-1].FrameNumber,
+				Record:   thumbnailRecord(eftp.Index),
+				Message: fmt.Sprintf("frame %d has more than one thumbnail",
+					r.EFRMs[eftp.Index-1].FrameNumber),
+			})
+		}
+
+		seen[eftp.Index] = true
+	}
+
+	return findings
+}
+
+func checkModifiedRecords(r records.Root) []Finding {
+	var findings []Finding
+
+	for _, efrm := range r.EFRMs {
+		if efrm.IsModifiedRecord == 0 {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Severity: SeverityInfo,
+			Check:    CheckModifiedRecord,
+			Frame:    efrm.FrameNumber,
+			Record:   frameRecord(efrm.FrameNumber),
+			Message:  "frame was modified after it was recorded",
+		})
+	}
+
+	return findings
+}
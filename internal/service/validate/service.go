@@ -0,0 +1,181 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:generate mockgen -destination=./mocks/service_mock.go -package=validate_test github.com/ma-tf/meta1v/internal/service/validate Service
+
+// Package validate provides consistency checks across a whole film roll.
+//
+// Individual values are validated by the domain package as they are displayed.
+// This package looks at how the records of an EFD file relate to each other,
+// such as frames that disagree with the roll header or thumbnails that point
+// at frames that do not exist.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/ma-tf/meta1v/internal/records"
+)
+
+const (
+	severityWidth = 8
+	recordWidth   = 14
+	checkWidth    = 24
+)
+
+// Severity ranks how serious a finding is.
+type Severity int
+
+const (
+	// SeverityInfo marks noteworthy but expected content, such as edited frames.
+	SeverityInfo Severity = iota
+	// SeverityWarning marks content that is unusual but may be legitimate.
+	SeverityWarning
+	// SeverityError marks content that cannot belong to a consistent roll.
+	SeverityError
+)
+
+// String returns the lower case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Finding describes a single problem found in a roll.
+type Finding struct {
+	Severity Severity
+	Check    string // stable identifier of the check, e.g. "duplicate-frame-number"
+	Frame    uint32 // frame number the finding relates to, 0 for the roll and orphan thumbnails
+	Record   string // record the finding relates to, e.g. "roll" or "frame 3"
+	Message  string
+}
+
+// Service checks a roll for logical inconsistencies between its records.
+type Service interface {
+	// Validate runs every check against the roll and returns the findings
+	// in the order the checks ran. An empty result means the roll is consistent.
+	Validate(ctx context.Context, r records.Root) []Finding
+
+	// Report writes the findings as a table followed by a summary line.
+	Report(ctx context.Context, w io.Writer, findings []Finding)
+}
+
+type service struct {
+	log *slog.Logger
+}
+
+func NewService(log *slog.Logger) Service {
+	return &service{
+		log: log,
+	}
+}
+
+func (s *service) Validate(ctx context.Context, r records.Root) []Finding {
+	s.log.InfoContext(ctx, "validating roll",
+		slog.Int("frame_count", len(r.EFRMs)),
+		slog.Int("thumbnail_count", len(r.EFTPs)))
+
+	checks := []func(records.Root) []Finding{
+		checkFrameCount,
+		checkFrameNumbers,
+		checkFilmIDs,
+		checkRollLoadDates,
+		checkTakenAt,
+		checkThumbnails,
+		checkModifiedRecords,
+	}
+
+	var findings []Finding
+	for _, check := range checks {
+		findings = append(findings, check(r)...)
+	}
+
+	s.log.DebugContext(ctx, "roll validated",
+		slog.Int("finding_count", len(findings)))
+
+	return findings
+}
+
+func (s *service) Report(
+	ctx context.Context,
+	w io.Writer,
+	findings []Finding,
+) {
+	s.log.InfoContext(ctx, "formatting validation report",
+		slog.Int("finding_count", len(findings)))
+
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "no problems found")
+
+		return
+	}
+
+	header := fmt.Sprintf("%-*s %-*s %-*s %s",
+		severityWidth, "SEVERITY",
+		recordWidth, "RECORD",
+		checkWidth, "CHECK",
+		"MESSAGE",
+	)
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, strings.Repeat("-", len(header)))
+
+	counts := make(map[Severity]int, len(findings))
+	for _, f := range findings {
+		counts[f.Severity]++
+
+		fmt.Fprintf(w, "%-*s %-*s %-*s %s\n",
+			severityWidth, strings.ToUpper(f.Severity.String()),
+			recordWidth, f.Record,
+			checkWidth, f.Check,
+			f.Message,
+		)
+	}
+
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s), %d info\n",
+		counts[SeverityError],
+		counts[SeverityWarning],
+		counts[SeverityInfo],
+	)
+
+	s.log.DebugContext(ctx, "validation report formatted")
+}
+
+// Highest returns the most serious severity among the findings,
+// and false if there are none.
+func Highest(findings []Finding) (Severity, bool) {
+	if len(findings) == 0 {
+		return SeverityInfo, false
+	}
+
+	highest := SeverityInfo
+	for _, f := range findings {
+		highest = max(highest, f.Severity)
+	}
+
+	return highest, true
+}
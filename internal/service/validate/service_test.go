@@ -0,0 +1,437 @@
+// meta1v is a command-line tool for viewing and manipulating metadata for Canon EOS-1V files of the EFD format.
+// Copyright (C) 2026  Matt F
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published
+// by the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package validate_test
+
+import (
+	"bytes"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ma-tf/meta1v/internal/records"
+	"github.com/ma-tf/meta1v/internal/service/validate"
+)
+
+//nolint:exhaustruct // only partial is needed
+func newTestLogger() *slog.Logger {
+	buf := &bytes.Buffer{}
+
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+//nolint:exhaustruct // only partial is needed
+func newEFDF(frameCount uint32) records.EFDF {
+	return records.EFDF{
+		CodeA:      12,
+		CodeB:      345,
+		Year:       2024,
+		Month:      5,
+		Day:        1,
+		Hour:       9,
+		FrameCount: frameCount,
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func newEFRM(frameNumber uint32, minute uint8) records.EFRM {
+	return records.EFRM{
+		FrameNumber: frameNumber,
+		CodeA:       12,
+		CodeB:       345,
+		RollYear:    2024,
+		RollMonth:   5,
+		RollDay:     1,
+		RollHour:    9,
+		Year:        2024,
+		Month:       5,
+		Day:         1,
+		Hour:        10,
+		Minute:      minute,
+	}
+}
+
+type finding struct {
+	severity validate.Severity
+	check    string
+	record   string
+}
+
+//nolint:exhaustruct,funlen // only partial is needed, table driven test
+func Test_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		root     func() records.Root
+		expected []finding
+	}{
+		{
+			name: "consistent roll",
+			root: func() records.Root {
+				return records.Root{
+					EFDF:  newEFDF(2),
+					EFRMs: []records.EFRM{newEFRM(1, 0), newEFRM(2, 1)},
+					EFTPs: []records.EFTP{{Index: 1}, {Index: 2}},
+				}
+			},
+			expected: nil,
+		},
+		{
+			name: "more frames than the roll counts",
+			root: func() records.Root {
+				efdf := newEFDF(1)
+
+				return records.Root{
+					EFDF:  efdf,
+					EFRMs: []records.EFRM{newEFRM(1, 0), newEFRM(2, 1)},
+				}
+			},
+			expected: []finding{
+				{validate.SeverityError, validate.CheckFrameCountExceeded, "roll"},
+			},
+		},
+		{
+			name: "duplicate and missing frame numbers",
+			root: func() records.Root {
+				return records.Root{
+					EFDF: newEFDF(7),
+					EFRMs: []records.EFRM{
+						newEFRM(1, 0),
+						newEFRM(1, 1),
+						newEFRM(2, 2),
+						newEFRM(5, 3),
+						newEFRM(7, 4),
+						newEFRM(7, 5),
+						newEFRM(7, 6),
+					},
+				}
+			},
+			expected: []finding{
+				{
+					validate.SeverityError,
+					validate.CheckDuplicateFrameNumber,
+					"frame 1",
+				},
+				{
+					validate.SeverityError,
+					validate.CheckDuplicateFrameNumber,
+					"frame 7",
+				},
+				{validate.SeverityWarning, validate.CheckMissingFrameNumber, "roll"},
+				{validate.SeverityWarning, validate.CheckMissingFrameNumber, "roll"},
+			},
+		},
+		{
+			name: "frame from another roll",
+			root: func() records.Root {
+				other := newEFRM(2, 1)
+				other.CodeB = 346
+				other.RollMinute = 30
+
+				return records.Root{
+					EFDF:  newEFDF(2),
+					EFRMs: []records.EFRM{newEFRM(1, 0), other},
+				}
+			},
+			expected: []finding{
+				{validate.SeverityError, validate.CheckFilmIDMismatch, "frame 2"},
+				{
+					validate.SeverityError,
+					validate.CheckRollLoadedMismatch,
+					"frame 2",
+				},
+			},
+		},
+		{
+			name: "taken at goes backwards and is invalid",
+			root: func() records.Root {
+				invalid := newEFRM(4, 0)
+				invalid.Month = 13
+
+				unrecorded := newEFRM(5, 0)
+				unrecorded.Year, unrecorded.Month, unrecorded.Day = 0, 0, 0
+				unrecorded.Hour = 0
+
+				return records.Root{
+					EFDF: newEFDF(5),
+					EFRMs: []records.EFRM{
+						newEFRM(3, 1),
+						newEFRM(1, 5),
+						newEFRM(2, 10),
+						invalid,
+						unrecorded,
+					},
+				}
+			},
+			expected: []finding{
+				{
+					validate.SeverityWarning,
+					validate.CheckTakenAtBackwards,
+					"frame 3",
+				},
+				{validate.SeverityError, validate.CheckInvalidTakenAt, "frame 4"},
+			},
+		},
+		{
+			name: "orphan and duplicate thumbnails",
+			root: func() records.Root {
+				return records.Root{
+					EFDF:  newEFDF(2),
+					EFRMs: []records.EFRM{newEFRM(1, 0), newEFRM(2, 1)},
+					EFTPs: []records.EFTP{
+						{Index: 0},
+						{Index: 1},
+						{Index: 3},
+						{Index: 1},
+					},
+				}
+			},
+			expected: []finding{
+				{
+					validate.SeverityError,
+					validate.CheckOrphanThumbnail,
+					"thumbnail 0",
+				},
+				{
+					validate.SeverityError,
+					validate.CheckOrphanThumbnail,
+					"thumbnail 3",
+				},
+				{
+					validate.SeverityError,
+					validate.CheckDuplicateThumbnail,
+					"thumbnail 1",
+				},
+			},
+		},
+		{
+			name: "modified record",
+			root: func() records.Root {
+				modified := newEFRM(1, 0)
+				modified.IsModifiedRecord = 1
+
+				return records.Root{
+					EFDF:  newEFDF(1),
+					EFRMs: []records.EFRM{modified},
+				}
+			},
+			expected: []finding{
+				{validate.SeverityInfo, validate.CheckModifiedRecord, "frame 1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			findings := validate.NewService(newTestLogger()).
+				Validate(t.Context(), tt.root())
+
+			got := make([]finding, 0, len(findings))
+			for _, f := range findings {
+				if f.Message == "" {
+					t.Errorf("finding %s on %s has no message", f.Check, f.Record)
+				}
+
+				got = append(got, finding{f.Severity, f.Check, f.Record})
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("unexpected findings:\ngot  %v\nwant %v",
+					got, tt.expected)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Validate_MissingFrames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		frameCount   uint32
+		frameNumbers []uint32
+		expected     []string
+	}{
+		{
+			name:         "gaps between recorded frames",
+			frameCount:   9,
+			frameNumbers: []uint32{9, 1, 4, 5},
+			expected:     []string{"frames 2-3 are missing", "frames 6-8 are missing"},
+		},
+		{
+			name:         "frames after the highest recorded",
+			frameCount:   5,
+			frameNumbers: []uint32{1, 2, 3},
+			expected:     []string{"frames 4-5 are missing"},
+		},
+		{
+			name:         "one frame after the highest recorded",
+			frameCount:   2,
+			frameNumbers: []uint32{1},
+			expected:     []string{"frame 2 is missing"},
+		},
+		{
+			name:         "highest possible frame number",
+			frameCount:   3,
+			frameNumbers: []uint32{1, 2, 0xFFFFFFFF},
+			expected:     []string{"frames 3-4294967294 are missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := records.Root{EFDF: newEFDF(tt.frameCount)}
+			for i, n := range tt.frameNumbers {
+				root.EFRMs = append(root.EFRMs, newEFRM(n, uint8(i)))
+			}
+
+			var got []string
+
+			for _, f := range validate.NewService(newTestLogger()).
+				Validate(t.Context(), root) {
+				if f.Check == validate.CheckMissingFrameNumber {
+					got = append(got, f.Message)
+				}
+			}
+
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("unexpected missing frames:\ngot  %q\nwant %q",
+					got, tt.expected)
+			}
+		})
+	}
+}
+
+//nolint:exhaustruct // only partial is needed
+func Test_Validate_FindingFrames(t *testing.T) {
+	t.Parallel()
+
+	modified := newEFRM(7, 1)
+	modified.IsModifiedRecord = 1
+
+	root := records.Root{
+		EFDF:  newEFDF(7),
+		EFRMs: []records.EFRM{newEFRM(1, 0), modified},
+		EFTPs: []records.EFTP{{Index: 2}, {Index: 2}, {Index: 5}},
+	}
+
+	var got []uint32
+
+	for _, f := range validate.NewService(newTestLogger()).
+		Validate(t.Context(), root) {
+		got = append(got, f.Frame)
+	}
+
+	// missing frames 2-6, the thumbnail of frame 7 twice, an orphan thumbnail
+	// and frame 7 modified
+	expected := []uint32{0, 7, 0, 7}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected finding frames %v, got %v", expected, got)
+	}
+}
+
+func Test_Report(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		findings []validate.Finding
+		contains []string
+	}{
+		{
+			name:     "no findings",
+			findings: nil,
+			contains: []string{"no problems found"},
+		},
+		{
+			name: "findings with summary",
+			findings: []validate.Finding{
+				{
+					Severity: validate.SeverityError,
+					Check:    validate.CheckFilmIDMismatch,
+					Record:   "frame 2",
+					Message:  "film ID 12-346 differs from the roll's 12-345",
+				},
+				{
+					Severity: validate.SeverityInfo,
+					Check:    validate.CheckModifiedRecord,
+					Record:   "frame 1",
+					Message:  "frame was modified after it was recorded",
+				},
+			},
+			contains: []string{
+				"SEVERITY",
+				"ERROR    frame 2",
+				validate.CheckFilmIDMismatch,
+				"film ID 12-346 differs from the roll's 12-345",
+				"INFO     frame 1",
+				"1 error(s), 0 warning(s), 1 info",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			validate.NewService(newTestLogger()).
+				Report(t.Context(), buf, tt.findings)
+
+			for _, want := range tt.contains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s",
+						want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func Test_Highest(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := validate.Highest(nil); ok {
+		t.Error("expected no severity for no findings")
+	}
+
+	//nolint:exhaustruct // only severity is needed
+	findings := []validate.Finding{
+		{Severity: validate.SeverityInfo},
+		{Severity: validate.SeverityError},
+		{Severity: validate.SeverityWarning},
+	}
+
+	got, ok := validate.Highest(findings)
+	if !ok || got != validate.SeverityError {
+		t.Errorf("expected %v, got %v (ok %t)", validate.SeverityError, got, ok)
+	}
+}